@@ -290,6 +290,14 @@ func (c *prevCol) GetGeneratedAsIdentitySequenceOption(
 	return nil, errors.AssertionFailedf("unexpected call to GetGeneratedAsIdentitySequenceOption on cdc_prev")
 }
 
+func (c *prevCol) IsEncrypted() bool {
+	return false
+}
+
+func (c *prevCol) GetEncryptionKeyID() descpb.ColumnEncryptionKeyID {
+	return 0
+}
+
 func (c *prevCol) initColumnDescriptor() {
 	c.d = &descpb.ColumnDescriptor{
 		Name:         c.GetName(),
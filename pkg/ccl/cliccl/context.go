@@ -38,6 +38,8 @@ func setProxyContextDefaults() {
 	proxyContext.ThrottleBaseDelay = time.Second
 	proxyContext.DisableConnectionRebalancing = false
 	proxyContext.RequireProxyProtocol = false
+	proxyContext.PoolMaxBackendConns = 0
+	proxyContext.PoolDetachDelay = time.Second
 }
 
 var testDirectorySvrContext struct {
@@ -33,6 +33,8 @@ func init() {
 		cliflagcfg.DurationFlag(f, &proxyContext.ThrottleBaseDelay, cliflags.ThrottleBaseDelay)
 		cliflagcfg.BoolFlag(f, &proxyContext.DisableConnectionRebalancing, cliflags.DisableConnectionRebalancing)
 		cliflagcfg.BoolFlag(f, &proxyContext.RequireProxyProtocol, cliflags.RequireProxyProtocol)
+		cliflagcfg.IntFlag(f, &proxyContext.PoolMaxBackendConns, cliflags.PoolMaxBackendConns)
+		cliflagcfg.DurationFlag(f, &proxyContext.PoolDetachDelay, cliflags.PoolDetachDelay)
 	}
 
 	// Multi-tenancy test directory command flags.
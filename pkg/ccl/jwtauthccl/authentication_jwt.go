@@ -66,6 +66,10 @@ type jwtAuthenticatorConf struct {
 	issuers  []string
 	jwks     jwk.Set
 	claim    string
+
+	provisioningEnabled bool
+	groupClaim          string
+	groupRoleMapping    map[string][]username.SQLUsername
 }
 
 // reloadConfig locks mutex and then refreshes the values in conf from the cluster settings.
@@ -85,6 +89,10 @@ func (authenticator *jwtAuthenticator) reloadConfigLocked(
 		issuers:  mustParseValueOrArray(JWTAuthIssuers.Get(&st.SV)),
 		jwks:     mustParseJWKS(JWTAuthJWKS.Get(&st.SV)),
 		claim:    JWTAuthClaim.Get(&st.SV),
+
+		provisioningEnabled: JWTAuthProvisioningEnabled.Get(&st.SV),
+		groupClaim:          JWTAuthGroupClaim.Get(&st.SV),
+		groupRoleMapping:    mustParseGroupRoleMapping(JWTAuthGroupRoleMapping.Get(&st.SV)),
 	}
 
 	if !authenticator.mu.conf.enabled && conf.enabled {
@@ -162,21 +170,7 @@ func (authenticator *jwtAuthenticator) ValidateJWTLogin(
 				errors.Newf("JWT authentication: missing claim"),
 				"token does not contain a claim for %s", authenticator.mu.conf.claim)
 		}
-		switch castClaimValue := claimValue.(type) {
-		case string:
-			// Accept a single string value.
-			tokenPrincipals = []string{castClaimValue}
-		case []interface{}:
-			// Iterate over the slice and add all string values to the tokenPrincipals.
-			for _, maybePrincipal := range castClaimValue {
-				tokenPrincipals = append(tokenPrincipals, fmt.Sprint(maybePrincipal))
-			}
-		case []string:
-			// This case never seems to happen but is included in case an implementation detail changes in the library.
-			tokenPrincipals = castClaimValue
-		default:
-			tokenPrincipals = []string{fmt.Sprint(castClaimValue)}
-		}
+		tokenPrincipals = claimValues(claimValue)
 	}
 
 	// Take the principals from the token and send each of them through the identity map to generate the
@@ -236,6 +230,72 @@ func (authenticator *jwtAuthenticator) ValidateJWTLogin(
 	return nil
 }
 
+// claimValues returns the values of a claim, which is either a single value or
+// an array of values.
+func claimValues(claimValue interface{}) []string {
+	switch castClaimValue := claimValue.(type) {
+	case string:
+		// Accept a single string value.
+		return []string{castClaimValue}
+	case []interface{}:
+		// Iterate over the slice and add all string values.
+		values := make([]string, 0, len(castClaimValue))
+		for _, maybeValue := range castClaimValue {
+			values = append(values, fmt.Sprint(maybeValue))
+		}
+		return values
+	case []string:
+		// This case never seems to happen but is included in case an implementation detail changes in the library.
+		return castClaimValue
+	default:
+		return []string{fmt.Sprint(castClaimValue)}
+	}
+}
+
+// ProvisioningEnabled returns whether users that do not exist yet are created
+// on their first successful JWT login.
+func (authenticator *jwtAuthenticator) ProvisioningEnabled(_ *cluster.Settings) bool {
+	authenticator.mu.RLock()
+	defer authenticator.mu.RUnlock()
+	return authenticator.mu.enabled && authenticator.mu.conf.provisioningEnabled
+}
+
+// RoleGrantsForJWTLogin returns the roles that the groups in the given token
+// map to, along with all the roles in the group role mapping, which are the
+// ones whose memberships are managed through JWT logins. ok is false if no
+// group claim is configured, in which case memberships are left untouched.
+//
+// The token must have been validated by ValidateJWTLogin beforehand. A token
+// that does not contain the group claim belongs to no group.
+func (authenticator *jwtAuthenticator) RoleGrantsForJWTLogin(
+	_ *cluster.Settings, tokenBytes []byte,
+) (grants, managed []username.SQLUsername, ok bool, err error) {
+	authenticator.mu.RLock()
+	defer authenticator.mu.RUnlock()
+
+	if !authenticator.mu.enabled || authenticator.mu.conf.groupClaim == "" {
+		return nil, nil, false, nil
+	}
+	parsedToken, err := jwt.Parse(tokenBytes, jwt.WithKeySet(authenticator.mu.conf.jwks), jwt.InferAlgorithmFromKey(true))
+	if err != nil {
+		return nil, nil, false, errors.Newf("JWT authentication: invalid token")
+	}
+	var groups []string
+	if claimValue, found := parsedToken.Get(authenticator.mu.conf.groupClaim); found {
+		groups = claimValues(claimValue)
+	}
+	for _, group := range groups {
+		grants = append(grants, authenticator.mu.conf.groupRoleMapping[group]...)
+	}
+	// A non-nil slice signals that only the mapped roles are managed, even if
+	// the mapping is empty.
+	managed = []username.SQLUsername{}
+	for _, roles := range authenticator.mu.conf.groupRoleMapping {
+		managed = append(managed, roles...)
+	}
+	return grants, managed, true, nil
+}
+
 // ConfigureJWTAuth initializes and returns a jwtAuthenticator. It also sets up listeners so
 // that the jwtAuthenticator's config is updated when the cluster settings values change.
 var ConfigureJWTAuth = func(
@@ -262,6 +322,15 @@ var ConfigureJWTAuth = func(
 	JWTAuthClaim.SetOnChange(&st.SV, func(ctx context.Context) {
 		authenticator.reloadConfig(ambientCtx.AnnotateCtx(ctx), st)
 	})
+	JWTAuthProvisioningEnabled.SetOnChange(&st.SV, func(ctx context.Context) {
+		authenticator.reloadConfig(ambientCtx.AnnotateCtx(ctx), st)
+	})
+	JWTAuthGroupClaim.SetOnChange(&st.SV, func(ctx context.Context) {
+		authenticator.reloadConfig(ambientCtx.AnnotateCtx(ctx), st)
+	})
+	JWTAuthGroupRoleMapping.SetOnChange(&st.SV, func(ctx context.Context) {
+		authenticator.reloadConfig(ambientCtx.AnnotateCtx(ctx), st)
+	})
 	return &authenticator
 }
 
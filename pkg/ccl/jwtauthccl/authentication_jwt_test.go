@@ -588,3 +588,71 @@ func TestAudienceCheck(t *testing.T) {
 	err = verifier.ValidateJWTLogin(s.ClusterSettings(), username.MakeSQLUsernameFromPreNormalizedString(username1), token, identMap)
 	require.NoError(t, err)
 }
+
+func TestProvisioningEnabled(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	verifier := ConfigureJWTAuth(ctx, s.AmbientCtx(), s.ClusterSettings(), s.StorageClusterID())
+	require.False(t, verifier.ProvisioningEnabled(s.ClusterSettings()))
+
+	// Provisioning is only enabled when JWT authentication is.
+	JWTAuthProvisioningEnabled.Override(ctx, &s.ClusterSettings().SV, true)
+	require.False(t, verifier.ProvisioningEnabled(s.ClusterSettings()))
+	JWTAuthEnabled.Override(ctx, &s.ClusterSettings().SV, true)
+	require.True(t, verifier.ProvisioningEnabled(s.ClusterSettings()))
+}
+
+func TestGroupRoleGrants(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	keySet, key, _ := createJWKS(t)
+	token := createJWT(t, username1, audience1, issuer1, timeutil.Now().Add(time.Hour), key, jwa.RS256,
+		customClaimName, []string{"analysts", "unmapped"})
+	noGroupsToken := createJWT(t, username1, audience1, issuer1, timeutil.Now().Add(time.Hour), key, jwa.RS256, "", "")
+
+	JWTAuthEnabled.Override(ctx, &s.ClusterSettings().SV, true)
+	JWTAuthJWKS.Override(ctx, &s.ClusterSettings().SV, serializePublicKeySet(t, keySet))
+	verifier := ConfigureJWTAuth(ctx, s.AmbientCtx(), s.ClusterSettings(), s.StorageClusterID())
+
+	// Role memberships are not managed until a group claim is configured.
+	_, _, ok, err := verifier.RoleGrantsForJWTLogin(s.ClusterSettings(), token)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	JWTAuthGroupClaim.Override(ctx, &s.ClusterSettings().SV, customClaimName)
+	JWTAuthGroupRoleMapping.Override(ctx, &s.ClusterSettings().SV,
+		`{"analysts": ["reader", "reporter"], "admins": "writer"}`)
+	roles := func(names ...string) []username.SQLUsername {
+		var res []username.SQLUsername
+		for _, name := range names {
+			res = append(res, username.MakeSQLUsernameFromPreNormalizedString(name))
+		}
+		return res
+	}
+
+	grants, managed, ok, err := verifier.RoleGrantsForJWTLogin(s.ClusterSettings(), token)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, roles("reader", "reporter"), grants)
+	require.ElementsMatch(t, roles("reader", "reporter", "writer"), managed)
+
+	// A token without the group claim belongs to no group, so all the mapped
+	// roles are revoked.
+	grants, managed, ok, err = verifier.RoleGrantsForJWTLogin(s.ClusterSettings(), noGroupsToken)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, grants)
+	require.ElementsMatch(t, roles("reader", "reporter", "writer"), managed)
+
+	_, _, _, err = verifier.RoleGrantsForJWTLogin(s.ClusterSettings(), []byte("not a token"))
+	require.ErrorContains(t, err, "JWT authentication: invalid token")
+}
@@ -12,6 +12,7 @@ import (
 	"bytes"
 	"encoding/json"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/errors"
 	"github.com/lestrrat-go/jwx/jwk"
@@ -25,6 +26,10 @@ const (
 	JWTAuthIssuersSettingName  = baseJWTAuthSettingName + "issuers"
 	JWTAuthJWKSSettingName     = baseJWTAuthSettingName + "jwks"
 	JWTAuthClaimSettingName    = baseJWTAuthSettingName + "claim"
+
+	JWTAuthProvisioningEnabledSettingName = baseJWTAuthSettingName + "provisioning.enabled"
+	JWTAuthGroupClaimSettingName          = baseJWTAuthSettingName + "group_claim"
+	JWTAuthGroupRoleMappingSettingName    = baseJWTAuthSettingName + "group_role_mapping"
 )
 
 // JWTAuthClaim sets the JWT claim that is parsed to get the username.
@@ -88,6 +93,48 @@ var JWTAuthIssuers = func() *settings.StringSetting {
 	return s
 }()
 
+// JWTAuthProvisioningEnabled enables the creation of users that do not exist
+// yet on their first successful JWT login.
+var JWTAuthProvisioningEnabled = func() *settings.BoolSetting {
+	s := settings.RegisterBoolSetting(
+		settings.TenantWritable,
+		JWTAuthProvisioningEnabledSettingName,
+		"enables the creation of SQL users on their first successful JWT login",
+		false,
+	)
+	s.SetReportable(true)
+	return s
+}()
+
+// JWTAuthGroupClaim sets the JWT claim that is parsed to get the groups of the
+// user, which are mapped to roles using JWTAuthGroupRoleMapping.
+var JWTAuthGroupClaim = func() *settings.StringSetting {
+	s := settings.RegisterStringSetting(
+		settings.TenantWritable,
+		JWTAuthGroupClaimSettingName,
+		"sets the JWT claim that is parsed to get the groups of the user; if empty, "+
+			"role memberships are not synchronized on JWT logins",
+		"",
+	)
+	s.SetReportable(true)
+	return s
+}()
+
+// JWTAuthGroupRoleMapping maps the values of the group claim to SQL roles.
+var JWTAuthGroupRoleMapping = func() *settings.StringSetting {
+	s := settings.RegisterValidatedStringSetting(
+		settings.TenantWritable,
+		JWTAuthGroupRoleMappingSettingName,
+		"sets the mapping from the values of the group claim to SQL roles, as a JSON object whose "+
+			"values are either a role name or an array of role names; on every JWT login, the user "+
+			"is granted the roles its groups map to, and its memberships of the other roles in the "+
+			"mapping are revoked; the admin role cannot be mapped",
+		"{}",
+		validateJWTAuthGroupRoleMapping,
+	)
+	return s
+}()
+
 func validateJWTAuthIssuers(values *settings.Values, s string) error {
 	var issuers []string
 
@@ -131,6 +178,52 @@ func validateJWTAuthJWKS(values *settings.Values, s string) error {
 	return nil
 }
 
+func validateJWTAuthGroupRoleMapping(values *settings.Values, s string) error {
+	_, err := parseGroupRoleMapping(s)
+	return err
+}
+
+// parseGroupRoleMapping parses the value of the group role mapping setting.
+// Each group maps to one or more roles, none of which can be the admin role
+// or a reserved one.
+func parseGroupRoleMapping(s string) (map[string][]username.SQLUsername, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil, errors.Wrap(err, "JWT authentication group role mapping JSON not valid")
+	}
+	mapping := make(map[string][]username.SQLUsername, len(raw))
+	for group, rawRoles := range raw {
+		var roleNames []string
+		var roleName string
+		if err := json.Unmarshal(rawRoles, &roleName); err == nil {
+			roleNames = []string{roleName}
+		} else if err := json.Unmarshal(rawRoles, &roleNames); err != nil {
+			return nil, errors.Newf(
+				"JWT authentication group role mapping for %q must be a role name or an array of role names", group)
+		}
+		for _, name := range roleNames {
+			role, err := username.MakeSQLUsernameFromUserInput(name, username.PurposeValidation)
+			if err != nil {
+				return nil, errors.Wrapf(err, "JWT authentication group role mapping for %q not valid", group)
+			}
+			if role.IsRootUser() || role.IsAdminRole() || role.IsReserved() {
+				return nil, errors.Newf(
+					"JWT authentication group role mapping for %q cannot use privileged or reserved role %s", group, role)
+			}
+			mapping[group] = append(mapping[group], role)
+		}
+	}
+	return mapping, nil
+}
+
+func mustParseGroupRoleMapping(s string) map[string][]username.SQLUsername {
+	mapping, err := parseGroupRoleMapping(s)
+	if err != nil {
+		return nil
+	}
+	return mapping
+}
+
 func mustParseValueOrArray(rawString string) []string {
 	var array []string
 
@@ -154,3 +154,55 @@ func TestValidateAndParseJWTAuthJWKS(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAndParseJWTAuthGroupRoleMapping(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	tests := []struct {
+		name            string
+		setting         string
+		wantErr         bool
+		expectedMapping map[string][]string
+	}{
+		{"empty json",
+			"{}", false,
+			map[string][]string{}},
+		{"single role",
+			"{\"group1\": \"role1\"}", false,
+			map[string][]string{"group1": {"role1"}}},
+		{"multiple roles",
+			"{\"group1\": [\"role1\", \"Role2\"], \"group2\": \"role3\"}", false,
+			map[string][]string{"group1": {"role1", "role2"}, "group2": {"role3"}}},
+		{"empty string",
+			"", true,
+			nil},
+		{"json array",
+			"[\"role1\"]", true,
+			nil},
+		{"invalid role value",
+			"{\"group1\": 1}", true,
+			nil},
+		{"reserved role",
+			"{\"group1\": \"public\"}", true,
+			nil},
+		{"admin role",
+			"{\"group1\": [\"role1\", \"admin\"]}", true,
+			nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateJWTAuthGroupRoleMapping(nil, tt.setting); (err != nil) != tt.wantErr {
+				t.Errorf("validateJWTAuthGroupRoleMapping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				parsedMapping := mustParseGroupRoleMapping(tt.setting)
+				mapping := make(map[string][]string, len(parsedMapping))
+				for group, roles := range parsedMapping {
+					for _, role := range roles {
+						mapping[group] = append(mapping[group], role.Normalized())
+					}
+				}
+				require.Equal(t, tt.expectedMapping, mapping)
+			}
+		})
+	}
+}
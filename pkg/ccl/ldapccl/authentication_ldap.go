@@ -0,0 +1,365 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	counterPrefix           = "auth.ldap."
+	beginAuthCounterName    = counterPrefix + "begin_auth"
+	loginSuccessCounterName = counterPrefix + "login_success"
+)
+
+var (
+	beginAuthUseCounter    = telemetry.GetCounterOnce(beginAuthCounterName)
+	loginSuccessUseCounter = telemetry.GetCounterOnce(loginSuccessCounterName)
+)
+
+// authCleartextPassword is the pgwire auth response code to request a
+// plaintext password from the client.
+const authCleartextPassword int32 = 3
+
+// The options accepted by the "ldap" HBA method. They match the ones of
+// PostgreSQL, with the addition of ldapgrouplistfilter which enables the
+// synchronization of role memberships with directory groups.
+const (
+	optServer          = "ldapserver"
+	optPort            = "ldapport"
+	optScheme          = "ldapscheme"
+	optTLS             = "ldaptls"
+	optPrefix          = "ldapprefix"
+	optSuffix          = "ldapsuffix"
+	optBaseDN          = "ldapbasedn"
+	optBindDN          = "ldapbinddn"
+	optBindPasswd      = "ldapbindpasswd"
+	optSearchAttribute = "ldapsearchattribute"
+	optSearchFilter    = "ldapsearchfilter"
+	optGroupListFilter = "ldapgrouplistfilter"
+)
+
+// ldapConfig is the directory server configuration of an "ldap" HBA entry.
+//
+// In simple bind mode, the user is authenticated by binding as the DN formed
+// by ldapprefix, the user name and ldapsuffix. In search+bind mode, which is
+// selected by ldapbasedn, the DN of the user is first looked up under the base
+// DN, optionally binding as ldapbinddn to do so, and the user is then
+// authenticated by binding as that DN.
+type ldapConfig struct {
+	server   string
+	port     string
+	scheme   string
+	startTLS bool
+
+	prefix string
+	suffix string
+
+	baseDN          string
+	bindDN          string
+	bindPasswd      string
+	searchAttribute string
+	searchFilter    string
+
+	groupListFilter string
+}
+
+func makeLDAPConfig(entry *hba.Entry) ldapConfig {
+	conf := ldapConfig{
+		server:          entry.GetOption(optServer),
+		port:            entry.GetOption(optPort),
+		scheme:          entry.GetOption(optScheme),
+		startTLS:        entry.GetOption(optTLS) == "1",
+		prefix:          entry.GetOption(optPrefix),
+		suffix:          entry.GetOption(optSuffix),
+		baseDN:          entry.GetOption(optBaseDN),
+		bindDN:          entry.GetOption(optBindDN),
+		bindPasswd:      entry.GetOption(optBindPasswd),
+		searchAttribute: entry.GetOption(optSearchAttribute),
+		searchFilter:    entry.GetOption(optSearchFilter),
+		groupListFilter: entry.GetOption(optGroupListFilter),
+	}
+	if conf.scheme == "" {
+		conf.scheme = "ldap"
+	}
+	if conf.port == "" {
+		conf.port = "389"
+		if conf.scheme == "ldaps" {
+			conf.port = "636"
+		}
+	}
+	if conf.searchAttribute == "" {
+		conf.searchAttribute = "uid"
+	}
+	return conf
+}
+
+// url returns the URL of the directory server.
+func (c ldapConfig) url() string {
+	return fmt.Sprintf("%s://%s", c.scheme, net.JoinHostPort(c.server, c.port))
+}
+
+// searchAndBind returns true if the user DN is looked up in the directory
+// before binding, as opposed to being formed from the user name.
+func (c ldapConfig) searchAndBind() bool {
+	return c.baseDN != ""
+}
+
+// userSearchFilter returns the filter used to look up the user in search+bind
+// mode.
+func (c ldapConfig) userSearchFilter(user username.SQLUsername) string {
+	escaped := ldap.EscapeFilter(user.Normalized())
+	if c.searchFilter != "" {
+		return strings.ReplaceAll(c.searchFilter, "$username", escaped)
+	}
+	return fmt.Sprintf("(%s=%s)", c.searchAttribute, escaped)
+}
+
+// checkEntry validates the options of an "ldap" HBA entry.
+func checkEntry(_ *settings.Values, entry hba.Entry) error {
+	for _, op := range entry.Options {
+		switch op[0] {
+		case optServer, optPrefix, optSuffix, optBaseDN, optBindDN, optBindPasswd,
+			optSearchAttribute, optSearchFilter, optGroupListFilter:
+		case optPort:
+			if _, err := strconv.ParseUint(op[1], 10, 16); err != nil {
+				return errors.Errorf("invalid LDAP port number: %s", op[1])
+			}
+		case optScheme:
+			if op[1] != "ldap" && op[1] != "ldaps" {
+				return errors.Errorf("invalid ldapscheme value: %s", op[1])
+			}
+		case optTLS:
+			if op[1] != "0" && op[1] != "1" {
+				return errors.Errorf("ldaptls must be set to 0 or 1: %s", op[1])
+			}
+		default:
+			return errors.Errorf("unsupported option %s", op[0])
+		}
+	}
+	conf := makeLDAPConfig(&entry)
+	if conf.server == "" {
+		return errors.Newf(`the "%s" option is required`, optServer)
+	}
+	if conf.startTLS && conf.scheme == "ldaps" {
+		return errors.Newf(`cannot use "%s=1" with "%s=ldaps"`, optTLS, optScheme)
+	}
+	simpleBind := conf.prefix != "" || conf.suffix != ""
+	searchBind := conf.baseDN != "" || conf.bindDN != "" || conf.bindPasswd != "" ||
+		entry.GetOption(optSearchAttribute) != "" || conf.searchFilter != ""
+	if simpleBind && searchBind {
+		return errors.Newf(`cannot use "%s" or "%s" together with search+bind options`, optPrefix, optSuffix)
+	}
+	if searchBind && conf.baseDN == "" {
+		return errors.Newf(`the "%s" option is required for search+bind`, optBaseDN)
+	}
+	if entry.GetOption(optSearchAttribute) != "" && conf.searchFilter != "" {
+		return errors.Newf(`cannot use "%s" together with "%s"`, optSearchAttribute, optSearchFilter)
+	}
+	if conf.groupListFilter != "" && conf.baseDN == "" {
+		return errors.Newf(`the "%s" option requires "%s"`, optGroupListFilter, optBaseDN)
+	}
+	return nil
+}
+
+// ldapTLSConfig returns the TLS configuration used to connect to the
+// directory server over LDAPS or StartTLS.
+func ldapTLSConfig(st *cluster.Settings, conf ldapConfig) (*tls.Config, error) {
+	tlsConf := &tls.Config{ServerName: conf.server}
+	if ca := LDAPDomainCACertificate.Get(&st.SV); ca != "" {
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM([]byte(ca)); !ok {
+			return nil, errors.New("LDAP authentication: custom CA is not a valid PEM certificate")
+		}
+		tlsConf.RootCAs = pool
+	}
+	return tlsConf, nil
+}
+
+// ldapDNSpecialChars are the characters that are not allowed in user names in
+// simple bind mode, as they would alter the meaning of the DN.
+const ldapDNSpecialChars = `,+"\<>;=#`
+
+// authenticate binds to the directory server as the given user, and returns
+// the DN of the user. On success, the connection remains bound as the user.
+func (c ldapConfig) authenticate(
+	ctx context.Context, util ldapUtil, user username.SQLUsername, password string,
+) (userDN string, _ error) {
+	if !c.searchAndBind() {
+		if strings.ContainsAny(user.Normalized(), ldapDNSpecialChars) {
+			return "", errors.Newf("LDAP authentication: invalid character in user name %s", user)
+		}
+		userDN = c.prefix + user.Normalized() + c.suffix
+	} else {
+		if c.bindDN != "" {
+			if err := util.bind(ctx, c.bindDN, c.bindPasswd); err != nil {
+				return "", errors.Wrapf(err, "LDAP authentication: unable to bind as %s", c.bindDN)
+			}
+		}
+		dns, err := util.search(ctx, c.baseDN, c.userSearchFilter(user))
+		if err != nil {
+			return "", errors.Wrap(err, "LDAP authentication: user search failed")
+		}
+		if len(dns) != 1 {
+			return "", errors.WithDetailf(
+				errors.Newf("LDAP authentication: user %s not found", user),
+				"the user search returned %d entries", len(dns))
+		}
+		userDN = dns[0]
+	}
+	if err := util.bind(ctx, userDN, password); err != nil {
+		return "", security.NewErrPasswordUserAuthFailed(user)
+	}
+	return userDN, nil
+}
+
+func passwordString(pwdData []byte) (string, error) {
+	if bytes.IndexByte(pwdData, 0) != len(pwdData)-1 {
+		return "", errors.New("expected 0-terminated byte array")
+	}
+	return string(pwdData[:len(pwdData)-1]), nil
+}
+
+// authLDAP is the AuthMethod constructor for HBA method "ldap": the client
+// sends its password in clear, which is verified by binding to a directory
+// server. If the ldapgrouplistfilter option is set and role synchronization is
+// enabled, the memberships of the user of the roles in the group role mapping
+// are then synchronized with its groups in the directory.
+func authLDAP(
+	_ context.Context,
+	c pgwire.AuthConn,
+	_ tls.ConnectionState,
+	execCfg *sql.ExecutorConfig,
+	entry *hba.Entry,
+	_ *identmap.Conf,
+) (*pgwire.AuthBehaviors, error) {
+	conf := makeLDAPConfig(entry)
+	util := newLDAPUtil()
+	// userDN is set by the authenticator, for use by the authorizer.
+	var userDN string
+
+	b := &pgwire.AuthBehaviors{}
+	b.SetConnClose(util.close)
+	b.SetRoleMapper(pgwire.UseProvidedIdentity)
+	b.SetAuthenticator(func(
+		ctx context.Context,
+		user username.SQLUsername,
+		clientConnection bool,
+		_ pgwire.PasswordRetrievalFn,
+	) error {
+		if conf.groupListFilter == "" {
+			defer util.close()
+		}
+		if !clientConnection {
+			err := errors.New("LDAP authentication is only available for client connections")
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		if user.IsRootUser() || user.IsReserved() {
+			err := errors.WithDetailf(
+				errors.Newf("LDAP authentication: invalid identity"),
+				"cannot use LDAP auth to login to a reserved user %s", user)
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		telemetry.Inc(beginAuthUseCounter)
+
+		// Request the password from the client.
+		if err := c.SendAuthRequest(authCleartextPassword, nil /* data */); err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		pwdData, err := c.GetPwdData()
+		if err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		password, err := passwordString(pwdData)
+		if err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		// An empty password would result in an unauthenticated bind, which
+		// most directory servers accept.
+		if password == "" {
+			err := security.NewErrPasswordUserAuthFailed(user)
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_INVALID, err)
+			return err
+		}
+
+		if err := utilccl.CheckEnterpriseEnabled(
+			execCfg.Settings, execCfg.NodeInfo.LogicalClusterID(), "LDAP authentication",
+		); err != nil {
+			return err
+		}
+		tlsConf, err := ldapTLSConfig(execCfg.Settings, conf)
+		if err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		if err := util.connect(ctx, conf, tlsConf, LDAPConnectTimeout.Get(&execCfg.Settings.SV)); err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		if userDN, err = conf.authenticate(ctx, util, user, password); err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_INVALID, err)
+			return err
+		}
+		c.LogAuthInfof(ctx, "LDAP bind succeeded as %s", userDN)
+		telemetry.Inc(loginSuccessUseCounter)
+		return nil
+	})
+	if conf.groupListFilter != "" {
+		b.SetAuthorizer(func(ctx context.Context, _, sessionUser username.SQLUsername) error {
+			defer util.close()
+			if !LDAPSyncRolesEnabled.Get(&execCfg.Settings.SV) {
+				return nil
+			}
+			mapping, err := parseGroupRoleMapping(LDAPGroupRoleMapping.Get(&execCfg.Settings.SV))
+			if err != nil {
+				c.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+				return err
+			}
+			roles, err := conf.fetchGroupRoles(ctx, util, userDN, mapping)
+			if err != nil {
+				c.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+				return err
+			}
+			// Only the mapped roles are managed, so that the memberships granted
+			// by hand are left alone.
+			return sql.SyncRoleMemberships(ctx, execCfg, sessionUser, roles, mapping.managedRoles())
+		})
+	}
+	return b, nil
+}
+
+func init() {
+	pgwire.RegisterAuthMethod("ldap", authLDAP, hba.ConnAny, checkEntry)
+}
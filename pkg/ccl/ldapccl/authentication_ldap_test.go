@@ -0,0 +1,257 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+const baseDN = "dc=example,dc=com"
+
+func parseEntry(t *testing.T, line string) *hba.Entry {
+	conf, err := hba.ParseAndNormalize(line)
+	require.NoError(t, err)
+	require.Len(t, conf.Entries, 1)
+	return &conf.Entries[0]
+}
+
+func makeTestDirectory() *testDirectory {
+	d := &testDirectory{}
+	d.addEntry("cn=search,"+baseDN, "searchpw")
+	d.addEntry("uid=alice,ou=users,"+baseDN, "alicepw",
+		"objectClass", "person", "uid", "alice", "mail", "alice@example.com")
+	d.addEntry("uid=bob,ou=users,"+baseDN, "bobpw",
+		"objectClass", "person", "uid", "bob")
+	d.addEntry("cn=analysts,ou=groups,"+baseDN, "",
+		"objectClass", "groupOfNames", "member", "uid=alice,ou=users,"+baseDN)
+	d.addEntry("cn=Writers,ou=groups,"+baseDN, "",
+		"objectClass", "groupOfNames",
+		"member", "uid=alice,ou=users,"+baseDN, "member", "uid=bob,ou=users,"+baseDN)
+	d.addEntry("cn=admins,ou=other,"+baseDN, "",
+		"objectClass", "posixGroup", "member", "uid=alice,ou=users,"+baseDN)
+	return d
+}
+
+func TestCheckEntry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		options string
+		err     string
+	}{
+		{options: `ldapserver=ldap.example.com`},
+		{options: `ldapserver=ldap.example.com ldapprefix=uid= "ldapsuffix=,ou=users,dc=example,dc=com"`},
+		{options: `ldapserver=ldap.example.com ldapscheme=ldaps ldapport=10636 "ldapbasedn=dc=example,dc=com"`},
+		{options: `ldapserver=ldap.example.com ldaptls=1 "ldapbasedn=dc=example,dc=com" "ldapbinddn=cn=search,dc=example,dc=com" ldapbindpasswd=pw ldapsearchattribute=sAMAccountName "ldapgrouplistfilter=(objectClass=group)"`},
+		{options: `ldapserver=ldap.example.com "ldapbasedn=dc=example,dc=com" "ldapsearchfilter=(&(objectClass=person)(uid=$username))"`},
+		{options: `ldapprefix=uid=`, err: `the "ldapserver" option is required`},
+		{options: `ldapserver=ldap.example.com ldapfoo=bar`, err: `unsupported option ldapfoo`},
+		{options: `ldapserver=ldap.example.com ldapport=http`, err: `invalid LDAP port number: http`},
+		{options: `ldapserver=ldap.example.com ldapscheme=http`, err: `invalid ldapscheme value: http`},
+		{options: `ldapserver=ldap.example.com ldaptls=yes`, err: `ldaptls must be set to 0 or 1`},
+		{options: `ldapserver=ldap.example.com ldaptls=1 ldapscheme=ldaps`, err: `cannot use "ldaptls=1" with "ldapscheme=ldaps"`},
+		{options: `ldapserver=ldap.example.com ldapprefix=uid= "ldapbasedn=dc=example,dc=com"`, err: `cannot use "ldapprefix" or "ldapsuffix" together with search+bind options`},
+		{options: `ldapserver=ldap.example.com ldapsearchattribute=uid`, err: `the "ldapbasedn" option is required for search+bind`},
+		{options: `ldapserver=ldap.example.com "ldapbasedn=dc=example,dc=com" ldapsearchattribute=uid "ldapsearchfilter=(uid=$username)"`, err: `cannot use "ldapsearchattribute" together with "ldapsearchfilter"`},
+		{options: `ldapserver=ldap.example.com ldapprefix=uid= "ldapgrouplistfilter=(objectClass=group)"`, err: `the "ldapgrouplistfilter" option requires "ldapbasedn"`},
+	} {
+		t.Run(tc.options, func(t *testing.T) {
+			entry := parseEntry(t, "host all all all ldap "+tc.options)
+			err := checkEntry(nil /* values */, *entry)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestLDAPConfig(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	conf := makeLDAPConfig(parseEntry(t, `host all all all ldap ldapserver=ldap.example.com`))
+	require.Equal(t, "ldap://ldap.example.com:389", conf.url())
+	require.False(t, conf.searchAndBind())
+
+	conf = makeLDAPConfig(parseEntry(t,
+		`host all all all ldap ldapserver=ldap.example.com ldapscheme=ldaps "ldapbasedn=dc=example,dc=com"`))
+	require.Equal(t, "ldaps://ldap.example.com:636", conf.url())
+	require.True(t, conf.searchAndBind())
+	require.Equal(t, "(uid=a\\2ab)", conf.userSearchFilter(username.MakeSQLUsernameFromPreNormalizedString("a*b")))
+
+	conf = makeLDAPConfig(parseEntry(t,
+		`host all all all ldap ldapserver=ldap.example.com "ldapbasedn=dc=example,dc=com" "ldapsearchfilter=(&(objectClass=person)(mail=$username@example.com))"`))
+	require.Equal(t, "(&(objectClass=person)(mail=alice@example.com))",
+		conf.userSearchFilter(username.MakeSQLUsernameFromPreNormalizedString("alice")))
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	alice := username.MakeSQLUsernameFromPreNormalizedString("alice")
+	aliceDN := "uid=alice,ou=users," + baseDN
+
+	for _, tc := range []struct {
+		name     string
+		options  string
+		user     username.SQLUsername
+		password string
+		dn       string
+		err      string
+		binds    []string
+	}{{
+		name:     "simple bind",
+		options:  `ldapprefix=uid= "ldapsuffix=,ou=users,dc=example,dc=com"`,
+		user:     alice,
+		password: "alicepw",
+		dn:       aliceDN,
+		binds:    []string{aliceDN},
+	}, {
+		name:     "simple bind wrong password",
+		options:  `ldapprefix=uid= "ldapsuffix=,ou=users,dc=example,dc=com"`,
+		user:     alice,
+		password: "bobpw",
+		err:      "password authentication failed for user alice",
+	}, {
+		name:     "simple bind invalid character",
+		options:  `ldapprefix=uid= "ldapsuffix=,ou=users,dc=example,dc=com"`,
+		user:     username.MakeSQLUsernameFromPreNormalizedString("alice,ou=other"),
+		password: "alicepw",
+		err:      "invalid character in user name",
+	}, {
+		name:     "search and bind",
+		options:  `"ldapbasedn=dc=example,dc=com" "ldapbinddn=cn=search,dc=example,dc=com" ldapbindpasswd=searchpw`,
+		user:     alice,
+		password: "alicepw",
+		dn:       aliceDN,
+		binds:    []string{"cn=search," + baseDN, aliceDN},
+	}, {
+		name:     "search and bind with filter",
+		options:  `"ldapbasedn=dc=example,dc=com" "ldapsearchfilter=(&(objectClass=person)(mail=$username@example.com))"`,
+		user:     alice,
+		password: "alicepw",
+		dn:       aliceDN,
+		binds:    []string{aliceDN},
+	}, {
+		name:     "search and bind wrong search password",
+		options:  `"ldapbasedn=dc=example,dc=com" "ldapbinddn=cn=search,dc=example,dc=com" ldapbindpasswd=wrong`,
+		user:     alice,
+		password: "alicepw",
+		err:      "unable to bind as cn=search,dc=example,dc=com",
+	}, {
+		name:     "search and bind unknown user",
+		options:  `"ldapbasedn=dc=example,dc=com"`,
+		user:     username.MakeSQLUsernameFromPreNormalizedString("carol"),
+		password: "carolpw",
+		err:      "LDAP authentication: user carol not found",
+	}, {
+		name:     "search and bind wrong password",
+		options:  `"ldapbasedn=dc=example,dc=com"`,
+		user:     alice,
+		password: "wrong",
+		err:      "password authentication failed for user alice",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := makeLDAPConfig(parseEntry(t, "host all all all ldap ldapserver=localhost "+tc.options))
+			dir := makeTestDirectory()
+			util := dir.util()
+			require.NoError(t, util.connect(ctx, conf, nil /* tlsConf */, 0 /* timeout */))
+			defer util.close()
+
+			dn, err := conf.authenticate(ctx, util, tc.user, tc.password)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.dn, dn)
+			require.Equal(t, tc.binds, dir.binds)
+		})
+	}
+}
+
+func TestLDAPFetchGroupRoles(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	conf := makeLDAPConfig(parseEntry(t, `host all all all ldap ldapserver=localhost `+
+		`"ldapbasedn=dc=example,dc=com" "ldapbinddn=cn=search,dc=example,dc=com" ldapbindpasswd=searchpw `+
+		`"ldapgrouplistfilter=(objectClass=groupOfNames)"`))
+	dir := makeTestDirectory()
+	util := dir.util()
+	require.NoError(t, util.connect(ctx, conf, nil /* tlsConf */, 0 /* timeout */))
+	defer util.close()
+
+	// The groups are matched case-insensitively, and the groups that are not
+	// mapped are ignored.
+	mapping, err := parseGroupRoleMapping(`{
+		"CN=analysts,OU=groups,DC=example,DC=com": ["analysts", "readers"],
+		"cn=writers,ou=groups,dc=example,dc=com": "writers",
+		"cn=admins,ou=other,dc=example,dc=com": "operators"
+	}`)
+	require.NoError(t, err)
+
+	roles, err := conf.fetchGroupRoles(ctx, util, "uid=alice,ou=users,"+baseDN, mapping)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []username.SQLUsername{
+		username.MakeSQLUsernameFromPreNormalizedString("analysts"),
+		username.MakeSQLUsernameFromPreNormalizedString("readers"),
+		username.MakeSQLUsernameFromPreNormalizedString("writers"),
+	}, roles)
+
+	roles, err = conf.fetchGroupRoles(ctx, util, "uid=bob,ou=users,"+baseDN, mapping)
+	require.NoError(t, err)
+	require.Equal(t, []username.SQLUsername{
+		username.MakeSQLUsernameFromPreNormalizedString("writers"),
+	}, roles)
+
+	roles, err = conf.fetchGroupRoles(ctx, util, "uid=bob,ou=users,"+baseDN, nil /* mapping */)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+}
+
+func TestParseGroupRoleMapping(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	mapping, err := parseGroupRoleMapping(`{}`)
+	require.NoError(t, err)
+	require.NotNil(t, mapping.managedRoles())
+	require.Empty(t, mapping.managedRoles())
+
+	mapping, err = parseGroupRoleMapping(`{"cn=Data Analysts,ou=groups,dc=example,dc=com": ["Analysts", "readers"]}`)
+	require.NoError(t, err)
+	require.Equal(t, []username.SQLUsername{
+		username.MakeSQLUsernameFromPreNormalizedString("analysts"),
+		username.MakeSQLUsernameFromPreNormalizedString("readers"),
+	}, mapping.managedRoles())
+
+	for _, tc := range []struct {
+		mapping string
+		err     string
+	}{
+		{mapping: `not json`, err: `group role mapping JSON not valid`},
+		{mapping: `{"not a dn": "analysts"}`, err: `invalid group DN "not a dn"`},
+		{mapping: `{"cn=analysts,dc=example,dc=com": 1}`, err: `must be a role name or an array of role names`},
+		{mapping: `{"cn=admins,dc=example,dc=com": "admin"}`, err: `cannot use privileged or reserved role admin`},
+		{mapping: `{"cn=admins,dc=example,dc=com": ["analysts", "root"]}`, err: `cannot use privileged or reserved role root`},
+		{mapping: `{"cn=public,dc=example,dc=com": "public"}`, err: `cannot use privileged or reserved role public`},
+	} {
+		t.Run(tc.mapping, func(t *testing.T) {
+			require.ErrorContains(t, validateLDAPGroupRoleMapping(nil /* values */, tc.mapping), tc.err)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// groupSearchFilter returns the filter used to look up the groups of the user
+// with the given DN.
+func (c ldapConfig) groupSearchFilter(userDN string) string {
+	return fmt.Sprintf("(&%s(member=%s))", c.groupListFilter, ldap.EscapeFilter(userDN))
+}
+
+// fetchGroupRoles returns the SQL roles the directory groups of the user with
+// the given DN map to in the given mapping. Groups that are not in the
+// mapping are ignored.
+func (c ldapConfig) fetchGroupRoles(
+	ctx context.Context, util ldapUtil, userDN string, mapping groupRoleMapping,
+) ([]username.SQLUsername, error) {
+	// Users are not always allowed to search the directory, so the groups are
+	// looked up as the search user if there is one.
+	if c.bindDN != "" {
+		if err := util.bind(ctx, c.bindDN, c.bindPasswd); err != nil {
+			return nil, errors.Wrapf(err, "LDAP authorization: unable to bind as %s", c.bindDN)
+		}
+	}
+	groupDNs, err := util.search(ctx, c.baseDN, c.groupSearchFilter(userDN))
+	if err != nil {
+		return nil, errors.Wrap(err, "LDAP authorization: group search failed")
+	}
+	var roles []username.SQLUsername
+	for _, groupDN := range groupDNs {
+		dn, err := ldap.ParseDN(groupDN)
+		if err != nil {
+			log.Warningf(ctx, "ignoring LDAP group %q: %v", groupDN, err)
+			continue
+		}
+		roles = append(roles, mapping.rolesOf(dn)...)
+	}
+	return roles, nil
+}
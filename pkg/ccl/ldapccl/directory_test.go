@@ -0,0 +1,149 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"crypto/tls"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// directoryEntry is an entry of a testDirectory.
+type directoryEntry struct {
+	attrs    map[string][]string
+	password string
+}
+
+// testDirectory is an in-process stand-in for a directory server. It
+// supports binding and searching with filters made of equality and
+// conjunction terms, which is all the LDAP authentication method needs.
+type testDirectory struct {
+	entries map[string]directoryEntry
+	// binds records the DNs successfully bound to, in order.
+	binds []string
+}
+
+// addEntry adds an entry with the given DN, password and attributes, given
+// as alternating names and values.
+func (d *testDirectory) addEntry(dn string, password string, attrs ...string) {
+	if d.entries == nil {
+		d.entries = make(map[string]directoryEntry)
+	}
+	e := directoryEntry{attrs: make(map[string][]string), password: password}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		name := strings.ToLower(attrs[i])
+		e.attrs[name] = append(e.attrs[name], attrs[i+1])
+	}
+	d.entries[dn] = e
+}
+
+// util returns an ldapUtil connected to the directory.
+func (d *testDirectory) util() ldapUtil {
+	return &testDirectoryUtil{dir: d}
+}
+
+type testDirectoryUtil struct {
+	dir       *testDirectory
+	connected bool
+}
+
+var _ ldapUtil = &testDirectoryUtil{}
+
+func (u *testDirectoryUtil) connect(context.Context, ldapConfig, *tls.Config, time.Duration) error {
+	u.connected = true
+	return nil
+}
+
+func (u *testDirectoryUtil) bind(_ context.Context, dn string, password string) error {
+	if !u.connected {
+		return errors.New("not connected")
+	}
+	e, ok := u.dir.entries[dn]
+	if !ok || password == "" || e.password != password {
+		return errors.New("LDAP Result Code 49 \"Invalid Credentials\"")
+	}
+	u.dir.binds = append(u.dir.binds, dn)
+	return nil
+}
+
+func (u *testDirectoryUtil) search(
+	_ context.Context, baseDN string, filter string,
+) ([]string, error) {
+	if !u.connected {
+		return nil, errors.New("not connected")
+	}
+	var dns []string
+	for dn, e := range u.dir.entries {
+		if !strings.HasSuffix(dn, baseDN) {
+			continue
+		}
+		ok, rest, err := matchFilter(filter, e)
+		if err != nil {
+			return nil, err
+		}
+		if rest != "" {
+			return nil, errors.Newf("invalid filter %q", filter)
+		}
+		if ok {
+			dns = append(dns, dn)
+		}
+	}
+	sort.Strings(dns)
+	return dns, nil
+}
+
+func (u *testDirectoryUtil) close() {
+	u.connected = false
+}
+
+// matchFilter evaluates the filter at the start of the given string against
+// the entry, and returns the remainder of the string.
+func matchFilter(filter string, e directoryEntry) (ok bool, rest string, _ error) {
+	if !strings.HasPrefix(filter, "(") {
+		return false, "", errors.Newf("invalid filter %q", filter)
+	}
+	if strings.HasPrefix(filter, "(&") {
+		rest = filter[2:]
+		ok = true
+		for !strings.HasPrefix(rest, ")") {
+			var termOK bool
+			var err error
+			if termOK, rest, err = matchFilter(rest, e); err != nil {
+				return false, "", err
+			}
+			ok = ok && termOK
+		}
+		return ok, rest[1:], nil
+	}
+	end := strings.IndexByte(filter, ')')
+	if end < 0 {
+		return false, "", errors.Newf("invalid filter %q", filter)
+	}
+	kv := strings.SplitN(filter[1:end], "=", 2)
+	if len(kv) != 2 {
+		return false, "", errors.Newf("invalid filter %q", filter)
+	}
+	value := unescapeFilterValue(kv[1])
+	for _, v := range e.attrs[strings.ToLower(kv[0])] {
+		if strings.EqualFold(v, value) {
+			return true, filter[end+1:], nil
+		}
+	}
+	return false, filter[end+1:], nil
+}
+
+// unescapeFilterValue reverses the escaping of ldap.EscapeFilter for the
+// characters that it escapes.
+func unescapeFilterValue(v string) string {
+	return strings.NewReplacer(`\28`, "(", `\29`, ")", `\2a`, "*", `\5c`, `\`, `\00`, "\x00").Replace(v)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapUtil is the interface to the directory server used by the LDAP
+// authentication method. It is implemented on top of the go-ldap client, and
+// replaced by an in-process stand-in in tests.
+type ldapUtil interface {
+	// connect opens a connection to the directory server described by conf,
+	// upgrading it to TLS first if StartTLS is requested.
+	connect(ctx context.Context, conf ldapConfig, tlsConf *tls.Config, timeout time.Duration) error
+	// bind authenticates the connection as the given DN.
+	bind(ctx context.Context, dn string, password string) error
+	// search returns the DNs of the entries under baseDN that match filter.
+	search(ctx context.Context, baseDN string, filter string) ([]string, error)
+	// close closes the connection, if any. It may be called more than once.
+	close()
+}
+
+// newLDAPUtil returns the ldapUtil used for every LDAP login. It is a variable
+// so that tests can substitute it.
+var newLDAPUtil = func() ldapUtil {
+	return &goLDAPUtil{}
+}
+
+// goLDAPUtil implements ldapUtil using the go-ldap client.
+type goLDAPUtil struct {
+	conn *ldap.Conn
+}
+
+var _ ldapUtil = &goLDAPUtil{}
+
+func (u *goLDAPUtil) connect(
+	_ context.Context, conf ldapConfig, tlsConf *tls.Config, timeout time.Duration,
+) error {
+	conn, err := ldap.DialURL(
+		conf.url(),
+		ldap.DialWithTLSConfig(tlsConf),
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "LDAP authentication: unable to connect to %s", conf.url())
+	}
+	u.conn = conn
+	if conf.startTLS {
+		if err := u.conn.StartTLS(tlsConf); err != nil {
+			return errors.Wrap(err, "LDAP authentication: unable to start TLS")
+		}
+	}
+	return nil
+}
+
+func (u *goLDAPUtil) bind(_ context.Context, dn string, password string) error {
+	return u.conn.Bind(dn, password)
+}
+
+func (u *goLDAPUtil) search(_ context.Context, baseDN string, filter string) ([]string, error) {
+	res, err := u.conn.Search(ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,     /* sizeLimit */
+		0,     /* timeLimit */
+		false, /* typesOnly */
+		filter,
+		[]string{"dn"},
+		nil, /* controls */
+	))
+	if err != nil {
+		return nil, err
+	}
+	dns := make([]string, len(res.Entries))
+	for i, e := range res.Entries {
+		dns[i] = e.DN
+	}
+	return dns, nil
+}
+
+func (u *goLDAPUtil) close() {
+	if u.conn != nil {
+		u.conn.Close()
+		u.conn = nil
+	}
+}
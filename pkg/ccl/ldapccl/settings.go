@@ -0,0 +1,165 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// All cluster settings necessary for the LDAP authentication feature. The
+// directory server itself is configured through the options of the "ldap"
+// HBA method.
+const (
+	baseLDAPAuthSettingName            = "server.ldap_authentication."
+	LDAPDomainCACertificateSettingName = baseLDAPAuthSettingName + "domain.custom_ca"
+	LDAPConnectTimeoutSettingName      = baseLDAPAuthSettingName + "connect_timeout"
+	LDAPSyncRolesEnabledSettingName    = baseLDAPAuthSettingName + "sync_roles.enabled"
+	LDAPGroupRoleMappingSettingName    = baseLDAPAuthSettingName + "group_role_mapping"
+)
+
+// LDAPDomainCACertificate is the PEM encoded CA certificate used to verify
+// the certificate of the directory server over LDAPS or StartTLS. The system
+// certificate pool is used if it is empty.
+var LDAPDomainCACertificate = func() *settings.StringSetting {
+	s := settings.RegisterValidatedStringSetting(
+		settings.TenantWritable,
+		LDAPDomainCACertificateSettingName,
+		"sets the PEM encoded custom root CA for verifying the directory server "+
+			"certificate when using LDAPS or StartTLS",
+		"",
+		validateLDAPDomainCACertificate,
+	)
+	return s
+}()
+
+// LDAPConnectTimeout bounds the time spent connecting to the directory server.
+var LDAPConnectTimeout = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	LDAPConnectTimeoutSettingName,
+	"sets the timeout for connecting to the directory server during LDAP logins",
+	15*time.Second,
+	settings.PositiveDuration,
+)
+
+// LDAPSyncRolesEnabled controls whether the role memberships of users logging
+// in through an HBA rule with the ldapgrouplistfilter option are synchronized
+// with their directory groups, using LDAPGroupRoleMapping.
+var LDAPSyncRolesEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	LDAPSyncRolesEnabledSettingName,
+	"if set, LDAP logins using the ldapgrouplistfilter HBA option grant the "+
+		"user the roles its directory groups map to, and revoke the other mapped roles",
+	false,
+)
+
+// LDAPGroupRoleMapping maps the DNs of directory groups to SQL roles. Only
+// the roles in the mapping are ever granted or revoked by the
+// synchronization.
+var LDAPGroupRoleMapping = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	LDAPGroupRoleMappingSettingName,
+	"sets the mapping from the DNs of directory groups to SQL roles, as a JSON object whose "+
+		"values are either a role name or an array of role names; on every LDAP login, the user "+
+		"is granted the roles its groups map to, and its memberships of the other roles in the "+
+		"mapping are revoked; the admin role cannot be mapped",
+	"{}",
+	validateLDAPGroupRoleMapping,
+)
+
+func validateLDAPDomainCACertificate(_ *settings.Values, s string) error {
+	if s == "" {
+		return nil
+	}
+	if ok := x509.NewCertPool().AppendCertsFromPEM([]byte(s)); !ok {
+		return errors.New("LDAP authentication: custom CA is not a valid PEM certificate")
+	}
+	return nil
+}
+
+func validateLDAPGroupRoleMapping(_ *settings.Values, s string) error {
+	_, err := parseGroupRoleMapping(s)
+	return err
+}
+
+// groupRoleMapping maps the DNs of directory groups to SQL roles.
+type groupRoleMapping []groupRoles
+
+// groupRoles are the SQL roles a directory group maps to.
+type groupRoles struct {
+	groupDN *ldap.DN
+	roles   []username.SQLUsername
+}
+
+// parseGroupRoleMapping parses the value of the group role mapping setting.
+// Each group maps to one or more roles, none of which can be the admin role
+// or a reserved one.
+func parseGroupRoleMapping(s string) (groupRoleMapping, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil, errors.Wrap(err, "LDAP authentication group role mapping JSON not valid")
+	}
+	mapping := make(groupRoleMapping, 0, len(raw))
+	for group, rawRoles := range raw {
+		groupDN, err := ldap.ParseDN(group)
+		if err != nil {
+			return nil, errors.Wrapf(err, "LDAP authentication group role mapping: invalid group DN %q", group)
+		}
+		var roleNames []string
+		var roleName string
+		if err := json.Unmarshal(rawRoles, &roleName); err == nil {
+			roleNames = []string{roleName}
+		} else if err := json.Unmarshal(rawRoles, &roleNames); err != nil {
+			return nil, errors.Newf(
+				"LDAP authentication group role mapping for %q must be a role name or an array of role names", group)
+		}
+		entry := groupRoles{groupDN: groupDN}
+		for _, name := range roleNames {
+			role, err := username.MakeSQLUsernameFromUserInput(name, username.PurposeValidation)
+			if err != nil {
+				return nil, errors.Wrapf(err, "LDAP authentication group role mapping for %q not valid", group)
+			}
+			if role.IsRootUser() || role.IsAdminRole() || role.IsReserved() {
+				return nil, errors.Newf(
+					"LDAP authentication group role mapping for %q cannot use privileged or reserved role %s", group, role)
+			}
+			entry.roles = append(entry.roles, role)
+		}
+		mapping = append(mapping, entry)
+	}
+	return mapping, nil
+}
+
+// rolesOf returns the roles the group with the given DN maps to. Group DNs
+// are compared case-insensitively.
+func (m groupRoleMapping) rolesOf(groupDN *ldap.DN) []username.SQLUsername {
+	var roles []username.SQLUsername
+	for _, e := range m {
+		if e.groupDN.EqualFold(groupDN) {
+			roles = append(roles, e.roles...)
+		}
+	}
+	return roles
+}
+
+// managedRoles returns all the roles in the mapping. The result is never nil,
+// so that only the mapped roles are managed even if the mapping is empty.
+func (m groupRoleMapping) managedRoles() []username.SQLUsername {
+	managed := []username.SQLUsername{}
+	for _, e := range m {
+		managed = append(managed, e.roles...)
+	}
+	return managed
+}
@@ -0,0 +1,324 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package sqlproxyccl
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/logtags"
+)
+
+// defaultPoolAcquireTimeout corresponds to the maximum duration that a client
+// connection waits for a backend connection to become available when
+// transaction pooling is enabled. If the timeout gets triggered, the client
+// connection will be closed.
+//
+// This is a variable instead of a constant to support testing hooks.
+var defaultPoolAcquireTimeout = 30 * time.Second
+
+// defaultPoolDetachDelay is the detach delay used when none is specified.
+const defaultPoolDetachDelay = time.Second
+
+// backendPool limits the number of backend connections that are used by the
+// client connections of a single tenant when transaction pooling is enabled.
+//
+// With transaction pooling, a client connection which has been idle outside
+// of a transaction for detachDelay gives up its backend connection: the
+// session is serialized through SHOW TRANSFER STATE, and the backend
+// connection is closed. When the client sends its next message, a backend
+// connection is acquired from the pool, and the session is revived on an
+// available SQL pod, in the same way as a connection migration. This allows
+// many mostly-idle client connections to share a smaller number of backend
+// connections.
+type backendPool struct {
+	// metrics contains various counters reflecting proxy operations. This is
+	// the same as the metrics field in the proxyHandler instance.
+	metrics *metrics
+
+	// detachDelay is the duration that a client connection has to be idle at
+	// a transaction boundary before its backend connection is released.
+	detachDelay time.Duration
+
+	// slots is a semaphore which bounds the number of backend connections in
+	// use. Its capacity corresponds to the size of the pool.
+	slots chan struct{}
+}
+
+// newBackendPool returns a new instance of backendPool which allows up to size
+// backend connections to be used at the same time. If detachDelay is not
+// positive, defaultPoolDetachDelay will be used.
+func newBackendPool(metrics *metrics, size int, detachDelay time.Duration) *backendPool {
+	if detachDelay <= 0 {
+		detachDelay = defaultPoolDetachDelay
+	}
+	return &backendPool{
+		metrics:     metrics,
+		detachDelay: detachDelay,
+		slots:       make(chan struct{}, size),
+	}
+}
+
+// acquire blocks until a backend connection can be used by the caller. This
+// returns an error if ctx has been cancelled, or if no backend connections
+// became available within defaultPoolAcquireTimeout. If acquire returns nil,
+// the caller must call release once the backend connection has been closed.
+func (p *backendPool) acquire(ctx context.Context) error {
+	// Fast path: the pool is not saturated.
+	select {
+	case p.slots <- struct{}{}:
+		p.metrics.PoolBackendConnCount.Inc(1)
+		return nil
+	default:
+	}
+
+	p.metrics.PoolWaitingCount.Inc(1)
+	tBegin := timeutil.Now()
+	defer func() {
+		p.metrics.PoolWaitingCount.Dec(1)
+		p.metrics.PoolAcquireLatency.RecordValue(timeutil.Since(tBegin).Nanoseconds())
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, defaultPoolAcquireTimeout) // nolint:context
+	defer cancel()
+	select {
+	case p.slots <- struct{}{}:
+		p.metrics.PoolBackendConnCount.Inc(1)
+		return nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			p.metrics.PoolAcquireTimeoutCount.Inc(1)
+		}
+		return withCode(
+			errors.Wrap(ctx.Err(), "waiting for an available backend connection"),
+			codeProxyRefusedConnection,
+		)
+	}
+}
+
+// release returns a backend connection that was obtained through acquire to
+// the pool.
+func (p *backendPool) release() {
+	<-p.slots
+	p.metrics.PoolBackendConnCount.Dec(1)
+}
+
+// runPooling releases the backend connection of the forwarder whenever the
+// connection has been idle at a transaction boundary for the pool's
+// detachDelay, and waits for the client to become active again before
+// reattaching a backend connection. This blocks until the forwarder has been
+// closed, so it should be called in a separate goroutine.
+//
+// It is assumed that the backend connection used by the forwarder was
+// acquired from f.pool before the forwarder was started.
+func (f *forwarder) runPooling() {
+	ticker := f.timeSource.NewTicker(f.pool.detachDelay)
+	defer ticker.Stop()
+
+	// lastRequest, lastRequestAt and lastResponseAt record the state of the
+	// processors at the previous tick. The forwarder is only detached if no
+	// messages have been forwarded since then. Processors are recreated with
+	// a new logical clock whenever the server connection is replaced, so we
+	// also need to compare their identities.
+	var lastRequest *processor
+	var lastRequestAt, lastResponseAt uint64
+	attempted := false
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.Ch():
+		}
+
+		request, response := f.getProcessors()
+		reqAt := request.lastMessageTransferredAt()
+		resAt := response.lastMessageTransferredAt()
+		if request != lastRequest || reqAt != lastRequestAt || resAt != lastResponseAt {
+			lastRequest, lastRequestAt, lastResponseAt = request, reqAt, resAt
+			attempted = false
+			continue
+		}
+
+		// Only attempt to detach once per idle period. A failed attempt
+		// usually means that the session cannot be serialized (e.g. an open
+		// transaction), and retrying would just add load to the SQL pod.
+		if attempted {
+			continue
+		}
+		attempted = true
+
+		if err := f.detachUntilActive(); err != nil && !errors.Is(err, errTransferCannotStart) {
+			log.VEventf(f.ctx, 2, "unable to release backend connection: %v", err)
+		}
+	}
+}
+
+// detachUntilActive releases the backend connection of the forwarder, and
+// reattaches one once the client sends its next message. If the backend
+// connection could not be released, the forwarder will be resumed as before
+// when the error is recoverable, and closed otherwise.
+//
+// NOTE: This has the same invariant as TransferConnection: processors must have
+// been resumed prior to calling this method, and they will either be resumed
+// again, or the forwarder will be closed when this returns.
+func (f *forwarder) detachUntilActive() error {
+	if f.ctx.Err() != nil {
+		return f.ctx.Err()
+	}
+
+	// Detaching the backend connection is treated as a transfer, so that the
+	// connection cannot be rebalanced by the balancer until it is reattached.
+	started, cleanupFn := f.tryBeginTransfer()
+	if !started {
+		return errTransferCannotStart
+	}
+	defer cleanupFn()
+
+	detached, err := f.detach()
+	if !detached {
+		if err := f.resumeProcessors(); err != nil {
+			f.Close()
+		}
+		return err
+	}
+
+	// The backend connection has been released. From now on, failures are
+	// non-recoverable since there is no backend connection to resume on.
+	f.metrics.PoolDetachedConnCount.Inc(1)
+	defer f.metrics.PoolDetachedConnCount.Dec(1)
+
+	// Wait for the next message from the client. Peeking does not consume the
+	// message, so it will be forwarded once the processors have been resumed.
+	clientConn, _ := f.getConns()
+	typ, _, err := clientConn.PeekMsg()
+	if err != nil {
+		f.tryReportError(wrapClientToServerError(err))
+		return err
+	}
+	if pgwirebase.ClientMessageType(typ) == pgwirebase.ClientMsgTerminate {
+		// The client is closing the connection, so there is no need to revive
+		// the session.
+		f.tryReportError(nil)
+		return nil
+	}
+
+	if err := f.attach(); err != nil {
+		f.tryReportError(err)
+		return err
+	}
+	if err := f.resumeProcessors(); err != nil {
+		f.Close()
+		return err
+	}
+	return nil
+}
+
+// detach suspends the processors, retrieves the transfer state of the session
+// and closes the backend connection. detached is true if the backend
+// connection has been released back to the pool, in which case the session
+// state will be kept within the forwarder. If detached is false, the forwarder
+// will have been closed if the error was non-recoverable.
+func (f *forwarder) detach() (detached bool, _ error) {
+	ctx, cancel := newTransferContext(f.ctx)
+	defer cancel()
+
+	// See TransferConnection for more details about the timeout handler.
+	go func() {
+		<-ctx.Done()
+		if !ctx.isRecoverable() {
+			f.Close()
+		}
+	}()
+	defer func() {
+		if !detached && !ctx.isRecoverable() {
+			f.Close()
+		}
+	}()
+
+	request, response := f.getProcessors()
+	if err := request.suspend(ctx); err != nil {
+		return false, errors.Wrap(err, "suspending request processor")
+	}
+	if err := response.suspend(ctx); err != nil {
+		return false, errors.Wrap(err, "suspending response processor")
+	}
+
+	clientConn, serverConn := f.getConns()
+	state, revivalToken, err := retrieveTransferState(ctx, f.metrics, clientConn, serverConn)
+	if err != nil {
+		return false, errors.Wrap(err, "retrieving transfer state")
+	}
+
+	serverConn.Close()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.releaseBackendConnLocked()
+	f.mu.pooling.state = state
+	f.mu.pooling.revivalToken = revivalToken
+	return true, nil
+}
+
+// attach acquires a backend connection from the pool, and revives the detached
+// session on an available SQL pod. When this returns nil, the backend
+// connection of the forwarder will have been replaced, and processors will
+// need to be resumed.
+func (f *forwarder) attach() error {
+	if err := f.pool.acquire(f.ctx); err != nil {
+		return err
+	}
+
+	// Hand the backend connection over to the forwarder. This is done within
+	// the same critical section as the context check so that it is either
+	// released here, or by Close.
+	f.mu.Lock()
+	if f.ctx.Err() != nil {
+		f.mu.Unlock()
+		f.pool.release()
+		return f.ctx.Err()
+	}
+	f.mu.pooling.detached = false
+	state, revivalToken := f.mu.pooling.state, f.mu.pooling.revivalToken
+	f.mu.pooling.state, f.mu.pooling.revivalToken = "", ""
+	f.mu.Unlock()
+
+	// Use the transfer timeout when reviving the session. If the timeout
+	// gets triggered, connectFn will be aborted, and an error will be returned.
+	ctx, cancel := context.WithTimeout(f.ctx, defaultTransferTimeout) // nolint:context
+	defer cancel()
+
+	tBegin := timeutil.Now()
+	newServerConn, err := openConnWithTransferState(ctx, f, f.connector, state, revivalToken)
+	if err != nil {
+		logCtx := logtags.WithTags(context.Background(), logtags.FromContext(f.ctx))
+		log.Infof(logCtx, "unable to reattach backend connection: %v", err)
+		return withCode(errors.Wrap(err, "reattaching backend connection"), codeBackendDown)
+	}
+	log.VEventf(f.ctx, 2, "reattached backend connection, latency=%v", timeutil.Since(tBegin))
+
+	f.replaceServerConn(newServerConn)
+	return nil
+}
+
+// releaseBackendConnLocked returns the backend connection of the forwarder to
+// the pool if transaction pooling is enabled, and the forwarder is not already
+// detached. This is idempotent.
+//
+// NOTE: f.mu must be held when calling this.
+func (f *forwarder) releaseBackendConnLocked() {
+	if f.pool == nil || f.mu.pooling.detached {
+		return
+	}
+	f.mu.pooling.detached = true
+	f.pool.release()
+}
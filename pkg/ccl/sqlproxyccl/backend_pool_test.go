@@ -0,0 +1,119 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package sqlproxyccl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendPool(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	t.Run("acquire and release", func(t *testing.T) {
+		m := makeProxyMetrics()
+		pool := newBackendPool(&m, 2 /* size */, 0 /* detachDelay */)
+		require.Equal(t, defaultPoolDetachDelay, pool.detachDelay)
+
+		require.NoError(t, pool.acquire(ctx))
+		require.NoError(t, pool.acquire(ctx))
+		require.Equal(t, int64(2), m.PoolBackendConnCount.Value())
+		require.Equal(t, int64(0), m.PoolWaitingCount.Value())
+
+		pool.release()
+		pool.release()
+		require.Equal(t, int64(0), m.PoolBackendConnCount.Value())
+	})
+
+	t.Run("saturated pool", func(t *testing.T) {
+		m := makeProxyMetrics()
+		pool := newBackendPool(&m, 1 /* size */, time.Second)
+		require.NoError(t, pool.acquire(ctx))
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- pool.acquire(ctx) }()
+
+		// The second caller waits until the backend connection is released.
+		require.Eventually(t, func() bool {
+			return m.PoolWaitingCount.Value() == 1
+		}, 10*time.Second, 10*time.Millisecond)
+		pool.release()
+		require.NoError(t, <-errCh)
+		require.Equal(t, int64(0), m.PoolWaitingCount.Value())
+		require.Equal(t, int64(1), m.PoolBackendConnCount.Value())
+		require.Equal(t, int64(0), m.PoolAcquireTimeoutCount.Count())
+
+		pool.release()
+		require.Equal(t, int64(0), m.PoolBackendConnCount.Value())
+	})
+
+	t.Run("acquire timeout", func(t *testing.T) {
+		defer testutils.TestingHook(&defaultPoolAcquireTimeout, 50*time.Millisecond)()
+
+		m := makeProxyMetrics()
+		pool := newBackendPool(&m, 1 /* size */, time.Second)
+		require.NoError(t, pool.acquire(ctx))
+
+		err := pool.acquire(ctx)
+		require.Error(t, err)
+		require.Equal(t, codeProxyRefusedConnection, getErrorCode(err))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Equal(t, int64(1), m.PoolAcquireTimeoutCount.Count())
+		require.Equal(t, int64(0), m.PoolWaitingCount.Value())
+		require.Equal(t, int64(1), m.PoolBackendConnCount.Value())
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		m := makeProxyMetrics()
+		pool := newBackendPool(&m, 1 /* size */, time.Second)
+		require.NoError(t, pool.acquire(ctx))
+
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := pool.acquire(cancelledCtx)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Equal(t, int64(1), m.PoolBackendConnCount.Value())
+	})
+}
+
+func TestForwarder_ReleaseBackendConn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	m := makeProxyMetrics()
+	pool := newBackendPool(&m, 1 /* size */, time.Second)
+
+	f := newForwarder(ctx, nil /* connector */, &m, nil /* timeSource */)
+	require.NoError(t, pool.acquire(ctx))
+	f.pool = pool
+	require.Equal(t, int64(1), m.PoolBackendConnCount.Value())
+
+	// Closing the forwarder returns the backend connection to the pool, and
+	// Close is idempotent.
+	f.Close()
+	require.Equal(t, int64(0), m.PoolBackendConnCount.Value())
+	f.Close()
+	require.Equal(t, int64(0), m.PoolBackendConnCount.Value())
+
+	// A cancelled forwarder cannot reattach a backend connection.
+	err := f.attach()
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Equal(t, int64(0), m.PoolBackendConnCount.Value())
+}
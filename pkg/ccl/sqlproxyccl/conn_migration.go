@@ -232,12 +232,25 @@ func transferConnection(
 	connector *connector,
 	metrics *metrics,
 	clientConn, serverConn *interceptor.PGConn,
-) (_ *interceptor.PGConn, retErr error) {
+) (*interceptor.PGConn, error) {
+	state, revivalToken, err := retrieveTransferState(ctx, metrics, clientConn, serverConn)
+	if err != nil {
+		return nil, err
+	}
+	return openConnWithTransferState(ctx, requester, connector, state, revivalToken)
+}
+
+// retrieveTransferState retrieves the serialized session state and the
+// session revival token from the SQL pod behind serverConn. Failures where
+// the connection is non-recoverable are marked as such in ctx.
+func retrieveTransferState(
+	ctx *transferContext, metrics *metrics, clientConn, serverConn *interceptor.PGConn,
+) (state string, revivalToken string, _ error) {
 	ctx.markRecoverable(true)
 
 	// Context was cancelled.
 	if ctx.Err() != nil {
-		return nil, ctx.Err()
+		return "", "", ctx.Err()
 	}
 
 	transferKey := uuid.MakeV4().String()
@@ -246,13 +259,13 @@ func transferConnection(
 	// non-recoverable because the message has already been sent to the server.
 	ctx.markRecoverable(false)
 	if err := runShowTransferState(serverConn, transferKey); err != nil {
-		return nil, errors.Wrap(err, "sending transfer request")
+		return "", "", errors.Wrap(err, "sending transfer request")
 	}
 
 	transferErr, state, revivalToken, err := waitForShowTransferState(
 		ctx, serverConn.ToFrontendConn(), clientConn, transferKey, metrics)
 	if err != nil {
-		return nil, errors.Wrap(err, "waiting for transfer state")
+		return "", "", errors.Wrap(err, "waiting for transfer state")
 	}
 
 	// Failures after this point are recoverable, and connections should not be
@@ -266,9 +279,20 @@ func transferConnection(
 	// This case may happen pretty frequently (e.g. open transactions, temporary
 	// tables, etc.).
 	if transferErr != "" {
-		return nil, errors.Newf("%s", transferErr)
+		return "", "", errors.Newf("%s", transferErr)
 	}
+	return state, revivalToken, nil
+}
 
+// openConnWithTransferState opens a new connection to an available SQL pod
+// using the given session revival token, and deserializes the given session
+// state within that pod.
+func openConnWithTransferState(
+	ctx context.Context,
+	requester balancer.ConnectionHandle,
+	connector *connector,
+	state, revivalToken string,
+) (_ *interceptor.PGConn, retErr error) {
 	// Connect to a new SQL pod.
 	connectFn := connector.OpenTenantConnWithToken
 	if transferConnectionConnectorTestHook != nil {
@@ -61,6 +61,12 @@ type forwarder struct {
 	// by default. This is often replaced in tests.
 	timeSource timeutil.TimeSource
 
+	// pool is the pool of backend connections of the tenant, and is only set
+	// when transaction pooling is enabled. When set, the backend connection
+	// passed to run must have been acquired from the pool, and the forwarder
+	// takes over the responsibility of releasing it.
+	pool *backendPool
+
 	// While not all of these fields may need to be guarded by a mutex, we do
 	// so for consistency. Fields like clientConn and serverConn need them
 	// because Close can be invoked anytime from a different goroutine while
@@ -129,6 +135,19 @@ type forwarder struct {
 			// fields were updated.
 			lastUpdated time.Time
 		}
+
+		// pooling represents internal states used for transaction pooling.
+		pooling struct {
+			// detached indicates that the backend connection has been released
+			// back to the pool, and serverConn is no longer usable.
+			detached bool
+
+			// state and revivalToken are the serialized session state and the
+			// session revival token of a detached session, which are used to
+			// revive the session once the client becomes active again.
+			state        string
+			revivalToken string
+		}
 	}
 }
 
@@ -213,6 +232,10 @@ func (f *forwarder) run(clientConn net.Conn, serverConn net.Conn) error {
 
 	// Mark the forwarder as initialized, and connection is ready for a transfer.
 	markInitialized()
+
+	if f.pool != nil {
+		go f.runPooling()
+	}
 	return nil
 }
 
@@ -252,6 +275,12 @@ func (f *forwarder) Close() {
 	if serverConn != nil {
 		serverConn.Close()
 	}
+
+	// Return the backend connection to the pool, if any. Processors may
+	// still be running at this point, but serverConn has already been closed.
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.releaseBackendConnLocked()
 }
 
 // IsIdle returns true if the forwarder is idle, and false otherwise.
@@ -45,6 +45,12 @@ type metrics struct {
 	QueryCancelSuccessful     *metric.Counter
 
 	AccessControlFileErrorCount *metric.Gauge
+
+	PoolBackendConnCount    *metric.Gauge
+	PoolDetachedConnCount   *metric.Gauge
+	PoolWaitingCount        *metric.Gauge
+	PoolAcquireTimeoutCount *metric.Counter
+	PoolAcquireLatency      metric.IHistogram
 }
 
 // MetricStruct implements the metrics.Struct interface.
@@ -218,6 +224,37 @@ var (
 		Measurement: "Access Control File Errors",
 		Unit:        metric.Unit_COUNT,
 	}
+	// Transaction pooling metrics.
+	metaPoolBackendConnCount = metric.Metadata{
+		Name:        "proxy.pool.backend_conns",
+		Help:        "Number of backend connections in use by client connections with transaction pooling",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaPoolDetachedConnCount = metric.Metadata{
+		Name:        "proxy.pool.detached_conns",
+		Help:        "Number of client connections that have released their backend connection to the pool",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaPoolWaitingCount = metric.Metadata{
+		Name:        "proxy.pool.waiting",
+		Help:        "Number of client connections waiting for a backend connection because the pool is saturated",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaPoolAcquireTimeoutCount = metric.Metadata{
+		Name:        "proxy.pool.acquire_timeouts",
+		Help:        "Number of client connections closed after waiting too long for a backend connection",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaPoolAcquireLatency = metric.Metadata{
+		Name:        "proxy.pool.acquire.latency",
+		Help:        "Latency histogram for waiting on a backend connection when the pool is saturated",
+		Measurement: "Latency",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
 )
 
 // makeProxyMetrics instantiates the metrics holder for proxy monitoring.
@@ -274,6 +311,18 @@ func makeProxyMetrics() metrics {
 		QueryCancelSuccessful:     metric.NewCounter(metaQueryCancelSuccessful),
 
 		AccessControlFileErrorCount: metric.NewGauge(accessControlFileErrorCount),
+
+		// Transaction pooling metrics.
+		PoolBackendConnCount:    metric.NewGauge(metaPoolBackendConnCount),
+		PoolDetachedConnCount:   metric.NewGauge(metaPoolDetachedConnCount),
+		PoolWaitingCount:        metric.NewGauge(metaPoolWaitingCount),
+		PoolAcquireTimeoutCount: metric.NewCounter(metaPoolAcquireTimeoutCount),
+		PoolAcquireLatency: metric.NewHistogram(metric.HistogramOptions{
+			Mode:     metric.HistogramModePreferHdrLatency,
+			Metadata: metaPoolAcquireLatency,
+			Duration: base.DefaultHistogramWindowInterval(),
+			Buckets:  metric.IOLatencyBuckets,
+		}),
 	}
 }
 
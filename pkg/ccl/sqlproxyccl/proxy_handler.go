@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/netutil/addr"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/logtags"
@@ -116,6 +117,15 @@ type ProxyOptions struct {
 	// PROXY info from upstream will be trusted on both HTTP and SQL, if the
 	// headers are allowed.
 	RequireProxyProtocol bool
+	// PoolMaxBackendConns enables transaction pooling when set to a positive
+	// value. With transaction pooling, client connections that are idle
+	// outside of a transaction release their backend connection, and the
+	// number of backend connections per tenant is limited to this value.
+	PoolMaxBackendConns int
+	// PoolDetachDelay is the duration that a client connection has to be idle
+	// at a transaction boundary before its backend connection is released when
+	// transaction pooling is enabled.
+	PoolDetachDelay time.Duration
 
 	// testingKnobs are knobs used for testing.
 	testingKnobs struct {
@@ -169,6 +179,13 @@ type proxyHandler struct {
 
 	// cancelInfoMap keeps track of all the cancel request keys for this proxy.
 	cancelInfoMap *cancelInfoMap
+
+	// backendPools contains the backend connection pools of all tenants when
+	// transaction pooling is enabled.
+	backendPools struct {
+		syncutil.Mutex
+		pools map[roachpb.TenantID]*backendPool
+	}
 }
 
 const throttledErrorHint string = `Connection throttling is triggered by repeated authentication failure. Make
@@ -461,6 +478,18 @@ func (handler *proxyHandler) handle(ctx context.Context, incomingConn net.Conn)
 	f := newForwarder(ctx, connector, handler.metrics, nil /* timeSource */)
 	defer f.Close()
 
+	// With transaction pooling, a backend connection has to be acquired from
+	// the tenant's pool before connecting. Once acquired, the forwarder will
+	// be responsible for returning it to the pool.
+	if pool := handler.getBackendPool(tenID); pool != nil {
+		if err := pool.acquire(ctx); err != nil {
+			log.Errorf(ctx, "could not acquire backend connection: %v", err.Error())
+			updateMetricsAndSendErrToClient(err, fe.Conn, handler.metrics)
+			return err
+		}
+		f.pool = pool
+	}
+
 	crdbConn, sentToClient, err := connector.OpenTenantConnWithAuth(ctx, f, fe.Conn,
 		func(status throttler.AttemptStatus) error {
 			if err := handler.throttleService.ReportAttempt(
@@ -545,6 +574,25 @@ func (handler *proxyHandler) handle(ctx context.Context, incomingConn net.Conn)
 	}
 }
 
+// getBackendPool returns the backend connection pool of the given tenant, or
+// nil if transaction pooling is disabled.
+func (handler *proxyHandler) getBackendPool(tenID roachpb.TenantID) *backendPool {
+	if handler.PoolMaxBackendConns <= 0 {
+		return nil
+	}
+	handler.backendPools.Lock()
+	defer handler.backendPools.Unlock()
+	if handler.backendPools.pools == nil {
+		handler.backendPools.pools = make(map[roachpb.TenantID]*backendPool)
+	}
+	pool, ok := handler.backendPools.pools[tenID]
+	if !ok {
+		pool = newBackendPool(handler.metrics, handler.PoolMaxBackendConns, handler.PoolDetachDelay)
+		handler.backendPools.pools[tenID] = pool
+	}
+	return pool
+}
+
 // validateRequest validates the incoming connection by ensuring that the SQL
 // connection knows some additional information about the tenant (i.e. the
 // cluster name) before being allowed to connect.
@@ -94,6 +94,18 @@ true, the PROXY info from upstream will be trusted on both SQL and HTTP
 listeners, if the headers are allowed.`,
 	}
 
+	PoolMaxBackendConns = FlagInfo{
+		Name: "pool-max-backend-conns",
+		Description: `If positive, enables transaction pooling: client connections
+that are idle outside of a transaction release their backend connection, and
+the number of backend connections per tenant is limited to this value.`,
+	}
+
+	PoolDetachDelay = FlagInfo{
+		Name:        "pool-detach-delay",
+		Description: "Duration that a client connection has to be idle before its backend connection is released when transaction pooling is enabled.",
+	}
+
 	RatelimitBaseDelay = FlagInfo{
 		Name:        "ratelimit-base-delay",
 		Description: "Initial backoff after a failed login attempt. Set to 0 to disable rate limiting.",
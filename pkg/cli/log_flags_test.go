@@ -62,6 +62,19 @@ func TestSetupLogging(t *testing.T) {
 		`flush-trigger-size: 1.0MiB, ` +
 		`max-buffer-size: 50MiB, ` +
 		`format: newline}}`
+	const defaultOTLPConfig = `otlp-defaults: {` +
+		`mode: grpc, ` +
+		`insecure: false, ` +
+		`timeout: 5s, ` +
+		`compression: gzip, ` +
+		`filter: INFO, ` +
+		`format: json, ` +
+		`redactable: true, ` +
+		`exit-on-error: false, ` +
+		`buffering: {max-staleness: 5s, ` +
+		`flush-trigger-size: 1.0MiB, ` +
+		`max-buffer-size: 50MiB, ` +
+		`format: newline}}`
 	stdFileDefaultsRe := regexp.MustCompile(
 		`file-defaults: \{` +
 			`dir: (?P<path>[^,]+), ` +
@@ -194,6 +207,7 @@ func TestSetupLogging(t *testing.T) {
 		// Shorten the configuration for legibility during reviews of test changes.
 		actual = strings.ReplaceAll(actual, defaultFluentConfig, "<fluentDefaults>")
 		actual = strings.ReplaceAll(actual, defaultHTTPConfig, "<httpDefaults>")
+		actual = strings.ReplaceAll(actual, defaultOTLPConfig, "<otlpDefaults>")
 		actual = stdFileDefaultsRe.ReplaceAllString(actual, "<stdFileDefaults($path)>")
 		actual = fileDefaultsNoMaxSizeRe.ReplaceAllString(actual, "<fileDefaultsNoMaxSize($path)>")
 		actual = strings.ReplaceAll(actual, fileDefaultsNoDir, "<fileDefaultsNoDir>")
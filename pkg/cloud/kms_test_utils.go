@@ -13,12 +13,18 @@ package cloud
 import (
 	"bytes"
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"net/url"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -88,3 +94,65 @@ func CheckNoKMSAccess(t *testing.T, kmsURI string, env KMSEnv) {
 
 	require.Regexp(t, "(PermissionDenied|AccessDenied|PERMISSION_DENIED|failed to acquire a token)", err)
 }
+
+// LocalTestKMS is a KMS stand-in which encrypts data locally with
+// AES-GCM, using a master key derived from the path of its URI. It lets
+// tests exercise the code that wraps keys with a KMS without an external
+// service; it provides no security and must only be used in tests,
+// after registering MakeLocalTestKMS for a scheme of their choice.
+type LocalTestKMS struct {
+	keyID string
+	aead  cipher.AEAD
+}
+
+var _ KMS = &LocalTestKMS{}
+
+// MakeLocalTestKMS is the KMSFromURIFactory of LocalTestKMS. Two URIs
+// with the same path refer to the same master key.
+func MakeLocalTestKMS(_ context.Context, uri string, _ KMSEnv) (KMS, error) {
+	u, err := url.ParseRequestURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" || u.Path == "/" {
+		return nil, errors.New("a master key ID must be specified in the path of the KMS URI")
+	}
+	masterKey := sha256.Sum256([]byte(u.Path))
+	block, err := aes.NewCipher(masterKey[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalTestKMS{keyID: u.Path, aead: aead}, nil
+}
+
+// MasterKeyID implements the KMS interface.
+func (k *LocalTestKMS) MasterKeyID() (string, error) {
+	return k.keyID, nil
+}
+
+// Encrypt implements the KMS interface.
+func (k *LocalTestKMS) Encrypt(_ context.Context, data []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return k.aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// Decrypt implements the KMS interface.
+func (k *LocalTestKMS) Decrypt(_ context.Context, data []byte) ([]byte, error) {
+	if len(data) < k.aead.NonceSize() {
+		return nil, errors.New("invalid ciphertext")
+	}
+	nonce, sealed := data[:k.aead.NonceSize()], data[k.aead.NonceSize():]
+	return k.aead.Open(nil, nonce, sealed, nil)
+}
+
+// Close implements the KMS interface.
+func (k *LocalTestKMS) Close() error {
+	return nil
+}
@@ -557,6 +557,38 @@ const (
 	// FormatVirtualSSTables, allowing use of virtual sstables in Pebble.
 	V23_2_PebbleFormatVirtualSSTables
 
+	// V23_2_PasswordPolicyTables adds the system.password_history and
+	// system.login_lockouts tables used to enforce the password policy.
+	V23_2_PasswordPolicyTables
+
+	// V23_2_AuditLogTable adds the system.audit_log table, which stores
+	// the hash-chained audit events when sql.audit_log.table.enabled is set.
+	V23_2_AuditLogTable
+
+	// V23_2_ColumnEncryption enables the columns declared with ENCRYPTED WITH
+	// KEY, whose values older nodes would not encrypt.
+	V23_2_ColumnEncryption
+
+	// V23_2_StatementHintsTable adds the system.statement_hints table, which
+	// stores the hints attached to statement fingerprints.
+	V23_2_StatementHintsTable
+
+	// V23_2_AnalyzeWorkload enables ANALYZE WORKLOAD, whose job older nodes
+	// cannot decode.
+	V23_2_AnalyzeWorkload
+
+	// V23_2_CostCalibration enables the cost model calibration job, which
+	// older nodes cannot decode.
+	V23_2_CostCalibration
+
+	// V23_2_ExtendedStatistics enables the collection of extended statistics,
+	// which older nodes cannot decode.
+	V23_2_ExtendedStatistics
+
+	// V23_2_RuntimeFilters enables the runtime filters sent by the hash joiners
+	// to the table readers, which older nodes do not set up.
+	V23_2_RuntimeFilters
+
 	// *************************************************
 	// Step (1) Add new versions here.
 	// Do not add new versions to a patch release.
@@ -970,6 +1002,38 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V23_2_PebbleFormatVirtualSSTables,
 		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 16},
 	},
+	{
+		Key:     V23_2_PasswordPolicyTables,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 18},
+	},
+	{
+		Key:     V23_2_AuditLogTable,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 20},
+	},
+	{
+		Key:     V23_2_ColumnEncryption,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 22},
+	},
+	{
+		Key:     V23_2_StatementHintsTable,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 24},
+	},
+	{
+		Key:     V23_2_AnalyzeWorkload,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 26},
+	},
+	{
+		Key:     V23_2_CostCalibration,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 28},
+	},
+	{
+		Key:     V23_2_ExtendedStatistics,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 30},
+	},
+	{
+		Key:     V23_2_RuntimeFilters,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 32},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
@@ -35,6 +35,8 @@ const (
 	Constraints            // constraints
 	VoterConstraints       // voter_constraints
 	LeasePreferences       // lease_preferences
+	ColdStorage            // cold_storage
+	ColdMergeSeconds       // cold_merge_seconds
 
 	// NumFields is the number of fields in the config.
 	NumFields int = iota - 1
@@ -17,6 +17,8 @@ func _() {
 	_ = x[Constraints-7]
 	_ = x[VoterConstraints-8]
 	_ = x[LeasePreferences-9]
+	_ = x[ColdStorage-10]
+	_ = x[ColdMergeSeconds-11]
 }
 
 func (i Field) String() string {
@@ -39,6 +41,10 @@ func (i Field) String() string {
 		return "voter_constraints"
 	case LeasePreferences:
 		return "lease_preferences"
+	case ColdStorage:
+		return "cold_storage"
+	case ColdMergeSeconds:
+		return "cold_merge_seconds"
 	default:
 		return "Field(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
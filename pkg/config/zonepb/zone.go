@@ -406,6 +406,10 @@ func (z *ZoneConfig) Validate() error {
 		return fmt.Errorf("GC.TTLSeconds %d less than minimum allowed 1", z.GC.TTLSeconds)
 	}
 
+	if z.ColdMergeSeconds != nil && *z.ColdMergeSeconds < 0 {
+		return fmt.Errorf("ColdMergeSeconds %d less than minimum allowed 0", *z.ColdMergeSeconds)
+	}
+
 	for _, constraints := range z.Constraints {
 		for _, constraint := range constraints.Constraints {
 			if constraint.Type == Constraint_DEPRECATED_POSITIVE {
@@ -558,6 +562,16 @@ func (z *ZoneConfig) InheritFromParent(parent *ZoneConfig) {
 			z.GlobalReads = proto.Bool(*parent.GlobalReads)
 		}
 	}
+	if z.ColdStorage == nil {
+		if parent.ColdStorage != nil {
+			z.ColdStorage = proto.Bool(*parent.ColdStorage)
+		}
+	}
+	if z.ColdMergeSeconds == nil {
+		if parent.ColdMergeSeconds != nil {
+			z.ColdMergeSeconds = proto.Int32(*parent.ColdMergeSeconds)
+		}
+	}
 	if z.RangeMinBytes == nil {
 		if parent.RangeMinBytes != nil {
 			z.RangeMinBytes = proto.Int64(*parent.RangeMinBytes)
@@ -617,6 +631,16 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 			if other.GlobalReads != nil {
 				z.GlobalReads = proto.Bool(*other.GlobalReads)
 			}
+		case "cold_storage":
+			z.ColdStorage = nil
+			if other.ColdStorage != nil {
+				z.ColdStorage = proto.Bool(*other.ColdStorage)
+			}
+		case "cold_merge_seconds":
+			z.ColdMergeSeconds = nil
+			if other.ColdMergeSeconds != nil {
+				z.ColdMergeSeconds = proto.Int32(*other.ColdMergeSeconds)
+			}
 		case "gc.ttlseconds":
 			z.GC = nil
 			if other.GC != nil {
@@ -721,6 +745,26 @@ func (z *ZoneConfig) DiffWithZone(
 					Field: "global_reads",
 				}, nil
 			}
+		case "cold_storage":
+			if other.ColdStorage == nil && z.ColdStorage == nil {
+				continue
+			}
+			if z.ColdStorage == nil || other.ColdStorage == nil ||
+				*z.ColdStorage != *other.ColdStorage {
+				return false, DiffWithZoneMismatch{
+					Field: "cold_storage",
+				}, nil
+			}
+		case "cold_merge_seconds":
+			if other.ColdMergeSeconds == nil && z.ColdMergeSeconds == nil {
+				continue
+			}
+			if z.ColdMergeSeconds == nil || other.ColdMergeSeconds == nil ||
+				*z.ColdMergeSeconds != *other.ColdMergeSeconds {
+				return false, DiffWithZoneMismatch{
+					Field: "cold_merge_seconds",
+				}, nil
+			}
 		case "gc.ttlseconds":
 			if other.GC == nil && z.GC == nil {
 				continue
@@ -1128,6 +1172,14 @@ func (z *ZoneConfig) toSpanConfig() (roachpb.SpanConfig, error) {
 	if z.GlobalReads != nil {
 		sc.GlobalReads = *z.GlobalReads
 	}
+	// ColdStorage is false by default.
+	if z.ColdStorage != nil {
+		sc.ColdStorage = *z.ColdStorage
+	}
+	// Merging of cold ranges is disabled by default.
+	if z.ColdMergeSeconds != nil {
+		sc.ColdMergeSeconds = *z.ColdMergeSeconds
+	}
 	sc.NumReplicas = *z.NumReplicas
 	if z.NumVoters != nil {
 		sc.NumVoters = *z.NumVoters
@@ -966,6 +966,50 @@ func TestConstraintsListYAML(t *testing.T) {
 	}
 }
 
+// TestZoneConfigColdStorage checks that the cold_storage field is inherited,
+// copied, compared and marshaled like the other fields.
+func TestZoneConfigColdStorage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	parent := ZoneConfig{ColdStorage: proto.Bool(true)}
+
+	// The field is inherited from the parent if unset.
+	var child ZoneConfig
+	child.InheritFromParent(&parent)
+	require.Equal(t, proto.Bool(true), child.ColdStorage)
+
+	child = ZoneConfig{ColdStorage: proto.Bool(false)}
+	child.InheritFromParent(&parent)
+	require.Equal(t, proto.Bool(false), child.ColdStorage)
+
+	// The field is copied by name.
+	var copied ZoneConfig
+	copied.CopyFromZone(parent, []tree.Name{"cold_storage"})
+	require.Equal(t, proto.Bool(true), copied.ColdStorage)
+	copied.CopyFromZone(ZoneConfig{}, []tree.Name{"cold_storage"})
+	require.Nil(t, copied.ColdStorage)
+
+	// Differences in the field are reported.
+	same, diff, err := parent.DiffWithZone(ZoneConfig{ColdStorage: proto.Bool(false)}, []tree.Name{"cold_storage"})
+	require.NoError(t, err)
+	require.False(t, same)
+	require.Equal(t, "cold_storage", diff.Field)
+	same, _, err = parent.DiffWithZone(ZoneConfig{ColdStorage: proto.Bool(true)}, []tree.Name{"cold_storage"})
+	require.NoError(t, err)
+	require.True(t, same)
+
+	// The field is only marshaled when set.
+	body, err := yaml.Marshal(ZoneConfig{})
+	require.NoError(t, err)
+	require.NotContains(t, string(body), "cold_storage")
+	body, err = yaml.Marshal(parent)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "cold_storage: true")
+	var unmarshaled ZoneConfig
+	require.NoError(t, yaml.UnmarshalStrict(body, &unmarshaled))
+	require.Equal(t, proto.Bool(true), unmarshaled.ColdStorage)
+}
+
 func TestMarshalableZoneConfigRoundTrip(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -1256,6 +1300,27 @@ func TestZoneConfigToSpanConfigConversion(t *testing.T) {
 				NumReplicas: 3,
 			},
 		},
+		{
+			// Test ColdStorage set to true.
+			zoneConfig: ZoneConfig{
+				RangeMinBytes: proto.Int64(100000),
+				RangeMaxBytes: proto.Int64(200000),
+				NumReplicas:   proto.Int32(3),
+				ColdStorage:   proto.Bool(true),
+				GC: &GCPolicy{
+					TTLSeconds: 2400,
+				},
+			},
+			expectSpanConfig: roachpb.SpanConfig{
+				RangeMinBytes: 100000,
+				RangeMaxBytes: 200000,
+				GCPolicy: roachpb.GCPolicy{
+					TTLSeconds: 2400,
+				},
+				ColdStorage: true,
+				NumReplicas: 3,
+			},
+		},
 		{
 			// Test `DEPRECATED_POSITIVE` constraints throw an error.
 			zoneConfig: ZoneConfig{
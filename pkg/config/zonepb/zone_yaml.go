@@ -203,6 +203,8 @@ type marshalableZoneConfig struct {
 	RangeMaxBytes                *int64            `json:"range_max_bytes" yaml:"range_max_bytes"`
 	GC                           *GCPolicy         `json:"gc"`
 	GlobalReads                  *bool             `json:"global_reads" yaml:"global_reads"`
+	ColdStorage                  *bool             `json:"cold_storage,omitempty" yaml:"cold_storage,omitempty"`
+	ColdMergeSeconds             *int32            `json:"cold_merge_seconds,omitempty" yaml:"cold_merge_seconds,omitempty"`
 	NumReplicas                  *int32            `json:"num_replicas" yaml:"num_replicas"`
 	NumVoters                    *int32            `json:"num_voters" yaml:"num_voters"`
 	Constraints                  ConstraintsList   `json:"constraints" yaml:"constraints,flow"`
@@ -228,6 +230,12 @@ func zoneConfigToMarshalable(c ZoneConfig) marshalableZoneConfig {
 	if c.GlobalReads != nil {
 		m.GlobalReads = proto.Bool(*c.GlobalReads)
 	}
+	if c.ColdStorage != nil {
+		m.ColdStorage = proto.Bool(*c.ColdStorage)
+	}
+	if c.ColdMergeSeconds != nil {
+		m.ColdMergeSeconds = proto.Int32(*c.ColdMergeSeconds)
+	}
 	if c.NumReplicas != nil && *c.NumReplicas != 0 {
 		m.NumReplicas = proto.Int32(*c.NumReplicas)
 	}
@@ -267,6 +275,12 @@ func zoneConfigFromMarshalable(m marshalableZoneConfig, c ZoneConfig) ZoneConfig
 	if m.GlobalReads != nil {
 		c.GlobalReads = proto.Bool(*m.GlobalReads)
 	}
+	if m.ColdStorage != nil {
+		c.ColdStorage = proto.Bool(*m.ColdStorage)
+	}
+	if m.ColdMergeSeconds != nil {
+		c.ColdMergeSeconds = proto.Int32(*m.ColdMergeSeconds)
+	}
 	if m.NumReplicas != nil {
 		c.NumReplicas = proto.Int32(*m.NumReplicas)
 	}
@@ -35,6 +35,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:alter_index_partition_by.bnf",
     "//docs/generated/sql/bnf:alter_index_visible_stmt.bnf",
     "//docs/generated/sql/bnf:alter_partition_stmt.bnf",
+    "//docs/generated/sql/bnf:alter_policy_stmt.bnf",
     "//docs/generated/sql/bnf:alter_primary_key.bnf",
     "//docs/generated/sql/bnf:alter_range.bnf",
     "//docs/generated/sql/bnf:alter_range_relocate_stmt.bnf",
@@ -66,6 +67,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:alter_zone_range_stmt.bnf",
     "//docs/generated/sql/bnf:alter_zone_table_stmt.bnf",
     "//docs/generated/sql/bnf:analyze_stmt.bnf",
+    "//docs/generated/sql/bnf:analyze_workload_stmt.bnf",
     "//docs/generated/sql/bnf:backup.bnf",
     "//docs/generated/sql/bnf:backup_options.bnf",
     "//docs/generated/sql/bnf:begin_stmt.bnf",
@@ -95,6 +97,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:create_index_stmt.bnf",
     "//docs/generated/sql/bnf:create_index_with_storage_param.bnf",
     "//docs/generated/sql/bnf:create_inverted_index_stmt.bnf",
+    "//docs/generated/sql/bnf:create_policy_stmt.bnf",
     "//docs/generated/sql/bnf:create_proc_stmt.bnf",
     "//docs/generated/sql/bnf:create_role_stmt.bnf",
     "//docs/generated/sql/bnf:create_schedule_for_backup_stmt.bnf",
@@ -122,6 +125,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:drop_func_stmt.bnf",
     "//docs/generated/sql/bnf:drop_index.bnf",
     "//docs/generated/sql/bnf:drop_owned_by_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_policy_stmt.bnf",
     "//docs/generated/sql/bnf:drop_role_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schedule_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schema.bnf",
@@ -275,6 +279,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:use_stmt.bnf",
     "//docs/generated/sql/bnf:validate_constraint.bnf",
     "//docs/generated/sql/bnf:values_clause.bnf",
+    "//docs/generated/sql/bnf:verify_audit_log_stmt.bnf",
     "//docs/generated/sql/bnf:window_definition.bnf",
     "//docs/generated/sql/bnf:with_clause.bnf",
 ]
@@ -36,6 +36,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:alter_index_partition_by.html",
     "//docs/generated/sql/bnf:alter_index_visible.html",
     "//docs/generated/sql/bnf:alter_partition.html",
+    "//docs/generated/sql/bnf:alter_policy.html",
     "//docs/generated/sql/bnf:alter_primary_key.html",
     "//docs/generated/sql/bnf:alter_range.html",
     "//docs/generated/sql/bnf:alter_range_relocate.html",
@@ -66,6 +67,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:alter_zone_range.html",
     "//docs/generated/sql/bnf:alter_zone_table.html",
     "//docs/generated/sql/bnf:analyze.html",
+    "//docs/generated/sql/bnf:analyze_workload.html",
     "//docs/generated/sql/bnf:backup.html",
     "//docs/generated/sql/bnf:backup_options.html",
     "//docs/generated/sql/bnf:begin.html",
@@ -96,6 +98,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:create_index.html",
     "//docs/generated/sql/bnf:create_index_with_storage_param.html",
     "//docs/generated/sql/bnf:create_inverted_index.html",
+    "//docs/generated/sql/bnf:create_policy.html",
     "//docs/generated/sql/bnf:create_proc.html",
     "//docs/generated/sql/bnf:create_role.html",
     "//docs/generated/sql/bnf:create_schedule.html",
@@ -123,6 +126,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:drop_func.html",
     "//docs/generated/sql/bnf:drop_index.html",
     "//docs/generated/sql/bnf:drop_owned_by.html",
+    "//docs/generated/sql/bnf:drop_policy.html",
     "//docs/generated/sql/bnf:drop_role.html",
     "//docs/generated/sql/bnf:drop_schedule.html",
     "//docs/generated/sql/bnf:drop_schema.html",
@@ -273,6 +277,7 @@ DIAGRAMS_SRCS = [
     "//docs/generated/sql/bnf:use.html",
     "//docs/generated/sql/bnf:validate_constraint.html",
     "//docs/generated/sql/bnf:values_clause.html",
+    "//docs/generated/sql/bnf:verify_audit_log.html",
     "//docs/generated/sql/bnf:window_definition.html",
     "//docs/generated/sql/bnf:with_clause.html",
 ]
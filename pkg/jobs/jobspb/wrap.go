@@ -52,6 +52,9 @@ var (
 	_ Details = AutoConfigEnvRunnerDetails{}
 	_ Details = AutoConfigTaskDetails{}
 	_ Details = AutoUpdateSQLActivityDetails{}
+	_ Details = ColumnEncryptionKeyRotationDetails{}
+	_ Details = AnalyzeWorkloadDetails{}
+	_ Details = CostCalibrationDetails{}
 )
 
 // ProgressDetails is a marker interface for job progress details proto structs.
@@ -76,6 +79,9 @@ var (
 	_ ProgressDetails = AutoConfigEnvRunnerProgress{}
 	_ ProgressDetails = AutoConfigTaskProgress{}
 	_ ProgressDetails = AutoUpdateSQLActivityProgress{}
+	_ ProgressDetails = ColumnEncryptionKeyRotationProgress{}
+	_ ProgressDetails = AnalyzeWorkloadProgress{}
+	_ ProgressDetails = CostCalibrationProgress{}
 )
 
 // Type returns the payload's job type and panics if the type is invalid.
@@ -212,6 +218,12 @@ func DetailsType(d isPayload_Details) (Type, error) {
 		return TypeAutoConfigTask, nil
 	case *Payload_AutoUpdateSqlActivities:
 		return TypeAutoUpdateSQLActivity, nil
+	case *Payload_ColumnEncryptionKeyRotation:
+		return TypeColumnEncryptionKeyRotation, nil
+	case *Payload_AnalyzeWorkload:
+		return TypeAnalyzeWorkload, nil
+	case *Payload_CostCalibration:
+		return TypeCostCalibration, nil
 	default:
 		return TypeUnspecified, errors.Newf("Payload.Type called on a payload with an unknown details type: %T", d)
 	}
@@ -256,6 +268,9 @@ var JobDetailsForEveryJobType = map[Type]Details{
 	TypeAutoConfigEnvRunner:          AutoConfigEnvRunnerDetails{},
 	TypeAutoConfigTask:               AutoConfigTaskDetails{},
 	TypeAutoUpdateSQLActivity:        AutoUpdateSQLActivityDetails{},
+	TypeColumnEncryptionKeyRotation:  ColumnEncryptionKeyRotationDetails{},
+	TypeAnalyzeWorkload:              AnalyzeWorkloadDetails{},
+	TypeCostCalibration:              CostCalibrationDetails{},
 }
 
 // WrapProgressDetails wraps a ProgressDetails object in the protobuf wrapper
@@ -311,6 +326,12 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_AutoConfigTask{AutoConfigTask: &d}
 	case AutoUpdateSQLActivityProgress:
 		return &Progress_UpdateSqlActivity{UpdateSqlActivity: &d}
+	case ColumnEncryptionKeyRotationProgress:
+		return &Progress_ColumnEncryptionKeyRotation{ColumnEncryptionKeyRotation: &d}
+	case AnalyzeWorkloadProgress:
+		return &Progress_AnalyzeWorkload{AnalyzeWorkload: &d}
+	case CostCalibrationProgress:
+		return &Progress_CostCalibration{CostCalibration: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown progress type %T", d))
 	}
@@ -364,6 +385,12 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.AutoConfigTask
 	case *Payload_AutoUpdateSqlActivities:
 		return *d.AutoUpdateSqlActivities
+	case *Payload_ColumnEncryptionKeyRotation:
+		return *d.ColumnEncryptionKeyRotation
+	case *Payload_AnalyzeWorkload:
+		return *d.AnalyzeWorkload
+	case *Payload_CostCalibration:
+		return *d.CostCalibration
 	default:
 		return nil
 	}
@@ -417,6 +444,12 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.AutoConfigTask
 	case *Progress_UpdateSqlActivity:
 		return *d.UpdateSqlActivity
+	case *Progress_ColumnEncryptionKeyRotation:
+		return *d.ColumnEncryptionKeyRotation
+	case *Progress_AnalyzeWorkload:
+		return *d.AnalyzeWorkload
+	case *Progress_CostCalibration:
+		return *d.CostCalibration
 	default:
 		return nil
 	}
@@ -494,6 +527,12 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_AutoConfigTask{AutoConfigTask: &d}
 	case AutoUpdateSQLActivityDetails:
 		return &Payload_AutoUpdateSqlActivities{AutoUpdateSqlActivities: &d}
+	case ColumnEncryptionKeyRotationDetails:
+		return &Payload_ColumnEncryptionKeyRotation{ColumnEncryptionKeyRotation: &d}
+	case AnalyzeWorkloadDetails:
+		return &Payload_AnalyzeWorkload{AnalyzeWorkload: &d}
+	case CostCalibrationDetails:
+		return &Payload_CostCalibration{CostCalibration: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -529,7 +568,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 24
+const NumJobTypes = 27
 
 // ChangefeedDetailsMarshaler allows for dependency injection of
 // cloud.SanitizeExternalStorageURI to avoid the dependency from this
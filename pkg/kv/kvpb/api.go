@@ -859,6 +859,9 @@ func (*BarrierRequest) Method() Method { return Barrier }
 // Method implements the Request interface.
 func (*IsSpanEmptyRequest) Method() Method { return IsSpanEmpty }
 
+// Method implements the Request interface.
+func (*ReadIndexRequest) Method() Method { return ReadIndex }
+
 // ShallowCopy implements the Request interface.
 func (gr *GetRequest) ShallowCopy() Request {
 	shallowCopy := *gr
@@ -1147,6 +1150,12 @@ func (r *IsSpanEmptyRequest) ShallowCopy() Request {
 	return &shallowCopy
 }
 
+// ShallowCopy implements the Request interface.
+func (r *ReadIndexRequest) ShallowCopy() Request {
+	shallowCopy := *r
+	return &shallowCopy
+}
+
 // NewGet returns a Request initialized to get the value at key. If
 // forUpdate is true, an unreplicated, exclusive lock is acquired on on
 // the key, if it exists.
@@ -1523,6 +1532,13 @@ func (*QueryResolvedTimestampRequest) flags() flag {
 func (*BarrierRequest) flags() flag     { return isWrite | isRange }
 func (*IsSpanEmptyRequest) flags() flag { return isRead | isRange }
 
+// ReadIndexRequest updates the timestamp cache over its span so that the
+// leaseholder does not perform any further writes on it at or below the batch
+// timestamp. Its response is only meaningful for a single range.
+func (*ReadIndexRequest) flags() flag {
+	return isRead | isRange | isAlone | isUnsplittable | updatesTSCache
+}
+
 // IsParallelCommit returns whether the EndTxn request is attempting to perform
 // a parallel commit. See txn_interceptor_committer.go for a discussion about
 // parallel commits.
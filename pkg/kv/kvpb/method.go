@@ -174,6 +174,10 @@ const (
 	// IsSpanEmpty is a non-transaction read request used to determine whether
 	// a span contains any keys whatsoever (garbage or otherwise).
 	IsSpanEmpty
+	// ReadIndex is a read request sent by a follower replica to the leaseholder
+	// to obtain a lease applied index after which the follower can serve a
+	// consistent read at a present-time timestamp.
+	ReadIndex
 	// MaxMethod is the maximum method.
 	MaxMethod Method = iota - 1
 	// NumMethods represents the total number of API methods.
@@ -60,6 +60,7 @@ func RangeStats(
 	if cpuOK {
 		reply.MaxCPUPerSecond = maxCPU
 	}
+	reply.ColdMergeMaxLoad, reply.ColdForMerge = cArgs.EvalCtx.GetColdMergeLoad(ctx)
 
 	reply.MaxQueriesPerSecondSet = true
 	reply.RangeInfo = cArgs.EvalCtx.GetRangeInfo(ctx)
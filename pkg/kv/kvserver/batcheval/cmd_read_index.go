@@ -0,0 +1,39 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package batcheval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+)
+
+func init() {
+	RegisterReadOnlyCommand(kvpb.ReadIndex, DefaultDeclareKeys, ReadIndex)
+}
+
+// ReadIndex returns the lease applied index of the leaseholder. By the time
+// the request is evaluated, it holds read latches at its timestamp over its key
+// span, so all writes at or below that timestamp which were in-flight when the
+// request arrived have been applied. Once the request completes, the timestamp
+// cache prevents any further writes at or below that timestamp. A follower
+// which has applied the returned index can therefore serve reads over the span
+// at or below the request's timestamp.
+func ReadIndex(
+	_ context.Context, _ storage.Reader, cArgs CommandArgs, resp kvpb.Response,
+) (result.Result, error) {
+	reply := resp.(*kvpb.ReadIndexResponse)
+	reply.LeaseAppliedIndex = cArgs.EvalCtx.GetLeaseAppliedIndex()
+	reply.RangeGeneration = cArgs.EvalCtx.Desc().Generation
+	return result.Result{}, nil
+}
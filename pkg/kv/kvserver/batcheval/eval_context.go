@@ -102,6 +102,11 @@ type EvalContext interface {
 	// is disabled.
 	GetMaxSplitCPU(context.Context) (float64, bool)
 
+	// GetColdMergeLoad returns the Replica's maximum load over the window given
+	// by the cold_merge_seconds field of its span config, and whether the
+	// Replica stayed cold over that entire window.
+	GetColdMergeLoad(context.Context) (float64, bool)
+
 	GetGCThreshold() hlc.Timestamp
 	ExcludeDataFromBackup() bool
 	GetLastReplicaGCTimestamp(context.Context) (hlc.Timestamp, error)
@@ -254,6 +259,9 @@ func (m *mockEvalCtxImpl) GetMaxSplitQPS(context.Context) (float64, bool) {
 func (m *mockEvalCtxImpl) GetMaxSplitCPU(context.Context) (float64, bool) {
 	return m.CPU, true
 }
+func (m *mockEvalCtxImpl) GetColdMergeLoad(context.Context) (float64, bool) {
+	return 0, false
+}
 func (m *mockEvalCtxImpl) CanCreateTxnRecord(
 	context.Context, uuid.UUID, []byte, hlc.Timestamp,
 ) (bool, kvpb.TransactionAbortedReason) {
@@ -4539,6 +4539,84 @@ func TestMergeQueue(t *testing.T) {
 		}
 	})
 
+	t.Run("cold-load-based-merging", func(t *testing.T) {
+		// After a reset, both ranges are above the minimum size threshold, so
+		// they can only be merged because they stayed cold over their cold merge
+		// window. The split threshold is set high enough that any load recorded
+		// during the test stays far below the cold threshold.
+		const splitByLoadStat = 10e9
+		const coldLoadFraction = 0.1
+		const coldThreshold = splitByLoadStat * coldLoadFraction
+		const splitByLoadMergeDelay = 1000 * time.Second
+		const coldMergeWindow = 5 * time.Minute
+		const sampleInterval = 30 * time.Second
+
+		resetForColdSubtest := func(t *testing.T) {
+			reset(t)
+
+			kvserver.SplitByLoadEnabled.Override(ctx, sv, true)
+			kvserver.LoadBasedRebalancingObjective.Override(ctx, sv, int64(kvserver.LBRebalancingQueries))
+			kvserver.SplitByLoadQPSThreshold.Override(ctx, sv, splitByLoadStat)
+			kvserver.SplitByLoadCPUThreshold.Override(ctx, sv, splitByLoadStat)
+			kvserverbase.SplitByLoadMergeDelay.Override(ctx, sv, splitByLoadMergeDelay)
+			kvserver.ColdRangeMergeLoadFraction.Override(ctx, sv, coldLoadFraction)
+
+			conf := conf
+			conf.ColdMergeSeconds = int32(coldMergeWindow / time.Second)
+			setSpanConfigs(t, conf)
+
+			// Reset both range's load-based splitters and bump the manual clock, so
+			// that their measurements are reliable but do not carry over between
+			// subtests. The jump also interrupts the ranges' load histories.
+			lhs().LoadBasedSplitter().Reset(tc.Servers[0].Clock().PhysicalTime())
+			rhs().LoadBasedSplitter().Reset(tc.Servers[0].Clock().PhysicalTime())
+			manualClock.Increment(splitByLoadMergeDelay.Nanoseconds())
+		}
+
+		// sampleLoad advances the manual clock by the given duration, letting the
+		// store sample the load on its replicas along the way.
+		sampleLoad := func(t *testing.T, d time.Duration) {
+			t.Helper()
+			require.NoError(t, store.ComputeMetrics(ctx))
+			for elapsed := time.Duration(0); elapsed < d; elapsed += sampleInterval {
+				manualClock.Increment(sampleInterval.Nanoseconds())
+				require.NoError(t, store.ComputeMetrics(ctx))
+			}
+		}
+
+		t.Run("cold", func(t *testing.T) {
+			resetForColdSubtest(t)
+
+			// The ranges are not merged until their load histories cover the
+			// entire cold merge window.
+			sampleLoad(t, coldMergeWindow/2)
+			verifyUnmergedSoon(t, store, lhsStartKey, rhsStartKey)
+
+			sampleLoad(t, coldMergeWindow)
+			verifyMergedSoon(t, store, lhsStartKey, rhsStartKey)
+		})
+
+		t.Run("hot-rhs", func(t *testing.T) {
+			resetForColdSubtest(t)
+
+			rhs().LoadBasedSplitter().RecordMax(tc.Servers[0].Clock().PhysicalTime(), 3*coldThreshold)
+			sampleLoad(t, 2*coldMergeWindow)
+			verifyUnmergedSoon(t, store, lhsStartKey, rhsStartKey)
+		})
+
+		t.Run("combined-above-cold-threshold", func(t *testing.T) {
+			resetForColdSubtest(t)
+
+			// Both ranges are cold on their own, but merging them would produce a
+			// range that is not.
+			moreThanHalfStat := coldThreshold/2 + 1
+			lhs().LoadBasedSplitter().RecordMax(tc.Servers[0].Clock().PhysicalTime(), moreThanHalfStat)
+			rhs().LoadBasedSplitter().RecordMax(tc.Servers[0].Clock().PhysicalTime(), moreThanHalfStat)
+			sampleLoad(t, 2*coldMergeWindow)
+			verifyUnmergedSoon(t, store, lhsStartKey, rhsStartKey)
+		})
+	})
+
 	t.Run("sticky-bit", func(t *testing.T) {
 		reset(t)
 		verifyUnmergedSoon(t, store, lhsStartKey, rhsStartKey)
@@ -144,6 +144,95 @@ func TestClosedTimestampCanServe(t *testing.T) {
 	})
 }
 
+// TestClosedTimestampCanServeWithReadIndex verifies that a follower replica can
+// serve a present-time read, which is above its closed timestamp, by asking the
+// leaseholder for a read index, and that doing so only prevents writes below
+// the read's timestamp over the span of the read.
+func TestClosedTimestampCanServeWithReadIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	skip.UnderRace(t)
+
+	ctx := context.Background()
+	// Use a long target duration so that present-time reads are never below the
+	// closed timestamp.
+	tc, db0, desc := setupClusterForClosedTSTesting(ctx, t, time.Minute, 0,
+		aggressiveResolvedTimestampManuallyReplicatedClusterArgs, "cttest", "kv")
+	defer tc.Stopper().Stop(ctx)
+
+	readKey := append(desc.StartKey.AsRawKey().Clone(), 'a')
+	otherKey := append(desc.StartKey.AsRawKey().Clone(), 'b')
+	require.NoError(t, tc.Server(0).DB().Put(ctx, readKey, "foo"))
+
+	lh := getCurrentLeaseholder(t, tc, desc)
+	var leaseholder, follower *kvserver.Replica
+	for _, repl := range replsForRange(ctx, t, tc, desc) {
+		if repl.StoreID() == lh.StoreID {
+			leaseholder = repl
+		} else if follower == nil {
+			follower = repl
+		}
+	}
+	require.NotNil(t, leaseholder)
+	require.NotNil(t, follower)
+
+	makeRead := func() (*kvpb.BatchRequest, hlc.Timestamp) {
+		ts := tc.Server(0).Clock().Now()
+		txn := roachpb.MakeTransaction("txn", nil, 0, 0, ts, 0, 0)
+		ba := &kvpb.BatchRequest{}
+		ba.RangeID = desc.RangeID
+		ba.Timestamp = ts
+		ba.Txn = &txn
+		ba.Add(&kvpb.GetRequest{RequestHeader: kvpb.RequestHeader{Key: readKey}})
+		return ba, ts
+	}
+
+	// Without read indexes, the follower redirects the read to the leaseholder.
+	ba, _ := makeRead()
+	_, pErr := follower.Send(ctx, ba)
+	require.True(t, errors.HasType(pErr.GoError(), (*kvpb.NotLeaseHolderError)(nil)), "%v", pErr)
+
+	_, err := db0.Exec(`SET CLUSTER SETTING kv.follower_reads.read_index.enabled = true`)
+	require.NoError(t, err)
+
+	// The follower waits until it has applied the write above before serving
+	// the read.
+	ba, ts := makeRead()
+	testutils.SucceedsSoon(t, func() error {
+		br, pErr := follower.Send(ctx, ba)
+		if pErr != nil {
+			return pErr.GoError()
+		}
+		value := br.Responses[0].GetGet().Value
+		if value == nil {
+			return errors.New("read did not observe the write")
+		}
+		b, err := value.GetBytes()
+		require.NoError(t, err)
+		require.Equal(t, "foo", string(b))
+		return nil
+	})
+	require.Less(t, int64(0), follower.Store().Metrics().FollowerReadsReadIndexSuccess.Count())
+
+	// Writes below the read's timestamp are pushed above it on the read's span,
+	// but not on the rest of the range.
+	write := func(key roachpb.Key) hlc.Timestamp {
+		txn := roachpb.MakeTransaction("testwrite", key, isolation.Serializable,
+			roachpb.NormalUserPriority, ts.Prev(), 0, 0)
+		ba := &kvpb.BatchRequest{}
+		ba.RangeID = desc.RangeID
+		ba.Timestamp = txn.WriteTimestamp
+		ba.Txn = &txn
+		ba.Add(kvpb.NewPut(key, roachpb.MakeValueFromString("bar")))
+		br, pErr := leaseholder.Send(ctx, ba)
+		require.Nil(t, pErr)
+		return br.Txn.WriteTimestamp
+	}
+	require.True(t, ts.Less(write(readKey)))
+	require.Equal(t, ts.Prev(), write(otherKey))
+}
+
 func TestClosedTimestampCanServeOnVoterIncoming(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -1437,11 +1437,13 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 	var txnHolder *enginepb.TxnMeta
 
 	durability := lock.Unreplicated
+	strength := lock.None
 	if l.isHeld() {
 		txnHolder = l.holder.txn
 		if l.isHeldReplicated() {
 			durability = lock.Replicated
 		}
+		strength = l.getLockMode().Strength
 	}
 
 	waiterCount := l.waitingReaders.Len() + l.queuedWriters.Len()
@@ -1480,6 +1482,7 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		Durability:   durability,
 		HoldDuration: l.lockHeldDuration(now),
 		Waiters:      lockWaiters,
+		LockStrength: strength,
 	}
 }
 
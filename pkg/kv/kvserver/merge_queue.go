@@ -27,6 +27,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -53,6 +55,59 @@ var MergeQueueInterval = settings.RegisterDurationSetting(
 	settings.NonNegativeDuration,
 )
 
+// ColdRangeMergeLoadFraction is a setting that controls the load below which a
+// range is considered cold, as a fraction of the load-based split threshold.
+// Cold ranges are merged with their right-hand neighbor, even when they exceed
+// the minimum size threshold, once they have been cold for the duration given
+// by the cold_merge_seconds field of their zone config.
+var ColdRangeMergeLoadFraction = settings.RegisterFloatSetting(
+	settings.SystemOnly,
+	"kv.range_merge.cold_range.load_fraction",
+	"fraction of the load-based split threshold below which a range is considered cold "+
+		"and may be merged once it has stayed cold for its zone's cold_merge_seconds",
+	0.1,
+	settings.NonNegativeFloatWithMaximum(0.25),
+)
+
+// coldRangeMergeExitFactor is the factor by which the load on a cold range
+// must exceed the load at which it is considered cold before it is no longer
+// considered cold. The gap between the two thresholds provides hysteresis, so
+// that a range whose load hovers around the threshold stays cold.
+const coldRangeMergeExitFactor = 2
+
+// coldRangeMergeMaxSizeFraction is the fraction of the maximum range size
+// that two cold ranges may not exceed when merged. Staying well below the
+// maximum size avoids merging ranges which would be split again soon after.
+const coldRangeMergeMaxSizeFraction = 0.5
+
+var (
+	metaSizeBasedMergeCount = metric.Metadata{
+		Name:        "queue.merge.size_based",
+		Help:        "Number of range merges due to both ranges being smaller than the configured min range size",
+		Measurement: "Range Merges",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaColdLoadBasedMergeCount = metric.Metadata{
+		Name:        "queue.merge.cold_load_based",
+		Help:        "Number of range merges due to a range having stayed below the cold load threshold",
+		Measurement: "Range Merges",
+		Unit:        metric.Unit_COUNT,
+	}
+)
+
+// MergeQueueMetrics is the set of metrics for the merge queue.
+type MergeQueueMetrics struct {
+	SizeBasedMergeCount     *metric.Counter
+	ColdLoadBasedMergeCount *metric.Counter
+}
+
+func makeMergeQueueMetrics() MergeQueueMetrics {
+	return MergeQueueMetrics{
+		SizeBasedMergeCount:     metric.NewCounter(metaSizeBasedMergeCount),
+		ColdLoadBasedMergeCount: metric.NewCounter(metaColdLoadBasedMergeCount),
+	}
+}
+
 // mergeQueue manages a queue of ranges slated to be merged with their right-
 // hand neighbor.
 //
@@ -89,6 +144,7 @@ type mergeQueue struct {
 	*baseQueue
 	db       *kv.DB
 	purgChan <-chan time.Time
+	metrics  MergeQueueMetrics
 }
 
 var _ queueImpl = &mergeQueue{}
@@ -97,7 +153,9 @@ func newMergeQueue(store *Store, db *kv.DB) *mergeQueue {
 	mq := &mergeQueue{
 		db:       db,
 		purgChan: time.NewTicker(mergeQueuePurgatoryCheckInterval).C,
+		metrics:  makeMergeQueueMetrics(),
 	}
+	store.metrics.registry.AddMetricStruct(&mq.metrics)
 	mq.baseQueue = newBaseQueue(
 		"merge", mq, store,
 		queueConfig{
@@ -158,8 +216,12 @@ func (mq *mergeQueue) shouldQueue(
 
 	sizeRatio := float64(repl.GetMVCCStats().Total()) / float64(repl.GetMinBytes())
 	if math.IsNaN(sizeRatio) || sizeRatio >= 1 {
-		// This range is above the minimum size threshold. It does not need to be
-		// merged.
+		// This range is above the minimum size threshold. It only needs to be
+		// merged if it has been cold for long enough, in which case it is merged
+		// after all ranges below the minimum size threshold.
+		if mq.isColdMergeCandidate(ctx, repl) {
+			return true, 0
+		}
 		return false, 0
 	}
 
@@ -190,6 +252,7 @@ func (mq *mergeQueue) requestRangeStats(
 	desc *roachpb.RangeDescriptor,
 	stats enginepb.MVCCStats,
 	lbSnap split.LoadSplitSnapshot,
+	coldLoad coldMergeLoad,
 	err error,
 ) {
 
@@ -200,12 +263,13 @@ func (mq *mergeQueue) requestRangeStats(
 
 	br, pErr := mq.db.NonTransactionalSender().Send(ctx, ba)
 	if pErr != nil {
-		return nil, enginepb.MVCCStats{}, lbSnap, pErr.GoError()
+		return nil, enginepb.MVCCStats{}, lbSnap, coldLoad, pErr.GoError()
 	}
 	res := br.Responses[0].GetInner().(*kvpb.RangeStatsResponse)
 
 	desc = &res.RangeInfo.Desc
 	stats = res.MVCCStats
+	coldLoad = coldMergeLoad{max: res.ColdMergeMaxLoad, cold: res.ColdForMerge}
 
 	// The load based splitter will only track the max of at most one statistic
 	// at a time for load based splitting. This is either CPU or QPS. However we
@@ -229,7 +293,7 @@ func (mq *mergeQueue) requestRangeStats(
 			Ok:             true,
 		}
 	}
-	return desc, stats, lbSnap, nil
+	return desc, stats, lbSnap, coldLoad, nil
 }
 
 func (mq *mergeQueue) process(
@@ -239,21 +303,26 @@ func (mq *mergeQueue) process(
 	lhsDesc := lhsRepl.Desc()
 	lhsStats := lhsRepl.GetMVCCStats()
 	minBytes := lhsRepl.GetMinBytes()
-	if lhsStats.Total() >= minBytes {
+	var lhsColdLoad coldMergeLoad
+	lhsColdLoad.max, lhsColdLoad.cold = lhsRepl.GetColdMergeLoad(ctx)
+	if lhsStats.Total() >= minBytes && !lhsColdLoad.cold {
 		log.VEventf(ctx, 2, "skipping merge: LHS meets minimum size threshold %d with %d bytes",
 			minBytes, lhsStats.Total())
 		return false, nil
 	}
 
-	rhsDesc, rhsStats, rhsLoadSplitSnap, err := mq.requestRangeStats(ctx, lhsDesc.EndKey.AsRawKey())
+	rhsDesc, rhsStats, rhsLoadSplitSnap, rhsColdLoad, err := mq.requestRangeStats(ctx, lhsDesc.EndKey.AsRawKey())
 	if err != nil {
 		return false, err
 	}
-	if rhsStats.Total() >= minBytes {
+	if rhsStats.Total() >= minBytes && !lhsColdLoad.cold {
 		log.VEventf(ctx, 2, "skipping merge: RHS meets minimum size threshold %d with %d bytes",
 			minBytes, rhsStats.Total())
 		return false, nil
 	}
+	// If either side meets the minimum size threshold, the merge can only
+	// proceed because the LHS is cold, in which case the RHS must be cold too.
+	coldMerge := lhsStats.Total() >= minBytes || rhsStats.Total() >= minBytes
 
 	// Range was manually split and not expired, so skip merging.
 	now := mq.store.Clock().NowAsClockTimestamp()
@@ -272,6 +341,18 @@ func (mq *mergeQueue) process(
 	mergedStats.Add(rhsStats)
 
 	lhsLoadSplitSnap := lhsRepl.loadBasedSplitter.Snapshot(ctx, mq.store.Clock().PhysicalTime())
+	var coldMergeReason string
+	if coldMerge {
+		var canMergeCold bool
+		if canMergeCold, coldMergeReason = canMergeColdRanges(
+			lhsLoadSplitSnap, rhsLoadSplitSnap, lhsColdLoad, rhsColdLoad,
+			mergedStats.Total(), lhsRepl.GetMaxBytes(),
+			ColdRangeMergeLoadFraction.Get(&mq.store.ClusterSettings().SV),
+		); !canMergeCold {
+			log.VEventf(ctx, 2, "skipping merge of cold range: %s", coldMergeReason)
+			return false, nil
+		}
+	}
 	var loadMergeReason string
 	if lhsRepl.SplitByLoadEnabled() {
 		var canMergeLoad bool
@@ -368,7 +449,7 @@ func (mq *mergeQueue) process(
 		}
 
 		// Refresh RHS descriptor.
-		rhsDesc, _, _, err = mq.requestRangeStats(ctx, lhsDesc.EndKey.AsRawKey())
+		rhsDesc, _, _, _, err = mq.requestRangeStats(ctx, lhsDesc.EndKey.AsRawKey())
 		if err != nil {
 			return false, err
 		}
@@ -393,6 +474,9 @@ func (mq *mergeQueue) process(
 		humanizeutil.IBytes(minBytes),
 		loadMergeReason,
 	)
+	if coldMerge {
+		reason = coldMergeReason
+	}
 	_, pErr := lhsRepl.AdminMerge(ctx, kvpb.AdminMergeRequest{
 		RequestHeader: kvpb.RequestHeader{Key: lhsRepl.Desc().StartKey.AsRawKey()},
 	}, reason)
@@ -413,6 +497,11 @@ func (mq *mergeQueue) process(
 		log.Warningf(ctx, "%v", err)
 		return false, rangeMergePurgatoryError{err}
 	}
+	if coldMerge {
+		mq.metrics.ColdLoadBasedMergeCount.Inc(1)
+	} else {
+		mq.metrics.SizeBasedMergeCount.Inc(1)
+	}
 	if testingAggressiveConsistencyChecks {
 		if _, err := mq.store.consistencyQueue.process(ctx, lhsRepl, confReader); err != nil {
 			log.Warningf(ctx, "%v", err)
@@ -502,3 +591,172 @@ func canMergeRangeLoad(
 		obj.Format(conservativeLoadBasedSplitThreshold),
 	)
 }
+
+// isColdMergeCandidate returns whether the replica has stayed cold over the
+// window configured by its span config, making it a candidate for merging
+// regardless of its size.
+func (mq *mergeQueue) isColdMergeCandidate(ctx context.Context, repl *Replica) bool {
+	_, cold := repl.GetColdMergeLoad(ctx)
+	return cold
+}
+
+// coldMergeLoad is the maximum load on a range over its cold merge window, as
+// returned by Replica.GetColdMergeLoad.
+type coldMergeLoad struct {
+	max  float64
+	cold bool
+}
+
+// canMergeColdRanges returns whether a cold LHS can be merged with its RHS,
+// which may exceed the minimum size threshold. The RHS must also have stayed
+// cold over its cold merge window, the combined load of both sides over their
+// windows must stay below the cold threshold, so that the merged range is cold
+// too, and the merged range must stay well below the maximum size threshold.
+func canMergeColdRanges(
+	lhs, rhs split.LoadSplitSnapshot,
+	lhsLoad, rhsLoad coldMergeLoad,
+	mergedBytes, maxBytes int64,
+	loadFraction float64,
+) (can bool, reason string) {
+	if !rhsLoad.cold {
+		return false, "RHS has not stayed cold over its cold merge window"
+	}
+	if !rhs.Ok || lhs.SplitObjective != rhs.SplitObjective {
+		return false, "RHS load measurement not yet reliable"
+	}
+	// The RHS snapshot does not carry the split threshold, which is the same on
+	// both sides.
+	obj := lhs.SplitObjective
+	if coldThreshold := loadFraction * lhs.Threshold; lhsLoad.max+rhsLoad.max >= coldThreshold {
+		return false, fmt.Sprintf("lhs+rhs %s over cold merge window (%s+%s) above cold threshold (%s)",
+			obj, obj.Format(lhsLoad.max), obj.Format(rhsLoad.max), obj.Format(coldThreshold))
+	}
+	maxMergedBytes := int64(coldRangeMergeMaxSizeFraction * float64(maxBytes))
+	if mergedBytes >= maxMergedBytes {
+		return false, fmt.Sprintf("lhs+rhs size (%s) above cold merge threshold (%s)",
+			humanizeutil.IBytes(mergedBytes), humanizeutil.IBytes(maxMergedBytes))
+	}
+	return true, fmt.Sprintf("cold lhs+rhs %s over cold merge window (%s+%s) and size (%s) below thresholds",
+		obj, obj.Format(lhsLoad.max), obj.Format(rhsLoad.max), humanizeutil.IBytes(mergedBytes))
+}
+
+// coldMergeLoadMaxSampleGap is the longest gap between two load samples for
+// which the cold merge load history of a replica is considered uninterrupted.
+// The store samples the load on its replicas every time it computes its
+// metrics, which happens far more frequently.
+const coldMergeLoadMaxSampleGap = time.Minute
+
+// coldMergeLoadHistory keeps a history of the load on a replica over the
+// window given by the cold_merge_seconds field of its span config, which the
+// merge queue uses to determine whether the replica stayed cold for long enough
+// to be merged. Like maxStatTracker, the history is a circular buffer of the
+// maximum load sampled in each of a fixed number of sub-windows, so that old
+// samples age out without hard resets.
+//
+// A replica becomes cold once its load stayed below the cold threshold over the
+// entire window, and remains cold until a sample exceeds
+// coldRangeMergeExitFactor times that threshold. The gap between the two
+// thresholds provides hysteresis, so that a range whose load hovers around the
+// threshold does not flip between being cold and not.
+type coldMergeLoadHistory struct {
+	mu struct {
+		syncutil.Mutex
+		windows  [12]float64
+		curIdx   int
+		curStart time.Time
+		// lastReset is the time since which samples have been recorded without
+		// interruption.
+		lastReset time.Time
+		// lastSample is the time of the most recent sample.
+		lastSample time.Time
+		// window and objective are the cold merge window and the split objective
+		// that the samples were recorded with.
+		window    time.Duration
+		objective split.SplitObjective
+		cold      bool
+	}
+}
+
+// record adds the given load snapshot, sampled at the given time, to the
+// history. An unreliable snapshot, a change of the window or split objective,
+// or a gap in the samples clears the history.
+func (h *coldMergeLoadHistory) record(
+	now time.Time, window time.Duration, snap split.LoadSplitSnapshot, loadFraction float64,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if window <= 0 || !snap.Ok || loadFraction == 0 {
+		h.resetLocked(time.Time{}, 0, snap.SplitObjective)
+		return
+	}
+	if window != h.mu.window || snap.SplitObjective != h.mu.objective ||
+		now.Sub(h.mu.lastSample) > coldMergeLoadMaxSampleGap {
+		h.resetLocked(now, window, snap.SplitObjective)
+	}
+	h.maybeRotateLocked(now)
+	h.mu.windows[h.mu.curIdx] = math.Max(h.mu.windows[h.mu.curIdx], snap.Max)
+	h.mu.lastSample = now
+
+	enterThreshold := loadFraction * snap.Threshold
+	exitThreshold := coldRangeMergeExitFactor * enterThreshold
+	switch {
+	case snap.Max >= exitThreshold:
+		h.mu.cold = false
+	case !h.mu.cold && now.Sub(h.mu.lastReset) >= h.mu.window && h.maxLocked() < enterThreshold:
+		h.mu.cold = true
+	}
+}
+
+// coldLoad returns the maximum load recorded over the window, and whether the
+// replica stayed cold over that window. A history that has not been sampled
+// recently is not considered cold.
+func (h *coldMergeLoadHistory) coldLoad(now time.Time) (float64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.mu.cold || now.Sub(h.mu.lastSample) > coldMergeLoadMaxSampleGap {
+		return 0, false
+	}
+	return h.maxLocked(), true
+}
+
+func (h *coldMergeLoadHistory) resetLocked(
+	now time.Time, window time.Duration, objective split.SplitObjective,
+) {
+	h.mu.windows = [12]float64{}
+	h.mu.curIdx = 0
+	h.mu.curStart = now
+	h.mu.lastReset = now
+	h.mu.lastSample = now
+	h.mu.window = window
+	h.mu.objective = objective
+	h.mu.cold = false
+}
+
+func (h *coldMergeLoadHistory) maybeRotateLocked(now time.Time) {
+	// NB: -1 because during a rotation, only len(windows)-1 windows survive.
+	windowWidth := h.mu.window / time.Duration(len(h.mu.windows)-1)
+	sinceLastRotate := now.Sub(h.mu.curStart)
+	if sinceLastRotate < windowWidth {
+		return
+	}
+	shift := int(sinceLastRotate / windowWidth)
+	if shift >= len(h.mu.windows) {
+		h.mu.windows = [12]float64{}
+		h.mu.curIdx = 0
+		h.mu.curStart = now
+		return
+	}
+	for i := 0; i < shift; i++ {
+		h.mu.curIdx = (h.mu.curIdx + 1) % len(h.mu.windows)
+		h.mu.curStart = h.mu.curStart.Add(windowWidth)
+		h.mu.windows[h.mu.curIdx] = 0
+	}
+}
+
+func (h *coldMergeLoadHistory) maxLocked() float64 {
+	var m float64
+	for _, v := range h.mu.windows {
+		m = math.Max(m, v)
+	}
+	return m
+}
@@ -13,11 +13,13 @@ package kvserver
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/split"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/bootstrap"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -26,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMergeQueueShouldQueue(t *testing.T) {
@@ -168,3 +171,148 @@ func TestMergeQueueShouldQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestColdMergeLoadHistory(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const loadFraction = 0.1
+	const window = 10 * time.Minute
+	snap := func(max float64) split.LoadSplitSnapshot {
+		return split.LoadSplitSnapshot{
+			SplitObjective: split.SplitQPS,
+			Max:            max,
+			Threshold:      1000,
+			Ok:             true,
+		}
+	}
+	start := time.Unix(0, 0)
+	var now time.Time
+	// sample records a sample with the given load every 30 seconds for the
+	// given duration.
+	var h coldMergeLoadHistory
+	sample := func(d time.Duration, max float64) {
+		for end := now.Add(d); now.Before(end); now = now.Add(30 * time.Second) {
+			h.record(now, window, snap(max), loadFraction)
+		}
+		h.record(now, window, snap(max), loadFraction)
+	}
+	requireCold := func(expMax float64) {
+		t.Helper()
+		max, cold := h.coldLoad(now)
+		require.True(t, cold)
+		require.Equal(t, expMax, max)
+	}
+	requireNotCold := func() {
+		t.Helper()
+		_, cold := h.coldLoad(now)
+		require.False(t, cold)
+	}
+
+	// Load below the cold threshold of 100 only makes the range cold once it
+	// covers the entire window.
+	now = start
+	sample(5*time.Minute, 50)
+	requireNotCold()
+	sample(5*time.Minute, 50)
+	requireCold(50)
+
+	// Load between the cold threshold and the exit threshold of 200 keeps the
+	// range cold.
+	sample(time.Minute, 150)
+	requireCold(150)
+
+	// Load above the exit threshold makes the range hot again, and it only
+	// becomes cold again once that load falls out of the window.
+	sample(time.Minute, 250)
+	requireNotCold()
+	sample(5*time.Minute, 50)
+	requireNotCold()
+	sample(6*time.Minute, 50)
+	requireCold(50)
+
+	// The history is not considered cold if it has not been sampled recently.
+	now = now.Add(2 * coldMergeLoadMaxSampleGap)
+	requireNotCold()
+	// A gap in the samples resets the history.
+	sample(5*time.Minute, 50)
+	requireNotCold()
+	sample(6*time.Minute, 50)
+	requireCold(50)
+
+	// An unreliable measurement resets the history.
+	h.record(now, window, split.LoadSplitSnapshot{}, loadFraction)
+	requireNotCold()
+	sample(11*time.Minute, 50)
+	requireCold(50)
+
+	// A change of the window resets the history.
+	h.record(now, 2*window, snap(50), loadFraction)
+	requireNotCold()
+}
+
+func TestCanMergeColdRanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	lhs := split.LoadSplitSnapshot{SplitObjective: split.SplitQPS, Max: 10, Threshold: 1000, Ok: true}
+	rhs := func(ok bool) split.LoadSplitSnapshot {
+		return split.LoadSplitSnapshot{SplitObjective: split.SplitQPS, Max: 10, Ok: ok}
+	}
+	const maxBytes = 512 << 20
+
+	testCases := []struct {
+		rhs         split.LoadSplitSnapshot
+		lhsLoad     coldMergeLoad
+		rhsLoad     coldMergeLoad
+		mergedBytes int64
+		expCanMerge bool
+	}{
+		{
+			rhs:         rhs(true),
+			lhsLoad:     coldMergeLoad{max: 40, cold: true},
+			rhsLoad:     coldMergeLoad{max: 40, cold: true},
+			mergedBytes: 100 << 20,
+			expCanMerge: true,
+		},
+		// The RHS did not stay cold over its window.
+		{
+			rhs:         rhs(true),
+			lhsLoad:     coldMergeLoad{max: 40, cold: true},
+			rhsLoad:     coldMergeLoad{},
+			mergedBytes: 100 << 20,
+			expCanMerge: false,
+		},
+		// The RHS load measurement is unreliable.
+		{
+			rhs:         rhs(false),
+			lhsLoad:     coldMergeLoad{max: 40, cold: true},
+			rhsLoad:     coldMergeLoad{max: 40, cold: true},
+			mergedBytes: 100 << 20,
+			expCanMerge: false,
+		},
+		// Both sides are cold, but their combined load is above the cold
+		// threshold of 100.
+		{
+			rhs:         rhs(true),
+			lhsLoad:     coldMergeLoad{max: 60, cold: true},
+			rhsLoad:     coldMergeLoad{max: 60, cold: true},
+			mergedBytes: 100 << 20,
+			expCanMerge: false,
+		},
+		// The merged range would be too large.
+		{
+			rhs:         rhs(true),
+			lhsLoad:     coldMergeLoad{max: 40, cold: true},
+			rhsLoad:     coldMergeLoad{max: 40, cold: true},
+			mergedBytes: 300 << 20,
+			expCanMerge: false,
+		},
+	}
+	for _, tc := range testCases {
+		can, reason := canMergeColdRanges(
+			lhs, tc.rhs, tc.lhsLoad, tc.rhsLoad, tc.mergedBytes, maxBytes, 0.1, /* loadFraction */
+		)
+		require.Equal(t, tc.expCanMerge, can, reason)
+	}
+}
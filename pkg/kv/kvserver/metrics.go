@@ -414,6 +414,18 @@ var (
 		Measurement: "Read Ops",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaFollowerReadsReadIndexSuccess = metric.Metadata{
+		Name:        "follower_reads.read_index.success",
+		Help:        "Number of read index requests that allowed a follower replica to serve a present-time read",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaFollowerReadsReadIndexFailure = metric.Metadata{
+		Name:        "follower_reads.read_index.failure",
+		Help:        "Number of read index requests that failed to allow a follower replica to serve a present-time read",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
 
 	// Server-side transaction metrics.
 	metaCommitWaitBeforeCommitTriggerCount = metric.Metadata{
@@ -768,6 +780,18 @@ bytes preserved during flushes and compactions over the lifetime of the process.
 		Measurement: "Bytes",
 		Unit:        metric.Unit_BYTES,
 	}
+	metaSharedStorageReadCount = metric.Metadata{
+		Name:        "storage.shared-storage.read-count",
+		Help:        "Number of reads from shared storage",
+		Measurement: "Reads",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaSharedStorageReadNanos = metric.Metadata{
+		Name:        "storage.shared-storage.read-nanos",
+		Help:        "Cumulative time spent reading from shared storage",
+		Measurement: "Nanoseconds",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
 	metaFlushableIngestCount = metric.Metadata{
 		Name:        "storage.flush.ingest.count",
 		Help:        "Flushes performing an ingest (flushable ingestions)",
@@ -2230,7 +2254,9 @@ type StoreMetrics struct {
 	}
 
 	// Follower read metrics.
-	FollowerReadsCount *metric.Counter
+	FollowerReadsCount            *metric.Counter
+	FollowerReadsReadIndexSuccess *metric.Counter
+	FollowerReadsReadIndexFailure *metric.Counter
 
 	// Server-side transaction metrics.
 	CommitWaitsBeforeCommitTrigger                           *metric.Counter
@@ -2285,6 +2311,8 @@ type StoreMetrics struct {
 	RdbWriteStallNanos            *metric.Gauge
 	SharedStorageBytesRead        *metric.Gauge
 	SharedStorageBytesWritten     *metric.Gauge
+	SharedStorageReadCount        *metric.Gauge
+	SharedStorageReadNanos        *metric.Gauge
 	StorageCompactionsPinnedKeys  *metric.Gauge
 	StorageCompactionsPinnedBytes *metric.Gauge
 	StorageCompactionsDuration    *metric.Gauge
@@ -2881,7 +2909,9 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		),
 
 		// Follower reads metrics.
-		FollowerReadsCount: metric.NewCounter(metaFollowerReadsCount),
+		FollowerReadsCount:            metric.NewCounter(metaFollowerReadsCount),
+		FollowerReadsReadIndexSuccess: metric.NewCounter(metaFollowerReadsReadIndexSuccess),
+		FollowerReadsReadIndexFailure: metric.NewCounter(metaFollowerReadsReadIndexFailure),
 
 		// Server-side transaction metrics.
 		CommitWaitsBeforeCommitTrigger:                           metric.NewCounter(metaCommitWaitBeforeCommitTriggerCount),
@@ -2938,6 +2968,8 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		IterInternalSteps:             metric.NewGauge(metaIterInternalSteps),
 		SharedStorageBytesRead:        metric.NewGauge(metaSharedStorageBytesRead),
 		SharedStorageBytesWritten:     metric.NewGauge(metaSharedStorageBytesWritten),
+		SharedStorageReadCount:        metric.NewGauge(metaSharedStorageReadCount),
+		SharedStorageReadNanos:        metric.NewGauge(metaSharedStorageReadNanos),
 		StorageCompactionsPinnedKeys:  metric.NewGauge(metaStorageCompactionsKeysPinnedCount),
 		StorageCompactionsPinnedBytes: metric.NewGauge(metaStorageCompactionsKeysPinnedBytes),
 		StorageCompactionsDuration:    metric.NewGauge(metaStorageCompactionsDuration),
@@ -3321,6 +3353,8 @@ func (sm *StoreMetrics) updateEngineMetrics(m storage.Metrics) {
 	sm.StorageCompactionsDuration.Update(int64(m.Compact.Duration))
 	sm.SharedStorageBytesRead.Update(m.SharedStorageReadBytes)
 	sm.SharedStorageBytesWritten.Update(m.SharedStorageWriteBytes)
+	sm.SharedStorageReadCount.Update(m.SharedStorageReadCount)
+	sm.SharedStorageReadNanos.Update(m.SharedStorageReadDuration.Nanoseconds())
 	sm.RdbL0Sublevels.Update(int64(m.Levels[0].Sublevels))
 	sm.RdbL0NumFiles.Update(m.Levels[0].NumFiles)
 	sm.RdbL0BytesFlushed.Update(int64(m.Levels[0].BytesFlushed))
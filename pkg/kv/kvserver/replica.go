@@ -446,6 +446,17 @@ type Replica struct {
 		// mergeTxnID contains the ID of the in-progress merge transaction, if a
 		// merge is currently in progress. Otherwise, the ID is empty.
 		mergeTxnID uuid.UUID
+		// readIndexClosed is the highest timestamp at or below which the
+		// leaseholder has promised, in response to a ReadIndex request issued by
+		// this replica, not to perform any further writes on span. It is only
+		// recorded once the replica has applied the lease applied index returned
+		// by the leaseholder, and it is only meaningful as long as the range
+		// descriptor's generation is still gen. See maybeAdvanceReadIndex.
+		readIndexClosed struct {
+			ts   hlc.Timestamp
+			span roachpb.RSpan
+			gen  roachpb.RangeGeneration
+		}
 		// The state of the Raft state machine. Updated only when raftMu and mu are
 		// both held.
 		state kvserverpb.ReplicaState
@@ -887,6 +898,10 @@ type Replica struct {
 	// loadBasedSplitter keeps information about load-based splitting.
 	loadBasedSplitter split.Decider
 
+	// coldMergeLoadHistory keeps a history of the load on the replica, which
+	// the merge queue uses to merge cold ranges.
+	coldMergeLoadHistory coldMergeLoadHistory
+
 	// unreachablesMu contains a set of remote ReplicaIDs that are to be reported
 	// as unreachable on the next raft tick.
 	unreachablesMu struct {
@@ -1407,6 +1422,31 @@ func (r *Replica) GetMaxSplitCPU(ctx context.Context) (float64, bool) {
 	return snap.Max, snap.Ok
 }
 
+// GetColdMergeLoad returns the Replica's maximum load, in the units of its
+// load-based split objective, over the window given by the cold_merge_seconds
+// field of its span config. If the Replica has not stayed cold over the entire
+// window, the method will return false.
+func (r *Replica) GetColdMergeLoad(ctx context.Context) (float64, bool) {
+	if r.SpanConfig().ColdMergeSeconds <= 0 || !r.SplitByLoadEnabled() {
+		return 0, false
+	}
+	return r.coldMergeLoadHistory.coldLoad(r.Clock().PhysicalTime())
+}
+
+// recordColdMergeLoad samples the load on the Replica into its cold merge load
+// history. The store calls it periodically, so that the history does not
+// depend on how often the merge queue processes the Replica.
+func (r *Replica) recordColdMergeLoad(ctx context.Context, now time.Time) {
+	window := time.Duration(r.SpanConfig().ColdMergeSeconds) * time.Second
+	var snap split.LoadSplitSnapshot
+	if window > 0 && r.SplitByLoadEnabled() {
+		snap = r.loadBasedSplitter.Snapshot(ctx, now)
+	}
+	r.coldMergeLoadHistory.record(
+		now, window, snap, ColdRangeMergeLoadFraction.Get(&r.ClusterSettings().SV),
+	)
+}
+
 // ContainsKey returns whether this range contains the specified key.
 //
 // TODO(bdarnell): This is not the same as RangeDescriptor.ContainsKey.
@@ -1815,6 +1855,14 @@ func (r *Replica) checkLeaseRLocked(
 			// No valid lease, but if we can serve this request via follower reads,
 			// we may continue.
 			if !r.canServeFollowerReadRLocked(ctx, ba) {
+				// If the lease is held by another replica, we may still be able to
+				// serve the request after asking the leaseholder for a read index.
+				// Return an InvalidLeaseError so that the request is retried through
+				// handleInvalidLeaseError, which does so.
+				var nlhe *kvpb.NotLeaseHolderError
+				if errors.As(err, &nlhe) && nlhe.Lease != nil && r.canRequestReadIndexRLocked(ba) {
+					return kvserverpb.LeaseStatus{}, &kvpb.InvalidLeaseError{}
+				}
 				// If not, return the error.
 				return kvserverpb.LeaseStatus{}, err
 			}
@@ -142,6 +142,12 @@ func (rec SpanSetReplicaEvalContext) GetMaxSplitCPU(ctx context.Context) (float6
 	return rec.i.GetMaxSplitCPU(ctx)
 }
 
+// GetColdMergeLoad returns the Replica's maximum load over its cold merge
+// window, and whether it stayed cold over that window.
+func (rec SpanSetReplicaEvalContext) GetColdMergeLoad(ctx context.Context) (float64, bool) {
+	return rec.i.GetColdMergeLoad(ctx)
+}
+
 // CanCreateTxnRecord determines whether a transaction record can be created
 // for the provided transaction information. See Replica.CanCreateTxnRecord
 // for details about its arguments, return values, and preconditions.
@@ -12,13 +12,18 @@ package kvserver
 
 import (
 	"context"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
 
@@ -33,6 +38,24 @@ var FollowerReadsEnabled = settings.RegisterBoolSetting(
 	true,
 ).WithPublic()
 
+// FollowerReadIndexEnabled controls whether replicas that cannot serve a
+// follower read because their closed timestamp lags the read's timestamp
+// consult the leaseholder for a read index instead of redirecting the read to
+// the leaseholder. This allows followers to serve consistent, present-time
+// reads at the cost of a small round-trip to the leaseholder.
+var FollowerReadIndexEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"kv.follower_reads.read_index.enabled",
+	"allow replicas to serve consistent present-time reads by requesting a read index "+
+		"from the leaseholder instead of redirecting the read to the leaseholder",
+	false,
+)
+
+// readIndexApplyTimeout bounds the time a follower waits to apply the lease
+// applied index returned by a ReadIndex request before giving up and letting
+// the read be redirected to the leaseholder.
+const readIndexApplyTimeout = 5 * time.Second
+
 // BatchCanBeEvaluatedOnFollower determines if a batch consists exclusively of
 // requests that can be evaluated on a follower replica, given a sufficiently
 // advanced closed timestamp.
@@ -104,6 +127,9 @@ func (r *Replica) canServeFollowerReadRLocked(ctx context.Context, ba *kvpb.Batc
 
 	requiredFrontier := ba.RequiredFrontier()
 	maxClosed := r.getCurrentClosedTimestampLocked(ctx, requiredFrontier /* sufficient */)
+	if span, err := keys.Range(ba.Requests); err == nil {
+		maxClosed.Forward(r.getReadIndexClosedTimestampRLocked(span))
+	}
 	canServeFollowerRead := requiredFrontier.LessEq(maxClosed)
 	tsDiff := requiredFrontier.GoTime().Sub(maxClosed.GoTime())
 	if !canServeFollowerRead {
@@ -155,3 +181,124 @@ func (r *Replica) GetCurrentClosedTimestamp(ctx context.Context) hlc.Timestamp {
 	defer r.mu.RUnlock()
 	return r.getCurrentClosedTimestampLocked(ctx, hlc.Timestamp{} /* sufficient */)
 }
+
+// getReadIndexClosedTimestampRLocked returns the highest timestamp at or below
+// which this replica can serve reads over the provided span based on a
+// ReadIndex request it has previously issued to the leaseholder, or an empty
+// timestamp if that request did not cover the span or the range descriptor has
+// changed since.
+func (r *Replica) getReadIndexClosedTimestampRLocked(span roachpb.RSpan) hlc.Timestamp {
+	if r.mu.readIndexClosed.gen != r.mu.state.Desc.Generation ||
+		!r.mu.readIndexClosed.span.ContainsKeyRange(span.Key, span.EndKey) {
+		return hlc.Timestamp{}
+	}
+	return r.mu.readIndexClosed.ts
+}
+
+// canRequestReadIndexRLocked returns whether a batch that cannot be served as a
+// follower read because the closed timestamp lags its timestamp may instead be
+// served after asking the leaseholder for a read index.
+func (r *Replica) canRequestReadIndexRLocked(ba *kvpb.BatchRequest) bool {
+	st := r.store.cfg.Settings
+	if !FollowerReadIndexEnabled.Get(&st.SV) || !FollowerReadsEnabled.Get(&st.SV) ||
+		!BatchCanBeEvaluatedOnFollower(ba) {
+		return false
+	}
+	repDesc, err := r.getReplicaDescriptorRLocked()
+	if err != nil {
+		return false
+	}
+	switch repDesc.Type {
+	case roachpb.VOTER_FULL, roachpb.VOTER_INCOMING, roachpb.NON_VOTER:
+		return true
+	default:
+		return false
+	}
+}
+
+// maybeAdvanceReadIndex is called when a batch could not be served by this
+// replica because it does not hold the lease, and the lease is known to be
+// held by another replica. If the batch could have been served as a follower
+// read if only the closed timestamp had been high enough, and read index
+// follower reads are enabled, it asks the leaseholder for a read index at the
+// batch's required frontier over the batch's span, waits until the replica has
+// applied it, and then records the frontier so that a subsequent call to
+// canServeFollowerReadRLocked will accept the batch. It returns true if the
+// batch should be retried on this replica.
+func (r *Replica) maybeAdvanceReadIndex(ctx context.Context, ba *kvpb.BatchRequest) bool {
+	r.mu.RLock()
+	desc := r.mu.state.Desc
+	canRequest := r.canRequestReadIndexRLocked(ba)
+	r.mu.RUnlock()
+	if !canRequest {
+		return false
+	}
+	span, err := keys.Range(ba.Requests)
+	if err != nil {
+		return false
+	}
+	// Only the span of the batch is covered by the read index, so that the
+	// timestamp cache of the rest of the range is not bumped on the leaseholder.
+	span, err = span.Intersect(desc.RSpan())
+	if err != nil {
+		return false
+	}
+	if err := r.advanceReadIndex(ctx, desc, span, ba.RequiredFrontier()); err != nil {
+		log.VEventf(ctx, 2, "unable to serve present-time follower read: %v", err)
+		r.store.metrics.FollowerReadsReadIndexFailure.Inc(1)
+		return false
+	}
+	r.store.metrics.FollowerReadsReadIndexSuccess.Inc(1)
+	return true
+}
+
+// advanceReadIndex issues a ReadIndex request at the provided timestamp over
+// the provided span of the range to the leaseholder, waits until the replica has
+// applied the returned lease applied index, and then records the timestamp as
+// closed over the span for as long as the descriptor's generation remains
+// unchanged.
+func (r *Replica) advanceReadIndex(
+	ctx context.Context, desc *roachpb.RangeDescriptor, span roachpb.RSpan, ts hlc.Timestamp,
+) error {
+	ba := &kvpb.BatchRequest{}
+	ba.Timestamp = ts
+	ba.Add(&kvpb.ReadIndexRequest{
+		RequestHeader: kvpb.RequestHeaderFromSpan(span.AsRawSpanWithNoLocals()),
+	})
+	br, pErr := r.store.DB().NonTransactionalSender().Send(ctx, ba)
+	if pErr != nil {
+		return pErr.GoError()
+	}
+	resp := br.Responses[0].GetInner().(*kvpb.ReadIndexResponse)
+	if resp.RangeGeneration != desc.Generation {
+		return errors.Errorf("range descriptor generation changed from %d to %d",
+			desc.Generation, resp.RangeGeneration)
+	}
+
+	if err := timeutil.RunWithTimeout(ctx, "wait for read index", readIndexApplyTimeout,
+		func(ctx context.Context) error {
+			retryOpts := retry.Options{InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+			for re := retry.StartWithCtx(ctx, retryOpts); re.Next(); {
+				if r.GetLeaseAppliedIndex() >= resp.LeaseAppliedIndex {
+					return nil
+				}
+			}
+			return ctx.Err()
+		}); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if gen := r.mu.state.Desc.Generation; gen != desc.Generation {
+		return errors.Errorf("range descriptor generation changed from %d to %d",
+			desc.Generation, gen)
+	}
+	if r.mu.readIndexClosed.gen != desc.Generation || !r.mu.readIndexClosed.span.Equal(span) {
+		r.mu.readIndexClosed.ts = hlc.Timestamp{}
+		r.mu.readIndexClosed.span = span
+		r.mu.readIndexClosed.gen = desc.Generation
+	}
+	r.mu.readIndexClosed.ts.Forward(ts)
+	return nil
+}
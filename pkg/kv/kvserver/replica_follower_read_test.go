@@ -187,3 +187,67 @@ func TestCheckExecutionCanProceedAllowsFollowerReadWithInvalidLease(t *testing.T
 	ls = r.CurrentLeaseStatus(ctx)
 	require.False(t, ls.IsValid())
 }
+
+// Test that a timestamp recorded by a read index request allows a follower
+// read at a present-time timestamp, as long as the range descriptor has not
+// changed since.
+func TestCanServeFollowerReadWithReadIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	manual := timeutil.NewManualTime(timeutil.Unix(0, 5))
+	clock := hlc.NewClockForTesting(manual)
+	tsc := TestStoreConfig(clock)
+	closedts.TargetDuration.Override(ctx, &tsc.Settings.SV, time.Second)
+
+	tc := testContext{manualClock: manual}
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	tc.StartWithStoreConfig(ctx, t, stopper, tsc)
+
+	key := roachpb.Key("a")
+	gArgs := getArgs(key)
+	txn := roachpb.MakeTransaction(
+		"test",
+		key,
+		isolation.Serializable,
+		roachpb.NormalUserPriority,
+		clock.Now(),
+		clock.MaxOffset().Nanoseconds(),
+		0, // coordinatorNodeID
+	)
+	ba := &kvpb.BatchRequest{}
+	ba.Header = kvpb.Header{Txn: &txn}
+	ba.Add(&gArgs)
+
+	r := tc.repl
+	canServe := func() bool {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return r.canServeFollowerReadRLocked(ctx, ba)
+	}
+	require.False(t, canServe())
+
+	// Record the read's frontier as closed under the current descriptor, over
+	// a span that does not cover the read.
+	r.mu.Lock()
+	gen := r.mu.state.Desc.Generation
+	r.mu.readIndexClosed.ts = ba.RequiredFrontier()
+	r.mu.readIndexClosed.span = roachpb.RSpan{Key: roachpb.RKey("b"), EndKey: roachpb.RKey("c")}
+	r.mu.readIndexClosed.gen = gen
+	r.mu.Unlock()
+	require.False(t, canServe())
+
+	// Once the span covers the read, it can be served.
+	r.mu.Lock()
+	r.mu.readIndexClosed.span = roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("c")}
+	r.mu.Unlock()
+	require.True(t, canServe())
+
+	// A read index recorded under a different descriptor is ignored.
+	r.mu.Lock()
+	r.mu.readIndexClosed.gen = gen - 1
+	r.mu.Unlock()
+	require.False(t, canServe())
+}
@@ -845,6 +845,14 @@ func (r *Replica) handleInvalidLeaseError(ctx context.Context, ba *kvpb.BatchReq
 	// process of doing so, we determine that the lease now lives elsewhere,
 	// redirect.
 	_, pErr := r.redirectOnOrAcquireLeaseForRequest(ctx, ba.Timestamp, r.signallerForBatch(ba))
+	// If the lease is held by another replica, we may still be able to serve
+	// the request as a present-time follower read after consulting the
+	// leaseholder for a read index.
+	if nlhe, ok := pErr.GetDetail().(*kvpb.NotLeaseHolderError); ok && nlhe.Lease != nil {
+		if r.maybeAdvanceReadIndex(ctx, ba) {
+			return nil
+		}
+	}
 	// If we managed to get a lease (i.e. pErr == nil), the request evaluation
 	// will be retried.
 	return pErr
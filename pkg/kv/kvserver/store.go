@@ -2983,6 +2983,7 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 	)
 
 	now := s.cfg.Clock.NowAsClockTimestamp()
+	physicalNow := s.cfg.Clock.PhysicalTime()
 	clusterNodes := s.ClusterNodeCount()
 
 	s.mu.RLock()
@@ -2999,6 +3000,9 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 	// visiting, so load it once up front for all nodes.
 	livenessMap := s.cfg.NodeLiveness.ScanNodeVitalityFromCache()
 	newStoreReplicaVisitor(s).Visit(func(rep *Replica) bool {
+		// Sample the load on the replica for the merge queue, which merges
+		// ranges that stayed cold over a window of time.
+		rep.recordColdMergeLoad(ctx, physicalNow)
 		metrics := rep.Metrics(ctx, now, livenessMap, clusterNodes)
 		if metrics.Leader {
 			raftLeaderCount++
@@ -2029,6 +2029,7 @@ func (ls LockStateInfo) SafeFormat(w redact.SafePrinter, r rune) {
 		}
 	}
 	w.Printf("holder=%s ", redactableLockHolder)
+	w.Printf("strength=%s ", ls.LockStrength)
 	w.Printf("durability=%s ", ls.Durability)
 	w.Printf("duration=%s", ls.HoldDuration)
 	if len(ls.Waiters) > 0 {
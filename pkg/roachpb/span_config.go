@@ -89,6 +89,12 @@ func (s *SpanConfig) ValidateSystemTargetSpanConfig() error {
 	if s.RangefeedEnabled {
 		return errors.AssertionFailedf("RangefeedEnabled set on system span config")
 	}
+	if s.ColdStorage {
+		return errors.AssertionFailedf("ColdStorage set on system span config")
+	}
+	if s.ColdMergeSeconds != 0 {
+		return errors.AssertionFailedf("ColdMergeSeconds set on system span config")
+	}
 	if s.ExcludeDataFromBackup {
 		return errors.AssertionFailedf("ExcludeDataFromBackup set on system span config")
 	}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"crypto/tls"
 	"strconv"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/security/certnames"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
@@ -23,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/sysutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -46,6 +48,8 @@ import (
 //   - client.<user>.crt  client certificate for 'user'. Verified using 'ca.crt', or 'ca-client.crt'.
 //   - client.node.crt    client certificate for the 'node' user. If it does not exist,
 //     fall back on 'node.crt'.
+//   - ca<suffix>.crl     optional: certificate revocation list for 'ca<suffix>.crt'.
+//     Certificates it revokes are rejected when verifying peers.
 type CertificateManager struct {
 	tenantIdentifier uint64
 	certnames.Locator
@@ -74,6 +78,10 @@ type CertificateManager struct {
 	// Certs only used with multi-tenancy.
 	tenantCACert, tenantCert, tenantSigningCert *CertInfo
 
+	// crls holds the revocation lists of the CA certificates. It is referenced
+	// by the TLS configs, and swapped on every successful Load() or LoadCRLs().
+	crls *crlStore
+
 	// TLS configs. Initialized lazily. Wiped on every successful Load().
 	// Server-side config.
 	serverConfig *tls.Config
@@ -94,11 +102,13 @@ func makeCertificateManager(
 		fn(&o)
 	}
 
+	certMetrics := makeMetrics()
 	return &CertificateManager{
 		Locator:          certnames.MakeLocator(certsDir),
 		tenantIdentifier: o.tenantIdentifier,
 		tlsSettings:      tlsSettings,
-		certMetrics:      makeMetrics(),
+		certMetrics:      certMetrics,
+		crls:             newCRLStore(certMetrics.CRLRejections),
 	}
 }
 
@@ -155,16 +165,38 @@ func (cm *CertificateManager) Metrics() Metrics {
 }
 
 // RegisterSignalHandler registers a signal handler for SIGHUP, triggering a
-// refresh of the certificates directory on notification.
+// refresh of the certificates directory on notification. The certificate
+// revocation lists are also reloaded periodically, as configured by the
+// security.crl.refresh_interval cluster setting.
 func (cm *CertificateManager) RegisterSignalHandler(
 	ctx context.Context, stopper *stop.Stopper,
 ) error {
 	return stopper.RunAsyncTask(ctx, "refresh-certs", func(ctx context.Context) {
 		ch := sysutil.RefreshSignaledChan()
+		var crlTimer timeutil.Timer
+		defer crlTimer.Stop()
+		resetCRLTimer := func() {
+			interval := cm.tlsSettings.crlRefreshInterval()
+			if interval == 0 {
+				// Periodic reloading is disabled; check again later in case
+				// the setting changes.
+				interval = time.Minute
+			}
+			crlTimer.Reset(interval)
+		}
+		resetCRLTimer()
 		for {
 			select {
 			case <-stopper.ShouldQuiesce():
 				return
+			case <-crlTimer.C:
+				crlTimer.Read = true
+				if cm.tlsSettings.crlRefreshInterval() != 0 {
+					if err := cm.LoadCRLs(); err != nil {
+						log.Ops.Warningf(ctx, "could not reload certificate revocation lists: %v", err)
+					}
+				}
+				resetCRLTimer()
 			case sig := <-ch:
 				log.Ops.Infof(ctx, "received signal %q, triggering certificate reload", sig)
 				if err := cm.LoadCertificates(); err != nil {
@@ -305,6 +337,11 @@ func (cm *CertificateManager) LoadCertificates() error {
 		}
 	}
 
+	crls, err := loadRevocationLists(cm.CertsDir(), caCert, clientCACert, uiCACert, tenantCACert)
+	if err != nil {
+		return makeErrorf(err, "problem loading certificate revocation lists in %s", cm.CertsDir())
+	}
+
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 	if cm.initialized {
@@ -369,6 +406,23 @@ func (cm *CertificateManager) LoadCertificates() error {
 	cm.tenantCert = tenantCert
 	cm.tenantSigningCert = tenantSigningCert
 
+	cm.crls.set(crls)
+
+	cm.updateMetricsLocked()
+	return nil
+}
+
+// LoadCRLs reloads the certificate revocation lists of the CA certificates
+// currently in use. Upon success, it swaps the existing revocation lists for
+// the new ones. The certificates themselves are not reloaded.
+func (cm *CertificateManager) LoadCRLs() error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	crls, err := loadRevocationLists(cm.CertsDir(), cm.caCert, cm.clientCACert, cm.uiCACert, cm.tenantCACert)
+	if err != nil {
+		return makeErrorf(err, "problem loading certificate revocation lists in %s", cm.CertsDir())
+	}
+	cm.crls.set(crls)
 	cm.updateMetricsLocked()
 	return nil
 }
@@ -409,6 +463,19 @@ func (cm *CertificateManager) updateMetricsLocked() {
 
 	// UI certificate expiration.
 	maybeSetMetric(cm.certMetrics.UIExpiration, cm.uiCert)
+
+	// Certificate revocation lists.
+	crls := cm.crls.get()
+	if m := cm.certMetrics.CRLRevoked; m != nil {
+		m.Update(int64(crls.numRevoked))
+	}
+	if m := cm.certMetrics.CRLNextUpdate; m != nil {
+		if crls.nextUpdate.IsZero() {
+			m.Update(0)
+		} else {
+			m.Update(crls.nextUpdate.Unix())
+		}
+	}
 }
 
 // GetServerTLSConfig returns a server TLS config with a callback to fetch the
@@ -489,8 +556,8 @@ func (cm *CertificateManager) getEmbeddedServerTLSConfig(
 		return nil, err
 	}
 
-	cm.serverConfig = cfg
-	return cfg, nil
+	cm.serverConfig = withRevocationCheck(cfg, cm.crls)
+	return cm.serverConfig, nil
 }
 
 // GetNodeClientTLSConfig returns a client TLS config suitable for
@@ -524,8 +591,8 @@ func (cm *CertificateManager) GetNodeClientTLSConfig() (*tls.Config, error) {
 	}
 
 	// Cache the config.
-	cm.clientConfig = cfg
-	return cfg, nil
+	cm.clientConfig = withRevocationCheck(cfg, cm.crls)
+	return cm.clientConfig, nil
 }
 
 // GetUIServerTLSConfig returns a server TLS config for the Admin UI with a
@@ -732,8 +799,8 @@ func (cm *CertificateManager) GetTenantTLSConfig() (*tls.Config, error) {
 		return nil, err
 	}
 
-	cm.tenantConfig = cfg
-	return cfg, nil
+	cm.tenantConfig = withRevocationCheck(cfg, cm.crls)
+	return cm.tenantConfig, nil
 }
 
 // GetTenantSigningCert returns the most up-to-date tenant signing certificate.
@@ -796,7 +863,7 @@ func (cm *CertificateManager) GetClientTLSConfig(user username.SQLUsername) (*tl
 		return nil, err
 	}
 
-	return cfg, nil
+	return withRevocationCheck(cfg, cm.crls), nil
 }
 
 // GetUIClientTLSConfig returns the most up-to-date client tls.Config for Admin UI clients.
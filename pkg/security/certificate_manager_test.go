@@ -11,7 +11,13 @@
 package security_test
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -144,3 +151,89 @@ func TestManagerWithPrincipalMap(t *testing.T) {
 	setCertPrincipalMap("testuser:foo,node.crdb.io:node")
 	require.NoError(t, loadUserCert(username.MakeSQLUsernameFromPreNormalizedString("foo")))
 }
+
+func TestManagerWithCRL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Do not mock cert access for this test.
+	securityassets.ResetLoader()
+	defer ResetTest()
+
+	certsDir := t.TempDir()
+
+	caKeyPath := filepath.Join(certsDir, "ca.key")
+	require.NoError(t, security.CreateCAPair(
+		certsDir, caKeyPath, testKeySize, time.Hour*96, true, true,
+	))
+	require.NoError(t, security.CreateClientPair(
+		certsDir, caKeyPath, testKeySize, time.Hour*48, true, username.TestUserName(), []roachpb.TenantID{roachpb.SystemTenantID}, false,
+	))
+	require.NoError(t, security.CreateNodePair(
+		certsDir, caKeyPath, testKeySize, time.Hour*48, true, []string{"127.0.0.1"},
+	))
+
+	cm, err := security.NewCertificateManager(certsDir, security.CommandTLSSettings{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), cm.Metrics().CRLRevoked.Value())
+	require.Equal(t, int64(0), cm.Metrics().CRLNextUpdate.Value())
+
+	caCert := cm.CACert().ParsedCertificates[0]
+	clientCert := cm.ClientCerts()[username.TestUserName()].ParsedCertificates[0]
+	nodeCert := cm.NodeCert().ParsedCertificates[0]
+	keyPEM, err := os.ReadFile(caKeyPath)
+	require.NoError(t, err)
+	caKey, err := security.PEMToPrivateKey(keyPEM)
+	require.NoError(t, err)
+
+	// Revoke the client certificate.
+	now := timeutil.Now()
+	nextUpdate := now.Add(time.Hour)
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now,
+		NextUpdate: nextUpdate,
+		RevokedCertificates: []pkix.RevokedCertificate{
+			{SerialNumber: clientCert.SerialNumber, RevocationTime: now},
+		},
+	}, caCert, caKey.(crypto.Signer))
+	require.NoError(t, err)
+	crlPath := filepath.Join(certsDir, "ca.crl")
+	require.NoError(t, os.WriteFile(crlPath,
+		pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER}), 0600))
+
+	// The CRL is picked up by a reload and applies to the existing TLS configs.
+	serverCfg, err := cm.GetServerTLSConfig()
+	require.NoError(t, err)
+	cfg, err := serverCfg.GetConfigForClient(nil)
+	require.NoError(t, err)
+	require.NoError(t, cfg.VerifyPeerCertificate(nil, [][]*x509.Certificate{{clientCert, caCert}}))
+
+	require.NoError(t, cm.LoadCRLs())
+	require.Equal(t, int64(1), cm.Metrics().CRLRevoked.Value())
+	require.Equal(t, nextUpdate.Unix(), cm.Metrics().CRLNextUpdate.Value())
+
+	require.Regexp(t, `was revoked`,
+		cfg.VerifyPeerCertificate(nil, [][]*x509.Certificate{{clientCert, caCert}}))
+	require.NoError(t, cfg.VerifyPeerCertificate(nil, [][]*x509.Certificate{{nodeCert, caCert}}))
+	require.Equal(t, int64(1), cm.Metrics().CRLRejections.Count())
+
+	// The CRL is also checked by newly built configs.
+	clientCfg, err := cm.GetNodeClientTLSConfig()
+	require.NoError(t, err)
+	require.Regexp(t, `was revoked`,
+		clientCfg.VerifyPeerCertificate(nil, [][]*x509.Certificate{{clientCert, caCert}}))
+
+	// An invalid CRL is rejected and the previous one remains in effect.
+	require.NoError(t, os.WriteFile(crlPath, []byte("not a CRL"), 0600))
+	require.Regexp(t, `failed to parse certificate revocation list`, cm.LoadCRLs())
+	require.Regexp(t, `failed to parse certificate revocation list`, cm.LoadCertificates())
+	require.Equal(t, int64(1), cm.Metrics().CRLRevoked.Value())
+	require.Regexp(t, `was revoked`,
+		cfg.VerifyPeerCertificate(nil, [][]*x509.Certificate{{clientCert, caCert}}))
+
+	// Removing the CRL lifts the revocation.
+	require.NoError(t, os.Remove(crlPath))
+	require.NoError(t, cm.LoadCRLs())
+	require.Equal(t, int64(0), cm.Metrics().CRLRevoked.Value())
+	require.NoError(t, cfg.VerifyPeerCertificate(nil, [][]*x509.Certificate{{clientCert, caCert}}))
+}
@@ -36,6 +36,11 @@ type Metrics struct {
 	// The top-level aggregated value for this metric is not meaningful
 	// (it sums up all the minimum expirations of all users).
 	ClientExpiration *aggmetric.AggGauge
+
+	// Certificate revocation lists.
+	CRLRevoked    *metric.Gauge
+	CRLNextUpdate *metric.Gauge
+	CRLRejections *metric.Counter
 }
 
 var _ metric.Struct = (*Metrics)(nil)
@@ -100,6 +105,26 @@ var (
 		Measurement: "Certificate Expiration",
 		Unit:        metric.Unit_TIMESTAMP_SEC,
 	}
+
+	metaCRLRevoked = metric.Metadata{
+		Name:        "security.certificate.crl.revoked",
+		Help:        "Number of certificates revoked by the loaded certificate revocation lists.",
+		Measurement: "Certificates",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaCRLNextUpdate = metric.Metadata{
+		Name: "security.certificate.crl.next-update",
+		Help: "Earliest time at which a newer certificate revocation list is expected. " +
+			"0 means no certificate revocation list.",
+		Measurement: "Certificate Revocation List Update",
+		Unit:        metric.Unit_TIMESTAMP_SEC,
+	}
+	metaCRLRejections = metric.Metadata{
+		Name:        "security.certificate.crl.rejections",
+		Help:        "Number of peer certificates rejected because they are revoked.",
+		Measurement: "Certificates",
+		Unit:        metric.Unit_COUNT,
+	}
 )
 
 func makeMetrics() Metrics {
@@ -114,6 +139,9 @@ func makeMetrics() Metrics {
 		NodeExpiration:       metric.NewGauge(metaNodeExpiration),
 		NodeClientExpiration: metric.NewGauge(metaNodeClientExpiration),
 		UIExpiration:         metric.NewGauge(metaUIExpiration),
+		CRLRevoked:           metric.NewGauge(metaCRLRevoked),
+		CRLNextUpdate:        metric.NewGauge(metaCRLNextUpdate),
+		CRLRejections:        metric.NewCounter(metaCRLRejections),
 	}
 	return m
 }
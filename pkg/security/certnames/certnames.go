@@ -19,6 +19,7 @@ import (
 const (
 	certExtension = `.crt`
 	keyExtension  = `.key`
+	crlExtension  = `.crl`
 )
 
 // IsCertificateFilename returns true if the file name looks like a certificate file.
@@ -32,6 +33,13 @@ func KeyForCert(certFile string) string {
 	return strings.TrimSuffix(certFile, certExtension) + keyExtension
 }
 
+// CRLForCACert returns the expected certificate revocation list file name for
+// the given CA cert file name.
+// The caller is responsible for calling IsCertFile beforehand.
+func CRLForCACert(caCertFile string) string {
+	return strings.TrimSuffix(caCertFile, certExtension) + crlExtension
+}
+
 // CACertFilename returns the expected file name for the CA certificate.
 func CACertFilename() string { return "ca" + certExtension }
 
@@ -0,0 +1,206 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security/certnames"
+	"github.com/cockroachdb/cockroach/pkg/security/securityassets"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+)
+
+// revocationLists is the set of certificate revocation lists (CRL) loaded
+// for the CA certificates in the certs directory. The CRL for a CA
+// certificate ca<suffix>.crt is read from ca<suffix>.crl, in either PEM or
+// DER format. It is never mutated after creation.
+type revocationLists struct {
+	// revoked maps the DER encoding of a CA certificate to the serial numbers
+	// of the certificates revoked by it.
+	revoked map[string]map[string]struct{}
+	// numRevoked is the total number of revoked certificates.
+	numRevoked int
+	// nextUpdate is the earliest time at which a newer CRL is expected to be
+	// issued. It is zero if no CRL was loaded.
+	nextUpdate time.Time
+}
+
+// isRevoked returns true if the given certificate was revoked by the given
+// issuer.
+func (l *revocationLists) isRevoked(cert, issuer *x509.Certificate) bool {
+	serials, ok := l.revoked[string(issuer.Raw)]
+	if !ok {
+		return false
+	}
+	_, revoked := serials[cert.SerialNumber.String()]
+	return revoked
+}
+
+// loadRevocationLists loads the CRLs of the given CA certificates from the
+// certs directory. Nil and invalid CA certificates are skipped. A CRL that
+// cannot be read or that is not signed by its CA certificate is an error.
+func loadRevocationLists(certsDir string, caCerts ...*CertInfo) (*revocationLists, error) {
+	l := &revocationLists{revoked: make(map[string]map[string]struct{})}
+	for _, ca := range caCerts {
+		if ca == nil || ca.Error != nil {
+			continue
+		}
+		crlFilename := certnames.CRLForCACert(ca.Filename)
+		crlPath := filepath.Join(certsDir, crlFilename)
+		// Stat the file first: unlike ReadFile, the Stat of all asset loaders,
+		// including the embedded one used in tests, reports missing files with
+		// os.ErrNotExist.
+		if _, err := securityassets.GetLoader().Stat(crlPath); err != nil {
+			if oserror.IsNotExist(err) {
+				continue
+			}
+			return nil, makeErrorf(err, "could not stat certificate revocation list %s", crlPath)
+		}
+		contents, err := securityassets.GetLoader().ReadFile(crlPath)
+		if err != nil {
+			return nil, makeErrorf(err, "could not read certificate revocation list %s", crlPath)
+		}
+		crls, err := parseRevocationLists(contents)
+		if err != nil {
+			return nil, makeErrorf(err, "failed to parse certificate revocation list %s", crlPath)
+		}
+		for _, crl := range crls {
+			signer, err := findCRLSigner(crl, ca.ParsedCertificates)
+			if err != nil {
+				return nil, makeErrorf(err,
+					"certificate revocation list %s is not signed by a certificate in %s", crlPath, ca.Filename)
+			}
+			if !crl.NextUpdate.IsZero() {
+				if crl.NextUpdate.Before(timeutil.Now()) {
+					log.Ops.Warningf(context.Background(),
+						"certificate revocation list %s is stale: a newer list was due at %s", crlPath, crl.NextUpdate)
+				}
+				if l.nextUpdate.IsZero() || crl.NextUpdate.Before(l.nextUpdate) {
+					l.nextUpdate = crl.NextUpdate
+				}
+			}
+			serials := l.revoked[string(signer.Raw)]
+			if serials == nil {
+				serials = make(map[string]struct{}, len(crl.RevokedCertificates))
+				l.revoked[string(signer.Raw)] = serials
+			}
+			for _, rc := range crl.RevokedCertificates {
+				if _, ok := serials[rc.SerialNumber.String()]; !ok {
+					serials[rc.SerialNumber.String()] = struct{}{}
+					l.numRevoked++
+				}
+			}
+		}
+	}
+	return l, nil
+}
+
+// parseRevocationLists parses the given file contents as a sequence of
+// PEM-encoded CRLs or, failing that, as a single DER-encoded CRL.
+func parseRevocationLists(contents []byte) ([]*x509.RevocationList, error) {
+	var crls []*x509.RevocationList
+	rest := contents
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "X509 CRL" {
+			continue
+		}
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		crls = append(crls, crl)
+	}
+	if len(crls) > 0 {
+		return crls, nil
+	}
+	crl, err := x509.ParseRevocationList(contents)
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.RevocationList{crl}, nil
+}
+
+// findCRLSigner returns the certificate among the given ones that signed the
+// given CRL. Note that the certificate must allow signing CRLs.
+func findCRLSigner(
+	crl *x509.RevocationList, certs []*x509.Certificate,
+) (*x509.Certificate, error) {
+	err := errors.New("no CA certificate")
+	for _, cert := range certs {
+		if err = crl.CheckSignatureFrom(cert); err == nil {
+			return cert, nil
+		}
+	}
+	return nil, err
+}
+
+// crlStore holds the revocationLists currently in use. It is shared by all
+// the tls.Config objects built by a CertificateManager, so that the CRLs can
+// be reloaded without rebuilding them.
+type crlStore struct {
+	lists atomic.Value // *revocationLists
+
+	// rejected counts the peer certificates rejected because they are revoked.
+	rejected *metric.Counter
+}
+
+func newCRLStore(rejected *metric.Counter) *crlStore {
+	s := &crlStore{rejected: rejected}
+	s.lists.Store(&revocationLists{})
+	return s
+}
+
+func (s *crlStore) get() *revocationLists {
+	return s.lists.Load().(*revocationLists)
+}
+
+func (s *crlStore) set(l *revocationLists) {
+	s.lists.Store(l)
+}
+
+// verify returns an error if a certificate in one of the given verified
+// chains was revoked by its issuer. The root of each chain is not checked
+// since its issuer is not known.
+func (s *crlStore) verify(verifiedChains [][]*x509.Certificate) error {
+	if s == nil {
+		return nil
+	}
+	l := s.get()
+	if l.numRevoked == 0 {
+		return nil
+	}
+	for _, chain := range verifiedChains {
+		for i := 0; i < len(chain)-1; i++ {
+			if cert, issuer := chain[i], chain[i+1]; l.isRevoked(cert, issuer) {
+				if s.rejected != nil {
+					s.rejected.Inc(1)
+				}
+				return errors.Newf("certificate %s (serial number %s) was revoked by %s",
+					cert.Subject, cert.SerialNumber, issuer.Subject)
+			}
+		}
+	}
+	return nil
+}
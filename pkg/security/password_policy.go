@@ -0,0 +1,150 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+)
+
+// PasswordMinCharacterClasses is the cluster setting that configures the
+// minimum number of character classes (lowercase letters, uppercase letters,
+// digits and other characters) that a password must contain.
+var PasswordMinCharacterClasses = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"server.user_login.password_policy.min_character_classes",
+	"the minimum number of character classes (lowercase letters, uppercase letters, "+
+		"digits, other characters) in passwords set in cleartext via SQL",
+	0,
+	settings.NonNegativeIntWithMaximum(4),
+).WithPublic()
+
+// PasswordRejectUsername is the cluster setting that configures whether
+// passwords containing the name of the user are rejected.
+var PasswordRejectUsername = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"server.user_login.password_policy.reject_username.enabled",
+	"if set, passwords set in cleartext via SQL cannot contain the name of the user",
+	false,
+).WithPublic()
+
+// PasswordHistoryCount is the cluster setting that configures how many of
+// the previous passwords of a user cannot be reused.
+var PasswordHistoryCount = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"server.user_login.password_policy.history_count",
+	"the number of previous passwords of a user that cannot be reused "+
+		"when setting a password in cleartext via SQL; 0 disables the check",
+	0,
+	settings.NonNegativeIntWithMaximum(100),
+).WithPublic()
+
+// PasswordMaxLifetime is the cluster setting that configures how long a
+// password remains valid after it was last changed.
+var PasswordMaxLifetime = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.password_policy.max_lifetime",
+	"the duration after which a password must be changed before the user can log in "+
+		"with it again; 0 disables password expiry",
+	0,
+	settings.NonNegativeDuration,
+).WithPublic()
+
+// LockoutMaxFailedAttempts is the cluster setting that configures the
+// number of failed password logins after which an account is locked.
+var LockoutMaxFailedAttempts = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"server.user_login.lockout.max_failed_attempts",
+	"the number of failed password logins within server.user_login.lockout.window "+
+		"after which the account of the user is locked; 0 disables account lockout",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+// LockoutWindow is the cluster setting that configures the period over
+// which failed password logins are counted.
+var LockoutWindow = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.lockout.window",
+	"the period over which failed password logins are counted towards "+
+		"server.user_login.lockout.max_failed_attempts",
+	15*time.Minute,
+	settings.PositiveDuration,
+).WithPublic()
+
+// LockoutDuration is the cluster setting that configures how long an
+// account remains locked.
+var LockoutDuration = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.lockout.duration",
+	"how long an account remains locked after too many failed password logins; "+
+		"0 keeps the account locked until it is unlocked with ALTER ROLE ... ACCOUNT UNLOCK",
+	30*time.Minute,
+	settings.NonNegativeDuration,
+).WithPublic()
+
+// ErrPasswordTooSimple indicates that a client provided a password
+// that does not satisfy the complexity rules.
+var ErrPasswordTooSimple = errors.New("password does not satisfy the complexity requirements")
+
+// ErrPasswordReused indicates that a client provided a password that
+// was used recently by the same user.
+var ErrPasswordReused = errors.New("password was used recently")
+
+// CheckPasswordComplexity returns an error if the given cleartext password
+// for the given user does not satisfy the complexity rules configured via
+// cluster settings.
+func CheckPasswordComplexity(
+	sv *settings.Values, user username.SQLUsername, passwordStr string,
+) error {
+	if minClasses := PasswordMinCharacterClasses.Get(sv); minClasses > 0 {
+		if classes := passwordCharacterClasses(passwordStr); classes < int(minClasses) {
+			return errors.WithHintf(ErrPasswordTooSimple,
+				"Passwords must contain characters from at least %d of the following classes: "+
+					"lowercase letters, uppercase letters, digits, other characters.", minClasses)
+		}
+	}
+	if PasswordRejectUsername.Get(sv) && !user.Undefined() &&
+		strings.Contains(strings.ToLower(passwordStr), user.Normalized()) {
+		return errors.WithHint(ErrPasswordTooSimple, "Passwords cannot contain the user name.")
+	}
+	return nil
+}
+
+// passwordCharacterClasses returns the number of distinct character classes
+// in the given password.
+func passwordCharacterClasses(passwordStr string) int {
+	var lower, upper, digit, other bool
+	for _, r := range passwordStr {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	n := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPasswordComplexity(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	user := username.MakeSQLUsernameFromPreNormalizedString("carl")
+
+	for _, tc := range []struct {
+		minClasses     int64
+		rejectUsername bool
+		password       string
+		ok             bool
+	}{
+		{minClasses: 0, password: "a", ok: true},
+		{minClasses: 1, password: "aaaa", ok: true},
+		{minClasses: 2, password: "aaaa", ok: false},
+		{minClasses: 2, password: "aaaA", ok: true},
+		{minClasses: 3, password: "aaA1", ok: true},
+		{minClasses: 4, password: "aaA1", ok: false},
+		{minClasses: 4, password: "aA1!", ok: true},
+		{minClasses: 4, password: "éÉ1 ", ok: true},
+		{rejectUsername: false, password: "mycarlpw", ok: true},
+		{rejectUsername: true, password: "mycarlpw", ok: false},
+		{rejectUsername: true, password: "myCARLpw", ok: false},
+		{rejectUsername: true, password: "mycarpw", ok: true},
+	} {
+		security.PasswordMinCharacterClasses.Override(ctx, &st.SV, tc.minClasses)
+		security.PasswordRejectUsername.Override(ctx, &st.SV, tc.rejectUsername)
+		err := security.CheckPasswordComplexity(&st.SV, user, tc.password)
+		if tc.ok {
+			require.NoError(t, err, "password %q", tc.password)
+		} else {
+			require.True(t, errors.Is(err, security.ErrPasswordTooSimple), "password %q: %v", tc.password, err)
+		}
+	}
+}
@@ -116,6 +116,23 @@ func newBaseTLSConfigWithCertificate(
 	return cfg, nil
 }
 
+// withRevocationCheck extends the peer certificate verification of the
+// given tls.Config to reject the certificates revoked by the CRLs in the
+// given store. The CRLs are checked before OCSP, if enabled.
+func withRevocationCheck(cfg *tls.Config, crls *crlStore) *tls.Config {
+	verifyOCSP := cfg.VerifyPeerCertificate
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if err := crls.verify(verifiedChains); err != nil {
+			return err
+		}
+		if verifyOCSP != nil {
+			return verifyOCSP(rawCerts, verifiedChains)
+		}
+		return nil
+	}
+	return cfg
+}
+
 // newBaseTLSConfig returns a tls.Config. If caPEM != nil, it is set in RootCAs.
 func newBaseTLSConfig(settings TLSSettings, caPEM []byte) (*tls.Config, error) {
 	var certPool *x509.CertPool
@@ -37,6 +37,7 @@ type TLSSettings interface {
 	ocspEnabled() bool
 	ocspStrict() bool
 	ocspTimeout() time.Duration
+	crlRefreshInterval() time.Duration
 	oldCipherSuitesEnabled() bool
 }
 
@@ -54,6 +55,14 @@ var ocspTimeout = settings.RegisterDurationSetting(
 	settings.NonNegativeDuration,
 ).WithPublic()
 
+var crlRefreshInterval = settings.RegisterDurationSetting(
+	settings.TenantWritable, "security.crl.refresh_interval",
+	"how often the certificate revocation lists (CRL) of the CA certificates are reloaded "+
+		"from the certificates directory; 0 disables periodic reloading",
+	time.Hour,
+	settings.NonNegativeDuration,
+).WithPublic()
+
 type clusterTLSSettings struct {
 	settings *cluster.Settings
 }
@@ -72,6 +81,10 @@ func (c clusterTLSSettings) ocspTimeout() time.Duration {
 	return ocspTimeout.Get(&c.settings.SV)
 }
 
+func (c clusterTLSSettings) crlRefreshInterval() time.Duration {
+	return crlRefreshInterval.Get(&c.settings.SV)
+}
+
 func (c clusterTLSSettings) oldCipherSuitesEnabled() bool {
 	return areOldCipherSuitesEnabled()
 }
@@ -83,7 +96,8 @@ func ClusterTLSSettings(settings *cluster.Settings) TLSSettings {
 }
 
 // CommandTLSSettings defines the TLS settings for command-line tools.
-// OCSP is not currently supported in this mode.
+// OCSP is not currently supported in this mode, and CRLs are only loaded
+// along with the certificates.
 type CommandTLSSettings struct{}
 
 var _ TLSSettings = CommandTLSSettings{}
@@ -100,6 +114,10 @@ func (CommandTLSSettings) ocspTimeout() time.Duration {
 	return 0
 }
 
+func (CommandTLSSettings) crlRefreshInterval() time.Duration {
+	return 0
+}
+
 func (c CommandTLSSettings) oldCipherSuitesEnabled() bool {
 	return areOldCipherSuitesEnabled()
 }
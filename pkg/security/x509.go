@@ -92,6 +92,8 @@ func GenerateCA(signer crypto.Signer, lifetime time.Duration) ([]byte, error) {
 	template.MaxPathLen = maxPathLength
 	template.KeyUsage |= x509.KeyUsageCertSign
 	template.KeyUsage |= x509.KeyUsageContentCommitment
+	// Allow the CA to sign certificate revocation lists.
+	template.KeyUsage |= x509.KeyUsageCRLSign
 
 	certBytes, err := x509.CreateCertificate(
 		rand.Reader,
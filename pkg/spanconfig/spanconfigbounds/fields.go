@@ -53,6 +53,8 @@ var fields = [config.NumFields]Field{
 	constraints,
 	voterConstraints,
 	leasePreferences,
+	coldStorage,
+	coldMergeSeconds,
 }
 
 const (
@@ -65,4 +67,6 @@ const (
 	constraints      = constraintsConjunctionField(config.Constraints)
 	voterConstraints = constraintsConjunctionField(config.VoterConstraints)
 	leasePreferences = leasePreferencesField(config.LeasePreferences)
+	coldStorage      = boolField(config.ColdStorage)
+	coldMergeSeconds = int32Field(config.ColdMergeSeconds)
 )
@@ -629,6 +629,12 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.GlobalReads != defaultConf.GlobalReads {
 		diffs = append(diffs, fmt.Sprintf("global_reads=%v", conf.GlobalReads))
 	}
+	if conf.ColdStorage != defaultConf.ColdStorage {
+		diffs = append(diffs, fmt.Sprintf("cold_storage=%t", conf.ColdStorage))
+	}
+	if conf.ColdMergeSeconds != defaultConf.ColdMergeSeconds {
+		diffs = append(diffs, fmt.Sprintf("cold_merge_seconds=%d", conf.ColdMergeSeconds))
+	}
 	if conf.NumReplicas != defaultConf.NumReplicas {
 		diffs = append(diffs, fmt.Sprintf("num_replicas=%d", conf.NumReplicas))
 	}
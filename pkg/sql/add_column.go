@@ -122,6 +122,12 @@ func (p *planner) addColumnImpl(
 		return err
 	}
 
+	if d.IsEncrypted() {
+		if err := params.p.setupColumnEncryption(params.ctx, n.tableDesc, col, d.Encryption.KMSURI); err != nil {
+			return err
+		}
+	}
+
 	n.tableDesc.AddColumnMutation(col, descpb.DescriptorMutation_ADD)
 	if idx != nil {
 		if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(idx, descpb.DescriptorMutation_ADD); err != nil {
@@ -209,6 +209,15 @@ func alterColumnTypeGeneral(
 		}
 	}
 
+	// Disallow ALTER COLUMN TYPE general for columns that are referenced by a
+	// row-level security policy, as in Postgres.
+	for i := range tableDesc.Policies {
+		if catalog.MakeTableColSet(tableDesc.Policies[i].ColumnIDs...).Contains(col.GetID()) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot alter type of a column used in a policy definition")
+		}
+	}
+
 	// Disallow ALTER COLUMN TYPE general for columns that have a
 	// UNIQUE WITHOUT INDEX constraint.
 	for _, uc := range tableDesc.UniqueConstraintsWithoutIndex() {
@@ -0,0 +1,90 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type alterPolicyNode struct {
+	n         *tree.AlterPolicy
+	tableDesc *tabledesc.Mutable
+	policy    descpb.PolicyDescriptor
+}
+
+// AlterPolicy renames a row-level security policy or changes the roles and
+// expressions it applies.
+// Privileges: ownership of the table.
+func (p *planner) AlterPolicy(ctx context.Context, n *tree.AlterPolicy) (planNode, error) {
+	tableDesc, tn, err := p.resolveTableForPolicy(ctx, n.TableName, "ALTER POLICY")
+	if err != nil {
+		return nil, err
+	}
+	existing := tableDesc.FindPolicyByName(string(n.PolicyName))
+	if existing == nil {
+		return nil, newUndefinedPolicyError(n.PolicyName, tableDesc.GetName())
+	}
+	policy := *existing
+
+	if n.NewPolicyName != "" {
+		if n.NewPolicyName != n.PolicyName && tableDesc.FindPolicyByName(string(n.NewPolicyName)) != nil {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.NewPolicyName, tableDesc.GetName())
+		}
+		policy.Name = string(n.NewPolicyName)
+		return &alterPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+	}
+
+	if len(n.Roles) > 0 {
+		if policy.RoleNames, err = p.policyRoleNames(ctx, n.Roles); err != nil {
+			return nil, err
+		}
+	}
+	switch policy.Command {
+	case descpb.PolicyDescriptor_SELECT, descpb.PolicyDescriptor_DELETE:
+		if n.Exprs.WithCheck != nil {
+			return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case descpb.PolicyDescriptor_INSERT:
+		if n.Exprs.Using != nil {
+			return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+	if err := p.setPolicyExprs(ctx, tableDesc, tn, n.Exprs, &policy); err != nil {
+		return nil, err
+	}
+	return &alterPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+}
+
+func (n *alterPolicyNode) startExec(params runParams) error {
+	existing := n.tableDesc.FindPolicyByName(string(n.n.PolicyName))
+	*existing = n.policy
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *alterPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterPolicyNode) Close(context.Context)        {}
+
+func newUndefinedPolicyError(policyName tree.Name, tableName string) error {
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"policy %q for table %q does not exist", policyName, tableName)
+}
@@ -221,7 +221,7 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		}
 	}
 
-	hasPasswordOpt, hashedPassword, err := retrievePasswordFromRoleOptions(params, n.roleOptions)
+	hasPasswordOpt, hashedPassword, err := retrievePasswordFromRoleOptions(params, n.roleName, n.roleOptions)
 	if err != nil {
 		return err
 	}
@@ -246,6 +246,11 @@ func (n *alterRoleNode) startExec(params runParams) error {
 				return err
 			}
 		}
+		if hashedPassword != nil {
+			if err := params.p.recordPasswordChange(params.ctx, opName, n.roleName, hashedPassword); err != nil {
+				return err
+			}
+		}
 	}
 
 	rowsAffected, err := updateRoleOptions(params, opName, n.roleOptions, n.roleName, sqltelemetry.AlterRole)
@@ -277,6 +282,81 @@ func (*alterRoleNode) Next(runParams) (bool, error) { return false, nil }
 func (*alterRoleNode) Values() tree.Datums          { return tree.Datums{} }
 func (*alterRoleNode) Close(context.Context)        {}
 
+// alterRoleUnlockNode represents an `ALTER ROLE ... ACCOUNT UNLOCK` statement.
+type alterRoleUnlockNode struct {
+	roleName username.SQLUsername
+	ifExists bool
+	isRole   bool
+}
+
+// AlterRoleUnlock represents an `ALTER ROLE ... ACCOUNT UNLOCK` statement.
+// Privileges: CREATEROLE privilege; admin-only if the role is an admin.
+func (p *planner) AlterRoleUnlock(ctx context.Context, n *tree.AlterRoleUnlock) (planNode, error) {
+	if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+		return nil, err
+	}
+	roleName, err := decodeusername.FromRoleSpec(
+		p.SessionData(), username.PurposeValidation, n.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &alterRoleUnlockNode{
+		roleName: roleName,
+		ifExists: n.IfExists,
+		isRole:   n.IsRole,
+	}, nil
+}
+
+func (n *alterRoleUnlockNode) startExec(params runParams) error {
+	opName := "alter-role-unlock"
+	if !n.isRole {
+		opName = "alter-user-unlock"
+	}
+	exists, err := RoleExists(params.ctx, params.p.InternalSQLTxn(), n.roleName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if n.ifExists {
+			return nil
+		}
+		return sqlerrors.NewUndefinedUserError(n.roleName)
+	}
+
+	isAdmin, err := params.p.UserHasAdminRole(params.ctx, n.roleName)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		if err := params.p.RequireAdminRole(params.ctx, "ALTER ROLE admin"); err != nil {
+			return err
+		}
+	}
+
+	if passwordPolicyTablesActive(params.ctx, params.ExecCfg()) {
+		if _, err := params.p.InternalSQLTxn().ExecEx(
+			params.ctx, opName, params.p.txn,
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.login_lockouts WHERE username = $1`,
+			n.roleName.Normalized(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return params.p.logEvent(params.ctx,
+		0, /* no target */
+		&eventpb.AlterRole{
+			RoleName: n.roleName.Normalized(),
+			Options:  []string{"ACCOUNT UNLOCK"},
+		})
+}
+
+func (*alterRoleUnlockNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterRoleUnlockNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterRoleUnlockNode) Close(context.Context)        {}
+
 // AlterRoleSet represents a `ALTER ROLE ... SET` statement.
 // Privileges: CREATEROLE privilege; or admin-only if `ALTER ROLE ALL`.
 func (p *planner) AlterRoleSet(ctx context.Context, n *tree.AlterRoleSet) (planNode, error) {
@@ -705,6 +705,19 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableSetRLSMode:
+			changed, err := params.p.setRowLevelSecurityMode(params.ctx, n.tableDesc, t.Mode)
+			if err != nil {
+				return err
+			}
+			descriptorChanged = descriptorChanged || changed
+
+		case *tree.AlterTableRotateEncryptionKeys:
+			if err := params.p.rotateColumnEncryptionKeys(params.ctx, n.tableDesc); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -916,6 +929,38 @@ func (p *planner) setAuditMode(
 	return desc.SetAuditMode(auditMode)
 }
 
+// setRowLevelSecurityMode enables, disables, forces or unforces row-level
+// security on the table. It returns whether the descriptor was changed.
+func (p *planner) setRowLevelSecurityMode(
+	ctx context.Context, desc *tabledesc.Mutable, mode tree.TableRLSMode,
+) (bool, error) {
+	// As in Postgres, only the table owner may change its row-level security
+	// settings.
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return false, err
+	}
+	if !hasOwnership {
+		return false, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", desc.GetName())
+	}
+
+	enabled, forced := desc.RowLevelSecurityEnabled, desc.RowLevelSecurityForced
+	switch mode {
+	case tree.TableRLSEnable:
+		desc.RowLevelSecurityEnabled = true
+	case tree.TableRLSDisable:
+		desc.RowLevelSecurityEnabled = false
+	case tree.TableRLSForce:
+		desc.RowLevelSecurityForced = true
+	case tree.TableRLSNoForce:
+		desc.RowLevelSecurityForced = false
+	default:
+		return false, errors.AssertionFailedf("unknown row-level security mode %d", mode)
+	}
+	return enabled != desc.RowLevelSecurityEnabled || forced != desc.RowLevelSecurityForced, nil
+}
+
 func (n *alterTableNode) Next(runParams) (bool, error) { return false, nil }
 func (n *alterTableNode) Values() tree.Datums          { return tree.Datums{} }
 func (n *alterTableNode) Close(context.Context)        {}
@@ -1684,6 +1729,9 @@ func dropColumnImpl(
 	if err := schemaexpr.ValidateTTLExpressionDoesNotDependOnColumn(tableDesc, rowLevelTTL, colToDrop); err != nil {
 		return nil, err
 	}
+	if err := removePoliciesDependingOnColumn(tableDesc, colToDrop, t.DropBehavior); err != nil {
+		return nil, err
+	}
 
 	if tableDesc.GetPrimaryIndex().CollectKeyColumnIDs().Contains(colToDrop.GetID()) {
 		return nil, sqlerrors.NewColumnReferencedByPrimaryKeyError(colToDrop.GetName())
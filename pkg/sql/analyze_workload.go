@@ -0,0 +1,577 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/workloadindexrec"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var analyzeWorkloadTopStatements = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.workload_index_advisor.top_statements",
+	"the number of most frequently executed statement fingerprints replayed by ANALYZE WORKLOAD",
+	100,
+	settings.PositiveInt,
+)
+
+var analyzeWorkloadLookback = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.workload_index_advisor.lookback",
+	"the interval of statement statistics analyzed by ANALYZE WORKLOAD",
+	7*24*time.Hour,
+	settings.PositiveDuration,
+)
+
+var analyzeWorkloadColumns = colinfo.ResultColumns{
+	{Name: "type", Typ: types.String},
+	{Name: "sql", Typ: types.String},
+	{Name: "cost_savings", Typ: types.Float},
+	{Name: "write_overhead", Typ: types.Float},
+	{Name: "statements", Typ: types.Int},
+	{Name: "reason", Typ: types.String},
+}
+
+// AnalyzeWorkload returns an ANALYZE WORKLOAD statement. It runs a job which
+// replays the most frequently executed statement fingerprints against
+// hypothetical indexes, and returns one row per recommended index change,
+// ranked by decreasing net savings. The report is also stored in the
+// progress of the job.
+// Privileges: VIEWACTIVITY or VIEWACTIVITYREDACTED.
+func (p *planner) AnalyzeWorkload(ctx context.Context, n *tree.AnalyzeWorkload) (planNode, error) {
+	hasViewActivity, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !hasViewActivity {
+		return nil, noViewActivityOrViewActivityRedactedRoleError(p.User())
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_AnalyzeWorkload) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"ANALYZE WORKLOAD requires the cluster to be upgraded to version %s",
+			clusterversion.V23_2_AnalyzeWorkload)
+	}
+	if !p.extendedEvalCtx.TxnIsSingleStmt {
+		return nil, errors.Errorf("ANALYZE WORKLOAD cannot be used inside a multi-statement transaction")
+	}
+	return &delayedNode{
+		name:    n.String(),
+		columns: analyzeWorkloadColumns,
+
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			report, err := p.runAnalyzeWorkloadJob(ctx, n)
+			if err != nil {
+				return nil, err
+			}
+			v := p.newContainerValuesNode(analyzeWorkloadColumns, len(report.Recommendations))
+			for _, rec := range report.Recommendations {
+				reason := tree.DNull
+				if rec.Reason != "" {
+					reason = tree.NewDString(rec.Reason)
+				}
+				row := tree.Datums{
+					tree.NewDString(rec.Type),
+					tree.NewDString(rec.SQL),
+					tree.NewDFloat(tree.DFloat(rec.CostSavings)),
+					tree.NewDFloat(tree.DFloat(rec.WriteOverhead)),
+					tree.NewDInt(tree.DInt(rec.Statements)),
+					reason,
+				}
+				if _, err := v.rows.AddRow(ctx, row); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
+
+// runAnalyzeWorkloadJob starts an ANALYZE WORKLOAD job, waits for it to
+// complete and returns its report.
+func (p *planner) runAnalyzeWorkloadJob(
+	ctx context.Context, n *tree.AnalyzeWorkload,
+) (*jobspb.WorkloadIndexReport, error) {
+	execCfg := p.ExecCfg()
+	sv := &execCfg.Settings.SV
+	record := jobs.Record{
+		Description: n.String(),
+		Statements:  []string{n.String()},
+		Username:    p.User(),
+		Details: jobspb.AnalyzeWorkloadDetails{
+			Since:         timeutil.Now().Add(-analyzeWorkloadLookback.Get(sv)),
+			TopStatements: analyzeWorkloadTopStatements.Get(sv),
+		},
+		Progress: jobspb.AnalyzeWorkloadProgress{},
+	}
+
+	var job *jobs.StartableJob
+	jobID := execCfg.JobRegistry.MakeJobID()
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return execCfg.JobRegistry.CreateStartableJobWithTxn(ctx, &job, jobID, txn, record)
+	}); err != nil {
+		if job != nil {
+			if cleanupErr := job.CleanupOnRollback(ctx); cleanupErr != nil {
+				log.Warningf(ctx, "failed to cleanup StartableJob: %v", cleanupErr)
+			}
+		}
+		return nil, err
+	}
+	if err := job.Start(ctx); err != nil {
+		return nil, err
+	}
+	if err := job.AwaitCompletion(ctx); err != nil {
+		return nil, err
+	}
+
+	loaded, err := execCfg.JobRegistry.LoadJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	prog := loaded.Progress()
+	progress := prog.GetAnalyzeWorkload()
+	if progress == nil {
+		return nil, errors.AssertionFailedf("job %d has no ANALYZE WORKLOAD progress", jobID)
+	}
+	return &progress.Report, nil
+}
+
+// workloadFingerprint is a statement fingerprint replayed by ANALYZE
+// WORKLOAD.
+type workloadFingerprint struct {
+	query string
+	db    string
+	count int64
+}
+
+// topWorkloadStatementsQuery finds the most frequently executed DML statement
+// fingerprints of the applications since the given time.
+const topWorkloadStatementsQuery = `
+SELECT
+  metadata->>'query' AS query,
+  metadata->>'db' AS db,
+  sum((statistics->'statistics'->>'cnt')::INT8)::INT8 AS cnt
+FROM crdb_internal.statement_statistics
+WHERE aggregated_ts >= $1
+  AND metadata->>'stmtType' = 'TypeDML'
+  AND app_name NOT LIKE $2
+GROUP BY query, db
+ORDER BY cnt DESC, query, db
+LIMIT $3`
+
+// unusedIndexesQuery finds the last read time of the secondary indexes of the
+// tables of a database. The placeholder is replaced by the escaped name of
+// the database.
+const unusedIndexesQuery = `
+SELECT
+  ti.descriptor_id,
+  ti.index_id,
+  ti.index_type,
+  us.last_read,
+  ti.created_at,
+  ti.is_unique
+FROM %[1]s.crdb_internal.index_usage_statistics AS us
+JOIN %[1]s.crdb_internal.table_indexes AS ti
+  ON us.index_id = ti.index_id AND us.table_id = ti.descriptor_id AND ti.index_type = 'secondary'
+JOIN %[1]s.crdb_internal.tables AS t
+  ON ti.descriptor_id = t.table_id AND t.database_name != 'system'`
+
+type analyzeWorkloadResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*analyzeWorkloadResumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r *analyzeWorkloadResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.AnalyzeWorkloadDetails)
+	user := r.job.Payload().UsernameProto.Decode()
+
+	fingerprints, err := topWorkloadStatements(ctx, execCfg.InternalDB, details)
+	if err != nil {
+		return err
+	}
+
+	var stmts []workloadindexrec.Statement
+	var skipped int64
+	var dbs []string
+	for _, fp := range fingerprints {
+		stmt, err := replayWorkloadStatement(ctx, execCfg, user, fp)
+		if err != nil {
+			// The statement may refer to objects which were dropped since it
+			// was executed, or which the user is not allowed to access.
+			log.VEventf(ctx, 2, "skipping statement %q: %v", fp.query, err)
+			skipped++
+			continue
+		}
+		stmts = append(stmts, stmt)
+		dbs = append(dbs, fp.db)
+	}
+
+	sort.Strings(dbs)
+	var unused []workloadindexrec.UnusedIndex
+	for i, db := range dbs {
+		if i > 0 && dbs[i-1] == db {
+			continue
+		}
+		dbUnused, err := findUnusedWorkloadIndexes(ctx, execCfg, user, db)
+		if err != nil {
+			return err
+		}
+		unused = append(unused, dbUnused...)
+	}
+
+	report := workloadindexrec.FindWorkloadRecs(stmts, unused)
+	progress := jobspb.AnalyzeWorkloadProgress{
+		Report: jobspb.WorkloadIndexReport{
+			Recommendations:    make([]jobspb.WorkloadIndexRecommendation, len(report.Recommendations)),
+			TotalCostSavings:   report.TotalCostSavings,
+			TotalWriteOverhead: report.TotalWriteOverhead,
+			Statements:         int64(len(stmts)),
+			SkippedStatements:  skipped,
+		},
+	}
+	for i := range report.Recommendations {
+		rec := &report.Recommendations[i]
+		progress.Report.Recommendations[i] = jobspb.WorkloadIndexRecommendation{
+			Type:          rec.Type.String(),
+			SQL:           rec.SQL,
+			CostSavings:   rec.CostSavings,
+			WriteOverhead: rec.WriteOverhead,
+			Statements:    int64(rec.Statements),
+			Reason:        rec.Reason,
+		}
+	}
+	return r.job.NoTxn().SetProgress(ctx, progress)
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r *analyzeWorkloadResumer) OnFailOrCancel(context.Context, interface{}, error) error {
+	return nil
+}
+
+// topWorkloadStatements returns the most frequently executed statement
+// fingerprints of the workload.
+func topWorkloadStatements(
+	ctx context.Context, db isql.DB, details jobspb.AnalyzeWorkloadDetails,
+) ([]workloadFingerprint, error) {
+	rows, err := db.Executor().QueryBufferedEx(ctx,
+		"analyze-workload-top-statements",
+		nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		topWorkloadStatementsQuery,
+		details.Since,
+		catconstants.InternalAppNamePrefix+"%",
+		details.TopStatements,
+	)
+	if err != nil {
+		return nil, err
+	}
+	fingerprints := make([]workloadFingerprint, 0, len(rows))
+	for _, row := range rows {
+		if row[0] == tree.DNull || row[1] == tree.DNull || row[2] == tree.DNull {
+			continue
+		}
+		fingerprints = append(fingerprints, workloadFingerprint{
+			query: string(tree.MustBeDString(row[0])),
+			db:    string(tree.MustBeDString(row[1])),
+			count: int64(tree.MustBeDInt(row[2])),
+		})
+	}
+	return fingerprints, nil
+}
+
+// replayWorkloadStatement optimizes a statement fingerprint as the given user,
+// in the database in which it was executed, with the existing indexes and
+// with hypothetical indexes.
+func replayWorkloadStatement(
+	ctx context.Context, execCfg *ExecutorConfig, user username.SQLUsername, fp workloadFingerprint,
+) (stmt workloadindexrec.Statement, err error) {
+	parsed, err := parseWorkloadFingerprint(fp.query)
+	if err != nil {
+		return stmt, err
+	}
+	if err := withWorkloadPlanner(ctx, execCfg, "analyze-workload", user, fp.db,
+		func(ctx context.Context, p *planner) error {
+			stmt, err = p.optimizeWorkloadStatement(ctx, parsed)
+			return err
+		},
+	); err != nil {
+		return stmt, err
+	}
+	stmt.Fingerprint = fp.query
+	stmt.Count = fp.count
+	return stmt, nil
+}
+
+// withWorkloadPlanner calls fn with an internal planner which plans
+// statements as the given user, in the given database.
+func withWorkloadPlanner(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	opName string,
+	user username.SQLUsername,
+	db string,
+	fn func(ctx context.Context, p *planner) error,
+) error {
+	return execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		sd := NewInternalSessionData(ctx, execCfg.Settings, opName)
+		sd.Database = db
+		// The constants of the replayed statements are replaced by
+		// placeholders, so they are planned like generic query plans, which
+		// can use the placeholders in lookup joins.
+		sd.PlanCacheMode = sessiondatapb.PlanCacheModeForceGeneric
+		p, cleanup := newInternalPlanner(
+			opName, txn.KV(), user, &MemoryMetrics{}, execCfg, sd,
+			WithDescCollection(txn.Descriptors()),
+		)
+		defer cleanup()
+		return fn(ctx, p)
+	})
+}
+
+// buildWorkloadStatement builds the statement into the memo of the planner's
+// optimizer, without optimizing it. The placeholders of the statement are not
+// assigned, so it is built like a generic query plan.
+func (p *planner) buildWorkloadStatement(
+	ctx context.Context, stmt statements.Statement[tree.Statement],
+) error {
+	p.stmt = makeStatement(stmt, clusterunique.ID{} /* queryID */)
+	if err := p.semaCtx.Placeholders.Init(stmt.NumPlaceholders, nil /* typeHints */); err != nil {
+		return err
+	}
+	opc := &p.optPlanningCtx
+	opc.init(p)
+	opc.reset(ctx)
+
+	f := opc.optimizer.Factory()
+	f.FoldingControl().AllowStableFolds()
+	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, stmt.AST)
+	bld.KeepPlaceholders = true
+	return bld.Build()
+}
+
+// optimizeWorkloadStatement builds and optimizes the statement without
+// executing it, with the existing indexes and with hypothetical indexes.
+func (p *planner) optimizeWorkloadStatement(
+	ctx context.Context, stmt statements.Statement[tree.Statement],
+) (workloadindexrec.Statement, error) {
+	if err := p.buildWorkloadStatement(ctx, stmt); err != nil {
+		return workloadindexrec.Statement{}, err
+	}
+	opc := &p.optPlanningCtx
+	f := opc.optimizer.Factory()
+	recs, hypotheticalCost, err := opc.makeQueryIndexRecommendation(ctx)
+	if err != nil {
+		return workloadindexrec.Statement{}, err
+	}
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return workloadindexrec.Statement{}, err
+	}
+	root := f.Memo().RootExpr()
+	return workloadindexrec.Statement{
+		Cost:             root.(memo.RelExpr).Cost(),
+		HypotheticalCost: hypotheticalCost,
+		Recs:             recs,
+		Writes:           workloadindexrec.FindWrites(root, f.Metadata()),
+	}, nil
+}
+
+// parseWorkloadFingerprint parses a statement fingerprint. The constants
+// removed from the fingerprint are replaced with placeholders numbered after
+// the placeholders of the original statement, and the markers which replace
+// the elements of long lists are removed.
+func parseWorkloadFingerprint(fingerprint string) (statements.Statement[tree.Statement], error) {
+	stmt, err := parser.ParseOne(fingerprint)
+	if err != nil {
+		return statements.Statement[tree.Statement]{}, err
+	}
+	switch stmt.AST.(type) {
+	case *tree.Select, *tree.ParenSelect, *tree.Insert, *tree.Update, *tree.Delete:
+	default:
+		return statements.Statement[tree.Statement]{}, errors.Newf("%s statements are not replayed", stmt.AST.StatementTag())
+	}
+	// The rows of a VALUES clause are not expressions, so the markers of an
+	// INSERT are removed before visiting the statement. The columns which are
+	// not given a value are written with their default value, unless the
+	// columns are listed explicitly.
+	if ins, ok := stmt.AST.(*tree.Insert); ok && ins.Rows != nil {
+		if values, ok := ins.Rows.Select.(*tree.ValuesClause); ok {
+			rows := values.Rows[:0]
+			for _, row := range values.Rows {
+				row = removeArityMarkers(row)
+				if len(row) == 0 {
+					continue
+				}
+				for len(row) < len(ins.Columns) {
+					row = append(row, tree.NewUnresolvedName("_"))
+				}
+				rows = append(rows, row)
+			}
+			values.Rows = rows
+		}
+	}
+	stmt.AST, err = tree.SimpleStmtVisit(stmt.AST, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts == 1 && t.Parts[0] == "_" {
+				stmt.NumPlaceholders++
+				return false, &tree.Placeholder{Idx: tree.PlaceholderIdx(stmt.NumPlaceholders - 1)}, nil
+			}
+		case *tree.Tuple:
+			tuple := *t
+			tuple.Exprs = removeArityMarkers(t.Exprs)
+			return true, &tuple, nil
+		case *tree.Array:
+			array := *t
+			array.Exprs = removeArityMarkers(t.Exprs)
+			return true, &array, nil
+		}
+		return true, expr, nil
+	})
+	if err != nil {
+		return statements.Statement[tree.Statement]{}, err
+	}
+	return stmt, nil
+}
+
+// removeArityMarkers returns the expressions without the markers which
+// replace the elements of long lists in statement fingerprints.
+func removeArityMarkers(exprs tree.Exprs) tree.Exprs {
+	res := make(tree.Exprs, 0, len(exprs))
+	for _, expr := range exprs {
+		if name, ok := expr.(*tree.UnresolvedName); ok && name.NumParts == 1 &&
+			strings.HasPrefix(name.Parts[0], "__more") {
+			continue
+		}
+		res = append(res, expr)
+	}
+	return res
+}
+
+// findUnusedWorkloadIndexes returns the secondary indexes of the tables of the
+// database which are recommended to be dropped because they are not used.
+func findUnusedWorkloadIndexes(
+	ctx context.Context, execCfg *ExecutorConfig, user username.SQLUsername, db string,
+) (unused []workloadindexrec.UnusedIndex, _ error) {
+	if db == "" || db == catconstants.SystemDatabaseName {
+		return nil, nil
+	}
+	err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		unused = unused[:0]
+		rows, err := txn.QueryBufferedEx(ctx,
+			"analyze-workload-unused-indexes",
+			txn.KV(),
+			sessiondata.InternalExecutorOverride{User: user, Database: db},
+			fmt.Sprintf(unusedIndexesQuery, lexbase.EscapeSQLIdent(db)),
+		)
+		if err != nil {
+			return err
+		}
+
+		sd := NewInternalSessionData(ctx, execCfg.Settings, "analyze-workload")
+		sd.Database = db
+		p, cleanup := newInternalPlanner(
+			"analyze-workload", txn.KV(), user, &MemoryMetrics{}, execCfg, sd,
+			WithDescCollection(txn.Descriptors()),
+		)
+		defer cleanup()
+		oc := &optCatalog{}
+		oc.init(p)
+
+		for _, row := range rows {
+			statsRow := idxusage.IndexStatsRow{
+				TableID:   roachpb.TableID(tree.MustBeDInt(row[0])),
+				IndexID:   roachpb.IndexID(tree.MustBeDInt(row[1])),
+				IndexType: string(tree.MustBeDString(row[2])),
+				IsUnique:  bool(tree.MustBeDBool(row[5])),
+			}
+			if row[3] != tree.DNull {
+				statsRow.LastRead = tree.MustBeDTimestampTZ(row[3]).Time
+			}
+			if row[4] != tree.DNull {
+				createdAt := tree.MustBeDTimestamp(row[4]).Time
+				statsRow.CreatedAt = &createdAt
+			}
+			for _, rec := range statsRow.GetRecommendationsFromIndexStats(db, execCfg.Settings) {
+				ds, _, err := oc.ResolveDataSourceByID(ctx, cat.Flags{}, cat.StableID(rec.TableID))
+				if err != nil {
+					return err
+				}
+				table, ok := ds.(cat.Table)
+				if !ok {
+					continue
+				}
+				tableName, err := oc.FullyQualifiedName(ctx, table)
+				if err != nil {
+					return err
+				}
+				for i, n := 0, table.IndexCount(); i < n; i++ {
+					if index := table.Index(i); index.ID() == cat.StableID(rec.IndexID) {
+						unused = append(unused, workloadindexrec.UnusedIndex{
+							Table:     table,
+							TableName: tableName,
+							Index:     index,
+							Reason:    rec.Reason,
+						})
+						break
+					}
+				}
+			}
+		}
+		return nil
+	})
+	return unused, err
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeAnalyzeWorkload,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &analyzeWorkloadResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
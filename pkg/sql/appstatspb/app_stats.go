@@ -160,6 +160,7 @@ func (s *StatementStatistics) Add(other *StatementStatistics) {
 	s.PlanGists = util.CombineUnique(s.PlanGists, other.PlanGists)
 	s.IndexRecommendations = other.IndexRecommendations
 	s.Indexes = util.CombineUnique(s.Indexes, other.Indexes)
+	s.StatementHints = util.CombineUnique(s.StatementHints, other.StatementHints)
 
 	s.ExecStats.Add(other.ExecStats)
 	s.LatencyInfo.Add(other.LatencyInfo)
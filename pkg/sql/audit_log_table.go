@@ -0,0 +1,339 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/auditlogging"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/envutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/logtags"
+)
+
+// auditLogTableEnabled enables the system.audit_log destination for the
+// audit events.
+var auditLogTableEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.audit_log.table.enabled",
+	"if set, audit events are also written to the system.audit_log table, "+
+		"where the rows written by each SQL instance form a hash chain that "+
+		"can be checked with VERIFY AUDIT LOG; the hashes are keyed with the "+
+		"contents of the file named by the COCKROACH_AUDIT_LOG_HMAC_KEY_FILE "+
+		"environment variable, which must be the same on all nodes",
+	false,
+).WithPublic()
+
+// auditLogTableRetention is the amount of time after which the rows of
+// the system.audit_log table are deleted. The expired rows at the start
+// of the chain of each instance are deleted by the instance when it
+// writes new rows.
+var auditLogTableRetention = settings.RegisterPublicDurationSettingWithExplicitUnit(
+	settings.TenantWritable,
+	"sql.audit_log.table.retention",
+	"the amount of time audit events are kept in the system.audit_log table "+
+		"before being deleted; set to 0 to keep them forever. The new value "+
+		"only applies to the events written after it is changed",
+	0,
+	settings.NonNegativeDuration,
+)
+
+// auditLogHMACKeyFile is the path of the file holding the key of the
+// hashes of the system.audit_log table. The key is kept outside of the
+// database, so that the rows of the table cannot be forged by someone
+// who can write to it.
+var auditLogHMACKeyFile = envutil.EnvOrDefaultString("COCKROACH_AUDIT_LOG_HMAC_KEY_FILE", "")
+
+// AuditLogWriter appends audit events to the hash chain of the current
+// SQL instance in the system.audit_log table.
+//
+// The events are written asynchronously, one batch at a time: the
+// sequence number and the hash of the last row of the chain are only
+// advanced once a batch is committed, so that a failed write does not
+// leave a gap in the chain. The events of a batch that cannot be
+// written after a few attempts are dropped, with a warning in the OPS
+// channel; they are still emitted to the log channels.
+type AuditLogWriter struct {
+	// key is the key of the hashes of the chain.
+	key []byte
+	// noKeyWarning limits the rate of the warnings about the lack of key.
+	noKeyWarning log.EveryN
+
+	mu struct {
+		syncutil.Mutex
+		// initialized is set once head and hash have been loaded from the
+		// table. It is reset after a failed write, since the write may
+		// have been committed nonetheless.
+		initialized bool
+		// head is the content of the head row of the chain, and hash the
+		// hash of its last row, or empty if the chain is empty.
+		head auditlogging.ChainHead
+		hash []byte
+	}
+}
+
+// NewAuditLogWriter creates an AuditLogWriter, whose key is read from
+// the file named by COCKROACH_AUDIT_LOG_HMAC_KEY_FILE.
+func NewAuditLogWriter() (*AuditLogWriter, error) {
+	w := &AuditLogWriter{noKeyWarning: log.Every(time.Hour)}
+	if auditLogHMACKeyFile != "" {
+		key, err := os.ReadFile(auditLogHMACKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading the audit log HMAC key")
+		}
+		if len(key) == 0 {
+			return nil, errors.Newf("audit log HMAC key file %s is empty", auditLogHMACKeyFile)
+		}
+		w.key = key
+	}
+	return w, nil
+}
+
+// auditLogHMACKey returns the key of the hashes of the system.audit_log
+// table.
+func auditLogHMACKey(execCfg *ExecutorConfig) []byte {
+	if execCfg.AuditLogWriter == nil {
+		return nil
+	}
+	return execCfg.AuditLogWriter.key
+}
+
+// auditLogTableWriteEnabled returns true if the audit events must be
+// written to the system.audit_log table.
+func auditLogTableWriteEnabled(ctx context.Context, execCfg *ExecutorConfig) bool {
+	return execCfg.AuditLogWriter != nil &&
+		auditLogTableEnabled.Get(&execCfg.Settings.SV) &&
+		execCfg.Settings.Version.IsActive(ctx, clusterversion.V23_2_AuditLogTable)
+}
+
+// writeAuditEventsToTable appends the given audit events, which must
+// have been logged already so that their common fields are populated,
+// to the system.audit_log table.
+func (p *planner) writeAuditEventsToTable(ctx context.Context, entries []logpb.EventPayload) {
+	execCfg := p.ExecCfg()
+	if !auditLogTableWriteEnabled(ctx, execCfg) {
+		return
+	}
+	var expiresAt time.Time
+	if retention := auditLogTableRetention.Get(&execCfg.Settings.SV); retention > 0 {
+		expiresAt = timeutil.Now().Add(retention)
+	}
+	// The records are built synchronously: the entries must not be used
+	// after this function returns.
+	records := make([]auditlogging.ChainRecord, len(entries))
+	for i, event := range entries {
+		records[i] = auditlogging.ChainRecord{
+			InstanceID: int64(execCfg.NodeInfo.NodeID.SQLInstanceID()),
+			Timestamp:  timeutil.Unix(0, event.CommonDetails().Timestamp).Truncate(auditlogging.ChainTimestampPrecision),
+			EventType:  event.CommonDetails().EventType,
+			Info:       eventInfoJSON(event),
+		}
+	}
+	execCfg.AuditLogWriter.asyncWrite(ctx, execCfg, records, expiresAt)
+}
+
+func (w *AuditLogWriter) asyncWrite(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	records []auditlogging.ChainRecord,
+	expiresAt time.Time,
+) {
+	// perAttemptTimeout is the maximum amount of time to wait on each
+	// write attempt.
+	const perAttemptTimeout time.Duration = 5 * time.Second
+	// maxAttempts is the maximum number of attempts to write a batch.
+	const maxAttempts = 10
+
+	stopper := execCfg.RPCContext.Stopper
+	origCtx := ctx
+	if len(w.key) == 0 && w.noKeyWarning.ShouldLog() {
+		log.Ops.Warningf(ctx, "no audit log HMAC key is configured: the hashes of system.audit_log "+
+			"can be recomputed by anyone able to write to the table")
+	}
+	if err := stopper.RunAsyncTask(
+		// Note: we don't want to inherit the cancellation of the parent
+		// context, as the statement may terminate before the events are
+		// written.
+		context.Background(), "write-audit-log", func(ctx context.Context) {
+			ctx, span := execCfg.AmbientCtx.AnnotateCtxWithSpan(ctx, "write-audit-log")
+			defer span.Finish()
+			ctx = logtags.AddTags(ctx, logtags.FromContext(origCtx))
+			ctx, stopCancel := stopper.WithCancelOnQuiesce(ctx)
+			defer stopCancel()
+
+			// The batches are written one at a time, since each one extends
+			// the chain ending with the previous one.
+			w.mu.Lock()
+			defer w.mu.Unlock()
+
+			retryOpts := base.DefaultRetryOptions()
+			retryOpts.Closer = ctx.Done()
+			retryOpts.MaxRetries = int(maxAttempts)
+			var err error
+			for r := retry.Start(retryOpts); r.Next(); {
+				if err = timeutil.RunWithTimeout(ctx, "write-audit-log", perAttemptTimeout, func(ctx context.Context) error {
+					return w.writeLocked(ctx, execCfg.InternalDB, records, expiresAt)
+				}); err == nil {
+					return
+				}
+				// The write may have been committed even though it returned an
+				// error: reload the end of the chain before the next attempt.
+				w.mu.initialized = false
+			}
+			log.Ops.Warningf(ctx, "unable to save %d entries to system.audit_log: %v", len(records), err)
+		}); err != nil {
+		expectedStopperError := errors.Is(err, stop.ErrThrottled) || errors.Is(err, stop.ErrUnavailable)
+		if !expectedStopperError {
+			err = errors.NewAssertionErrorWithWrappedErrf(err, "unexpected stopper error")
+		}
+		log.Warningf(ctx, "failed to start task to save %d events in system.audit_log: %v", len(records), err)
+	}
+}
+
+// maxExpiredAuditLogRowsPerWrite is the maximum number of expired rows
+// deleted from the start of the chain each time records are appended to
+// it.
+const maxExpiredAuditLogRowsPerWrite = 1000
+
+// writeLocked appends the records to the chain, deletes the expired rows
+// at its start and updates its head, in a single transaction. The
+// sequence numbers and hashes of the records are assigned here.
+//
+// The end of the chain is read from its head rather than from its last
+// row, so that rows removed from the end of the chain are not silently
+// replaced by new ones.
+func (w *AuditLogWriter) writeLocked(
+	ctx context.Context, db isql.DB, records []auditlogging.ChainRecord, expiresAt time.Time,
+) error {
+	instanceID := records[0].InstanceID
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		head, hash := w.mu.head, w.mu.hash
+		if !w.mu.initialized {
+			row, err := txn.QueryRowEx(ctx, "audit-log-chain-head", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride,
+				`SELECT event_type, info, prev_hash FROM system.audit_log WHERE instance_id = $1 AND seq = $2`,
+				instanceID, auditlogging.ChainHeadSeq,
+			)
+			if err != nil {
+				return err
+			}
+			// The first row of the chain has an empty, rather than NULL,
+			// previous hash.
+			head, hash = auditlogging.ChainHead{FirstSeq: 1}, []byte{}
+			if row != nil {
+				if head, err = auditlogging.DecodeChainHead(auditlogging.ChainRecord{
+					Seq:       auditlogging.ChainHeadSeq,
+					EventType: string(tree.MustBeDString(row[0])),
+					Info:      string(tree.MustBeDString(row[1])),
+				}); err != nil {
+					return err
+				}
+				hash = []byte(tree.MustBeDBytes(row[2]))
+			}
+		}
+
+		const colsPerRecord = 8
+		var query strings.Builder
+		query.WriteString(`INSERT INTO system.audit_log (
+  instance_id, seq, "timestamp", event_type, info, prev_hash, hash, expires_at
+) VALUES `)
+		args := make([]interface{}, 0, len(records)*colsPerRecord)
+		for i := range records {
+			r := &records[i]
+			head.LastSeq++
+			r.Seq, r.PrevHash, r.ExpiresAt = head.LastSeq, hash, expiresAt
+			r.Hash = r.ComputeHash(w.key)
+			hash = r.Hash
+
+			var expires interface{}
+			if !expiresAt.IsZero() {
+				expires = expiresAt
+			}
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			n := len(args)
+			fmt.Fprintf(&query, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+				n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8)
+			args = append(args, r.InstanceID, r.Seq, r.Timestamp, r.EventType, r.Info, r.PrevHash, r.Hash, expires)
+		}
+		if _, err := txn.ExecEx(ctx, "audit-log-write", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			query.String(), args...,
+		); err != nil {
+			return err
+		}
+
+		// Only a prefix of the chain is deleted, so that the rows that
+		// remain still form a chain, whose start is recorded in the head.
+		row, err := txn.QueryRowEx(ctx, "audit-log-chain-start", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT seq FROM system.audit_log
+			 WHERE instance_id = $1 AND seq >= $2 AND (expires_at IS NULL OR expires_at > $3)
+			 ORDER BY seq LIMIT 1`,
+			instanceID, head.FirstSeq, timeutil.Now(),
+		)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			firstSeq := int64(tree.MustBeDInt(row[0]))
+			if firstSeq > head.FirstSeq+maxExpiredAuditLogRowsPerWrite {
+				firstSeq = head.FirstSeq + maxExpiredAuditLogRowsPerWrite
+			}
+			if firstSeq > head.FirstSeq {
+				if _, err := txn.ExecEx(ctx, "audit-log-delete-expired", txn.KV(),
+					sessiondata.NodeUserSessionDataOverride,
+					`DELETE FROM system.audit_log WHERE instance_id = $1 AND seq >= $2 AND seq < $3`,
+					instanceID, head.FirstSeq, firstSeq,
+				); err != nil {
+					return err
+				}
+				head.FirstSeq = firstSeq
+			}
+		}
+
+		h := auditlogging.MakeChainHeadRecord(instanceID, timeutil.Now(), head, hash, w.key)
+		if _, err := txn.ExecEx(ctx, "audit-log-write-head", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPSERT INTO system.audit_log (
+  instance_id, seq, "timestamp", event_type, info, prev_hash, hash, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, NULL)`,
+			h.InstanceID, h.Seq, h.Timestamp, h.EventType, h.Info, h.PrevHash, h.Hash,
+		); err != nil {
+			return err
+		}
+
+		// The transaction may still be retried, in which case this function
+		// is called again with the end of the chain loaded above.
+		txn.KV().AddCommitTrigger(func(ctx context.Context) {
+			w.mu.initialized = true
+			w.mu.head, w.mu.hash = head, hash
+		})
+		return nil
+	})
+}
@@ -0,0 +1,295 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package auditlogging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ChainRecord is an audit event as stored in the system.audit_log
+// table. The records written by a SQL instance form a hash chain: the
+// hash of each record covers its contents and the hash of the previous
+// record of the same instance, so that modifying, inserting or
+// removing a record in the middle of the chain can be detected.
+//
+// The hashes are HMACs keyed with a secret kept outside of the database,
+// so that a record cannot be forged, or the chain recomputed, without
+// the key.
+//
+// Each chain also has a head record, with sequence number ChainHeadSeq,
+// which records the sequence numbers of the first and last records of
+// the chain and the hash of the last one. It allows the removal of
+// records at either end of the chain to be detected.
+type ChainRecord struct {
+	InstanceID int64
+	Seq        int64
+	Timestamp  time.Time
+	// ExpiresAt is the time after which the record can be removed from
+	// the start of the chain. It is zero if the record never expires.
+	ExpiresAt time.Time
+	EventType string
+	Info      string
+	PrevHash  []byte
+	Hash      []byte
+}
+
+// ChainTimestampPrecision is the precision of the timestamps covered
+// by the hash of a record. It matches the precision of the TIMESTAMPTZ
+// column where the timestamp is stored.
+const ChainTimestampPrecision = time.Microsecond
+
+// ChainHeadSeq is the sequence number of the head record of a chain.
+// The records of the chain are numbered from 1.
+const ChainHeadSeq = 0
+
+// ChainHeadEventType is the event type of the head record of a chain.
+const ChainHeadEventType = "audit_log_chain_head"
+
+// ComputeHash returns the hash of the record, keyed with the given key,
+// which covers the hash of the previous record. The Hash field of the
+// record is ignored.
+func (r *ChainRecord) ComputeHash(key []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	var buf [binary.MaxVarintLen64]byte
+	// Each variable-length field is prefixed by its length, so that
+	// bytes cannot be moved from one field to the next without changing
+	// the hash.
+	writeBytes := func(b []byte) {
+		n := binary.PutUvarint(buf[:], uint64(len(b)))
+		h.Write(buf[:n])
+		h.Write(b)
+	}
+	writeInt := func(i int64) {
+		n := binary.PutVarint(buf[:], i)
+		h.Write(buf[:n])
+	}
+	writeTime := func(t time.Time) {
+		if t.IsZero() {
+			writeInt(0)
+			return
+		}
+		writeInt(t.Truncate(ChainTimestampPrecision).UnixMicro())
+	}
+	writeBytes(r.PrevHash)
+	writeInt(r.InstanceID)
+	writeInt(r.Seq)
+	writeTime(r.Timestamp)
+	writeTime(r.ExpiresAt)
+	writeBytes([]byte(r.EventType))
+	writeBytes([]byte(r.Info))
+	return h.Sum(nil)
+}
+
+// ChainHead is the content of the head record of a chain.
+type ChainHead struct {
+	// FirstSeq is the sequence number of the first record of the chain.
+	// The records before it were removed once they expired.
+	FirstSeq int64 `json:"first_seq"`
+	// LastSeq is the sequence number of the last record of the chain, or
+	// zero if the chain is empty.
+	LastSeq int64 `json:"last_seq"`
+}
+
+// MakeChainHeadRecord returns the head record of the chain of the given
+// instance, whose last record has the given hash.
+func MakeChainHeadRecord(
+	instanceID int64, ts time.Time, head ChainHead, lastHash []byte, key []byte,
+) ChainRecord {
+	info, err := json.Marshal(head)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "encoding audit log chain head"))
+	}
+	r := ChainRecord{
+		InstanceID: instanceID,
+		Seq:        ChainHeadSeq,
+		Timestamp:  ts.Truncate(ChainTimestampPrecision),
+		EventType:  ChainHeadEventType,
+		Info:       string(info),
+		PrevHash:   lastHash,
+	}
+	r.Hash = r.ComputeHash(key)
+	return r
+}
+
+// DecodeChainHead decodes the contents of a head record.
+func DecodeChainHead(r ChainRecord) (ChainHead, error) {
+	var head ChainHead
+	if r.Seq != ChainHeadSeq || r.EventType != ChainHeadEventType {
+		return head, errors.Newf("record %d is not a chain head", r.Seq)
+	}
+	if err := json.Unmarshal([]byte(r.Info), &head); err != nil {
+		return head, errors.Wrap(err, "decoding audit log chain head")
+	}
+	return head, nil
+}
+
+// ChainVerificationResult is the outcome of the verification of the
+// hash chain of a SQL instance.
+type ChainVerificationResult struct {
+	InstanceID int64
+	// FirstSeq and LastSeq are the sequence numbers of the first and last
+	// records of the chain that are present in the table, or zero if
+	// there are none.
+	FirstSeq, LastSeq int64
+	// NumRecords is the number of records of the chain present in the
+	// table, not counting its head.
+	NumRecords int64
+	// Problem describes the first inconsistency found in the chain. It is
+	// empty if the chain is intact.
+	Problem string
+}
+
+// ChainVerifier checks the hash chains of the system.audit_log table.
+// The records must be added in (instance ID, sequence number) order, so
+// that the head of each chain comes first.
+//
+// The first record of a chain may not have a sequence number of 1, as
+// the oldest records of the table are deleted once their retention
+// period expires: the verification of a chain starts at the first
+// record recorded in its head.
+type ChainVerifier struct {
+	key     []byte
+	results []ChainVerificationResult
+	// prev is the last record added to the verifier.
+	prev ChainRecord
+	// head is the head of the chain being verified, if valid, and
+	// headLastHash the hash of the last record of the chain it records.
+	head         ChainHead
+	headLastHash []byte
+	hasHead      bool
+	// finished is set once the last chain has been checked against its
+	// head.
+	finished bool
+}
+
+// MakeChainVerifier returns a ChainVerifier for the chains whose hashes
+// are keyed with the given key.
+func MakeChainVerifier(key []byte) ChainVerifier {
+	return ChainVerifier{key: key}
+}
+
+// Add verifies the next record.
+func (v *ChainVerifier) Add(r ChainRecord) {
+	if len(v.results) == 0 || v.prev.InstanceID != r.InstanceID {
+		// This is the first record of a new chain.
+		v.finish()
+		v.finished = false
+		v.results = append(v.results, ChainVerificationResult{InstanceID: r.InstanceID})
+		v.hasHead = false
+		v.prev = r
+		if r.Seq == ChainHeadSeq {
+			v.addHead(r)
+			return
+		}
+		v.setProblem("the chain head is missing")
+	} else if v.prev.Seq == ChainHeadSeq {
+		// This is the first record after the head.
+		if v.hasHead {
+			switch {
+			case r.Seq < v.head.FirstSeq:
+				v.setProblem(fmt.Sprintf("record %d precedes the start of the chain", r.Seq))
+			case r.Seq == v.head.FirstSeq+1:
+				v.setProblem(fmt.Sprintf("record %d is missing", v.head.FirstSeq))
+			case r.Seq > v.head.FirstSeq+1:
+				v.setProblem(fmt.Sprintf("records %d to %d are missing", v.head.FirstSeq, r.Seq-1))
+			}
+		}
+	} else {
+		switch {
+		case r.Seq == v.prev.Seq+1:
+			if !bytes.Equal(r.PrevHash, v.prev.Hash) {
+				v.setProblem(fmt.Sprintf("record %d is not chained to record %d", r.Seq, v.prev.Seq))
+			}
+		case r.Seq == v.prev.Seq+2:
+			v.setProblem(fmt.Sprintf("record %d is missing", v.prev.Seq+1))
+		default:
+			v.setProblem(fmt.Sprintf("records %d to %d are missing", v.prev.Seq+1, r.Seq-1))
+		}
+	}
+	res := &v.results[len(v.results)-1]
+	if res.NumRecords == 0 {
+		res.FirstSeq = r.Seq
+		if r.Seq == 1 && len(r.PrevHash) != 0 {
+			v.setProblem("record 1 refers to a previous record")
+		}
+	}
+	if !hmac.Equal(r.ComputeHash(v.key), r.Hash) {
+		v.setProblem(fmt.Sprintf("record %d was modified", r.Seq))
+	}
+	res.LastSeq = r.Seq
+	res.NumRecords++
+	v.prev = r
+}
+
+// addHead verifies the head record of a chain.
+func (v *ChainVerifier) addHead(r ChainRecord) {
+	if !hmac.Equal(r.ComputeHash(v.key), r.Hash) {
+		v.setProblem("the chain head was modified")
+		return
+	}
+	head, err := DecodeChainHead(r)
+	if err != nil {
+		v.setProblem(err.Error())
+		return
+	}
+	v.head, v.headLastHash, v.hasHead = head, r.PrevHash, true
+}
+
+// finish checks the end of the last chain against its head.
+func (v *ChainVerifier) finish() {
+	if len(v.results) == 0 || v.finished || !v.hasHead {
+		return
+	}
+	v.finished = true
+	res := &v.results[len(v.results)-1]
+	if res.NumRecords == 0 {
+		switch {
+		case v.head.LastSeq == v.head.FirstSeq:
+			v.setProblem(fmt.Sprintf("record %d is missing", v.head.FirstSeq))
+		case v.head.LastSeq > v.head.FirstSeq:
+			v.setProblem(fmt.Sprintf("records %d to %d are missing", v.head.FirstSeq, v.head.LastSeq))
+		}
+		return
+	}
+	switch {
+	case res.LastSeq == v.head.LastSeq-1:
+		v.setProblem(fmt.Sprintf("record %d is missing", v.head.LastSeq))
+	case res.LastSeq < v.head.LastSeq:
+		v.setProblem(fmt.Sprintf("records %d to %d are missing", res.LastSeq+1, v.head.LastSeq))
+	case res.LastSeq > v.head.LastSeq:
+		v.setProblem(fmt.Sprintf("record %d follows the end of the chain", v.head.LastSeq+1))
+	case !bytes.Equal(v.prev.Hash, v.headLastHash):
+		v.setProblem(fmt.Sprintf("record %d is not the end of the chain", res.LastSeq))
+	}
+}
+
+// setProblem records the given problem for the current chain, unless
+// one was already found.
+func (v *ChainVerifier) setProblem(problem string) {
+	if res := &v.results[len(v.results)-1]; res.Problem == "" {
+		res.Problem = problem
+	}
+}
+
+// Results returns the verification result of each chain, in instance
+// ID order. No record can be added afterwards.
+func (v *ChainVerifier) Results() []ChainVerificationResult {
+	v.finish()
+	return v.results
+}
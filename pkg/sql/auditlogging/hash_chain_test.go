@@ -0,0 +1,245 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package auditlogging
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("audit log key")
+
+// makeTestChain returns a valid chain of n records for the given
+// instance, starting with its head.
+func makeTestChain(instanceID int64, n int) []ChainRecord {
+	ts := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	var prevHash []byte
+	records := make([]ChainRecord, n)
+	for i := range records {
+		r := ChainRecord{
+			InstanceID: instanceID,
+			Seq:        int64(i + 1),
+			Timestamp:  ts.Add(time.Duration(i) * time.Second),
+			EventType:  "sensitive_table_access",
+			Info:       fmt.Sprintf(`{"Statement": "SELECT %d"}`, i),
+			PrevHash:   prevHash,
+		}
+		r.Hash = r.ComputeHash(testKey)
+		prevHash = r.Hash
+		records[i] = r
+	}
+	head := MakeChainHeadRecord(instanceID, ts.Add(time.Hour),
+		ChainHead{FirstSeq: 1, LastSeq: int64(n)}, prevHash, testKey)
+	return append([]ChainRecord{head}, records...)
+}
+
+// expireTestChain removes the first n records of the chain, as done
+// once they expire.
+func expireTestChain(c []ChainRecord, n int) []ChainRecord {
+	head, err := DecodeChainHead(c[0])
+	if err != nil {
+		panic(err)
+	}
+	head.FirstSeq += int64(n)
+	c[0] = MakeChainHeadRecord(c[0].InstanceID, c[0].Timestamp, head, c[0].PrevHash, testKey)
+	return append(c[:1], c[1+n:]...)
+}
+
+func verifyTestChain(records ...[]ChainRecord) []ChainVerificationResult {
+	v := MakeChainVerifier(testKey)
+	for _, chain := range records {
+		for _, r := range chain {
+			v.Add(r)
+		}
+	}
+	return v.Results()
+}
+
+func TestChainVerifier(t *testing.T) {
+	t.Run("intact", func(t *testing.T) {
+		res := verifyTestChain(makeTestChain(1, 5), makeTestChain(2, 3))
+		require.Equal(t, []ChainVerificationResult{
+			{InstanceID: 1, FirstSeq: 1, LastSeq: 5, NumRecords: 5},
+			{InstanceID: 2, FirstSeq: 1, LastSeq: 3, NumRecords: 3},
+		}, res)
+	})
+
+	t.Run("expired prefix", func(t *testing.T) {
+		res := verifyTestChain(expireTestChain(makeTestChain(1, 5), 2))
+		require.Equal(t, []ChainVerificationResult{
+			{InstanceID: 1, FirstSeq: 3, LastSeq: 5, NumRecords: 3},
+		}, res)
+	})
+
+	t.Run("all expired", func(t *testing.T) {
+		res := verifyTestChain(expireTestChain(makeTestChain(1, 5), 5))
+		require.Equal(t, []ChainVerificationResult{{InstanceID: 1}}, res)
+	})
+
+	t.Run("timestamp precision", func(t *testing.T) {
+		chain := makeTestChain(1, 2)
+		chain[2].Timestamp = chain[2].Timestamp.Add(time.Nanosecond)
+		require.Empty(t, verifyTestChain(chain)[0].Problem)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		v := MakeChainVerifier([]byte("other key"))
+		for _, r := range makeTestChain(1, 2) {
+			v.Add(r)
+		}
+		require.Equal(t, "the chain head was modified", v.Results()[0].Problem)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		tamper  func([]ChainRecord) []ChainRecord
+		problem string
+	}{
+		{
+			name: "modified info",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[3].Info = `{"Statement": "SELECT 42"}`
+				return c
+			},
+			problem: "record 3 was modified",
+		},
+		{
+			name: "modified timestamp",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[2].Timestamp = c[2].Timestamp.Add(time.Hour)
+				return c
+			},
+			problem: "record 2 was modified",
+		},
+		{
+			name: "modified expiration",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[2].ExpiresAt = c[2].Timestamp
+				return c
+			},
+			problem: "record 2 was modified",
+		},
+		{
+			name: "rehashed record without the key",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[3].EventType = "other"
+				c[3].Hash = c[3].ComputeHash(nil /* key */)
+				return c
+			},
+			problem: "record 3 was modified",
+		},
+		{
+			name: "rehashed record",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[3].EventType = "other"
+				c[3].Hash = c[3].ComputeHash(testKey)
+				return c
+			},
+			problem: "record 4 is not chained to record 3",
+		},
+		{
+			name: "deleted record",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return append(c[:3], c[4:]...)
+			},
+			problem: "record 3 is missing",
+		},
+		{
+			name: "deleted records",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return append(c[:2], c[5:]...)
+			},
+			problem: "records 2 to 4 are missing",
+		},
+		{
+			name: "deleted first records",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return append(c[:1], c[3:]...)
+			},
+			problem: "records 1 to 2 are missing",
+		},
+		{
+			name: "deleted last record",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return c[:5]
+			},
+			problem: "record 5 is missing",
+		},
+		{
+			name: "deleted last records",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return c[:3]
+			},
+			problem: "records 3 to 5 are missing",
+		},
+		{
+			name: "deleted all records",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return c[:1]
+			},
+			problem: "records 1 to 5 are missing",
+		},
+		{
+			name: "appended record",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				r := c[5]
+				r.Seq, r.PrevHash = 6, c[5].Hash
+				r.Hash = r.ComputeHash(testKey)
+				return append(c, r)
+			},
+			problem: "record 6 follows the end of the chain",
+		},
+		{
+			name: "replaced last record",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[5].Info = `{"Statement": "SELECT 42"}`
+				c[5].Hash = c[5].ComputeHash(testKey)
+				return c
+			},
+			problem: "record 5 is not the end of the chain",
+		},
+		{
+			name: "deleted head",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				return c[1:]
+			},
+			problem: "the chain head is missing",
+		},
+		{
+			name: "modified head",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[0].Info = `{"first_seq": 1, "last_seq": 3}`
+				return c
+			},
+			problem: "the chain head was modified",
+		},
+		{
+			name: "forged first record",
+			tamper: func(c []ChainRecord) []ChainRecord {
+				c[1].PrevHash = []byte("forged")
+				c[1].Hash = c[1].ComputeHash(testKey)
+				return c
+			},
+			problem: "record 1 refers to a previous record",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := verifyTestChain(tc.tamper(makeTestChain(1, 5)), makeTestChain(2, 2))
+			require.Len(t, res, 2)
+			require.Equal(t, tc.problem, res[0].Problem)
+			// The other chains are not affected.
+			require.Empty(t, res[1].Problem)
+		})
+	}
+}
@@ -31,6 +31,7 @@ var (
 		catconstants.TransactionStatisticsTableName,
 		catconstants.StatementActivityTableName,
 		catconstants.TransactionActivityTableName,
+		catconstants.AuditLogTableName,
 	}
 
 	readWriteSystemTables = []catconstants.SystemTableName{
@@ -74,6 +75,9 @@ var (
 		catconstants.SpanStatsBuckets,
 		catconstants.SpanStatsSamples,
 		catconstants.SpanStatsTenantBoundaries,
+		catconstants.PasswordHistoryTableName,
+		catconstants.LoginLockoutsTableName,
+		catconstants.StatementHintsTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -74,6 +74,13 @@ type IndexID = catid.IndexID
 // ConstraintID is a custom type for TableDescriptor constraint IDs.
 type ConstraintID = catid.ConstraintID
 
+// PolicyID is a custom type for TableDescriptor row-level security policy IDs.
+type PolicyID uint32
+
+// ColumnEncryptionKeyID is a custom type for TableDescriptor column encryption
+// key IDs.
+type ColumnEncryptionKeyID uint32
+
 // DescriptorVersion is a custom type for TableDescriptor Versions.
 type DescriptorVersion uint64
 
@@ -759,6 +759,21 @@ type TableDescriptor interface {
 	// IsSchemaLocked returns true if we don't allow performing schema changes
 	// on this table descriptor.
 	IsSchemaLocked() bool
+	// GetPolicies returns the row-level security policies defined on this
+	// table.
+	GetPolicies() []descpb.PolicyDescriptor
+	// IsRowLevelSecurityEnabled returns true if row-level security policies are
+	// enforced on this table.
+	IsRowLevelSecurityEnabled() bool
+	// IsRowLevelSecurityForced returns true if row-level security policies are
+	// also enforced for the owner of this table.
+	IsRowLevelSecurityForced() bool
+	// GetColumnEncryptionKeys returns the data keys used to encrypt the values
+	// of the encrypted columns of this table.
+	GetColumnEncryptionKeys() []descpb.ColumnEncryptionKey
+	// FindColumnEncryptionKey returns the column encryption key with the given
+	// ID, or nil if there is no such key.
+	FindColumnEncryptionKey(id descpb.ColumnEncryptionKeyID) *descpb.ColumnEncryptionKey
 }
 
 // MutableTableDescriptor is both a MutableDescriptor and a TableDescriptor.
@@ -992,6 +992,66 @@ CREATE TABLE system.span_stats_tenant_boundaries (
 	CONSTRAINT "primary" PRIMARY KEY (tenant_id),
 	FAMILY "primary" (tenant_id, boundaries)
 );`
+
+	// PasswordHistoryTableSchema stores the hashes of the passwords set for
+	// each user, most recent first. It is used to reject the reuse of recent
+	// passwords, and to expire passwords that have not been changed in a while.
+	PasswordHistoryTableSchema = `
+CREATE TABLE system.password_history (
+	username        STRING NOT NULL,
+	changed_at      TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	hashed_password BYTES NOT NULL,
+	user_id         OID NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (username, changed_at DESC),
+	FAMILY "primary" (username, changed_at, hashed_password, user_id)
+);`
+
+	// LoginLockoutsTableSchema stores the recent failed password logins of
+	// each user, and when the account was locked as a result, if it was.
+	LoginLockoutsTableSchema = `
+CREATE TABLE system.login_lockouts (
+	username         STRING NOT NULL,
+	failed_attempts  INT8 NOT NULL,
+	first_failure_at TIMESTAMPTZ NOT NULL,
+	locked_at        TIMESTAMPTZ NULL,
+	user_id          OID NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (username),
+	FAMILY "primary" (username, failed_attempts, first_failure_at, locked_at, user_id)
+);`
+
+	// AuditLogTableSchema stores the audit events written by each SQL
+	// instance, when the table destination for audit events is enabled. The
+	// rows of each instance form a hash chain: the keyed hash of each row
+	// covers its contents and the hash of the previous row of the same
+	// instance. The row with seq 0 is the head of the chain, which records
+	// its first and last rows. The table is read-only to SQL users.
+	AuditLogTableSchema = `
+CREATE TABLE system.audit_log (
+	instance_id INT8 NOT NULL,
+	seq         INT8 NOT NULL,
+	"timestamp" TIMESTAMPTZ NOT NULL,
+	event_type  STRING NOT NULL,
+	info        STRING NOT NULL,
+	prev_hash   BYTES NOT NULL,
+	hash        BYTES NOT NULL,
+	expires_at  TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (instance_id, seq),
+	FAMILY "primary" (instance_id, seq, "timestamp", event_type, info, prev_hash, hash, expires_at)
+);`
+
+	// StatementHintsTableSchema stores the hints attached to statement
+	// fingerprints, which the optimizer applies to the matching statements.
+	// See the stmthints package.
+	StatementHintsTableSchema = `
+CREATE TABLE system.statement_hints (
+	hint_id        INT8 NOT NULL DEFAULT unique_rowid(),
+	fingerprint_id BYTES NOT NULL,
+	hint_type      STRING NOT NULL,
+	hint_value     STRING NOT NULL,
+	created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (hint_id),
+	FAMILY "primary" (hint_id, fingerprint_id, hint_type, hint_value, created_at)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1221,6 +1281,10 @@ func MakeSystemTables() []SystemTable {
 		SystemTenantTasksTable,
 		StatementActivityTable,
 		TransactionActivityTable,
+		PasswordHistoryTable,
+		LoginLockoutsTable,
+		AuditLogTable,
+		StatementHintsTable,
 	}
 }
 
@@ -4054,6 +4118,125 @@ var (
 			},
 		),
 	)
+
+	// PasswordHistoryTable is the descriptor for the password history table.
+	PasswordHistoryTable = makeSystemTable(
+		PasswordHistoryTableSchema,
+		systemTable(
+			catconstants.PasswordHistoryTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "username", ID: 1, Type: types.String},
+				{Name: "changed_at", ID: 2, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "hashed_password", ID: 3, Type: types.Bytes},
+				{Name: "user_id", ID: 4, Type: types.Oid},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"username", "changed_at", "hashed_password", "user_id"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"username", "changed_at"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_DESC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+			},
+		),
+	)
+
+	// LoginLockoutsTable is the descriptor for the login lockouts table.
+	LoginLockoutsTable = makeSystemTable(
+		LoginLockoutsTableSchema,
+		systemTable(
+			catconstants.LoginLockoutsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "username", ID: 1, Type: types.String},
+				{Name: "failed_attempts", ID: 2, Type: types.Int},
+				{Name: "first_failure_at", ID: 3, Type: types.TimestampTZ},
+				{Name: "locked_at", ID: 4, Type: types.TimestampTZ, Nullable: true},
+				{Name: "user_id", ID: 5, Type: types.Oid},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"username", "failed_attempts", "first_failure_at", "locked_at", "user_id"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			pk("username"),
+		),
+	)
+
+	// AuditLogTable is the descriptor for the audit log table.
+	AuditLogTable = makeSystemTable(
+		AuditLogTableSchema,
+		systemTable(
+			catconstants.AuditLogTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "instance_id", ID: 1, Type: types.Int},
+				{Name: "seq", ID: 2, Type: types.Int},
+				{Name: "timestamp", ID: 3, Type: types.TimestampTZ},
+				{Name: "event_type", ID: 4, Type: types.String},
+				{Name: "info", ID: 5, Type: types.String},
+				{Name: "prev_hash", ID: 6, Type: types.Bytes},
+				{Name: "hash", ID: 7, Type: types.Bytes},
+				{Name: "expires_at", ID: 8, Type: types.TimestampTZ, Nullable: true},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"instance_id", "seq", "timestamp", "event_type", "info", "prev_hash", "hash", "expires_at"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"instance_id", "seq"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC},
+				KeyColumnIDs:        []descpb.ColumnID{1, 2},
+			},
+		),
+	)
+
+	// StatementHintsTable is the descriptor for the statement hints table.
+	StatementHintsTable = makeSystemTable(
+		StatementHintsTableSchema,
+		systemTable(
+			catconstants.StatementHintsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "hint_id", ID: 1, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "fingerprint_id", ID: 2, Type: types.Bytes},
+				{Name: "hint_type", ID: 3, Type: types.String},
+				{Name: "hint_value", ID: 4, Type: types.String},
+				{Name: "created_at", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"hint_id", "fingerprint_id", "hint_type", "hint_value", "created_at"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			pk("hint_id"),
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
@@ -434,6 +434,15 @@ type Column interface {
 	// and the error.
 	// Note it doesn't return the sequence owner info.
 	GetGeneratedAsIdentitySequenceOption(defaultIntSize int32) (*descpb.TableDescriptor_SequenceOpts, error)
+
+	// IsEncrypted returns true iff the column is declared with ENCRYPTED WITH
+	// KEY, in which case its values are stored encrypted.
+	IsEncrypted() bool
+
+	// GetEncryptionKeyID returns the ID of the column encryption key of the
+	// table with which new values of the column are encrypted, or zero if the
+	// column is not encrypted.
+	GetEncryptionKeyID() descpb.ColumnEncryptionKeyID
 }
 
 // Constraint is an interface around a constraint.
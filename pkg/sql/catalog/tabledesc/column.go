@@ -271,6 +271,17 @@ func (w column) GetGeneratedAsIdentitySequenceOptionStr() string {
 	return strings.TrimSpace(*w.desc.GeneratedAsIdentitySequenceOption)
 }
 
+// IsEncrypted returns true iff the column is declared with ENCRYPTED WITH KEY.
+func (w column) IsEncrypted() bool {
+	return w.desc.EncryptionKeyID != 0
+}
+
+// GetEncryptionKeyID returns the ID of the column encryption key with which new
+// values of the column are encrypted, or zero if the column is not encrypted.
+func (w column) GetEncryptionKeyID() descpb.ColumnEncryptionKeyID {
+	return w.desc.EncryptionKeyID
+}
+
 // GetGeneratedAsIdentitySequenceOption returns the column's `GENERATED AS
 // IDENTITY` sequence option if it exists, and possible error.
 // If the column is not an identity column, return nil for both sequence option
@@ -1637,6 +1637,10 @@ func (desc *Mutable) MakeMutationComplete(m descpb.DescriptorMutation) error {
 			// indexes with the new indexes that have been backfilled already.
 			desc.SetPrimaryIndex(t.MaterializedViewRefresh.NewPrimaryIndex)
 			desc.SetPublicNonPrimaryIndexes(t.MaterializedViewRefresh.NewIndexes)
+			desc.MaterializedViewRefreshTime = hlc.Timestamp{}
+			if t.MaterializedViewRefresh.ShouldBackfill {
+				desc.MaterializedViewRefreshTime = t.MaterializedViewRefresh.AsOf
+			}
 		}
 
 	case descpb.DescriptorMutation_DROP:
@@ -2305,6 +2309,69 @@ func (desc *Mutable) SetOffline(reason string) {
 	desc.OfflineReason = reason
 }
 
+// AddPolicy allocates an ID for the given row-level security policy and adds
+// it to the table.
+func (desc *Mutable) AddPolicy(p descpb.PolicyDescriptor) descpb.PolicyID {
+	if desc.NextPolicyID == 0 {
+		desc.NextPolicyID = 1
+	}
+	p.ID = desc.NextPolicyID
+	desc.NextPolicyID++
+	desc.Policies = append(desc.Policies, p)
+	return p.ID
+}
+
+// FindPolicyByName returns the row-level security policy with the given name,
+// or nil if no such policy exists.
+func (desc *Mutable) FindPolicyByName(name string) *descpb.PolicyDescriptor {
+	for i := range desc.Policies {
+		if desc.Policies[i].Name == name {
+			return &desc.Policies[i]
+		}
+	}
+	return nil
+}
+
+// RemovePolicy removes the row-level security policy with the given ID from
+// the table.
+func (desc *Mutable) RemovePolicy(id descpb.PolicyID) {
+	for i := range desc.Policies {
+		if desc.Policies[i].ID == id {
+			desc.Policies = append(desc.Policies[:i], desc.Policies[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddColumnEncryptionKey allocates an ID for a column encryption key with the
+// given KMS URI and wrapped data key, and adds it to the table.
+func (desc *Mutable) AddColumnEncryptionKey(
+	kmsURI string, wrappedKey []byte,
+) descpb.ColumnEncryptionKeyID {
+	if desc.NextColumnEncryptionKeyID == 0 {
+		desc.NextColumnEncryptionKeyID = 1
+	}
+	id := desc.NextColumnEncryptionKeyID
+	desc.NextColumnEncryptionKeyID++
+	desc.ColumnEncryptionKeys = append(desc.ColumnEncryptionKeys, descpb.ColumnEncryptionKey{
+		ID:         id,
+		KMSURI:     kmsURI,
+		WrappedKey: wrappedKey,
+	})
+	return id
+}
+
+// RemoveColumnEncryptionKey removes the column encryption key with the given
+// ID from the table.
+func (desc *Mutable) RemoveColumnEncryptionKey(id descpb.ColumnEncryptionKeyID) {
+	for i := range desc.ColumnEncryptionKeys {
+		if desc.ColumnEncryptionKeys[i].ID == id {
+			desc.ColumnEncryptionKeys = append(desc.ColumnEncryptionKeys[:i], desc.ColumnEncryptionKeys[i+1:]...)
+			return
+		}
+	}
+}
+
 // IsLocalityRegionalByRow implements the TableDescriptor interface.
 func (desc *wrapper) IsLocalityRegionalByRow() bool {
 	return desc.LocalityConfig.GetRegionalByRow() != nil
@@ -521,6 +521,19 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policy expressions.
+	for i := range tableDesc.Policies {
+		p := &tableDesc.Policies[i]
+		for _, expr := range []*string{&p.UsingExpr, &p.WithCheckExpr} {
+			if *expr == "" {
+				continue
+			}
+			if err := renameInExpr(expr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
@@ -632,3 +632,25 @@ func (desc *wrapper) ForEachUDTDependentForHydration(fn func(t *types.T) error)
 func (desc *wrapper) IsSchemaLocked() bool {
 	return desc.SchemaLocked
 }
+
+// IsRowLevelSecurityEnabled implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityEnabled() bool {
+	return desc.RowLevelSecurityEnabled
+}
+
+// IsRowLevelSecurityForced implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityForced() bool {
+	return desc.RowLevelSecurityForced
+}
+
+// FindColumnEncryptionKey implements the TableDescriptor interface.
+func (desc *wrapper) FindColumnEncryptionKey(
+	id descpb.ColumnEncryptionKeyID,
+) *descpb.ColumnEncryptionKey {
+	for i := range desc.ColumnEncryptionKeys {
+		if desc.ColumnEncryptionKeys[i].ID == id {
+			return &desc.ColumnEncryptionKeys[i]
+		}
+	}
+	return nil
+}
@@ -11,6 +11,8 @@
 package tabledesc
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -756,6 +758,8 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
 			desc.validateTableIndexes(columnsByID),
 			desc.validatePartitioning(),
+			desc.validatePolicies(columnsByID),
+			desc.validateColumnEncryption(),
 		}
 		hasErrs := false
 		for _, err := range newErrs {
@@ -1211,6 +1215,179 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validatePolicies validates that row-level security policies are well formed.
+// Checks include validating the policy names, IDs and column IDs, and verifying
+// that the policy expressions only refer to existing columns.
+func (desc *wrapper) validatePolicies(columnsByID map[descpb.ColumnID]catalog.Column) error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	ids := make(map[descpb.PolicyID]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if p.Name == "" {
+			return errors.AssertionFailedf("policy %d has empty name", p.ID)
+		}
+		if _, ok := names[p.Name]; ok {
+			return errors.Newf("duplicate policy name: %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if p.ID == 0 || p.ID >= desc.NextPolicyID {
+			return errors.AssertionFailedf(
+				"policy %q has ID %d not less than NextPolicyID value %d",
+				p.Name, p.ID, desc.NextPolicyID)
+		}
+		if _, ok := ids[p.ID]; ok {
+			return errors.Newf("policy %q duplicate ID: %d", p.Name, p.ID)
+		}
+		ids[p.ID] = struct{}{}
+		for _, colID := range p.ColumnIDs {
+			if _, ok := columnsByID[colID]; !ok {
+				return errors.Newf("policy %q contains unknown column \"%d\"", p.Name, colID)
+			}
+		}
+		for _, e := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if e == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(e)
+			if err != nil {
+				return errors.Wrapf(err, "policy %q", p.Name)
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf("policy %q refers to unknown columns in expression: %s", p.Name, e)
+			}
+		}
+	}
+	return nil
+}
+
+// validateColumnEncryption validates that the column encryption keys are well
+// formed and that the encrypted columns refer to an existing key. Since the
+// values of an encrypted column are stored encrypted, the column must have a
+// string or bytes type and cannot be referenced by anything that depends on
+// its plaintext value: index keys, constraints, computed columns and partial
+// index predicates.
+func (desc *wrapper) validateColumnEncryption() error {
+	ids := make(map[descpb.ColumnEncryptionKeyID]struct{}, len(desc.ColumnEncryptionKeys))
+	for i := range desc.ColumnEncryptionKeys {
+		k := &desc.ColumnEncryptionKeys[i]
+		if k.ID == 0 || k.ID >= desc.NextColumnEncryptionKeyID {
+			return errors.AssertionFailedf(
+				"column encryption key has ID %d not less than NextColumnEncryptionKeyID value %d",
+				k.ID, desc.NextColumnEncryptionKeyID)
+		}
+		if _, ok := ids[k.ID]; ok {
+			return errors.Newf("duplicate column encryption key ID: %d", k.ID)
+		}
+		ids[k.ID] = struct{}{}
+		if k.KMSURI == "" || len(k.WrappedKey) == 0 {
+			return errors.AssertionFailedf("column encryption key %d is missing its KMS URI or wrapped key", k.ID)
+		}
+	}
+
+	var encrypted catalog.TableColSet
+	for _, col := range desc.DeletableColumns() {
+		if !col.IsEncrypted() {
+			continue
+		}
+		encrypted.Add(col.GetID())
+		if _, ok := ids[col.GetEncryptionKeyID()]; !ok {
+			return errors.AssertionFailedf("column %q refers to unknown column encryption key %d",
+				col.GetName(), col.GetEncryptionKeyID())
+		}
+		if f := col.GetType().Family(); f != types.StringFamily && f != types.BytesFamily {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"encrypted column %q must be of type STRING or BYTES, not %s",
+				col.GetName(), col.GetType().SQLString())
+		}
+		if col.IsComputed() || col.HasDefault() || col.HasOnUpdate() {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"encrypted column %q cannot have a DEFAULT, ON UPDATE or computed expression",
+				col.GetName())
+		}
+	}
+	if encrypted.Empty() {
+		return nil
+	}
+
+	referencesEncryptedColumn := func(ids catalog.TableColSet) (descpb.ColumnID, bool) {
+		intersection := ids.Intersection(encrypted)
+		if intersection.Empty() {
+			return 0, false
+		}
+		return intersection.Ordered()[0], true
+	}
+	exprReferencesEncryptedColumn := func(exprStr string) (descpb.ColumnID, bool, error) {
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			return 0, false, err
+		}
+		ids, err := schemaexpr.ExtractColumnIDs(desc, expr)
+		if err != nil {
+			return 0, false, err
+		}
+		colID, ok := referencesEncryptedColumn(ids)
+		return colID, ok, nil
+	}
+	encryptedColumnErr := func(colID descpb.ColumnID, format string, args ...interface{}) error {
+		name := "unknown"
+		if col := catalog.FindColumnByID(desc, colID); col != nil {
+			name = col.GetName()
+		}
+		return pgerror.Newf(pgcode.FeatureNotSupported, "encrypted column %q cannot be used in %s",
+			name, fmt.Sprintf(format, args...))
+	}
+
+	for _, idx := range desc.AllIndexes() {
+		if colID, ok := referencesEncryptedColumn(idx.CollectKeyColumnIDs()); ok {
+			return encryptedColumnErr(colID, "the key of index %q", idx.GetName())
+		}
+		if idx.IsPartial() {
+			colID, ok, err := exprReferencesEncryptedColumn(idx.GetPredicate())
+			if err != nil {
+				return err
+			}
+			if ok {
+				return encryptedColumnErr(colID, "the predicate of partial index %q", idx.GetName())
+			}
+		}
+	}
+	for _, col := range desc.DeletableColumns() {
+		if !col.IsComputed() {
+			continue
+		}
+		colID, ok, err := exprReferencesEncryptedColumn(col.GetComputeExpr())
+		if err != nil {
+			return err
+		}
+		if ok {
+			return encryptedColumnErr(colID, "the expression of computed column %q", col.GetName())
+		}
+	}
+	for _, ck := range desc.CheckConstraints() {
+		if ck.IsNotNullColumnConstraint() {
+			continue
+		}
+		if colID, ok := referencesEncryptedColumn(ck.CollectReferencedColumnIDs()); ok {
+			return encryptedColumnErr(colID, "check constraint %q", ck.GetName())
+		}
+	}
+	for _, uwi := range desc.UniqueConstraintsWithoutIndex() {
+		if colID, ok := referencesEncryptedColumn(uwi.CollectKeyColumnIDs()); ok {
+			return encryptedColumnErr(colID, "unique constraint %q", uwi.GetName())
+		}
+	}
+	for _, fk := range desc.OutboundForeignKeys() {
+		if colID, ok := referencesEncryptedColumn(fk.CollectOriginColumnIDs()); ok {
+			return encryptedColumnErr(colID, "foreign key constraint %q", fk.GetName())
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -184,6 +184,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 		}
 		return nil
 
+	case core.AdaptiveJoiner != nil:
+		return errAdaptiveJoinUnsupported
+
 	case core.Filterer != nil:
 		return nil
 
@@ -257,9 +260,10 @@ var (
 	errExporterWrap                   = errors.New("core.Exporter is not supported (not an execinfra.RowSource)")
 	errSamplerWrap                    = errors.New("core.Sampler is not supported (not an execinfra.RowSource)")
 	errSampleAggregatorWrap           = errors.New("core.SampleAggregator is not supported (not an execinfra.RowSource)")
-	errExperimentalWrappingProhibited = errors.New("wrapping for non-JoinReader and non-LocalPlanNode cores is prohibited in vectorize=experimental_always")
+	errExperimentalWrappingProhibited = errors.New("wrapping for non-JoinReader, non-AdaptiveJoiner and non-LocalPlanNode cores is prohibited in vectorize=experimental_always")
 	errWrappedCast                    = errors.New("mismatched types in NewColOperator and unsupported casts")
 	errLookupJoinUnsupported          = errors.New("lookup join reader is unsupported in vectorized")
+	errAdaptiveJoinUnsupported        = errors.New("adaptive joiner is unsupported in vectorized")
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
@@ -268,7 +272,8 @@ var (
 )
 
 func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCoreUnion) error {
-	if mode == sessiondatapb.VectorizeExperimentalAlways && core.JoinReader == nil && core.LocalPlanNode == nil {
+	if mode == sessiondatapb.VectorizeExperimentalAlways && core.JoinReader == nil &&
+		core.AdaptiveJoiner == nil && core.LocalPlanNode == nil {
 		return errExperimentalWrappingProhibited
 	}
 	switch {
@@ -315,6 +320,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	case core.StreamIngestionData != nil:
 	case core.StreamIngestionFrontier != nil:
 	case core.HashGroupJoiner != nil:
+	case core.AdaptiveJoiner != nil:
 	default:
 		return errors.AssertionFailedf("unexpected processor core %q", core)
 	}
@@ -1189,6 +1195,13 @@ func NewColOperator(
 					core.HashJoiner,
 					factory,
 				)
+				if spec.RuntimeFilterProducer != nil {
+					producer := colexecjoin.NewRuntimeFilterProducer(
+						getStreamingAllocator(ctx, args), spec.RuntimeFilterProducer, core.HashJoiner.RightEqColumns,
+					)
+					hjArgs.RuntimeFilterProducer = producer
+					result.RuntimeFilterProducer = producer
+				}
 				inMemoryHashJoiner := colexecjoin.NewHashJoiner(hjArgs)
 				if args.TestingKnobs.DiskSpillingDisabled {
 					// We will not be creating a disk-backed hash joiner because
@@ -1789,6 +1802,13 @@ func NewColOperator(
 	}
 
 	takeOverMetaInfo(&result.OpWithMetaInfo, inputs)
+	if result.RuntimeFilterProducer != nil {
+		// The runtime filter producer must be drained before the inputs since
+		// the left input might be blocked waiting for the filter.
+		result.MetadataSources = append(
+			colexecop.MetadataSources{result.RuntimeFilterProducer}, result.MetadataSources...,
+		)
+	}
 	if buildutil.CrdbTestBuild {
 		// Plan an invariants checker if it isn't already the root of the
 		// tree.
@@ -106,6 +106,9 @@ type NewColOperatorResult struct {
 	// behind the stats collector interface. We need to track it separately from
 	// all other stats collectors since it requires special handling.
 	Columnarizer colexecop.VectorizedStatsCollector
+	// RuntimeFilterProducer, if set, provides the runtime filter built by the
+	// hash joiner (see execinfrapb.RuntimeFilterProducerSpec).
+	RuntimeFilterProducer colexecop.RuntimeFilterProducer
 	// TODO(yuzefovich): consider keeping the reference to the types in Release.
 	// This will also require changes to the planning code to actually reuse the
 	// slice if possible. This is not exactly trivial since there is no clear
@@ -250,6 +250,10 @@ type hashJoiner struct {
 		rightExported      int
 		rightWindowedBatch coldata.Batch
 	}
+
+	// runtimeFilterProducer, if set, builds the runtime filter from the hash
+	// table once it has been built.
+	runtimeFilterProducer *RuntimeFilterProducer
 }
 
 var _ colexecop.BufferingInMemoryOperator = &hashJoiner{}
@@ -336,6 +340,9 @@ func (hj *hashJoiner) Next() coldata.Batch {
 
 func (hj *hashJoiner) build() {
 	hj.ht.FullBuild(hj.InputTwo)
+	if hj.runtimeFilterProducer != nil {
+		hj.runtimeFilterProducer.build(hj.ht.Vals)
+	}
 
 	// At this point, we have fully built the hash table on the right side
 	// (meaning we have fully consumed the right input), so it'd be a shame to
@@ -703,6 +710,12 @@ func (hj *hashJoiner) congregate(nResults int, batch coldata.Batch) {
 }
 
 func (hj *hashJoiner) ExportBuffered(input colexecop.Operator) coldata.Batch {
+	if hj.runtimeFilterProducer != nil {
+		// We're spilling to disk, so the runtime filter won't be built, yet
+		// the disk-backed hash joiner will consume the left input which might
+		// be waiting for the filter.
+		hj.runtimeFilterProducer.publish(nil /* filter */)
+	}
 	if hj.InputOne == input {
 		// We do not buffer anything from the left source. Furthermore, the memory
 		// limit can only hit during the building of the hash table step at which
@@ -863,6 +876,9 @@ type NewHashJoinerArgs struct {
 	LeftSource               colexecop.Operator
 	RightSource              colexecop.Operator
 	InitialNumBuckets        uint32
+	// RuntimeFilterProducer, if set, is used to build a runtime filter from
+	// the right input.
+	RuntimeFilterProducer *RuntimeFilterProducer
 }
 
 // NewHashJoiner creates a new equality hash join operator on the left and
@@ -879,5 +895,6 @@ func NewHashJoiner(args NewHashJoinerArgs) colexecop.ResettableOperator {
 		spec:                       args.Spec,
 		outputTypes:                args.Spec.JoinType.MakeOutputTypes(args.Spec.Left.SourceTypes, args.Spec.Right.SourceTypes),
 		hashTableInitialNumBuckets: args.InitialNumBuckets,
+		runtimeFilterProducer:      args.RuntimeFilterProducer,
 	}
 }
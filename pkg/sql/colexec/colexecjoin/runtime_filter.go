@@ -0,0 +1,397 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// maxRuntimeFilterNumHashes is the maximum number of hash functions used by
+// the bloom filters of runtime filters.
+const maxRuntimeFilterNumHashes = 8
+
+// RuntimeFilterProducer builds a runtime filter from the equality columns of
+// the right (build) input of a hash joiner. The filter is published once the
+// hash table has been built, and the consumers of the filter (one for each
+// processor producing the left input of the joiner) block until then.
+//
+// If the hash joiner spills to disk or is drained before the hash table has
+// been built, it publishes no filter instead.
+type RuntimeFilterProducer struct {
+	allocator *colmem.Allocator
+	spec      *execinfrapb.RuntimeFilterProducerSpec
+	eqCols    []int
+
+	once   sync.Once
+	done   chan struct{}
+	filter *execinfrapb.RuntimeFilter
+}
+
+var _ colexecop.RuntimeFilterProducer = &RuntimeFilterProducer{}
+
+// NewRuntimeFilterProducer returns a new RuntimeFilterProducer for the hash
+// joiner with the given right equality columns. The allocator is used to
+// account for the memory used by the filter.
+func NewRuntimeFilterProducer(
+	allocator *colmem.Allocator, spec *execinfrapb.RuntimeFilterProducerSpec, rightEqCols []uint32,
+) *RuntimeFilterProducer {
+	eqCols := make([]int, len(rightEqCols))
+	for i := range rightEqCols {
+		eqCols[i] = int(rightEqCols[i])
+	}
+	return &RuntimeFilterProducer{
+		allocator: allocator,
+		spec:      spec,
+		eqCols:    eqCols,
+		done:      make(chan struct{}),
+	}
+}
+
+// Init implements the colexecop.RuntimeFilterSource interface.
+func (p *RuntimeFilterProducer) Init(context.Context) {}
+
+// GetRuntimeFilter implements the colexecop.RuntimeFilterSource interface.
+func (p *RuntimeFilterProducer) GetRuntimeFilter(
+	ctx context.Context,
+) *execinfrapb.RuntimeFilter {
+	select {
+	case <-p.done:
+		return p.filter
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// DrainMeta implements the colexecop.MetadataSource interface.
+func (p *RuntimeFilterProducer) DrainMeta() []execinfrapb.ProducerMetadata {
+	// The hash joiner might have been drained before building the hash table,
+	// so we need to unblock the consumers.
+	p.publish(nil /* filter */)
+	return nil
+}
+
+// publish makes the filter available to the consumers. Only the first call
+// has an effect.
+func (p *RuntimeFilterProducer) publish(filter *execinfrapb.RuntimeFilter) {
+	p.once.Do(func() {
+		p.filter = filter
+		close(p.done)
+	})
+}
+
+// build builds the filter from the tuples of the hash table of the hash joiner
+// and publishes it.
+func (p *RuntimeFilterProducer) build(vals coldata.Batch) {
+	n := vals.Length()
+	if uint64(n) > p.spec.MaxKeys {
+		// The filter is unlikely to be selective.
+		p.publish(nil /* filter */)
+		return
+	}
+	filter := &execinfrapb.RuntimeFilter{Empty: true}
+	if p.spec.BloomFilterBits > 0 {
+		filter.Bloom = make([]byte, (p.spec.BloomFilterBits+7)/8)
+		filter.NumHashes = runtimeFilterNumHashes(len(filter.Bloom)*8, n)
+		p.allocator.AdjustMemoryUsage(int64(len(filter.Bloom)))
+	}
+	if n > 0 {
+		converter := colconv.NewVecToDatumConverter(len(vals.ColVecs()), p.eqCols, true /* willRelease */)
+		defer converter.Release()
+		converter.ConvertBatch(vals)
+		hasher := fnv.New64a()
+		var key []byte
+		colKeyEnds := make([]int, len(p.eqCols))
+	ROWS:
+		for row := 0; row < n; row++ {
+			key = key[:0]
+			for i, col := range p.eqCols {
+				d := converter.GetDatumColumn(col)[row]
+				if d == tree.DNull {
+					// NULL keys never match.
+					continue ROWS
+				}
+				var err error
+				key, err = keyside.Encode(key, d, encoding.Ascending)
+				if err != nil {
+					colexecerror.InternalError(err)
+				}
+				colKeyEnds[i] = len(key)
+			}
+			start := 0
+			for i, end := range colKeyEnds {
+				colKey := key[start:end]
+				if filter.Empty {
+					filter.MinKey = append(filter.MinKey, append([]byte(nil), colKey...))
+					filter.MaxKey = append(filter.MaxKey, append([]byte(nil), colKey...))
+				} else if bytes.Compare(colKey, filter.MinKey[i]) < 0 {
+					filter.MinKey[i] = append(filter.MinKey[i][:0], colKey...)
+				} else if bytes.Compare(colKey, filter.MaxKey[i]) > 0 {
+					filter.MaxKey[i] = append(filter.MaxKey[i][:0], colKey...)
+				}
+				start = end
+			}
+			filter.Empty = false
+			if len(filter.Bloom) > 0 {
+				runtimeFilterBloomAdd(filter, runtimeFilterHash(hasher, key))
+			}
+		}
+	}
+	p.publish(filter)
+}
+
+// runtimeFilterNumHashes returns the number of hash functions that minimizes
+// the false positive rate of a bloom filter of the given size with the given
+// number of keys.
+func runtimeFilterNumHashes(numBits int, numKeys int) uint32 {
+	if numKeys == 0 {
+		return 1
+	}
+	k := math.Round(float64(numBits) / float64(numKeys) * math.Ln2)
+	if k < 1 {
+		return 1
+	} else if k > maxRuntimeFilterNumHashes {
+		return maxRuntimeFilterNumHashes
+	}
+	return uint32(k)
+}
+
+func runtimeFilterHash(hasher hash.Hash64, key []byte) uint64 {
+	hasher.Reset()
+	_, _ = hasher.Write(key)
+	return hasher.Sum64()
+}
+
+// runtimeFilterBloomBit returns the position of the bit set by the i-th hash
+// function of the bloom filter. The hash functions are derived from a single
+// 64-bit hash using double hashing.
+func runtimeFilterBloomBit(h uint64, i uint32, numBits uint64) (byteIdx int, mask byte) {
+	h1, h2 := h&math.MaxUint32, (h>>32)|1
+	bit := (h1 + uint64(i)*h2) % numBits
+	return int(bit / 8), 1 << (bit % 8)
+}
+
+func runtimeFilterBloomAdd(filter *execinfrapb.RuntimeFilter, h uint64) {
+	numBits := uint64(len(filter.Bloom)) * 8
+	for i := uint32(0); i < filter.NumHashes; i++ {
+		byteIdx, mask := runtimeFilterBloomBit(h, i, numBits)
+		filter.Bloom[byteIdx] |= mask
+	}
+}
+
+func runtimeFilterBloomMayContain(filter *execinfrapb.RuntimeFilter, h uint64) bool {
+	numBits := uint64(len(filter.Bloom)) * 8
+	for i := uint32(0); i < filter.NumHashes; i++ {
+		byteIdx, mask := runtimeFilterBloomBit(h, i, numBits)
+		if filter.Bloom[byteIdx]&mask == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// runtimeFilterOp is an operator that discards the rows of its input whose
+// key columns cannot match any row of the right inputs of the hash joiners
+// that produced the runtime filters.
+type runtimeFilterOp struct {
+	colexecop.OneInputHelper
+
+	keyCols []int
+	sources []colexecop.RuntimeFilterSource
+
+	// filtersReceived is set once the filters have been received from all
+	// sources.
+	filtersReceived bool
+	// filters contains the non-empty filters received from the sources. It is
+	// only used if passThrough is false.
+	filters []*execinfrapb.RuntimeFilter
+	// passThrough is set if at least one source didn't build a filter, in
+	// which case every row is emitted.
+	passThrough bool
+
+	converter *colconv.VecToDatumConverter
+	hasher    hash.Hash64
+	key       []byte
+	// colKeyEnds contains the end offsets of the keys of the columns in key.
+	colKeyEnds []int
+
+	numRead, numDiscarded int
+}
+
+var _ colexecop.ClosableOperator = &runtimeFilterOp{}
+
+// NewRuntimeFilterOp returns an operator that applies the runtime filters
+// provided by sources to the rows of input with the given types. A row is
+// discarded if, according to every filter, the values of its keyCols cannot
+// match any row of the right input of the hash joiner that built the filter.
+func NewRuntimeFilterOp(
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	keyCols []uint32,
+	sources []colexecop.RuntimeFilterSource,
+) colexecop.Operator {
+	cols := make([]int, len(keyCols))
+	for i := range keyCols {
+		cols[i] = int(keyCols[i])
+	}
+	return &runtimeFilterOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		keyCols:        cols,
+		sources:        sources,
+		converter:      colconv.NewVecToDatumConverter(len(inputTypes), cols, true /* willRelease */),
+		hasher:         fnv.New64a(),
+		colKeyEnds:     make([]int, len(cols)),
+	}
+}
+
+// Init implements the colexecop.Operator interface.
+func (r *runtimeFilterOp) Init(ctx context.Context) {
+	if !r.InitHelper.Init(ctx) {
+		return
+	}
+	r.Input.Init(r.Ctx)
+	for _, source := range r.sources {
+		source.Init(r.Ctx)
+	}
+}
+
+func (r *runtimeFilterOp) receiveFilters() {
+	r.filtersReceived = true
+	for _, source := range r.sources {
+		filter := source.GetRuntimeFilter(r.Ctx)
+		if filter == nil {
+			r.passThrough = true
+		} else if !filter.Empty {
+			r.filters = append(r.filters, filter)
+		}
+	}
+	log.VEventf(
+		r.Ctx, 2, "received %d runtime filters (%d non-empty), pass-through: %t",
+		len(r.sources), len(r.filters), r.passThrough,
+	)
+}
+
+// Next implements the colexecop.Operator interface.
+func (r *runtimeFilterOp) Next() coldata.Batch {
+	if !r.filtersReceived {
+		r.receiveFilters()
+	}
+	if r.passThrough {
+		return r.Input.Next()
+	}
+	if len(r.filters) == 0 {
+		// The right inputs of all hash joiners were empty, so no row can have
+		// a match.
+		return coldata.ZeroBatch
+	}
+	for {
+		batch := r.Input.Next()
+		n := batch.Length()
+		if n == 0 {
+			return batch
+		}
+		r.numRead += n
+		r.converter.ConvertBatch(batch)
+		idx := 0
+		if sel := batch.Selection(); sel != nil {
+			sel = sel[:n]
+			for _, i := range sel {
+				if r.mayMatch(i) {
+					sel[idx] = i
+					idx++
+				}
+			}
+		} else {
+			batch.SetSelection(true)
+			sel := batch.Selection()
+			for i := 0; i < n; i++ {
+				if r.mayMatch(i) {
+					sel[idx] = i
+					idx++
+				}
+			}
+		}
+		r.numDiscarded += n - idx
+		if idx > 0 {
+			batch.SetLength(idx)
+			return batch
+		}
+	}
+}
+
+// mayMatch returns whether the row at position rowIdx of the last converted
+// batch might have a match according to any of the filters.
+func (r *runtimeFilterOp) mayMatch(rowIdx int) bool {
+	r.key = r.key[:0]
+	for i, col := range r.keyCols {
+		d := r.converter.GetDatumColumn(col)[rowIdx]
+		if d == tree.DNull {
+			// NULL keys never match.
+			return false
+		}
+		var err error
+		r.key, err = keyside.Encode(r.key, d, encoding.Ascending)
+		if err != nil {
+			colexecerror.InternalError(err)
+		}
+		r.colKeyEnds[i] = len(r.key)
+	}
+	hashComputed := false
+	var h uint64
+FILTERS:
+	for _, filter := range r.filters {
+		start := 0
+		for i, end := range r.colKeyEnds {
+			colKey := r.key[start:end]
+			if bytes.Compare(colKey, filter.MinKey[i]) < 0 || bytes.Compare(colKey, filter.MaxKey[i]) > 0 {
+				continue FILTERS
+			}
+			start = end
+		}
+		if len(filter.Bloom) == 0 {
+			return true
+		}
+		if !hashComputed {
+			h = runtimeFilterHash(r.hasher, r.key)
+			hashComputed = true
+		}
+		if runtimeFilterBloomMayContain(filter, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close implements the colexecop.Closer interface.
+func (r *runtimeFilterOp) Close(ctx context.Context) error {
+	if r.converter != nil {
+		log.VEventf(ctx, 2, "runtime filters discarded %d out of %d rows", r.numDiscarded, r.numRead)
+		r.converter.Release()
+		r.converter = nil
+	}
+	return nil
+}
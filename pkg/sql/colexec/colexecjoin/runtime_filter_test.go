@@ -0,0 +1,112 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// makeTestRuntimeFilterProducer returns a RuntimeFilterProducer which built
+// the filter from the given INT keys of the build side.
+func makeTestRuntimeFilterProducer(
+	bloomFilterBits uint32, maxKeys uint64, keys []interface{},
+) *RuntimeFilterProducer {
+	p := NewRuntimeFilterProducer(
+		testAllocator,
+		&execinfrapb.RuntimeFilterProducerSpec{BloomFilterBits: bloomFilterBits, MaxKeys: maxKeys},
+		[]uint32{0}, /* rightEqCols */
+	)
+	batch := testAllocator.NewMemBatchWithFixedCapacity([]*types.T{types.Int}, len(keys))
+	vec := batch.ColVec(0)
+	for i, key := range keys {
+		if key == nil {
+			vec.Nulls().SetNull(i)
+		} else {
+			vec.Int64()[i] = int64(key.(int))
+		}
+	}
+	batch.SetLength(len(keys))
+	p.build(batch)
+	return p
+}
+
+func TestRuntimeFilterOp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	input := colexectestutils.Tuples{{1}, {3}, {5}, {nil}, {7}, {10}, {12}}
+	for _, tc := range []struct {
+		name      string
+		producers []*RuntimeFilterProducer
+		expected  colexectestutils.Tuples
+	}{
+		{
+			name: "min/max",
+			producers: []*RuntimeFilterProducer{
+				makeTestRuntimeFilterProducer(0 /* bloomFilterBits */, 100, []interface{}{3, 7, nil, 10}),
+			},
+			expected: colexectestutils.Tuples{{3}, {5}, {7}, {10}},
+		},
+		{
+			name: "bloom",
+			producers: []*RuntimeFilterProducer{
+				makeTestRuntimeFilterProducer(1<<16 /* bloomFilterBits */, 100, []interface{}{3, 7, nil, 10}),
+			},
+			expected: colexectestutils.Tuples{{3}, {7}, {10}},
+		},
+		{
+			name: "too many keys",
+			producers: []*RuntimeFilterProducer{
+				makeTestRuntimeFilterProducer(1<<16 /* bloomFilterBits */, 2, []interface{}{3, 7, nil, 10}),
+			},
+			expected: input,
+		},
+		{
+			name: "empty",
+			producers: []*RuntimeFilterProducer{
+				makeTestRuntimeFilterProducer(1<<16 /* bloomFilterBits */, 100, []interface{}{nil}),
+			},
+			expected: colexectestutils.Tuples{},
+		},
+		{
+			name: "union",
+			producers: []*RuntimeFilterProducer{
+				makeTestRuntimeFilterProducer(1<<16 /* bloomFilterBits */, 100, []interface{}{3}),
+				makeTestRuntimeFilterProducer(1<<16 /* bloomFilterBits */, 100, []interface{}{10}),
+				makeTestRuntimeFilterProducer(1<<16 /* bloomFilterBits */, 100, []interface{}{}),
+			},
+			expected: colexectestutils.Tuples{{3}, {10}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sources := make([]colexecop.RuntimeFilterSource, len(tc.producers))
+			for i := range tc.producers {
+				sources[i] = tc.producers[i]
+			}
+			colexectestutils.RunTestsWithTyps(
+				t, testAllocator, []colexectestutils.Tuples{input}, [][]*types.T{{types.Int}},
+				tc.expected, colexectestutils.OrderedVerifier,
+				func(inputs []colexecop.Operator) (colexecop.Operator, error) {
+					return NewRuntimeFilterOp(
+						inputs[0], []*types.T{types.Int}, []uint32{0} /* keyCols */, sources,
+					), nil
+				},
+			)
+		})
+	}
+}
@@ -471,6 +471,27 @@ func (s MetadataSources) DrainMeta() []execinfrapb.ProducerMetadata {
 	return result
 }
 
+// RuntimeFilterSource provides a runtime filter built by a hash joiner (see
+// execinfrapb.RuntimeFilterProducerSpec).
+type RuntimeFilterSource interface {
+	// Init initializes the source. It must be called by every consumer of the
+	// source before GetRuntimeFilter.
+	Init(ctx context.Context)
+	// GetRuntimeFilter blocks until the runtime filter is available and
+	// returns it. nil is returned if the hash joiner didn't build a filter, in
+	// which case no rows can be discarded.
+	GetRuntimeFilter(ctx context.Context) *execinfrapb.RuntimeFilter
+}
+
+// RuntimeFilterProducer is a RuntimeFilterSource which is also a
+// MetadataSource. It must be drained before the inputs of the hash joiner
+// building the filter so that the consumers waiting for the filter are
+// unblocked even if the hash joiner didn't finish building it.
+type RuntimeFilterProducer interface {
+	RuntimeFilterSource
+	MetadataSource
+}
+
 // VectorizedStatsCollector is the common interface implemented by several
 // variations of the execution statistics collectors. At the moment of writing
 // we have two variants: the "default" option (for all Operators) and the
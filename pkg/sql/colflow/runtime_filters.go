@@ -0,0 +1,254 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colflow
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecargs"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecjoin"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colflow/colrpc"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// runtimeFilterTypes are the types of the batches used to send runtime filters
+// over the network: a single batch with a single row containing the marshaled
+// execinfrapb.RuntimeFilter is sent if the hash joiner built a filter, and no
+// batches are sent otherwise.
+var runtimeFilterTypes = []*types.T{types.Bytes}
+
+// localRuntimeFilterStream is a runtime filter stream between a hash joiner and
+// a consumer of its filter that are in the same flow. Either of the two might
+// be set up first, so the source is only populated when the hash joiner is set
+// up.
+type localRuntimeFilterStream struct {
+	source colexecop.RuntimeFilterSource
+}
+
+var _ colexecop.RuntimeFilterSource = &localRuntimeFilterStream{}
+
+// Init implements the colexecop.RuntimeFilterSource interface.
+func (s *localRuntimeFilterStream) Init(ctx context.Context) {
+	s.source.Init(ctx)
+}
+
+// GetRuntimeFilter implements the colexecop.RuntimeFilterSource interface.
+func (s *localRuntimeFilterStream) GetRuntimeFilter(
+	ctx context.Context,
+) *execinfrapb.RuntimeFilter {
+	return s.source.GetRuntimeFilter(ctx)
+}
+
+// noRuntimeFilter is a colexecop.RuntimeFilterSource that never provides a
+// filter. It is used when the hash joiner wasn't planned in a way that allows
+// building the filter.
+type noRuntimeFilter struct{}
+
+var _ colexecop.RuntimeFilterSource = noRuntimeFilter{}
+
+// Init implements the colexecop.RuntimeFilterSource interface.
+func (noRuntimeFilter) Init(context.Context) {}
+
+// GetRuntimeFilter implements the colexecop.RuntimeFilterSource interface.
+func (noRuntimeFilter) GetRuntimeFilter(context.Context) *execinfrapb.RuntimeFilter {
+	return nil
+}
+
+// inboxRuntimeFilterSource is a colexecop.RuntimeFilterSource that receives
+// the runtime filter from a remote hash joiner.
+type inboxRuntimeFilterSource struct {
+	inbox *colrpc.Inbox
+}
+
+var _ colexecop.RuntimeFilterSource = &inboxRuntimeFilterSource{}
+
+// Init implements the colexecop.RuntimeFilterSource interface.
+func (s *inboxRuntimeFilterSource) Init(ctx context.Context) {
+	s.inbox.Init(ctx)
+}
+
+// GetRuntimeFilter implements the colexecop.RuntimeFilterSource interface.
+func (s *inboxRuntimeFilterSource) GetRuntimeFilter(
+	context.Context,
+) *execinfrapb.RuntimeFilter {
+	batch := s.inbox.Next()
+	if batch.Length() == 0 {
+		return nil
+	}
+	var filter execinfrapb.RuntimeFilter
+	if err := protoutil.Unmarshal(batch.ColVec(0).Bytes().Get(0), &filter); err != nil {
+		colexecerror.InternalError(errors.Wrap(err, "unmarshaling runtime filter"))
+	}
+	return &filter
+}
+
+// runtimeFilterSender is an operator that emits the runtime filter provided
+// by the source so that it can be sent over the network by an outbox.
+type runtimeFilterSender struct {
+	colexecop.ZeroInputNode
+	colexecop.InitHelper
+
+	allocator *colmem.Allocator
+	source    colexecop.RuntimeFilterSource
+	done      bool
+}
+
+var _ colexecop.Operator = &runtimeFilterSender{}
+
+// Init implements the colexecop.Operator interface.
+func (s *runtimeFilterSender) Init(ctx context.Context) {
+	if !s.InitHelper.Init(ctx) {
+		return
+	}
+	s.source.Init(s.Ctx)
+}
+
+// Next implements the colexecop.Operator interface.
+func (s *runtimeFilterSender) Next() coldata.Batch {
+	if s.done {
+		return coldata.ZeroBatch
+	}
+	s.done = true
+	filter := s.source.GetRuntimeFilter(s.Ctx)
+	if filter == nil {
+		return coldata.ZeroBatch
+	}
+	data, err := protoutil.Marshal(filter)
+	if err != nil {
+		colexecerror.InternalError(errors.Wrap(err, "marshaling runtime filter"))
+	}
+	batch := s.allocator.NewMemBatchWithFixedCapacity(runtimeFilterTypes, 1 /* capacity */)
+	s.allocator.PerformOperation(batch.ColVecs(), func() {
+		batch.ColVec(0).Bytes().Set(0, data)
+	})
+	batch.SetLength(1)
+	return batch
+}
+
+// getLocalRuntimeFilterStream returns the localRuntimeFilterStream for the
+// given stream, creating it if necessary.
+func (s *vectorizedFlowCreator) getLocalRuntimeFilterStream(
+	streamID execinfrapb.StreamID,
+) *localRuntimeFilterStream {
+	stream, ok := s.runtimeFilterStreams[streamID]
+	if !ok {
+		stream = &localRuntimeFilterStream{}
+		s.runtimeFilterStreams[streamID] = stream
+	}
+	return stream
+}
+
+// setupRuntimeFilterProducer sends the runtime filter provided by producer
+// (which might be nil if the hash joiner couldn't build a filter) to all
+// consumers according to pspec.RuntimeFilterProducer.
+func (s *vectorizedFlowCreator) setupRuntimeFilterProducer(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	pspec *execinfrapb.ProcessorSpec,
+	producer colexecop.RuntimeFilterProducer,
+	factory coldata.ColumnFactory,
+) error {
+	var source colexecop.RuntimeFilterSource = noRuntimeFilter{}
+	if producer != nil {
+		source = producer
+	}
+	for i := range pspec.RuntimeFilterProducer.Streams {
+		stream := &pspec.RuntimeFilterProducer.Streams[i]
+		switch stream.Type {
+		case execinfrapb.StreamEndpointSpec_LOCAL:
+			s.getLocalRuntimeFilterStream(stream.StreamID).source = source
+		case execinfrapb.StreamEndpointSpec_REMOTE:
+			sender := &runtimeFilterSender{
+				allocator: colmem.NewAllocator(ctx, s.monitorRegistry.NewStreamingMemAccount(flowCtx), factory),
+				source:    source,
+			}
+			outbox, err := s.setupRemoteOutputStream(
+				ctx, flowCtx, pspec.ProcessorID, colexecargs.OpWithMetaInfo{Root: sender},
+				runtimeFilterTypes, stream, factory, nil, /* getStats */
+			)
+			if err != nil {
+				return err
+			}
+			s.opChains = append(s.opChains, outbox)
+		default:
+			return errors.Errorf("unsupported runtime filter stream type %s", stream.Type)
+		}
+	}
+	return nil
+}
+
+// setupRuntimeFilterConsumer wraps the output of the processor with an
+// operator applying the runtime filters received according to
+// pspec.RuntimeFilterConsumer.
+func (s *vectorizedFlowCreator) setupRuntimeFilterConsumer(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	pspec *execinfrapb.ProcessorSpec,
+	opWithMetaInfo *colexecargs.OpWithMetaInfo,
+	opOutputTypes []*types.T,
+	factory coldata.ColumnFactory,
+) error {
+	spec := pspec.RuntimeFilterConsumer
+	sources := make([]colexecop.RuntimeFilterSource, len(spec.Streams))
+	for i := range spec.Streams {
+		stream := &spec.Streams[i]
+		switch stream.Type {
+		case execinfrapb.StreamEndpointSpec_LOCAL:
+			sources[i] = s.getLocalRuntimeFilterStream(stream.StreamID)
+		case execinfrapb.StreamEndpointSpec_REMOTE:
+			if err := s.f.CheckInboundStreamID(stream.StreamID); err != nil {
+				return err
+			}
+			inbox, err := s.remoteComponentCreator.newInbox(
+				colmem.NewAllocator(ctx, s.monitorRegistry.NewStreamingMemAccount(flowCtx), factory),
+				runtimeFilterTypes,
+				stream.StreamID,
+				s.f.GetCtxDone(),
+				admissionOptions{},
+			)
+			if err != nil {
+				return err
+			}
+			s.f.AddRemoteStream(stream.StreamID, flowinfra.NewInboundStreamInfo(
+				vectorizedInboundStreamHandler{inbox},
+				s.f.GetWaitGroup(),
+			))
+			sources[i] = &inboxRuntimeFilterSource{inbox: inbox}
+			opWithMetaInfo.MetadataSources = append(opWithMetaInfo.MetadataSources, inbox)
+		default:
+			return errors.Errorf("unsupported runtime filter stream type %s", stream.Type)
+		}
+	}
+	op := colexecjoin.NewRuntimeFilterOp(opWithMetaInfo.Root, opOutputTypes, spec.KeyColumns, sources)
+	s.closers = append(s.closers, op.(colexecop.Closer))
+	opWithMetaInfo.Root = op
+	return nil
+}
+
+// checkRuntimeFilterStreams verifies that all local runtime filter streams
+// have been connected to their producers.
+func (s *vectorizedFlowCreator) checkRuntimeFilterStreams() error {
+	for streamID, stream := range s.runtimeFilterStreams {
+		if stream.source == nil {
+			return errors.AssertionFailedf("runtime filter stream %d has no producer", streamID)
+		}
+	}
+	return nil
+}
@@ -577,6 +577,9 @@ type vectorizedFlowCreator struct {
 	streamIDToSpecIdx map[execinfrapb.StreamID]int
 	exprHelper        *colexecargs.ExprHelper
 	typeResolver      descs.DistSQLTypeResolver
+	// runtimeFilterStreams contains the runtime filter streams between the
+	// processors of this flow.
+	runtimeFilterStreams map[execinfrapb.StreamID]*localRuntimeFilterStream
 
 	// numOutboxes counts how many colrpc.Outbox'es have been set up on this
 	// node. Note that unlike numOutboxesDrained, numOutboxes doesn't need to be
@@ -621,6 +624,8 @@ var vectorizedFlowCreatorPool = sync.Pool{
 			streamIDToInputOp: make(map[execinfrapb.StreamID]colexecargs.OpWithMetaInfo),
 			streamIDToSpecIdx: make(map[execinfrapb.StreamID]int),
 			exprHelper:        colexecargs.NewExprHelper(),
+
+			runtimeFilterStreams: make(map[execinfrapb.StreamID]*localRuntimeFilterStream),
 		}
 	},
 }
@@ -650,6 +655,8 @@ func newVectorizedFlowCreator(
 		monitorRegistry:   creator.monitorRegistry,
 		diskQueueCfg:      diskQueueCfg,
 		fdSemaphore:       fdSemaphore,
+
+		runtimeFilterStreams: creator.runtimeFilterStreams,
 	}
 	if componentCreator == nil {
 		// On the main code path, use the embedded component creator.
@@ -681,6 +688,9 @@ func (s *vectorizedFlowCreator) Release() {
 	for k := range s.streamIDToSpecIdx {
 		delete(s.streamIDToSpecIdx, k)
 	}
+	for k := range s.runtimeFilterStreams {
+		delete(s.runtimeFilterStreams, k)
+	}
 	for _, r := range s.releasables {
 		r.Release()
 	}
@@ -711,6 +721,8 @@ func (s *vectorizedFlowCreator) Release() {
 		closers:         s.closers[:0],
 		releasables:     s.releasables[:0],
 		monitorRegistry: s.monitorRegistry,
+
+		runtimeFilterStreams: s.runtimeFilterStreams,
 	}
 	vectorizedFlowCreatorPool.Put(s)
 }
@@ -1217,12 +1229,28 @@ func (s *vectorizedFlowCreator) setupFlow(
 				}
 			}
 
+			if pspec.RuntimeFilterConsumer != nil {
+				if err = s.setupRuntimeFilterConsumer(
+					ctx, flowCtx, pspec, &result.OpWithMetaInfo, result.ColumnTypes, factory,
+				); err != nil {
+					return
+				}
+			}
+
 			if err = s.setupOutput(
 				ctx, flowCtx, pspec, result.OpWithMetaInfo, result.ColumnTypes, factory,
 			); err != nil {
 				return
 			}
 
+			if pspec.RuntimeFilterProducer != nil {
+				if err = s.setupRuntimeFilterProducer(
+					ctx, flowCtx, pspec, result.RuntimeFilterProducer, factory,
+				); err != nil {
+					return
+				}
+			}
+
 			// Now queue all outputs from this op whose inputs are already all
 			// populated.
 		NEXTOUTPUT:
@@ -1259,6 +1287,7 @@ func (s *vectorizedFlowCreator) setupFlow(
 				}
 			}
 		}
+		err = s.checkRuntimeFilterStreams()
 	}); vecErr != nil {
 		return s.opChains, s.batchFlowCoordinator, vecErr
 	}
@@ -0,0 +1,372 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/columnencryption"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// columnEncryptionKMSEnv is the environment in which the KMS wrapping the
+// data keys of the encrypted columns is used.
+type columnEncryptionKMSEnv struct {
+	execCfg *ExecutorConfig
+	user    username.SQLUsername
+}
+
+var _ cloud.KMSEnv = &columnEncryptionKMSEnv{}
+
+// ClusterSettings implements the cloud.KMSEnv interface.
+func (e *columnEncryptionKMSEnv) ClusterSettings() *cluster.Settings {
+	return e.execCfg.Settings
+}
+
+// KMSConfig implements the cloud.KMSEnv interface.
+func (e *columnEncryptionKMSEnv) KMSConfig() *base.ExternalIODirConfig {
+	return &e.execCfg.ExternalIODirConfig
+}
+
+// DBHandle implements the cloud.KMSEnv interface.
+func (e *columnEncryptionKMSEnv) DBHandle() isql.DB {
+	return e.execCfg.InternalDB
+}
+
+// User implements the cloud.KMSEnv interface.
+func (e *columnEncryptionKMSEnv) User() username.SQLUsername {
+	return e.user
+}
+
+// columnEncryptionKeyCache returns the cache of the unwrapped data keys.
+func (cfg *ExecutorConfig) columnEncryptionKeyCache() *columnencryption.KeyCache {
+	if cfg.ColumnEncryptionKeyCache == nil {
+		// Some tests build an ExecutorConfig without a cache; the keys are
+		// then unwrapped on every use.
+		return columnencryption.NewKeyCache()
+	}
+	return cfg.ColumnEncryptionKeyCache
+}
+
+// checkColumnEncryptionKMSAccess checks that the current user may use the
+// KMS identified by kmsURI. As for BACKUP, only admins and the users with
+// the EXTERNALIOIMPLICITACCESS privilege may use implicit credentials.
+func (p *planner) checkColumnEncryptionKMSAccess(ctx context.Context, kmsURI string) error {
+	u, err := url.ParseRequestURI(kmsURI)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid encryption key URI")
+	}
+	if u.Query().Get(cloud.AuthParam) != cloud.AuthParamImplicit ||
+		p.ExecCfg().ExternalIODirConfig.EnableNonAdminImplicitAndArbitraryOutbound {
+		return nil
+	}
+	if isAdmin, err := p.HasAdminRole(ctx); err != nil || isAdmin {
+		return err
+	}
+	if err := p.CheckPrivilege(
+		ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.EXTERNALIOIMPLICITACCESS,
+	); err != nil {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role or the EXTERNALIOIMPLICITACCESS system privilege "+
+				"are allowed to use an encryption key with implicit credentials")
+	}
+	return nil
+}
+
+// newColumnEncryptionKey generates a data key, wraps it with the KMS
+// identified by kmsURI and adds it to the table.
+func (p *planner) newColumnEncryptionKey(
+	ctx context.Context, desc *tabledesc.Mutable, kmsURI string,
+) (descpb.ColumnEncryptionKeyID, error) {
+	if err := p.checkColumnEncryptionKMSAccess(ctx, kmsURI); err != nil {
+		return 0, err
+	}
+	key, err := columnencryption.GenerateDataKey()
+	if err != nil {
+		return 0, err
+	}
+	env := &columnEncryptionKMSEnv{execCfg: p.ExecCfg(), user: p.User()}
+	wrapped, err := columnencryption.WrapDataKey(ctx, kmsURI, env, key)
+	if err != nil {
+		return 0, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+			"failed to wrap encryption key with %s", redactColumnEncryptionKMSURI(kmsURI))
+	}
+	return desc.AddColumnEncryptionKey(kmsURI, wrapped), nil
+}
+
+// setupColumnEncryption sets up the encryption of a column declared with
+// ENCRYPTED WITH KEY. The columns of a table which use the same KMS URI
+// share the current data key for that URI.
+func (p *planner) setupColumnEncryption(
+	ctx context.Context, desc *tabledesc.Mutable, col *descpb.ColumnDescriptor, kmsURI string,
+) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_ColumnEncryption) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"encrypted columns require the cluster to be upgraded to version %s",
+			clusterversion.V23_2_ColumnEncryption)
+	}
+	// The ciphertexts are longer than the values, so the width of the
+	// column cannot be limited.
+	if !col.Type.Identical(types.String) && !col.Type.Identical(types.Bytes) {
+		return pgerror.Newf(pgcode.InvalidTableDefinition,
+			"encrypted column %q must be of type STRING or BYTES, not %s",
+			col.Name, col.Type.SQLString())
+	}
+	var keyID descpb.ColumnEncryptionKeyID
+	for _, k := range desc.ColumnEncryptionKeys {
+		if k.KMSURI == kmsURI && k.ID > keyID {
+			keyID = k.ID
+		}
+	}
+	if keyID == 0 {
+		var err error
+		if keyID, err = p.newColumnEncryptionKey(ctx, desc, kmsURI); err != nil {
+			return err
+		}
+	}
+	col.EncryptionKeyID = keyID
+	return nil
+}
+
+// setupColumnEncryptionForNewTable sets up the encryption of the columns
+// of a new table declared with ENCRYPTED WITH KEY.
+func (p *planner) setupColumnEncryptionForNewTable(
+	ctx context.Context, desc *tabledesc.Mutable, n *tree.CreateTable,
+) error {
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok || !d.IsEncrypted() {
+			continue
+		}
+		if n.As() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"encrypted columns are not supported in CREATE TABLE ... AS")
+		}
+		col, err := catalog.MustFindColumnByTreeName(desc, d.Name)
+		if err != nil {
+			return err
+		}
+		if err := p.setupColumnEncryption(ctx, desc, col.ColumnDesc(), d.Encryption.KMSURI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactColumnEncryptionKMSURI returns the KMS URI without its secrets,
+// for display.
+func redactColumnEncryptionKMSURI(kmsURI string) string {
+	redacted, err := cloud.SanitizeExternalStorageURI(kmsURI, nil /* extraParams */)
+	if err != nil {
+		return "<invalid URI>"
+	}
+	return redacted
+}
+
+// rotateColumnEncryptionKeys generates a new data key for each KMS URI
+// used by the encrypted columns of the table, and makes the columns use
+// them. The values encrypted with the previous keys are re-encrypted by a
+// job, which removes the previous keys once it is done.
+func (p *planner) rotateColumnEncryptionKeys(ctx context.Context, desc *tabledesc.Mutable) error {
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", desc.GetName())
+	}
+	newKeys := make(map[string]descpb.ColumnEncryptionKeyID)
+	for _, col := range desc.DeletableColumns() {
+		if !col.IsEncrypted() {
+			continue
+		}
+		oldKey := desc.FindColumnEncryptionKey(col.GetEncryptionKeyID())
+		if oldKey == nil {
+			return errors.AssertionFailedf("encryption key %d of column %q not found",
+				col.GetEncryptionKeyID(), col.GetName())
+		}
+		kmsURI := oldKey.KMSURI
+		newKeyID, ok := newKeys[kmsURI]
+		if !ok {
+			if newKeyID, err = p.newColumnEncryptionKey(ctx, desc, kmsURI); err != nil {
+				return err
+			}
+			newKeys[kmsURI] = newKeyID
+		}
+		col.ColumnDesc().EncryptionKeyID = newKeyID
+	}
+	if len(newKeys) == 0 {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"table %s has no encrypted columns", desc.GetName())
+	}
+	return p.createColumnEncryptionKeyRotationJob(ctx, desc)
+}
+
+// columnEncryptionKey returns the unwrapped data key with the given ID of
+// the table.
+func (p *planner) columnEncryptionKey(
+	ctx context.Context, desc catalog.TableDescriptor, keyID descpb.ColumnEncryptionKeyID,
+) ([]byte, error) {
+	key := desc.FindColumnEncryptionKey(keyID)
+	if key == nil {
+		return nil, pgerror.Newf(pgcode.DataCorrupted,
+			"encryption key %d of table %s not found", keyID, desc.GetName())
+	}
+	// The data keys are unwrapped as the node user: the privileges of the
+	// current user were checked when the key was created.
+	env := &columnEncryptionKMSEnv{execCfg: p.ExecCfg(), user: username.NodeUserName()}
+	return p.ExecCfg().columnEncryptionKeyCache().GetKey(ctx, desc.GetID(), key, env)
+}
+
+// lookupEncryptedColumn returns the descriptor of the table and the
+// encrypted column with the given IDs.
+func (p *planner) lookupEncryptedColumn(
+	ctx context.Context, tableID descpb.ID, colID descpb.ColumnID,
+) (catalog.TableDescriptor, catalog.Column, error) {
+	desc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(ctx, tableID)
+	if err != nil {
+		return nil, nil, err
+	}
+	col, err := catalog.MustFindColumnByID(desc, colID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !col.IsEncrypted() {
+		return nil, nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"column %q of table %s is not encrypted", col.GetName(), desc.GetName())
+	}
+	return desc, col, nil
+}
+
+// columnValueBytes returns the bytes of a value of an encrypted column,
+// and whether they are the representation of a ciphertext.
+func columnValueBytes(value tree.Datum) (b []byte, isCiphertext bool, _ error) {
+	switch t := value.(type) {
+	case *tree.DString:
+		if ciphertext, ok := columnencryption.DecodeString(string(*t)); ok {
+			return ciphertext, true, nil
+		}
+		return []byte(*t), false, nil
+	case *tree.DBytes:
+		_, ok := columnencryption.ParseKeyID([]byte(*t))
+		return []byte(*t), ok, nil
+	default:
+		return nil, false, errors.AssertionFailedf("unexpected type %T for encrypted column", value)
+	}
+}
+
+// makeColumnValue returns a value of the same type as like.
+func makeColumnValue(like tree.Datum, b []byte, isCiphertext bool) tree.Datum {
+	if _, ok := like.(*tree.DString); ok {
+		if isCiphertext {
+			return tree.NewDString(columnencryption.EncodeString(b))
+		}
+		return tree.NewDString(string(b))
+	}
+	return tree.NewDBytes(tree.DBytes(b))
+}
+
+// EncryptColumnValue is part of the eval.Planner interface.
+func (p *planner) EncryptColumnValue(
+	ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+) (tree.Datum, error) {
+	if value == tree.DNull {
+		return value, nil
+	}
+	desc, col, err := p.lookupEncryptedColumn(ctx, tableID, colID)
+	if err != nil {
+		return nil, err
+	}
+	b, isCiphertext, err := columnValueBytes(value)
+	if err != nil {
+		return nil, err
+	}
+	currentKeyID := col.GetEncryptionKeyID()
+	currentKey, err := p.columnEncryptionKey(ctx, desc, currentKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if isCiphertext {
+		// The value was read from this column by a user without the DECRYPT
+		// privilege, e.g. by UPDATE t SET c = c: it must not be encrypted
+		// twice. It is only considered a ciphertext if it can be decrypted
+		// for this column, which a forged value cannot be.
+		keyID, _ := columnencryption.ParseKeyID(b)
+		if key := desc.FindColumnEncryptionKey(keyID); key != nil {
+			k, err := p.columnEncryptionKey(ctx, desc, keyID)
+			if err != nil {
+				return nil, err
+			}
+			if plaintext, err := columnencryption.Decrypt(k, tableID, colID, b); err == nil {
+				if keyID == currentKeyID {
+					return value, nil
+				}
+				b = plaintext
+			}
+		}
+	}
+	ciphertext, err := columnencryption.Encrypt(currentKey, currentKeyID, tableID, colID, b)
+	if err != nil {
+		return nil, err
+	}
+	return makeColumnValue(value, ciphertext, true /* isCiphertext */), nil
+}
+
+// DecryptColumnValue is part of the eval.Planner interface.
+func (p *planner) DecryptColumnValue(
+	ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+) (tree.Datum, error) {
+	if value == tree.DNull {
+		return value, nil
+	}
+	desc, _, err := p.lookupEncryptedColumn(ctx, tableID, colID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.DECRYPT); err != nil {
+		return nil, err
+	}
+	b, isCiphertext, err := columnValueBytes(value)
+	if err != nil {
+		return nil, err
+	}
+	if !isCiphertext {
+		return nil, pgerror.Newf(pgcode.DataCorrupted,
+			"value of encrypted column is not a valid ciphertext")
+	}
+	keyID, _ := columnencryption.ParseKeyID(b)
+	key, err := p.columnEncryptionKey(ctx, desc, keyID)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := columnencryption.Decrypt(key, tableID, colID, b)
+	if err != nil {
+		return nil, err
+	}
+	return makeColumnValue(value, plaintext, false /* isCiphertext */), nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+)
+
+// columnEncryptionKeyRotationBatchSize is the number of rows re-encrypted
+// in each transaction of the key rotation job.
+const columnEncryptionKeyRotationBatchSize = 1000
+
+// createColumnEncryptionKeyRotationJob queues the job which re-encrypts
+// the values of the table with its current keys, and then removes the
+// keys which are no longer used by its columns.
+func (p *planner) createColumnEncryptionKeyRotationJob(
+	ctx context.Context, desc *tabledesc.Mutable,
+) error {
+	var retired []descpb.ColumnEncryptionKeyID
+	for _, k := range desc.ColumnEncryptionKeys {
+		used := false
+		for _, col := range desc.DeletableColumns() {
+			if col.GetEncryptionKeyID() == k.ID {
+				used = true
+				break
+			}
+		}
+		if !used {
+			retired = append(retired, k.ID)
+		}
+	}
+	p.extendedEvalCtx.QueueJob(&jobs.Record{
+		Description:   fmt.Sprintf("rotating the encryption keys of table %s", desc.GetName()),
+		Username:      p.User(),
+		DescriptorIDs: descpb.IDs{desc.GetID()},
+		Details: jobspb.ColumnEncryptionKeyRotationDetails{
+			TableID:       desc.GetID(),
+			RetiredKeyIDs: retired,
+		},
+		Progress: jobspb.ColumnEncryptionKeyRotationProgress{},
+	})
+	return nil
+}
+
+type columnEncryptionKeyRotationResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*columnEncryptionKeyRotationResumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r *columnEncryptionKeyRotationResumer) Resume(
+	ctx context.Context, execCtx interface{},
+) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.ColumnEncryptionKeyRotationDetails)
+
+	// Wait until every node uses the new keys to encrypt the values written
+	// to the table: once the table has been scanned, no value is encrypted
+	// with a retired key.
+	if _, err := execCfg.LeaseManager.WaitForOneVersion(
+		ctx, details.TableID, retry.Options{},
+	); err != nil {
+		if catalog.HasInactiveDescriptorError(err) {
+			return nil
+		}
+		return err
+	}
+	if err := reencryptColumnValues(ctx, execCfg.InternalDB, details.TableID); err != nil {
+		return err
+	}
+
+	return execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		desc, err := txn.Descriptors().MutableByID(txn.KV()).Table(ctx, details.TableID)
+		if err != nil {
+			if catalog.HasInactiveDescriptorError(err) {
+				return nil
+			}
+			return err
+		}
+		for _, id := range details.RetiredKeyIDs {
+			desc.RemoveColumnEncryptionKey(id)
+		}
+		return txn.Descriptors().WriteDesc(ctx, false /* kvTrace */, desc, txn.KV())
+	})
+}
+
+// reencryptColumnValues rewrites the values of the encrypted columns of
+// the table, one batch of rows at a time, in primary key order. The
+// values are decrypted when they are read and encrypted with the current
+// key of their column when they are written.
+func reencryptColumnValues(ctx context.Context, db *InternalDB, tableID descpb.ID) error {
+	var pkCols, encryptedCols []string
+	if err := db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		desc, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, tableID)
+		if err != nil {
+			return err
+		}
+		pkCols, encryptedCols = nil, nil
+		for i := 0; i < desc.GetPrimaryIndex().NumKeyColumns(); i++ {
+			pkCols = append(pkCols, tree.NameString(desc.GetPrimaryIndex().GetKeyColumnName(i)))
+		}
+		for _, col := range desc.PublicColumns() {
+			if col.IsEncrypted() {
+				encryptedCols = append(encryptedCols, tree.NameString(col.GetName()))
+			}
+		}
+		return nil
+	}); err != nil {
+		if catalog.HasInactiveDescriptorError(err) {
+			return nil
+		}
+		return err
+	}
+	if len(encryptedCols) == 0 {
+		return nil
+	}
+
+	var set strings.Builder
+	for i, c := range encryptedCols {
+		if i > 0 {
+			set.WriteString(", ")
+		}
+		fmt.Fprintf(&set, "%s = %s", c, c)
+	}
+	pk := strings.Join(pkCols, ", ")
+	placeholders := make([]string, len(pkCols))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	firstBatch := fmt.Sprintf(
+		`SELECT * FROM [UPDATE [%d AS t] SET %s ORDER BY %s LIMIT %d RETURNING %s] ORDER BY %s`,
+		tableID, set.String(), pk, columnEncryptionKeyRotationBatchSize, pk, pk,
+	)
+	nextBatch := fmt.Sprintf(
+		`SELECT * FROM [UPDATE [%d AS t] SET %s WHERE (%s) > (%s) ORDER BY %s LIMIT %d RETURNING %s] ORDER BY %s`,
+		tableID, set.String(), pk, strings.Join(placeholders, ", "), pk,
+		columnEncryptionKeyRotationBatchSize, pk, pk,
+	)
+
+	var lastKey tree.Datums
+	var numRows int
+	for {
+		var rows []tree.Datums
+		if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
+			if lastKey == nil {
+				rows, err = txn.QueryBufferedEx(ctx, "reencrypt-column-values", txn.KV(),
+					sessiondata.NodeUserSessionDataOverride, firstBatch)
+				return err
+			}
+			args := make([]interface{}, len(lastKey))
+			for i := range lastKey {
+				args[i] = lastKey[i]
+			}
+			rows, err = txn.QueryBufferedEx(ctx, "reencrypt-column-values", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride, nextBatch, args...)
+			return err
+		}); err != nil {
+			return err
+		}
+		numRows += len(rows)
+		if len(rows) < columnEncryptionKeyRotationBatchSize {
+			log.Infof(ctx, "re-encrypted %d rows of table %d", numRows, tableID)
+			return nil
+		}
+		lastKey = rows[len(rows)-1]
+	}
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface. The retired keys
+// are kept, since values may still be encrypted with them; a later
+// rotation retires them again.
+func (r *columnEncryptionKeyRotationResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, jobErr error,
+) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeColumnEncryptionKeyRotation,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &columnEncryptionKeyRotationResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testColumnEncryptionKMSScheme = "column-encryption-test-kms"
+
+func init() {
+	cloud.RegisterKMSFromURIFactory(cloud.MakeLocalTestKMS, testColumnEncryptionKMSScheme)
+}
+
+func TestColumnEncryption(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, `CREATE TABLE t (
+	k INT PRIMARY KEY,
+	s STRING ENCRYPTED WITH KEY '`+testColumnEncryptionKMSScheme+`:///key1',
+	n INT
+)`)
+	db.Exec(t, `ALTER TABLE t ADD COLUMN b BYTES ENCRYPTED WITH KEY '`+testColumnEncryptionKMSScheme+`:///key1'`)
+	db.Exec(t, `INSERT INTO t VALUES (1, 'secret', 10, b'\x01\x02'), (2, NULL, 20, NULL)`)
+	db.CheckQueryResults(t, `SELECT k, s, n, b::STRING FROM t ORDER BY k`, [][]string{
+		{"1", "secret", "10", "\\x0102"},
+		{"2", "NULL", "20", "NULL"},
+	})
+	db.CheckQueryResults(t, `INSERT INTO t VALUES (3, 'other', 30, NULL) RETURNING s`, [][]string{
+		{"other"},
+	})
+
+	// Both columns use the same key.
+	keyIDs := `SELECT jsonb_agg(k->'id' ORDER BY k->'id')::STRING FROM system.descriptor, jsonb_array_elements(
+  crdb_internal.pb_to_json('cockroach.sql.sqlbase.Descriptor', descriptor)->'table'->'columnEncryptionKeys'
+) AS k WHERE id = 't'::REGCLASS::INT`
+	db.CheckQueryResults(t, keyIDs, [][]string{{"[1]"}})
+
+	var showCreate string
+	db.QueryRow(t, `SELECT create_statement FROM [SHOW CREATE TABLE t]`).Scan(&showCreate)
+	require.Contains(t, showCreate, `s STRING NULL ENCRYPTED WITH KEY '`+testColumnEncryptionKMSScheme+`:///key1'`)
+
+	// A user without the DECRYPT privilege reads the ciphertexts, and can
+	// write the values of the table.
+	db.Exec(t, `CREATE USER testuser`)
+	db.Exec(t, `GRANT SELECT, INSERT, UPDATE ON t TO testuser`)
+	testuser := sqlutils.MakeSQLRunner(s.ApplicationLayer().SQLConnForUser(t, "testuser", ""))
+	var ciphertext string
+	testuser.QueryRow(t, `SELECT s FROM t WHERE k = 1`).Scan(&ciphertext)
+	require.NotEqual(t, "secret", ciphertext)
+	require.NotContains(t, ciphertext, "secret")
+	testuser.ExpectErr(t, `user testuser does not have DECRYPT privilege on relation t`,
+		`SELECT crdb_internal.decrypt_column_value('t'::REGCLASS::INT, 2, s) FROM t`)
+
+	testuser.Exec(t, `UPDATE t SET n = n + 1 WHERE k = 1`)
+	testuser.Exec(t, `UPDATE t SET s = s WHERE k = 1`)
+	testuser.Exec(t, `INSERT INTO t VALUES (4, 'inserted', 40, NULL)`)
+	db.CheckQueryResults(t, `SELECT k, s, n FROM t WHERE k IN (1, 4) ORDER BY k`, [][]string{
+		{"1", "secret", "11"},
+		{"4", "inserted", "40"},
+	})
+
+	db.Exec(t, `GRANT DECRYPT ON t TO testuser`)
+	testuser.Exec(t, `UPDATE t SET s = s || '!' WHERE k = 1`)
+	testuser.CheckQueryResults(t, `SELECT s FROM t WHERE k = 1`, [][]string{{"secret!"}})
+	testuser.CheckQueryResults(t, `SELECT k FROM t WHERE s = 'inserted'`, [][]string{{"4"}})
+
+	db.Exec(t, `UPSERT INTO t VALUES (2, 'upserted', 21, b'\x03')`)
+	db.Exec(t, `INSERT INTO t VALUES (3, 'conflict', 31, NULL) ON CONFLICT (k) DO UPDATE SET s = excluded.s`)
+	db.CheckQueryResults(t, `SELECT k, s, b::STRING FROM t WHERE k IN (2, 3) ORDER BY k`, [][]string{
+		{"2", "upserted", "\\x03"},
+		{"3", "conflict", "NULL"},
+	})
+
+	// Rotating the keys re-encrypts the values with a new key, and removes
+	// the previous key.
+	db.Exec(t, `ALTER TABLE t ROTATE ENCRYPTION KEYS`)
+	var jobID jobspb.JobID
+	db.QueryRow(t, `SELECT job_id FROM [SHOW JOBS] WHERE description LIKE 'rotating the encryption keys of table t'`).Scan(&jobID)
+	jobutils.WaitForJobToSucceed(t, db, jobID)
+	db.CheckQueryResults(t, keyIDs, [][]string{{"[2]"}})
+	db.CheckQueryResults(t, `SELECT k, s, b::STRING FROM t ORDER BY k`, [][]string{
+		{"1", "secret!", "\\x0102"},
+		{"2", "upserted", "\\x03"},
+		{"3", "conflict", "NULL"},
+		{"4", "inserted", "NULL"},
+	})
+
+	// Invalid uses of encrypted columns.
+	db.ExpectErr(t, `encrypted column "s" must be of type STRING or BYTES, not INT8`,
+		`CREATE TABLE bad (k INT PRIMARY KEY, s INT ENCRYPTED WITH KEY '`+testColumnEncryptionKMSScheme+`:///key1')`)
+	db.ExpectErr(t, `encrypted column "s" cannot be used in`, `CREATE INDEX ON t (s)`)
+	db.ExpectErr(t, `multiple encryption keys specified for column "s"`,
+		`CREATE TABLE bad (s STRING ENCRYPTED WITH KEY 'a' ENCRYPTED WITH KEY 'b')`)
+	db.Exec(t, `CREATE TABLE plain (k INT PRIMARY KEY)`)
+	db.ExpectErr(t, `table plain has no encrypted columns`, `ALTER TABLE plain ROTATE ENCRYPTION KEYS`)
+
+	// Tables with inverted indexes, whose inverted columns have no descriptor
+	// column, can be queried.
+	db.Exec(t, `CREATE TABLE inv (k INT PRIMARY KEY, j JSONB, INVERTED INDEX (j))`)
+	db.Exec(t, `INSERT INTO inv VALUES (1, '{"a": 1}'), (2, '{"a": 2}')`)
+	db.CheckQueryResults(t, `SELECT k FROM inv WHERE j @> '{"a": 1}'`, [][]string{{"1"}})
+}
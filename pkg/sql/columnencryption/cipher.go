@@ -0,0 +1,156 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package columnencryption implements the encryption of the values of
+// the columns declared with ENCRYPTED WITH KEY.
+//
+// The values of the encrypted columns of a table are encrypted with
+// AES-256-GCM, using data keys that are generated for the table and
+// stored in its descriptor, wrapped by an external KMS. The IDs of the
+// table and of the column are authenticated along with each value, so
+// that a ciphertext cannot be moved to another column without being
+// detected.
+package columnencryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// DataKeySize is the size of the data keys, in bytes.
+const DataKeySize = 32
+
+// ciphertextVersion is the first byte of every ciphertext. The
+// ciphertext format is:
+//
+//	version (1 byte) | key ID (uvarint) | nonce (12 bytes) | sealed value
+const ciphertextVersion byte = 1
+
+// GenerateDataKey returns a new random data key.
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != DataKeySize {
+		return nil, errors.AssertionFailedf("invalid data key size %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData returns the data authenticated along with the values
+// of the given column.
+func additionalData(tableID descpb.ID, colID descpb.ColumnID) []byte {
+	var buf [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(tableID))
+	n += binary.PutUvarint(buf[n:], uint64(colID))
+	return buf[:n]
+}
+
+// Encrypt encrypts a value of the given column with the data key whose
+// ID is keyID.
+func Encrypt(
+	key []byte,
+	keyID descpb.ColumnEncryptionKeyID,
+	tableID descpb.ID,
+	colID descpb.ColumnID,
+	plaintext []byte,
+) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, 1+binary.MaxVarintLen32+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	out = append(out, ciphertextVersion)
+	out = binary.AppendUvarint(out, uint64(keyID))
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, additionalData(tableID, colID)), nil
+}
+
+// ParseKeyID returns the ID of the data key used to encrypt the given
+// ciphertext. It returns false if the value does not have the format of
+// a ciphertext.
+func ParseKeyID(ciphertext []byte) (descpb.ColumnEncryptionKeyID, bool) {
+	if len(ciphertext) < 2 || ciphertext[0] != ciphertextVersion {
+		return 0, false
+	}
+	keyID, n := binary.Uvarint(ciphertext[1:])
+	if n <= 0 || keyID == 0 || keyID > uint64(^uint32(0)) {
+		return 0, false
+	}
+	return descpb.ColumnEncryptionKeyID(keyID), true
+}
+
+// Decrypt decrypts a value of the given column with the data key used
+// to encrypt it, whose ID can be obtained with ParseKeyID.
+func Decrypt(
+	key []byte, tableID descpb.ID, colID descpb.ColumnID, ciphertext []byte,
+) ([]byte, error) {
+	if _, ok := ParseKeyID(ciphertext); !ok {
+		return nil, errInvalidCiphertext
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	_, n := binary.Uvarint(ciphertext[1:])
+	rest := ciphertext[1+n:]
+	if len(rest) < aead.NonceSize()+aead.Overhead() {
+		return nil, errInvalidCiphertext
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData(tableID, colID))
+	if err != nil {
+		return nil, errInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+var errInvalidCiphertext = pgerror.New(pgcode.DataCorrupted,
+	"value of encrypted column is not a valid ciphertext")
+
+// EncodeString returns the representation of a ciphertext stored in a
+// STRING column.
+func EncodeString(ciphertext []byte) string {
+	return base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+// DecodeString returns the ciphertext stored in a STRING column. It
+// returns false if the value is not the representation of a ciphertext.
+func DecodeString(s string) ([]byte, bool) {
+	ciphertext, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	if _, ok := ParseKeyID(ciphertext); !ok {
+		return nil, false
+	}
+	return ciphertext, true
+}
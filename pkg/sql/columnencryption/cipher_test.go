@@ -0,0 +1,70 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package columnencryption
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err)
+	const tableID, colID, keyID = descpb.ID(104), descpb.ColumnID(2), descpb.ColumnEncryptionKeyID(300)
+
+	for _, plaintext := range []string{"", "4111 1111 1111 1111"} {
+		ciphertext, err := Encrypt(key, keyID, tableID, colID, []byte(plaintext))
+		require.NoError(t, err)
+		if plaintext != "" {
+			require.NotContains(t, string(ciphertext), plaintext)
+		}
+
+		id, ok := ParseKeyID(ciphertext)
+		require.True(t, ok)
+		require.Equal(t, keyID, id)
+
+		decrypted, err := Decrypt(key, tableID, colID, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, string(decrypted))
+
+		s := EncodeString(ciphertext)
+		decoded, ok := DecodeString(s)
+		require.True(t, ok)
+		require.Equal(t, ciphertext, decoded)
+
+		// The ciphertext is bound to its column and to its key.
+		_, err = Decrypt(key, tableID, colID+1, ciphertext)
+		require.Error(t, err)
+		_, err = Decrypt(key, tableID+1, colID, ciphertext)
+		require.Error(t, err)
+		otherKey, err := GenerateDataKey()
+		require.NoError(t, err)
+		_, err = Decrypt(otherKey, tableID, colID, ciphertext)
+		require.Error(t, err)
+
+		// Tampering is detected.
+		ciphertext[len(ciphertext)-1] ^= 1
+		_, err = Decrypt(key, tableID, colID, ciphertext)
+		require.Error(t, err)
+	}
+
+	// Values that do not look like ciphertexts.
+	for _, v := range []string{"", "x", "4111 1111 1111 1111", "\x01\x00abc"} {
+		_, ok := ParseKeyID([]byte(v))
+		require.False(t, ok, "%q", v)
+		_, ok = DecodeString(v)
+		require.False(t, ok, "%q", v)
+		_, err := Decrypt(key, tableID, colID, []byte(v))
+		require.Error(t, err)
+	}
+}
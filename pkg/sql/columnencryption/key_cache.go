@@ -0,0 +1,96 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package columnencryption
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// maxCachedKeys is the maximum number of unwrapped data keys kept by a
+// KeyCache. The cache is emptied when it is full; this is expected to
+// be rare, as few tables have encrypted columns.
+const maxCachedKeys = 1024
+
+// KeyCache caches the unwrapped data keys of the tables, so that the
+// KMS is not called for every encrypted value.
+type KeyCache struct {
+	mu struct {
+		syncutil.Mutex
+		keys map[keyCacheKey][]byte
+	}
+}
+
+// keyCacheKey identifies an unwrapped key. The wrapped key is part of
+// the cache key, so that a stale entry is never used if the key of a
+// table is replaced, e.g. by a RESTORE.
+type keyCacheKey struct {
+	tableID    descpb.ID
+	keyID      descpb.ColumnEncryptionKeyID
+	wrappedKey string
+}
+
+// NewKeyCache creates a KeyCache.
+func NewKeyCache() *KeyCache {
+	c := &KeyCache{}
+	c.mu.keys = make(map[keyCacheKey][]byte)
+	return c
+}
+
+// WrapDataKey wraps a data key with the KMS identified by kmsURI.
+func WrapDataKey(ctx context.Context, kmsURI string, env cloud.KMSEnv, key []byte) ([]byte, error) {
+	kms, err := cloud.KMSFromURI(ctx, kmsURI, env)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := kms.Encrypt(ctx, key)
+	return wrapped, errors.CombineErrors(err, kms.Close())
+}
+
+// GetKey returns the unwrapped data key of the given table, calling the
+// KMS if the key is not cached.
+func (c *KeyCache) GetKey(
+	ctx context.Context, tableID descpb.ID, key *descpb.ColumnEncryptionKey, env cloud.KMSEnv,
+) ([]byte, error) {
+	k := keyCacheKey{tableID: tableID, keyID: key.ID, wrappedKey: string(key.WrappedKey)}
+	c.mu.Lock()
+	unwrapped, ok := c.mu.keys[k]
+	c.mu.Unlock()
+	if ok {
+		return unwrapped, nil
+	}
+
+	// The KMS is called without holding the lock: concurrent misses on the
+	// same key may unwrap it more than once, which is harmless.
+	kms, err := cloud.KMSFromURI(ctx, key.KMSURI, env)
+	if err != nil {
+		return nil, err
+	}
+	unwrapped, err = kms.Decrypt(ctx, key.WrappedKey)
+	if err = errors.CombineErrors(err, kms.Close()); err != nil {
+		return nil, errors.Wrapf(err, "unwrapping encryption key %d", key.ID)
+	}
+	if len(unwrapped) != DataKeySize {
+		return nil, errors.Newf("unwrapped encryption key %d has an invalid size", key.ID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.mu.keys) >= maxCachedKeys {
+		c.mu.keys = make(map[keyCacheKey][]byte)
+	}
+	c.mu.keys[k] = unwrapped
+	return unwrapped, nil
+}
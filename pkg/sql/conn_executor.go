@@ -1608,6 +1608,10 @@ type connExecutor struct {
 	// before using.
 	planner planner
 
+	// hypotheticalIndexes contains the hypothetical indexes created in this
+	// session with crdb_internal.create_hypothetical_index.
+	hypotheticalIndexes []hypotheticalIndexDef
+
 	// phaseTimes tracks session- and transaction-level phase times. It is
 	// copied-by-value when resetting statsCollector before executing each
 	// statement.
@@ -3616,6 +3620,7 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.preparedStatements = ex.getPrepStmtsAccessor()
 	p.sqlCursors = ex.getCursorAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
+	p.hypotheticalIndexes = ex.getHypotheticalIndexesAccessor()
 
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
@@ -4120,6 +4125,12 @@ func (ex *connExecutor) getCreatedSequencesAccessor() createdSequences {
 	}
 }
 
+func (ex *connExecutor) getHypotheticalIndexesAccessor() hypotheticalIndexes {
+	return connExHypotheticalIndexesAccessor{
+		ex: ex,
+	}
+}
+
 // sessionEventf logs a message to the session event log (if any).
 func (ex *connExecutor) sessionEventf(ctx context.Context, format string, args ...interface{}) {
 	if log.ExpensiveLogEnabled(ctx, 2) {
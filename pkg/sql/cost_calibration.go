@@ -0,0 +1,289 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var costCalibrationTopStatements = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.top_statements",
+	"the maximum number of sampled statement fingerprints replayed by the cost model calibration job",
+	200,
+	settings.PositiveInt,
+)
+
+var costCalibrationLookback = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.lookback",
+	"the interval of statement statistics used by the cost model calibration job",
+	24*time.Hour,
+	settings.PositiveDuration,
+)
+
+// CalibrateCostModel is part of the eval.Planner interface.
+func (p *planner) CalibrateCostModel(ctx context.Context, apply bool) (int64, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_CostCalibration) {
+		return 0, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cost model calibration requires the cluster to be upgraded to version %s",
+			clusterversion.V23_2_CostCalibration)
+	}
+	if err := p.RequireAdminRole(ctx, "calibrate the cost model"); err != nil {
+		return 0, err
+	}
+	execCfg := p.ExecCfg()
+	sv := &execCfg.Settings.SV
+	description := "calibrate cost model"
+	if apply {
+		description += " and apply the calibrated cost factors"
+	}
+	record := jobs.Record{
+		Description: description,
+		Username:    p.User(),
+		Details: jobspb.CostCalibrationDetails{
+			Since:         timeutil.Now().Add(-costCalibrationLookback.Get(sv)),
+			TopStatements: costCalibrationTopStatements.Get(sv),
+			Apply:         apply,
+		},
+		Progress: jobspb.CostCalibrationProgress{},
+	}
+	jobID := execCfg.JobRegistry.MakeJobID()
+	if _, err := execCfg.JobRegistry.CreateAdoptableJobWithTxn(
+		ctx, record, jobID, p.InternalSQLTxn(),
+	); err != nil {
+		return 0, err
+	}
+	return int64(jobID), nil
+}
+
+// costCalibrationFingerprint is a statement fingerprint with sampled
+// execution statistics, replayed by the cost model calibration job.
+type costCalibrationFingerprint struct {
+	workloadFingerprint
+
+	// latency is the mean execution latency of the fingerprint, in seconds,
+	// without the time spent waiting on contention.
+	latency float64
+}
+
+// costCalibrationStatementsQuery finds the DML statement fingerprints of the
+// applications with the most executions sampled by execstats since the given
+// time, along with their mean run latency without contention.
+const costCalibrationStatementsQuery = `
+SELECT
+  query,
+  db,
+  sampled,
+  latency / sampled::FLOAT8 AS latency
+FROM (
+  SELECT
+    metadata->>'query' AS query,
+    metadata->>'db' AS db,
+    sum((statistics->'execution_statistics'->>'cnt')::INT8)::INT8 AS sampled,
+    sum(
+      (statistics->'execution_statistics'->>'cnt')::FLOAT8 * (
+        COALESCE((statistics->'statistics'->'runLat'->>'mean')::FLOAT8, 0) -
+        COALESCE((statistics->'execution_statistics'->'contentionTime'->>'mean')::FLOAT8, 0)
+      )
+    ) AS latency
+  FROM crdb_internal.statement_statistics
+  WHERE aggregated_ts >= $1
+    AND metadata->>'stmtType' = 'TypeDML'
+    AND app_name NOT LIKE $2
+    AND (statistics->'execution_statistics'->>'cnt')::INT8 > 0
+  GROUP BY query, db
+)
+ORDER BY sampled DESC, query, db
+LIMIT $3`
+
+type costCalibrationResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*costCalibrationResumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r *costCalibrationResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.CostCalibrationDetails)
+	user := r.job.Payload().UsernameProto.Decode()
+
+	fingerprints, err := sampledCostCalibrationStatements(ctx, execCfg.InternalDB, details)
+	if err != nil {
+		return err
+	}
+
+	var samples []xform.CostSample
+	var skipped int64
+	for _, fp := range fingerprints {
+		components, err := replayCostCalibrationStatement(ctx, execCfg, user, fp)
+		if err != nil {
+			// The statement may refer to objects which were dropped since it
+			// was executed.
+			log.VEventf(ctx, 2, "skipping statement %q: %v", fp.query, err)
+			skipped++
+			continue
+		}
+		samples = append(samples, xform.CostSample{Components: components, Latency: fp.latency})
+	}
+
+	calibration, ok := xform.FitCostCalibration(samples)
+	if !ok {
+		return jobs.MarkAsPermanentJobError(errors.Newf(
+			"insufficient sampled execution statistics to calibrate the cost model "+
+				"(%d statements replayed, %d skipped)", len(samples), skipped,
+		))
+	}
+	calibration.JobID = int64(r.job.ID())
+	log.Infof(ctx, "calibrated cost model from %d statements: %s", len(samples), calibration)
+
+	progress := jobspb.CostCalibrationProgress{
+		CPUCostFactor:     float64(calibration.CPUCostFactor),
+		SeqIOCostFactor:   float64(calibration.SeqIOCostFactor),
+		RandIOCostFactor:  float64(calibration.RandIOCostFactor),
+		Statements:        int64(len(samples)),
+		SkippedStatements: skipped,
+	}
+	if details.Apply {
+		if err := applyCostCalibration(ctx, execCfg.InternalDB, calibration); err != nil {
+			return err
+		}
+		progress.Applied = true
+	}
+	return r.job.NoTxn().SetProgress(ctx, progress)
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r *costCalibrationResumer) OnFailOrCancel(context.Context, interface{}, error) error {
+	return nil
+}
+
+// sampledCostCalibrationStatements returns the statement fingerprints of the
+// workload with the most sampled executions.
+func sampledCostCalibrationStatements(
+	ctx context.Context, db isql.DB, details jobspb.CostCalibrationDetails,
+) ([]costCalibrationFingerprint, error) {
+	rows, err := db.Executor().QueryBufferedEx(ctx,
+		"cost-calibration-sampled-statements",
+		nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		costCalibrationStatementsQuery,
+		details.Since,
+		catconstants.InternalAppNamePrefix+"%",
+		details.TopStatements,
+	)
+	if err != nil {
+		return nil, err
+	}
+	fingerprints := make([]costCalibrationFingerprint, 0, len(rows))
+	for _, row := range rows {
+		if row[0] == tree.DNull || row[1] == tree.DNull || row[3] == tree.DNull {
+			continue
+		}
+		fingerprints = append(fingerprints, costCalibrationFingerprint{
+			workloadFingerprint: workloadFingerprint{
+				query: string(tree.MustBeDString(row[0])),
+				db:    string(tree.MustBeDString(row[1])),
+				count: int64(tree.MustBeDInt(row[2])),
+			},
+			latency: float64(tree.MustBeDFloat(row[3])),
+		})
+	}
+	return fingerprints, nil
+}
+
+// replayCostCalibrationStatement optimizes a statement fingerprint as the
+// given user, in the database in which it was executed, and returns the cost
+// components of its plan.
+func replayCostCalibrationStatement(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	user username.SQLUsername,
+	fp costCalibrationFingerprint,
+) (components xform.CostComponents, err error) {
+	parsed, err := parseWorkloadFingerprint(fp.query)
+	if err != nil {
+		return components, err
+	}
+	err = withWorkloadPlanner(ctx, execCfg, "cost-calibration", user, fp.db,
+		func(ctx context.Context, p *planner) error {
+			if err := p.buildWorkloadStatement(ctx, parsed); err != nil {
+				return err
+			}
+			o := &p.optPlanningCtx.optimizer
+			o.CollectCostComponents()
+			if _, err := o.Optimize(); err != nil {
+				return err
+			}
+			components = o.CostComponents()
+			return nil
+		},
+	)
+	return components, err
+}
+
+// applyCostCalibration writes the calibrated cost factors to the cluster
+// settings used by the optimizer, and enables them.
+func applyCostCalibration(
+	ctx context.Context, db isql.DB, calibration memo.CostCalibration,
+) error {
+	for _, s := range []struct {
+		name  string
+		value interface{}
+	}{
+		{name: memo.CostCalibrationCPUCostFactor.Key(), value: float64(calibration.CPUCostFactor)},
+		{name: memo.CostCalibrationSeqIOCostFactor.Key(), value: float64(calibration.SeqIOCostFactor)},
+		{name: memo.CostCalibrationRandIOCostFactor.Key(), value: float64(calibration.RandIOCostFactor)},
+		{name: memo.CostCalibrationJobID.Key(), value: calibration.JobID},
+		{name: memo.CostCalibrationEnabled.Key(), value: true},
+	} {
+		if _, err := db.Executor().ExecEx(ctx,
+			"cost-calibration-apply",
+			nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			"SET CLUSTER SETTING "+s.name+" = $1",
+			s.value,
+		); err != nil {
+			return errors.Wrapf(err, "applying %s", s.name)
+		}
+	}
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeCostCalibration,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &costCalibrationResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -7409,7 +7409,9 @@ func generateTableSpan(
 }
 
 // crdbInternalClusterLocksTable exposes the state of locks, as well as lock waiters,
-// in range lock tables across the cluster.
+// in range lock tables across the cluster. Each waiter row records the
+// transaction holding the lock in blocking_txn_id, so the edges of the
+// cluster's wait-for graph can be derived by a self-join on txn_id.
 var crdbInternalClusterLocksTable = virtualSchemaTable{
 	comment: `cluster-wide locks held in lock tables. Querying this table is an
 		expensive operation since it creates a cluster-wide RPC-fanout.`,
@@ -7431,6 +7433,7 @@ CREATE TABLE crdb_internal.cluster_locks (
     contended           BOOL NOT NULL,
     duration            INTERVAL,
     isolation_level 		STRING NOT NULL,
+    blocking_txn_id     UUID,
     INDEX(table_id),
     INDEX(database_name),
     INDEX(table_name),
@@ -7649,13 +7652,20 @@ func genClusterLocksGenerator(
 			txnIDDatum := tree.DNull
 			tsDatum := tree.DNull
 			durationDatum := tree.DNull
+			blockingTxnIDDatum := tree.DNull
 			granted := false
 			// Utilize -1 to indicate that the row represents the lock holder.
 			if waiterIdx < 0 {
 				if curLock.LockHolder != nil {
 					txnIDDatum = tree.NewDUuid(tree.DUuid{UUID: curLock.LockHolder.ID})
 					tsDatum = eval.TimestampToInexactDTimestamp(curLock.LockHolder.WriteTimestamp)
-					strengthDatum = tree.NewDString(lock.Exclusive.String())
+					// Intents are exclusive locks from the perspective of SQL. Nodes
+					// that predate the tracking of lock strengths report None.
+					strength := curLock.LockStrength
+					if strength == lock.None || strength == lock.Intent {
+						strength = lock.Exclusive
+					}
+					strengthDatum = tree.NewDString(strength.String())
 					durationDatum = tree.NewDInterval(
 						duration.MakeDuration(curLock.HoldDuration.Nanoseconds(), 0 /* days */, 0 /* months */),
 						types.DefaultIntervalTypeMetadata,
@@ -7668,6 +7678,11 @@ func genClusterLocksGenerator(
 					txnIDDatum = tree.NewDUuid(tree.DUuid{UUID: waiter.WaitingTxn.ID})
 					tsDatum = eval.TimestampToInexactDTimestamp(waiter.WaitingTxn.WriteTimestamp)
 				}
+				// Waiters form the edges of the wait graph, pointing at the
+				// transaction holding the lock.
+				if curLock.LockHolder != nil {
+					blockingTxnIDDatum = tree.NewDUuid(tree.DUuid{UUID: curLock.LockHolder.ID})
+				}
 				strengthDatum = tree.NewDString(waiter.Strength.String())
 				durationDatum = tree.NewDInterval(
 					duration.MakeDuration(waiter.WaitDuration.Nanoseconds(), 0 /* days */, 0 /* months */),
@@ -7706,6 +7721,7 @@ func genClusterLocksGenerator(
 				tree.MakeDBool(len(curLock.Waiters) > 0),     /* contended */
 				durationDatum,                                /* duration */
 				tree.NewDString(tree.IsolationLevelFromKVTxnIsolationLevel(curLock.LockHolder.IsoLevel).String()), /* isolation_level */
+				blockingTxnIDDatum, /* blocking_txn_id */
 			}, nil
 
 		}, nil, nil
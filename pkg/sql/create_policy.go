@@ -0,0 +1,228 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tableDesc *tabledesc.Mutable
+	policy    descpb.PolicyDescriptor
+}
+
+// CreatePolicy creates a row-level security policy on a table.
+// Privileges: ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	tableDesc, tn, err := p.resolveTableForPolicy(ctx, n.TableName, "CREATE POLICY")
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc.FindPolicyByName(string(n.PolicyName)) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"policy %q for table %q already exists", n.PolicyName, tableDesc.GetName())
+	}
+
+	policy := descpb.PolicyDescriptor{
+		Name:    string(n.PolicyName),
+		Type:    policyTypeToDescpb(n.Type),
+		Command: policyCommandToDescpb(n.Cmd),
+	}
+	if policy.RoleNames, err = p.policyRoleNames(ctx, n.Roles); err != nil {
+		return nil, err
+	}
+	// As in Postgres, SELECT and DELETE policies only apply to existing rows,
+	// and INSERT policies only apply to new rows.
+	switch n.Cmd {
+	case tree.PolicyCommandSelect, tree.PolicyCommandDelete:
+		if n.Exprs.WithCheck != nil {
+			return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case tree.PolicyCommandInsert:
+		if n.Exprs.Using != nil {
+			return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+	if err := p.setPolicyExprs(ctx, tableDesc, tn, n.Exprs, &policy); err != nil {
+		return nil, err
+	}
+	return &createPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	n.tableDesc.AddPolicy(n.policy)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
+
+// resolveTableForPolicy resolves the table on which a policy is created,
+// altered or dropped, and checks that the current user owns it.
+func (p *planner) resolveTableForPolicy(
+	ctx context.Context, name *tree.UnresolvedObjectName, op string,
+) (*tabledesc.Mutable, *tree.TableName, error) {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), op); err != nil {
+		return nil, nil, err
+	}
+	tn := name.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	hasOwnership, err := p.HasOwnership(ctx, tableDesc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hasOwnership {
+		return nil, nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tableDesc.GetName())
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, nil, err
+	}
+	return tableDesc, &tn, nil
+}
+
+// policyRoleNames normalizes the roles a policy applies to, and checks that
+// they exist. PUBLIC is represented by an empty list.
+func (p *planner) policyRoleNames(ctx context.Context, roles tree.RoleSpecList) ([]string, error) {
+	users, err := decodeusername.FromRoleSpecList(p.SessionData(), username.PurposeValidation, roles)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, user := range users {
+		if user.IsPublicRole() {
+			if len(users) > 1 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"cannot combine PUBLIC with other roles")
+			}
+			return nil, nil
+		}
+		exists, err := RoleExists(ctx, p.InternalSQLTxn(), user)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, sqlerrors.NewUndefinedUserError(user)
+		}
+		names = append(names, user.Normalized())
+	}
+	return names, nil
+}
+
+// setPolicyExprs validates and serializes the USING and WITH CHECK expressions
+// of a policy, and records the columns that the expressions of the policy
+// reference so that they cannot be dropped.
+func (p *planner) setPolicyExprs(
+	ctx context.Context,
+	tableDesc *tabledesc.Mutable,
+	tn *tree.TableName,
+	exprs tree.PolicyExpressions,
+	policy *descpb.PolicyDescriptor,
+) error {
+	version := p.ExecCfg().Settings.Version.ActiveVersion(ctx)
+	for _, e := range []struct {
+		expr tree.Expr
+		dst  *string
+	}{
+		{exprs.Using, &policy.UsingExpr},
+		{exprs.WithCheck, &policy.WithCheckExpr},
+	} {
+		if e.expr == nil {
+			continue
+		}
+		expr, _, _, err := schemaexpr.DequalifyAndValidateExpr(
+			ctx,
+			tableDesc,
+			e.expr,
+			types.Bool,
+			tree.PolicyExpr,
+			p.SemaCtx(),
+			volatility.Volatile,
+			tn,
+			version,
+		)
+		if err != nil {
+			return err
+		}
+		*e.dst = expr
+	}
+	var colIDs catalog.TableColSet
+	for _, e := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+		if e == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(e)
+		if err != nil {
+			return err
+		}
+		ids, err := schemaexpr.ExtractColumnIDs(tableDesc, expr)
+		if err != nil {
+			return err
+		}
+		colIDs.UnionWith(ids)
+	}
+	policy.ColumnIDs = colIDs.Ordered()
+	return nil
+}
+
+func policyTypeToDescpb(t tree.PolicyType) descpb.PolicyDescriptor_Type {
+	switch t {
+	case tree.PolicyTypeDefault, tree.PolicyTypePermissive:
+		return descpb.PolicyDescriptor_PERMISSIVE
+	case tree.PolicyTypeRestrictive:
+		return descpb.PolicyDescriptor_RESTRICTIVE
+	default:
+		panic(errors.AssertionFailedf("unknown policy type %d", t))
+	}
+}
+
+func policyCommandToDescpb(c tree.PolicyCommand) descpb.PolicyDescriptor_Command {
+	switch c {
+	case tree.PolicyCommandDefault, tree.PolicyCommandAll:
+		return descpb.PolicyDescriptor_ALL
+	case tree.PolicyCommandSelect:
+		return descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		return descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		return descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		return descpb.PolicyDescriptor_DELETE
+	default:
+		panic(errors.AssertionFailedf("unknown policy command %d", c))
+	}
+}
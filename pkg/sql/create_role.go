@@ -130,7 +130,7 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 		opName = "create-user"
 	}
 
-	_, hashedPassword, err := retrievePasswordFromRoleOptions(params, n.roleOptions)
+	_, hashedPassword, err := retrievePasswordFromRoleOptions(params, n.roleName, n.roleOptions)
 	if err != nil {
 		return err
 	}
@@ -179,6 +179,12 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 		return err
 	}
 
+	if hashedPassword != nil {
+		if err := params.p.recordPasswordChange(params.ctx, opName, n.roleName, hashedPassword); err != nil {
+			return err
+		}
+	}
+
 	if sessioninit.CacheEnabled.Get(&params.p.ExecCfg().Settings.SV) {
 		// Bump role-related table versions to force a refresh of AuthInfo cache.
 		if err := params.p.bumpUsersTableVersion(params.ctx); err != nil {
@@ -265,7 +271,7 @@ func (*CreateRoleNode) Values() tree.Datums { return tree.Datums{} }
 func (*CreateRoleNode) Close(context.Context) {}
 
 func retrievePasswordFromRoleOptions(
-	params runParams, roleOptions roleoption.List,
+	params runParams, roleName username.SQLUsername, roleOptions roleoption.List,
 ) (hasPasswordOpt bool, hashedPassword []byte, err error) {
 	if !roleOptions.Contains(roleoption.PASSWORD) {
 		return false, nil, nil
@@ -287,7 +293,7 @@ func retrievePasswordFromRoleOptions(
 	}
 
 	if !isNull {
-		if hashedPassword, err = params.p.checkPasswordAndGetHash(params.ctx, password); err != nil {
+		if hashedPassword, err = params.p.checkPasswordAndGetHash(params.ctx, roleName, password); err != nil {
 			return true, nil, err
 		}
 	}
@@ -296,7 +302,7 @@ func retrievePasswordFromRoleOptions(
 }
 
 func (p *planner) checkPasswordAndGetHash(
-	ctx context.Context, passwordStr string,
+	ctx context.Context, roleName username.SQLUsername, passwordStr string,
 ) (hashedPassword []byte, err error) {
 	if passwordStr == "" {
 		return hashedPassword, security.ErrEmptyPassword
@@ -324,6 +330,12 @@ func (p *planner) checkPasswordAndGetHash(
 			"Passwords must be %d characters or longer.", minLength)
 	}
 
+	// The password policy can only be enforced on cleartext passwords;
+	// pre-hashed passwords are accepted as-is above.
+	if err := p.checkPasswordPolicy(ctx, roleName, passwordStr); err != nil {
+		return nil, err
+	}
+
 	method := security.GetConfiguredPasswordHashMethod(&st.SV)
 	cost, err := security.GetConfiguredPasswordCost(ctx, &st.SV, method)
 	if err != nil {
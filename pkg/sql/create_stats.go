@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -261,6 +262,17 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		return nil, err
 	}
 
+	hasMCV, hasDependencies, err := n.extendedStatisticsKinds()
+	if err != nil {
+		return nil, err
+	}
+	extendedStatsActive := n.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_ExtendedStatistics)
+	if (hasMCV || hasDependencies) && !extendedStatsActive {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"extended statistics require the cluster to be upgraded to version %s",
+			clusterversion.V23_2_ExtendedStatistics)
+	}
+
 	var colStats []jobspb.CreateStatsDetails_ColStat
 	var deleteOtherStats bool
 	if len(n.ColumnNames) == 0 {
@@ -278,6 +290,11 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		); err != nil {
 			return nil, err
 		}
+		if multiColEnabled && extendedStatsActive {
+			if colStats, err = n.addExistingExtendedStats(ctx, tableDesc, colStats); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		columns, err := catalog.MustFindPublicColumnsByNameList(tableDesc, n.ColumnNames)
 		if err != nil {
@@ -293,6 +310,14 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 					columns[i].ColName(),
 				)
 			}
+			if (hasMCV || hasDependencies) &&
+				colinfo.ColumnTypeIsOnlyInvertedIndexable(columns[i].GetType()) {
+				return nil, pgerror.Newf(
+					pgcode.FeatureNotSupported,
+					"cannot create extended statistics on column %q of type %s",
+					columns[i].ColName(), columns[i].GetType().SQLString(),
+				)
+			}
 			columnIDs[i] = columns[i].GetID()
 		}
 		col, err := catalog.MustFindColumnByID(tableDesc, columnIDs[0])
@@ -310,6 +335,8 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 			// with a single column that doesn't use an inverted index.
 			HasHistogram:        len(columnIDs) == 1 && !isInvIndex,
 			HistogramMaxBuckets: defaultHistogramBuckets,
+			HasMCV:              hasMCV,
+			HasDependencies:     hasDependencies,
 		}}
 		// Make histograms for inverted index column types.
 		if len(columnIDs) == 1 && isInvIndex {
@@ -364,6 +391,96 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 	}, nil
 }
 
+// extendedStatisticsKinds returns which kinds of extended statistics are
+// requested by the statement, and checks that they can be collected on its
+// columns.
+func (n *createStatsNode) extendedStatisticsKinds() (hasMCV, hasDependencies bool, _ error) {
+	if len(n.Kinds) == 0 {
+		return false, false, nil
+	}
+	for _, kind := range n.Kinds {
+		switch string(kind) {
+		case stats.ExtendedStatisticsMCV:
+			hasMCV = true
+		case stats.ExtendedStatisticsDependencies:
+			hasDependencies = true
+		default:
+			return false, false, pgerror.Newf(pgcode.Syntax, "unrecognized statistics kind %q", kind)
+		}
+	}
+	if len(n.ColumnNames) < 2 {
+		return false, false, pgerror.New(pgcode.InvalidObjectDefinition,
+			"extended statistics require at least 2 columns")
+	}
+	if len(n.ColumnNames) > stats.MaxExtendedStatisticsColumns {
+		return false, false, pgerror.Newf(pgcode.TooManyColumns,
+			"cannot have more than %d columns in extended statistics", stats.MaxExtendedStatisticsColumns)
+	}
+	if n.Options.UsingExtremes {
+		return false, false, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create partial extended statistics")
+	}
+	return hasMCV, hasDependencies, nil
+}
+
+// addExistingExtendedStats adds the extended statistics which were previously
+// created on the table to the given column statistics, so that they are
+// refreshed along with the default statistics instead of being deleted.
+// Extended statistics are only kept if they are the most recent statistics on
+// their columns.
+func (n *createStatsNode) addExistingExtendedStats(
+	ctx context.Context, desc catalog.TableDescriptor, colStats []jobspb.CreateStatsDetails_ColStat,
+) ([]jobspb.CreateStatsDetails_ColStat, error) {
+	tableStats, err := n.p.ExecCfg().TableStatsCache.GetTableStats(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	colStatKey := func(columnIDs []descpb.ColumnID) string {
+		return stats.MakeSortedColStatKey(append([]descpb.ColumnID(nil), columnIDs...))
+	}
+	// The statistics are ordered with the most recent first.
+	seen := make(map[string]struct{})
+StatsLoop:
+	for _, stat := range tableStats {
+		if stat.IsPartial() || stat.IsForecast() || stat.IsMerged() {
+			continue
+		}
+		key := colStatKey(stat.ColumnIDs)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if stat.HistogramData == nil || stat.HistogramData.Extended == nil {
+			continue
+		}
+		for _, colID := range stat.ColumnIDs {
+			col := catalog.FindColumnByID(desc, colID)
+			if col == nil || !col.Public() || col.IsVirtual() {
+				continue StatsLoop
+			}
+		}
+		ext := stat.HistogramData.Extended
+		merged := false
+		for i := range colStats {
+			if !colStats[i].Inverted && colStatKey(colStats[i].ColumnIDs) == key {
+				colStats[i].ColumnIDs = stat.ColumnIDs
+				colStats[i].HasMCV = ext.HasMCV
+				colStats[i].HasDependencies = ext.HasDependencies
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
+				ColumnIDs:       stat.ColumnIDs,
+				HasMCV:          ext.HasMCV,
+				HasDependencies: ext.HasDependencies,
+			})
+		}
+	}
+	return colStats, nil
+}
+
 // maxNonIndexCols is the maximum number of non-index columns that we will use
 // when choosing a default set of column statistics.
 const maxNonIndexCols = 100
@@ -819,6 +819,14 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// referencesPrivilegeChecker is implemented by the schema resolvers that check
+// whether the current user may create a foreign key referencing a table.
+type referencesPrivilegeChecker interface {
+	checkReferencesPrivilege(
+		ctx context.Context, target catalog.TableDescriptor, referencedCols []catalog.Column,
+	) error
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -831,6 +839,8 @@ func ResolveUniqueWithoutIndexConstraint(
 // The caller should pass an instance of fkSelfResolver as
 // SchemaResolver, so that FK references can find the newly created
 // table for self-references.
+// If the SchemaResolver implements referencesPrivilegeChecker, the current
+// user must be allowed to reference the referenced columns.
 //
 // The caller must also ensure that the SchemaResolver is configured to
 // bypass caching and enable visibility of just-added descriptors.
@@ -951,6 +961,13 @@ func ResolveFK(
 			return err
 		}
 	}
+	if target.ID != tbl.ID {
+		if c, ok := sc.(referencesPrivilegeChecker); ok {
+			if err := c.checkReferencesPrivilege(ctx, target, referencedCols); err != nil {
+				return err
+			}
+		}
+	}
 
 	if len(referencedCols) != len(originCols) {
 		return pgerror.Newf(pgcode.Syntax,
@@ -2391,6 +2408,10 @@ func newTableDesc(
 		return nil, err
 	}
 
+	if err := params.p.setupColumnEncryptionForNewTable(params.ctx, ret, n); err != nil {
+		return nil, err
+	}
+
 	// We need to ensure sequence ownerships so that column owned sequences are
 	// correctly dropped when a column/table is dropped.
 	for colName, seqDesc := range colNameToOwnedSeq {
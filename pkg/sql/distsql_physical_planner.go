@@ -835,6 +835,13 @@ type PlanningCtx struct {
 	// are supported natively by the vectorized engine.
 	parallelizeScansIfLocal bool
 
+	// keepParallelLocalScanStreams, if set, indicates that the streams of the
+	// parallel TableReaders in a local plan should not be merged right away
+	// because the consumer of the scan (an aggregation or a sort) will be
+	// planned on each stream. It is only set while planning the input to such
+	// a consumer (see canKeepParallelLocalScanStreams).
+	keepParallelLocalScanStreams bool
+
 	// Set if this is either a subquery or a postquery (i.e. not the "main"
 	// query).
 	subOrPostQuery bool
@@ -1708,6 +1715,19 @@ var localScansConcurrencyLimit = settings.RegisterIntSetting(
 	settings.NonNegativeInt,
 )
 
+// localScansIntraNodeConcurrency determines the number of TableReaders that a
+// scan in a local plan is split into when all of its spans are on a single
+// node. In such a case the spans are divided into morsels at the range
+// boundaries, and the morsels are distributed among the TableReaders.
+var localScansIntraNodeConcurrency = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.local_scans.intra_node_concurrency",
+	"number of goroutines for performing a scan in local plans when all spans "+
+		"of the scan are on a single node (0 or 1 disables the intra-node parallelism)",
+	0,
+	settings.NonNegativeInt,
+)
+
 // maybeParallelizeLocalScans check whether we are planning such a TableReader
 // for the local flow that would benefit (and is safe) to parallelize.
 func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
@@ -1719,7 +1739,9 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 	// - the parallelization of scans in local flows is allowed,
 	// - there is still quota for running more parallel local TableReaders,
 	// then we will split all spans according to the leaseholder boundaries and
-	// will create a separate TableReader for each node.
+	// will create a separate TableReader for each node. If all spans are on a
+	// single node, then we might split them further according to the range
+	// boundaries (see localScansIntraNodeConcurrency).
 	sd := planCtx.ExtendedEvalCtx.SessionData()
 	// If we have locality optimized search enabled and we won't use the
 	// vectorized engine, using the parallel scans might actually be
@@ -1750,21 +1772,37 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 		for i := range spanPartitions {
 			spanPartitions[i].SQLInstanceID = dsp.gatewaySQLInstanceID
 		}
+		const maxConcurrency = 64
+		concurrencyLimit := maxConcurrency
+		if len(spanPartitions) == 1 {
+			if intraNodeConcurrency := int(localScansIntraNodeConcurrency.Get(&dsp.st.SV)); intraNodeConcurrency > 1 {
+				// All spans are on a single node, so we'll split them into
+				// morsels that are processed by multiple TableReaders.
+				morsels, err := dsp.splitSpansIntoMorsels(ctx, planCtx, spanPartitions[0].Spans)
+				if err == nil {
+					spanPartitions = morsels
+					if intraNodeConcurrency < concurrencyLimit {
+						concurrencyLimit = intraNodeConcurrency
+					}
+				}
+			}
+		}
 		if len(spanPartitions) > 1 {
-			// We're touching ranges that have leaseholders on multiple nodes,
-			// so it'd be beneficial to parallelize such a scan.
+			// We're touching ranges that have leaseholders on multiple nodes
+			// (or we have split the spans into morsels), so it'd be beneficial
+			// to parallelize such a scan.
 			//
 			// Determine the desired concurrency. The concurrency is limited by
 			// the number of partitions as well as maxConcurrency constant (the
-			// upper bound). We then try acquiring the quota for all additional
-			// goroutines, and if the quota isn't available, we reduce the
-			// proposed concurrency by 1. If in the end we didn't manage to
-			// acquire the quota even for a single additional goroutine, we
-			// won't have parallel TableReaders.
-			const maxConcurrency = 64
+			// upper bound) or the intra-node concurrency setting. We then try
+			// acquiring the quota for all additional goroutines, and if the
+			// quota isn't available, we reduce the proposed concurrency by 1.
+			// If in the end we didn't manage to acquire the quota even for a
+			// single additional goroutine, we won't have parallel
+			// TableReaders.
 			actualConcurrency := len(spanPartitions)
-			if actualConcurrency > maxConcurrency {
-				actualConcurrency = maxConcurrency
+			if actualConcurrency > concurrencyLimit {
+				actualConcurrency = concurrencyLimit
 			}
 			if quota := int(dsp.parallelLocalScansSem.ApproximateQuota()); actualConcurrency > quota {
 				actualConcurrency = quota
@@ -1811,6 +1849,72 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 	return spanPartitions, parallelizeLocal
 }
 
+// splitSpansIntoMorsels breaks up the given spans, all of which are on the
+// gateway node, according to the range boundaries. Each resulting piece (a
+// "morsel") is put into a separate SpanPartition, and the morsels are returned
+// in the order of the spans.
+func (dsp *DistSQLPlanner) splitSpansIntoMorsels(
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans,
+) ([]SpanPartition, error) {
+	it := planCtx.spanIter
+	var morsels []SpanPartition
+	for _, span := range spans {
+		if len(span.EndKey) == 0 {
+			// Point lookups cannot be split any further.
+			morsels = append(morsels, SpanPartition{dsp.gatewaySQLInstanceID, roachpb.Spans{span}})
+			continue
+		}
+		rSpan, err := keys.SpanAddr(span)
+		if err != nil {
+			return nil, err
+		}
+		lastKey := rSpan.Key
+		for it.Seek(ctx, span, kvcoord.Ascending); ; it.Next(ctx) {
+			if !it.Valid() {
+				return nil, it.Error()
+			}
+			// Limit the end key to the end of the span we are splitting.
+			endKey := it.Desc().EndKey
+			if rSpan.EndKey.Less(endKey) {
+				endKey = rSpan.EndKey
+			}
+			morsels = append(morsels, SpanPartition{
+				SQLInstanceID: dsp.gatewaySQLInstanceID,
+				Spans: roachpb.Spans{{
+					Key:    lastKey.AsRawKey(),
+					EndKey: endKey.AsRawKey(),
+				}},
+			})
+			if !endKey.Less(rSpan.EndKey) {
+				break
+			}
+			lastKey = endKey
+		}
+	}
+	return morsels, nil
+}
+
+// canKeepParallelLocalScanStreams returns whether the given planNode, which is
+// the input to an aggregation or a sort, consists only of a scan with filters
+// and renders on top. If so, the streams of the parallel local TableReaders
+// can be kept separate up to the consumer, which then processes each stream
+// concurrently (the local aggregation stage or the sorters) before the results
+// are merged.
+func canKeepParallelLocalScanStreams(n planNode) bool {
+	for {
+		switch t := n.(type) {
+		case *filterNode:
+			n = t.source.plan
+		case *renderNode:
+			n = t.source.plan
+		case *scanNode:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
 func (dsp *DistSQLPlanner) planTableReaders(
 	ctx context.Context, planCtx *PlanningCtx, p *PhysicalPlan, info *tableReaderPlanningInfo,
 ) error {
@@ -1886,7 +1990,7 @@ func (dsp *DistSQLPlanner) planTableReaders(
 	p.PlanToStreamColMap = identityMap(make([]int, len(typs)), len(typs))
 	p.SetMergeOrdering(dsp.convertOrdering(info.reqOrdering, p.PlanToStreamColMap))
 
-	if parallelizeLocal {
+	if parallelizeLocal && !planCtx.keepParallelLocalScanStreams {
 		// If we planned multiple table readers, we need to merge the streams
 		// into one.
 		p.AddSingleGroupStage(ctx, dsp.gatewaySQLInstanceID, execinfrapb.ProcessorCoreUnion{Noop: &execinfrapb.NoopCoreSpec{}}, execinfrapb.PostProcessSpec{}, p.GetResultTypes())
@@ -2215,12 +2319,14 @@ func (dsp *DistSQLPlanner) planAggregators(
 
 	// We either have a local stage on each stream followed by a final stage, or
 	// just a final stage. We only use a local stage if:
-	//  - the previous stage is distributed on multiple nodes, and
+	//  - the previous stage is distributed on multiple nodes or has multiple
+	//    streams on a single node (which is the case with parallel local
+	//    scans), and
 	//  - all aggregation functions support it, and
 	//  - no function is performing distinct aggregation.
 	//  TODO(radu): we could relax this by splitting the aggregation into two
 	//  different paths and joining on the results.
-	multiStage := prevStageNode == 0
+	multiStage := prevStageNode == 0 || len(p.ResultRouters) > 1
 	if multiStage {
 		for _, e := range info.aggregations {
 			if e.Distinct {
@@ -2632,9 +2738,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 			}
 		}
 
-		// We have multiple streams, so we definitely have a processor planned
-		// on a remote node.
-		stageID := p.NewStage(true /* containsRemoteProcessor */, info.allowPartialDistribution)
+		// We have multiple streams, so we have a processor planned on a remote
+		// node unless all streams are on the gateway.
+		stageID := p.NewStage(prevStageNode != p.GatewaySQLInstanceID /* containsRemoteProcessor */, info.allowPartialDistribution)
 
 		// We have one final stage processor for each result router. This is a
 		// somewhat arbitrary decision; we could have a different number of nodes
@@ -2893,6 +2999,41 @@ func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 		}
 	}
 
+	if n.adaptiveThreshold > 0 && n.lookupExpr == nil && len(n.reqOrdering) == 0 &&
+		!n.isFirstJoinInPairedJoiner && !n.isSecondJoinInPairedJoiner {
+		// The hash join of an adaptive join uses the fetched index columns
+		// matched by the lookup columns as its right equality columns.
+		if rightEqCols, ok := fetchedIndexColumnOrdinals(
+			&joinReaderSpec.FetchSpec, len(joinReaderSpec.LookupColumns),
+		); ok {
+			spec := &execinfrapb.AdaptiveJoinerSpec{
+				LookupJoiner: joinReaderSpec,
+				TableReader: makeAdaptiveJoinTableReaderSpec(
+					planCtx.ExtendedEvalCtx.Codec, n.table, &joinReaderSpec.FetchSpec,
+				),
+				HashJoiner: execinfrapb.HashJoinerSpec{
+					LeftEqColumns:        joinReaderSpec.LookupColumns,
+					RightEqColumns:       rightEqCols,
+					OnExpr:               joinReaderSpec.OnExpr,
+					Type:                 n.joinType,
+					RightEqColumnsAreKey: n.eqColsAreKey,
+				},
+				Threshold: uint64(n.adaptiveThreshold),
+			}
+			// A single adaptive joiner must see all input rows in order to
+			// choose the join algorithm, so it is planned on the gateway.
+			plan.AddSingleGroupStage(
+				ctx,
+				dsp.gatewaySQLInstanceID,
+				execinfrapb.ProcessorCoreUnion{AdaptiveJoiner: spec},
+				execinfrapb.PostProcessSpec{},
+				outTypes,
+			)
+			plan.PlanToStreamColMap = planToStreamColMap
+			return plan, nil
+		}
+	}
+
 	// Instantiate one join reader for every stream. This is also necessary for
 	// correctness of paired-joins where this join is the second join -- it is
 	// necessary to have a one-to-one relationship between the first and second
@@ -3230,6 +3371,12 @@ func getTypesForPlanResult(node planNode, planToStreamColMap []int) ([]*types.T,
 func (dsp *DistSQLPlanner) createPlanForJoin(
 	ctx context.Context, planCtx *PlanningCtx, n *joinNode,
 ) (*PhysicalPlan, error) {
+	if scan, ok := n.right.plan.(*scanNode); ok && n.adaptiveThreshold > 0 {
+		plan, ok, err := dsp.planAdaptiveHashJoin(ctx, planCtx, n, scan)
+		if err != nil || ok {
+			return plan, err
+		}
+	}
 	leftPlan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.left.plan)
 	if err != nil {
 		return nil, err
@@ -3343,6 +3490,7 @@ func (dsp *DistSQLPlanner) planJoiners(
 		info.leftMergeOrd, info.rightMergeOrd,
 		leftRouters, rightRouters, info.joinResultTypes,
 	)
+	dsp.maybePlanRuntimeFilter(ctx, planCtx, p, info, leftRouters, p.ResultRouters)
 
 	p.PlanToStreamColMap = info.joinToStreamColMap
 
@@ -3404,7 +3552,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 		}
 
 	case *groupNode:
+		planCtx.keepParallelLocalScanStreams = canKeepParallelLocalScanStreams(n.plan)
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
+		planCtx.keepParallelLocalScanStreams = false
 		if err != nil {
 			return nil, err
 		}
@@ -3490,7 +3640,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 		plan, err = dsp.createTableReaders(ctx, planCtx, n)
 
 	case *sortNode:
+		planCtx.keepParallelLocalScanStreams = canKeepParallelLocalScanStreams(n.plan)
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
+		planCtx.keepParallelLocalScanStreams = false
 		if err != nil {
 			return nil, err
 		}
@@ -3498,7 +3650,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 		dsp.addSorters(ctx, plan, n.ordering, n.alreadyOrderedPrefix, 0 /* limit */)
 
 	case *topKNode:
+		planCtx.keepParallelLocalScanStreams = canKeepParallelLocalScanStreams(n.plan)
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
+		planCtx.keepParallelLocalScanStreams = false
 		if err != nil {
 			return nil, err
 		}
@@ -4634,7 +4788,7 @@ func maybeMoveSingleFlowToGateway(planCtx *PlanningCtx, plan *PhysicalPlan, rowC
 			}
 			core := p.Spec.Core
 			if core.JoinReader != nil || core.MergeJoiner != nil || core.HashJoiner != nil ||
-				core.ZigzagJoiner != nil || core.InvertedJoiner != nil {
+				core.ZigzagJoiner != nil || core.InvertedJoiner != nil || core.AdaptiveJoiner != nil {
 				// We want to move the flow when it contains a processor that
 				// might increase the cardinality of the data flowing through it
 				// or that performs the KV work.
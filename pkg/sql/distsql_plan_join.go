@@ -14,14 +14,22 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/span"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
 
@@ -80,6 +88,106 @@ func (info *joinPlanningInfo) makeCoreSpec() execinfrapb.ProcessorCoreUnion {
 	return core
 }
 
+var runtimeFiltersEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.runtime_filters.enabled",
+	"if set, distributed hash joiners send a filter built from their right input "+
+		"to the table readers producing their left input, which then discard the "+
+		"rows that cannot have a match",
+	false,
+).WithPublic()
+
+var runtimeFilterBloomFilterBits = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.distsql.runtime_filters.bloom_filter_bits",
+	"size in bits of the bloom filter of runtime filters; if zero, only the "+
+		"minimum and maximum values of the equality columns are used",
+	1<<20,
+	settings.NonNegativeIntWithMaximum(1<<27),
+)
+
+var runtimeFilterMaxBuildRows = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.distsql.runtime_filters.max_build_rows",
+	"maximum number of rows of the right input of a hash joiner for which a "+
+		"runtime filter is built",
+	100000,
+	settings.PositiveInt,
+)
+
+// maybePlanRuntimeFilter sets up a runtime filter between the hash joiners
+// (joiners) planned according to info and the processors producing their left
+// input (leftRouters), if the filter can only discard left rows that have no
+// match and it can save some network traffic. Runtime filters are only planned
+// once all nodes have been upgraded, since the hash joiners of older nodes
+// would never send the filters that the table readers wait for.
+//
+// Each hash joiner builds a filter from the equality columns of the rows of its
+// right input and sends it to every left processor once the right input has
+// been consumed. The left processors wait for the filters of all joiners
+// before producing any row: since every joiner only sees its share of the
+// right input, a row is discarded only if it cannot match according to any of
+// the filters.
+func (dsp *DistSQLPlanner) maybePlanRuntimeFilter(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	p *PhysicalPlan,
+	info *joinPlanningInfo,
+	leftRouters, joiners []physicalplan.ProcessorIdx,
+) {
+	if !runtimeFiltersEnabled.Get(&dsp.st.SV) || len(info.leftEqCols) == 0 ||
+		len(info.leftMergeOrd.Columns) != 0 {
+		return
+	}
+	if !dsp.st.Version.IsActive(ctx, clusterversion.V23_2_RuntimeFilters) {
+		return
+	}
+	// Only the vectorized engine supports runtime filters.
+	if planCtx.ExtendedEvalCtx.SessionData().VectorizeMode == sessiondatapb.VectorizeOff {
+		return
+	}
+	switch info.joinType {
+	case descpb.InnerJoin, descpb.LeftSemiJoin, descpb.RightOuterJoin,
+		descpb.RightSemiJoin, descpb.RightAntiJoin:
+		// The left rows that don't have a match are not emitted by these joins.
+	default:
+		return
+	}
+	leftTypes, rightTypes := info.leftPlan.GetResultTypes(), info.rightPlan.GetResultTypes()
+	for i := range info.leftEqCols {
+		leftType, rightType := leftTypes[info.leftEqCols[i]], rightTypes[info.rightEqCols[i]]
+		// The filters compare the key encodings of the values, which only
+		// preserve equality for the values of the same type.
+		if !leftType.Identical(rightType) || !colinfo.ColumnTypeIsIndexable(leftType) {
+			return
+		}
+	}
+	remote := false
+	for _, pIdx := range leftRouters {
+		proc := &p.Processors[pIdx]
+		if proc.Spec.Core.TableReader == nil || proc.Spec.RuntimeFilterConsumer != nil {
+			return
+		}
+		for _, joiner := range joiners {
+			if p.Processors[joiner].SQLInstanceID != proc.SQLInstanceID {
+				remote = true
+			}
+		}
+	}
+	if !remote {
+		// The left rows wouldn't be sent over the network anyway.
+		return
+	}
+	p.AddRuntimeFilter(
+		joiners, leftRouters,
+		execinfrapb.RuntimeFilterProducerSpec{
+			BloomFilterBits: uint32(runtimeFilterBloomFilterBits.Get(&dsp.st.SV)),
+			MaxKeys:         uint64(runtimeFilterMaxBuildRows.Get(&dsp.st.SV)),
+		},
+		info.leftEqCols,
+	)
+}
+
 // joinPlanningHelper is a utility struct that helps with the physical planning
 // of joins.
 type joinPlanningHelper struct {
@@ -226,3 +334,156 @@ func findJoinProcessorNodes(
 	// data for either source. In the future we should be smarter here.
 	return getSQLInstanceIDsOfRouters(append(leftRouters, rightRouters...), processors)
 }
+
+// planAdaptiveHashJoin creates a plan for a hash join against a full scan of
+// an index which is executed as an adaptive join: if the left input has at
+// most n.adaptiveThreshold rows, the adaptive joiner performs lookups into the
+// index instead of scanning it. It returns ok=false if the join cannot be
+// executed as an adaptive join, in which case a regular hash join should be
+// planned.
+func (dsp *DistSQLPlanner) planAdaptiveHashJoin(
+	ctx context.Context, planCtx *PlanningCtx, n *joinNode, scan *scanNode,
+) (_ *PhysicalPlan, ok bool, _ error) {
+	if len(n.mergeJoinOrdering) > 0 || len(n.reqOrdering) > 0 ||
+		scan.hardLimit != 0 || scan.reverse {
+		return nil, false, nil
+	}
+	// The lookup columns must be a prefix of the index key columns, in index
+	// order, so reorder the equality columns accordingly.
+	numEqCols := len(n.pred.rightEqualityIndices)
+	if numEqCols == 0 || numEqCols > scan.index.NumKeyColumns() {
+		return nil, false, nil
+	}
+	leftEqIndices := make([]exec.NodeColumnOrdinal, numEqCols)
+	rightEqIndices := make([]exec.NodeColumnOrdinal, numEqCols)
+	for i := 0; i < numEqCols; i++ {
+		colID := scan.index.GetKeyColumnID(i)
+		found := false
+		for j, rightIdx := range n.pred.rightEqualityIndices {
+			if scan.cols[rightIdx].GetID() == colID {
+				leftEqIndices[i] = n.pred.leftEqualityIndices[j]
+				rightEqIndices[i] = rightIdx
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false, nil
+		}
+	}
+
+	codec := planCtx.ExtendedEvalCtx.Codec
+	trSpec, _, err := initTableReaderSpecTemplate(scan, codec)
+	if err != nil {
+		return nil, false, err
+	}
+	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.left.plan)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The table reader outputs the columns of the scan in order.
+	rightMap := identityMap(nil /* buf */, len(scan.cols))
+	helper := &joinPlanningHelper{
+		numLeftOutCols:          n.pred.numLeftCols,
+		numRightOutCols:         n.pred.numRightCols,
+		numAllLeftCols:          len(plan.GetResultTypes()),
+		leftPlanToStreamColMap:  plan.PlanToStreamColMap,
+		rightPlanToStreamColMap: rightMap,
+	}
+	post, joinToStreamColMap := helper.joinOutColumns(n.pred.joinType, n.columns)
+	onExpr, err := helper.remapOnExpr(ctx, planCtx, n.pred.onCond)
+	if err != nil {
+		return nil, false, err
+	}
+	leftEqCols := eqCols(leftEqIndices, plan.PlanToStreamColMap)
+	rightEqCols := eqCols(rightEqIndices, rightMap)
+	joinResultTypes, err := getTypesForPlanResult(n, joinToStreamColMap)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var fetchOrdinals intsets.Fast
+	for i := range scan.cols {
+		fetchOrdinals.Add(scan.cols[i].Ordinal())
+	}
+	splitter := span.MakeSplitter(scan.desc, scan.index, fetchOrdinals)
+	spec := &execinfrapb.AdaptiveJoinerSpec{
+		LookupJoiner: execinfrapb.JoinReaderSpec{
+			FetchSpec:             trSpec.FetchSpec,
+			SplitFamilyIDs:        splitter.FamilyIDs(),
+			LookupColumns:         leftEqCols,
+			LookupColumnsAreKey:   n.pred.rightEqKey,
+			OnExpr:                onExpr,
+			Type:                  n.pred.joinType,
+			LockingStrength:       scan.lockingStrength,
+			LockingWaitPolicy:     scan.lockingWaitPolicy,
+			LookupBatchBytesLimit: dsp.distSQLSrv.TestingKnobs.JoinReaderBatchBytesLimit,
+		},
+		TableReader: makeAdaptiveJoinTableReaderSpec(codec, scan, &trSpec.FetchSpec),
+		HashJoiner: execinfrapb.HashJoinerSpec{
+			LeftEqColumns:        leftEqCols,
+			RightEqColumns:       rightEqCols,
+			OnExpr:               onExpr,
+			Type:                 n.pred.joinType,
+			LeftEqColumnsAreKey:  n.pred.leftEqKey,
+			RightEqColumnsAreKey: n.pred.rightEqKey,
+		},
+		Threshold:       uint64(n.adaptiveThreshold),
+		PlannedHashJoin: true,
+	}
+	plan.AddSingleGroupStage(
+		ctx,
+		dsp.gatewaySQLInstanceID,
+		execinfrapb.ProcessorCoreUnion{AdaptiveJoiner: spec},
+		post,
+		joinResultTypes,
+	)
+	plan.PlanToStreamColMap = joinToStreamColMap
+	return plan, true, nil
+}
+
+// makeAdaptiveJoinTableReaderSpec returns the spec of the table reader which
+// scans the entire index of the given scanNode when an adaptive joiner
+// executes a hash join.
+func makeAdaptiveJoinTableReaderSpec(
+	codec keys.SQLCodec, n *scanNode, fetchSpec *fetchpb.IndexFetchSpec,
+) execinfrapb.TableReaderSpec {
+	return execinfrapb.TableReaderSpec{
+		FetchSpec:                       *fetchSpec,
+		Spans:                           []roachpb.Span{n.desc.IndexSpan(codec, n.index.GetID())},
+		TableDescriptorModificationTime: n.desc.GetModificationTime(),
+		LockingStrength:                 n.lockingStrength,
+		LockingWaitPolicy:               n.lockingWaitPolicy,
+		// Adaptive joiners are planned on the gateway, so the scanned ranges
+		// are usually not local.
+		IgnoreMisplannedRanges: true,
+	}
+}
+
+// fetchedIndexColumnOrdinals returns the ordinals within the fetched columns
+// of the first numCols key and suffix columns of the index. It returns
+// ok=false if any of them is not fetched.
+func fetchedIndexColumnOrdinals(
+	fetchSpec *fetchpb.IndexFetchSpec, numCols int,
+) (_ []uint32, ok bool) {
+	if numCols > len(fetchSpec.KeyAndSuffixColumns) {
+		return nil, false
+	}
+	ords := make([]uint32, numCols)
+	for i := range ords {
+		colID := fetchSpec.KeyAndSuffixColumns[i].ColumnID
+		found := false
+		for j := range fetchSpec.FetchedColumns {
+			if fetchSpec.FetchedColumns[j].ColumnID == colID {
+				ords[i] = uint32(j)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return ords, true
+}
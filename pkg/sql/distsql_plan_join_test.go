@@ -0,0 +1,85 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuntimeFiltersRequireUpgrade verifies that the hash joiners only send
+// runtime filters to the table readers once the cluster has been upgraded to a
+// version which all nodes support them in.
+func TestRuntimeFiltersRequireUpgrade(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	skip.UnderRace(t, "multi-node test")
+
+	ctx := context.Background()
+	const numNodes = 3
+	tc := serverutils.StartNewTestCluster(t, numNodes, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					BinaryVersionOverride: clusterversion.ByKey(
+						clusterversion.V23_2_RuntimeFilters - 1),
+				},
+			},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	db.Exec(t, `SET CLUSTER SETTING sql.distsql.runtime_filters.enabled = true`)
+	db.Exec(t, `SET distsql = always`)
+	db.Exec(t, `CREATE TABLE fact (k INT PRIMARY KEY, d INT)`)
+	db.Exec(t, `CREATE TABLE dim (d INT PRIMARY KEY, region STRING)`)
+	db.Exec(t, `INSERT INTO fact SELECT i, i % 10 FROM generate_series(1, 300) AS g(i)`)
+	db.Exec(t, `INSERT INTO dim SELECT i, IF(i = 3, 'east', 'west') FROM generate_series(0, 9) AS g(i)`)
+	// Spread the fact table over all nodes, so that the hash joiners receive
+	// rows from remote table readers.
+	db.Exec(t, `ALTER TABLE fact SPLIT AT VALUES (100), (200)`)
+	db.Exec(t, fmt.Sprintf(
+		`ALTER TABLE fact EXPERIMENTAL_RELOCATE VALUES (ARRAY[%d], 0), (ARRAY[%d], 100), (ARRAY[%d], 200)`,
+		tc.Server(0).GetFirstStoreID(), tc.Server(1).GetFirstStoreID(), tc.Server(2).GetFirstStoreID(),
+	))
+	// Populate the range cache.
+	db.Exec(t, `SELECT count(*) FROM fact`)
+
+	const query = `SELECT count(*) FROM fact INNER HASH JOIN dim ON fact.d = dim.d WHERE dim.region = 'east'`
+	hasRuntimeFilter := func() bool {
+		var diagram string
+		db.QueryRow(t, `EXPLAIN (DISTSQL, JSON) `+query).Scan(&diagram)
+		require.Contains(t, diagram, `"nodeNames":["1","2","3"]`)
+		return strings.Contains(diagram, "Runtime filter")
+	}
+	require.False(t, hasRuntimeFilter())
+	db.CheckQueryResults(t, query, [][]string{{"30"}})
+
+	db.Exec(t, `SET CLUSTER SETTING version = crdb_internal.node_executable_version()`)
+	require.True(t, hasRuntimeFilter())
+	db.CheckQueryResults(t, query, [][]string{{"30"}})
+}
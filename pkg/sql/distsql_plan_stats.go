@@ -15,6 +15,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -42,6 +43,8 @@ type requestedStat struct {
 	histogramMaxBuckets uint32
 	name                string
 	inverted            bool
+	mcv                 bool
+	dependencies        bool
 }
 
 // histogramSamples is the number of sample rows to be collected for histogram
@@ -88,7 +91,8 @@ func (dsp *DistSQLPlanner) createAndAttachSamplers(
 	// For partial statistics this loop should only iterate once
 	// since we only support one reqStat at a time.
 	for _, s := range reqStats {
-		if s.histogram {
+		// Histograms and extended statistics are computed from the sampled rows.
+		if s.histogram || s.mcv || s.dependencies {
 			if count, ok := desc.HistogramSamplesCount(); ok {
 				sampler.SampleSize = count
 			} else {
@@ -416,11 +420,13 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 	sampledColumnIDs := make([]descpb.ColumnID, len(scan.cols))
 	for _, s := range reqStats {
 		spec := execinfrapb.SketchSpec{
-			SketchType:          execinfrapb.SketchType_HLL_PLUS_PLUS_V1,
-			GenerateHistogram:   s.histogram,
-			HistogramMaxBuckets: s.histogramMaxBuckets,
-			Columns:             make([]uint32, len(s.columns)),
-			StatName:            s.name,
+			SketchType:           execinfrapb.SketchType_HLL_PLUS_PLUS_V1,
+			GenerateHistogram:    s.histogram,
+			HistogramMaxBuckets:  s.histogramMaxBuckets,
+			Columns:              make([]uint32, len(s.columns)),
+			StatName:             s.name,
+			GenerateMCV:          s.mcv,
+			GenerateDependencies: s.dependencies,
 		}
 		for i, colID := range s.columns {
 			colIdx, ok := colIdxMap.Get(colID)
@@ -485,6 +491,9 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 	histogramCollectionEnabled := stats.HistogramClusterMode.Get(&dsp.st.SV)
 	tableDesc := tabledesc.NewBuilder(&details.Table).BuildImmutableTable()
 	defaultHistogramBuckets := stats.GetDefaultHistogramBuckets(&dsp.st.SV, tableDesc)
+	// Extended statistics are not collected until all the nodes can decode
+	// them.
+	extendedStatsActive := dsp.st.Version.IsActive(ctx, clusterversion.V23_2_ExtendedStatistics)
 	for i := 0; i < len(reqStats); i++ {
 		histogram := details.ColumnStats[i].HasHistogram && histogramCollectionEnabled
 		var histogramMaxBuckets = defaultHistogramBuckets
@@ -500,6 +509,8 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 			histogramMaxBuckets: histogramMaxBuckets,
 			name:                details.Name,
 			inverted:            details.ColumnStats[i].Inverted,
+			mcv:                 details.ColumnStats[i].HasMCV && extendedStatsActive,
+			dependencies:        details.ColumnStats[i].HasDependencies && extendedStatsActive,
 		}
 	}
 
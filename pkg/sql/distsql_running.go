@@ -441,6 +441,17 @@ func (dsp *DistSQLPlanner) setupFlows(
 			}
 		}
 	}
+	if !isVectorized {
+		// The row-based engine doesn't support runtime filters, so we remove
+		// them from the specs (otherwise the consumers in the remote flows
+		// would wait for the streams that are never connected).
+		for _, spec := range flows {
+			for i := range spec.Processors {
+				spec.Processors[i].RuntimeFilterProducer = nil
+				spec.Processors[i].RuntimeFilterConsumer = nil
+			}
+		}
+	}
 	if !planCtx.subOrPostQuery && planCtx.planner != nil && isVectorized {
 		// Only set the vectorized flag for the main query (to be consistent
 		// with the 'vectorized' attribute of the EXPLAIN output).
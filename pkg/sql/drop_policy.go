@@ -0,0 +1,86 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+type dropPolicyNode struct {
+	n         *tree.DropPolicy
+	tableDesc *tabledesc.Mutable
+	policyID  descpb.PolicyID
+}
+
+// DropPolicy removes a row-level security policy from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	tableDesc, _, err := p.resolveTableForPolicy(ctx, n.TableName, "DROP POLICY")
+	if err != nil {
+		return nil, err
+	}
+	policy := tableDesc.FindPolicyByName(string(n.PolicyName))
+	if policy == nil {
+		if n.IfExists {
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, newUndefinedPolicyError(n.PolicyName, tableDesc.GetName())
+	}
+	// Nothing depends on a policy, so CASCADE and RESTRICT behave the same.
+	return &dropPolicyNode{n: n, tableDesc: tableDesc, policyID: policy.ID}, nil
+}
+
+func (n *dropPolicyNode) startExec(params runParams) error {
+	n.tableDesc.RemovePolicy(n.policyID)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPolicyNode) Close(context.Context)        {}
+
+// removePoliciesDependingOnColumn removes the row-level security policies of
+// the table whose expressions reference the given column, which is being
+// dropped. As in Postgres, this is only allowed with CASCADE.
+func removePoliciesDependingOnColumn(
+	tableDesc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	var toRemove []descpb.PolicyID
+	for i := range tableDesc.Policies {
+		p := &tableDesc.Policies[i]
+		if !catalog.MakeTableColSet(p.ColumnIDs...).Contains(col.GetID()) {
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return errors.WithHintf(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop column %q because policy %q on table %q depends on it",
+					col.GetName(), p.Name, tableDesc.GetName()),
+				"you can drop policy %q instead, or use CASCADE to drop it with the column.", p.Name,
+			)
+		}
+		toRemove = append(toRemove, p.ID)
+	}
+	for _, id := range toRemove {
+		tableDesc.RemovePolicy(id)
+	}
+	return nil
+}
@@ -81,6 +81,7 @@ type objectAndType struct {
 	ObjectName         string
 	IsDefaultPrivilege bool
 	IsGlobalPrivilege  bool
+	IsPolicyTarget     bool
 	ErrorMessage       error
 }
 
@@ -179,18 +180,38 @@ func (n *DropRoleNode) startExec(params runParams) error {
 					ObjectName: tn.String(),
 				})
 		}
-		for _, u := range tableDescriptor.GetPrivileges().Users {
-			if _, ok := userNames[u.User()]; ok {
-				if privilegeObjectFormatter.Len() > 0 {
-					privilegeObjectFormatter.WriteString(", ")
+		// Roles cannot be dropped while a row-level security policy applies to
+		// them, as in Postgres.
+		for i := range tableDescriptor.GetPolicies() {
+			policy := &tableDescriptor.GetPolicies()[i]
+			for _, roleName := range policy.RoleNames {
+				role := username.MakeSQLUsernameFromPreNormalizedString(roleName)
+				if _, ok := userNames[role]; !ok {
+					continue
 				}
-				parentName := lCtx.getDatabaseName(tableDescriptor)
-				schemaName := lCtx.getSchemaName(tableDescriptor)
-				tn := tree.MakeTableNameWithSchema(tree.Name(parentName), tree.Name(schemaName), tree.Name(tableDescriptor.GetName()))
-				privilegeObjectFormatter.FormatNode(&tn)
-				break
+				tn, err := getTableNameFromTableDescriptor(lCtx, tableDescriptor, "")
+				if err != nil {
+					return err
+				}
+				userNames[role] = append(userNames[role], objectAndType{
+					ObjectType:     privilege.Table,
+					ObjectName:     tn.String(),
+					IsPolicyTarget: true,
+					ErrorMessage: errors.Newf(
+						"target of policy %s on table %s", tree.Name(policy.Name), tn.String(),
+					),
+				})
 			}
 		}
+		if tableHasPrivilegesForRoles(tableDescriptor, userNames) {
+			if privilegeObjectFormatter.Len() > 0 {
+				privilegeObjectFormatter.WriteString(", ")
+			}
+			parentName := lCtx.getDatabaseName(tableDescriptor)
+			schemaName := lCtx.getSchemaName(tableDescriptor)
+			tn := tree.MakeTableNameWithSchema(tree.Name(parentName), tree.Name(schemaName), tree.Name(tableDescriptor.GetName()))
+			privilegeObjectFormatter.FormatNode(&tn)
+		}
 	}
 	for _, schemaDesc := range lCtx.schemaDescs {
 		if !descriptorIsVisible(schemaDesc, true /* allowAdding */) {
@@ -335,7 +356,7 @@ func (n *DropRoleNode) startExec(params runParams) error {
 					hasDependentDefaultPrivilege = true
 					objectsMsg.WriteString(fmt.Sprintf("\n%s", obj.ErrorMessage))
 					hints = append(hints, errors.GetAllHints(obj.ErrorMessage)...)
-				} else if obj.IsGlobalPrivilege {
+				} else if obj.IsGlobalPrivilege || obj.IsPolicyTarget {
 					objectsMsg.WriteString(fmt.Sprintf("\n%s", obj.ErrorMessage))
 				} else {
 					objectsMsg.WriteString(fmt.Sprintf("\nowner of %s %s", obj.ObjectType, obj.ObjectName))
@@ -462,6 +483,10 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+
+		if err := params.p.deletePasswordPolicyState(params.ctx, opName, normalizedUsername); err != nil {
+			return err
+		}
 	}
 
 	// Bump role-related table versions to force a refresh of membership/auth
@@ -509,6 +534,26 @@ func (*DropRoleNode) Values() tree.Datums { return tree.Datums{} }
 // Close implements the planNode interface.
 func (*DropRoleNode) Close(context.Context) {}
 
+// tableHasPrivilegesForRoles returns true if any of the given roles has been
+// granted privileges on the table or on any of its columns.
+func tableHasPrivilegesForRoles(
+	desc catalog.TableDescriptor, roles map[username.SQLUsername][]objectAndType,
+) bool {
+	for _, u := range desc.GetPrivileges().Users {
+		if _, ok := roles[u.User()]; ok {
+			return true
+		}
+	}
+	for _, col := range desc.DeletableColumns() {
+		for _, u := range col.ColumnDesc().Privileges {
+			if _, ok := roles[u.User()]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // accumulateDependentDefaultPrivileges checks for any default privileges
 // that the users in userNames have and append them to the objectAndType array.
 func accumulateDependentDefaultPrivileges(
@@ -712,19 +712,13 @@ VALUES($1, $2, $3, $4, 0)`
 	for i := 0; i < len(entries); i++ {
 		event := entries[i]
 
-		infoBytes := redact.RedactableBytes("{")
-		_, infoBytes = event.AppendJSONFields(false /* printComma */, infoBytes)
-		infoBytes = append(infoBytes, '}')
-		// In the system.eventlog table, we do not use redaction markers.
-		// (compatibility with previous versions of CockroachDB.)
-		infoBytes = infoBytes.StripMarkers()
 		eventType := event.CommonDetails().EventType
 		args = append(
 			args,
 			timeutil.Unix(0, event.CommonDetails().Timestamp),
 			eventType,
 			reportingID,
-			string(infoBytes),
+			eventInfoJSON(event),
 		)
 
 		events[i] = otel_logs_pb.LogRecord{
@@ -760,6 +754,17 @@ VALUES($1, $2, $3, $4, 0)`
 	return query, args, events
 }
 
+// eventInfoJSON returns the payload of the event as a JSON object, as
+// stored in the info column of the system.eventlog table.
+func eventInfoJSON(event logpb.EventPayload) string {
+	infoBytes := redact.RedactableBytes("{")
+	_, infoBytes = event.AppendJSONFields(false /* printComma */, infoBytes)
+	infoBytes = append(infoBytes, '}')
+	// In the system.eventlog table, we do not use redaction markers.
+	// (compatibility with previous versions of CockroachDB.)
+	return string(infoBytes.StripMarkers())
+}
+
 func writeToSystemEventsTable(
 	ctx context.Context, txn isql.Txn, numEntries int, query string, args []interface{},
 ) error {
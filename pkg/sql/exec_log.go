@@ -236,6 +236,9 @@ func (p *planner) maybeLogStatementInternal(
 			entries[idx] = auditEvent
 		}
 		p.logEventsOnlyExternally(ctx, isCopy, entries...)
+		// The events are written to system.audit_log after they were
+		// logged, which populated their common fields.
+		p.writeAuditEventsToTable(ctx, entries)
 	}
 
 	if slowQueryLogEnabled && (
@@ -66,6 +66,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/columnencryption"
 	"github.com/cockroachdb/cockroach/pkg/sql/contention"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsql"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
@@ -100,6 +101,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilegecache"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
@@ -1319,6 +1321,9 @@ type ExecutorConfig struct {
 	// and per-role default settings.
 	SessionInitCache *sessioninit.Cache
 
+	// StatementHintsCache caches the contents of system.statement_hints.
+	StatementHintsCache *stmthints.Cache
+
 	// ProtectedTimestampProvider encapsulates the protected timestamp subsystem.
 	ProtectedTimestampProvider protectedts.Provider
 
@@ -1452,6 +1457,14 @@ type ExecutorConfig struct {
 	// AutoConfigProvider informs the auto config runner job of new
 	// tasks to run.
 	AutoConfigProvider acprovider.Provider
+
+	// AuditLogWriter writes the audit events to the system.audit_log table
+	// when sql.audit_log.table.enabled is set.
+	AuditLogWriter *AuditLogWriter
+
+	// ColumnEncryptionKeyCache caches the unwrapped data keys of the
+	// encrypted columns.
+	ColumnEncryptionKeyCache *columnencryption.KeyCache
 }
 
 // UpdateVersionSystemSettingHook provides a callback that allows us
@@ -3620,6 +3633,14 @@ func (m *sessionDataMutator) SetDurableLockingForSerializable(val bool) {
 	m.data.DurableLockingForSerializable = val
 }
 
+func (m *sessionDataMutator) SetPlanCacheMode(val sessiondatapb.PlanCacheMode) {
+	m.data.PlanCacheMode = val
+}
+
+func (m *sessionDataMutator) SetOptimizerUseExtendedStatistics(val bool) {
+	m.data.OptimizerUseExtendedStatistics = val
+}
+
 // Utility functions related to scrubbing sensitive information on SQL Stats.
 
 // quantizeCounts ensures that the Count field in the
@@ -64,7 +64,7 @@ import "github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 //
 // ATTENTION: When updating these fields, add a brief description of what
 // changed to the version history below.
-const Version execinfrapb.DistSQLVersion = 71
+const Version execinfrapb.DistSQLVersion = 73
 
 // MinAcceptedVersion is the oldest version that the server is compatible with.
 // A server will not accept flows with older versions.
@@ -76,6 +76,15 @@ const MinAcceptedVersion execinfrapb.DistSQLVersion = 71
 
 Please add new entries at the top.
 
+- Version: 73 (MinAcceptedVersion: 71)
+  - AdaptiveJoinerSpec has been introduced. Adaptive joiners are always
+    planned on the gateway, so nodes on older versions never receive them.
+
+- Version: 72 (MinAcceptedVersion: 71)
+  - RuntimeFilterProducerSpec and RuntimeFilterConsumerSpec have been added to
+    ProcessorSpec. Nodes on older versions don't connect the runtime filter
+    streams, so they can't participate in flows that use runtime filters.
+
 - Version: 71 (MinAcceptedVersion: 71)
   - On-wire representation of booleans and bytes-like values in the Arrow format
     has changed.
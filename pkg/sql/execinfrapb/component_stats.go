@@ -215,6 +215,9 @@ func (s *ComponentStats) formatStats(fn func(suffix string, value interface{}))
 	if s.Exec.CPUTime.HasValue() {
 		fn("sql cpu time", humanizeutil.Duration(s.Exec.CPUTime.Value()))
 	}
+	if s.Exec.AdaptiveJoinSwitches.HasValue() {
+		fn("adaptive join switches", humanizeutil.Count(s.Exec.AdaptiveJoinSwitches.Value()))
+	}
 
 	// Output stats.
 	if s.Output.NumBatches.HasValue() {
@@ -338,6 +341,9 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 	if !result.Exec.CPUTime.HasValue() {
 		result.Exec.CPUTime = other.Exec.CPUTime
 	}
+	if !result.Exec.AdaptiveJoinSwitches.HasValue() {
+		result.Exec.AdaptiveJoinSwitches = other.Exec.AdaptiveJoinSwitches
+	}
 
 	// Output stats.
 	if !result.Output.NumBatches.HasValue() {
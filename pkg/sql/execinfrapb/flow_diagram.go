@@ -244,6 +244,19 @@ func (hj *HashJoinerSpec) summary() (string, []string) {
 	return name, details
 }
 
+func (rf *RuntimeFilterProducerSpec) summary() string {
+	if rf.BloomFilterBits == 0 {
+		return fmt.Sprintf("Runtime filter: min/max (max keys: %d)", rf.MaxKeys)
+	}
+	return fmt.Sprintf(
+		"Runtime filter: min/max, bloom %d bits (max keys: %d)", rf.BloomFilterBits, rf.MaxKeys,
+	)
+}
+
+func (rf *RuntimeFilterConsumerSpec) summary() string {
+	return fmt.Sprintf("Runtime filter on: %s", colListStr(rf.KeyColumns))
+}
+
 // summary implements the diagramCellType interface.
 func (ifs *InvertedFiltererSpec) summary() (string, []string) {
 	name := "InvertedFilterer"
@@ -617,6 +630,18 @@ func (s *HashGroupJoinerSpec) summary() (string, []string) {
 	return "HashGroupJoiner", details
 }
 
+// summary implements the diagramCellType interface.
+func (s *AdaptiveJoinerSpec) summary() (string, []string) {
+	_, details := s.LookupJoiner.summary()
+	details = append(details, fmt.Sprintf("Threshold: %d", s.Threshold))
+	if s.PlannedHashJoin {
+		details = append(details, "Planned: hash join")
+	} else {
+		details = append(details, "Planned: lookup join")
+	}
+	return "AdaptiveJoiner", details
+}
+
 // summary implements the diagramCellType interface.
 func (g *GenerativeSplitAndScatterSpec) summary() (string, []string) {
 	detail := fmt.Sprintf("%d import spans, %d checkpointed spans", g.NumEntries, len(g.CheckpointedSpans))
@@ -808,6 +833,12 @@ func generateDiagramData(
 			proc.Core.Title += fmt.Sprintf("/%d", p.ProcessorID)
 			proc.ProcessorID = p.ProcessorID
 			proc.Core.Details = append(proc.Core.Details, p.Post.summary()...)
+			if p.RuntimeFilterProducer != nil {
+				proc.Core.Details = append(proc.Core.Details, p.RuntimeFilterProducer.summary())
+			}
+			if p.RuntimeFilterConsumer != nil {
+				proc.Core.Details = append(proc.Core.Details, p.RuntimeFilterConsumer.summary())
+			}
 
 			// We need explicit synchronizers if we have multiple inputs, or if the
 			// one input has multiple input streams.
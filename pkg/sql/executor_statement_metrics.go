@@ -214,6 +214,7 @@ func (ex *connExecutor) recordStatementSummary(
 		FullScan:             fullScan,
 		ExecStats:            queryLevelStats,
 		Indexes:              planner.instrumentation.indexesUsed,
+		StatementHints:       planner.instrumentation.statementHints,
 		Database:             planner.SessionData().Database,
 	}
 
@@ -42,6 +42,11 @@ type explainPlanNode struct {
 	flags explain.Flags
 	plan  *explain.Plan
 	run   explainPlanNodeRun
+
+	// hypotheticalIndexes are the names of the hypothetical indexes that the
+	// optimizer considered for the plan. If set, the plan is not executable and
+	// only the explain.Plan was built.
+	hypotheticalIndexes []string
 }
 
 type explainPlanNodeRun struct {
@@ -50,12 +55,27 @@ type explainPlanNodeRun struct {
 
 func (e *explainPlanNode) startExec(params runParams) error {
 	ob := explain.NewOutputBuilder(e.flags)
-	plan := e.plan.WrappedPlan.(*planComponents)
 
 	var rows []string
 	if e.options.Mode == tree.ExplainGist {
 		rows = []string{e.plan.Gist.String()}
+	} else if len(e.hypotheticalIndexes) > 0 {
+		// There is no physical plan for a plan with hypothetical indexes, so the
+		// "distribution" and "vectorized" rows are omitted.
+		ob.AddHypotheticalIndexes(e.hypotheticalIndexes)
+		if hints := params.p.instrumentation.statementHints; len(hints) > 0 {
+			ob.AddStatementHints(hints)
+		}
+		if c := params.p.instrumentation.costCalibration; !c.IsDefault() {
+			ob.AddCostCalibration(c.String())
+		}
+		if err := emitExplain(ob, params.EvalContext(), params.p.ExecCfg().Codec, e.plan); err != nil {
+			return err
+		}
+		rows = ob.BuildStringRows()
 	} else {
+		plan := e.plan.WrappedPlan.(*planComponents)
+
 		// Determine the "distribution" and "vectorized" values, which we will emit as
 		// special rows.
 
@@ -130,6 +150,12 @@ func (e *explainPlanNode) startExec(params runParams) error {
 			// For the JSON flag, we only want to emit the diagram JSON.
 			rows = []string{diagramJSON}
 		} else {
+			if hints := params.p.instrumentation.statementHints; len(hints) > 0 {
+				ob.AddStatementHints(hints)
+			}
+			if c := params.p.instrumentation.costCalibration; !c.IsDefault() {
+				ob.AddCostCalibration(c.String())
+			}
 			if err := emitExplain(ob, params.EvalContext(), params.p.ExecCfg().Codec, e.plan); err != nil {
 				return err
 			}
@@ -205,8 +231,15 @@ func emitExplain(
 		if table.IsVirtualTable() {
 			return "<virtual table spans>"
 		}
+		if hypTable, ok := table.(*indexrec.HypotheticalTable); ok {
+			table = hypTable.Table
+		}
+		optIdx, ok := index.(*optIndex)
+		if !ok {
+			return "<hypothetical index spans>"
+		}
 		tabDesc := table.(*optTable).desc
-		idx := index.(*optIndex).idx
+		idx := optIdx.idx
 		spans, err := generateScanSpans(evalCtx, codec, tabDesc, idx, scanParams)
 		if err != nil {
 			return err.Error()
@@ -243,6 +276,13 @@ func (e *explainPlanNode) Close(ctx context.Context) {
 			panic(errors.AssertionFailedf("unknown plan node type %T", n))
 		}
 	}
+	if e.plan.WrappedPlan == nil {
+		// Only the explain.Plan was built, see hypotheticalIndexes.
+		if e.run.results != nil {
+			e.run.results.Close(ctx)
+		}
+		return
+	}
 	// The wrapped node can be planNode or planMaybePhysical.
 	closeNode(e.plan.Root.WrappedNode())
 	for i := range e.plan.Subqueries {
@@ -317,6 +317,59 @@ func (*DummyEvalPlanner) RepairTTLScheduledJobForTable(ctx context.Context, tabl
 	return errors.WithStack(errEvalPlanner)
 }
 
+// EncryptColumnValue is part of the eval.Planner interface.
+func (*DummyEvalPlanner) EncryptColumnValue(
+	ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+) (tree.Datum, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// DecryptColumnValue is part of the eval.Planner interface.
+func (*DummyEvalPlanner) DecryptColumnValue(
+	ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+) (tree.Datum, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// CreateStatementHint is part of the eval.Planner interface.
+func (*DummyEvalPlanner) CreateStatementHint(
+	ctx context.Context, fingerprintID []byte, hintType, value string,
+) (int64, error) {
+	return 0, errors.WithStack(errEvalPlanner)
+}
+
+// DropStatementHint is part of the eval.Planner interface.
+func (*DummyEvalPlanner) DropStatementHint(ctx context.Context, hintID int64) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// ScheduleMaterializedViewRefresh is part of the eval.Planner interface.
+func (*DummyEvalPlanner) ScheduleMaterializedViewRefresh(
+	ctx context.Context, viewName string, recurrence string,
+) (int64, error) {
+	return 0, errors.WithStack(errEvalPlanner)
+}
+
+// CreateHypotheticalIndex is part of the eval.Planner interface.
+func (*DummyEvalPlanner) CreateHypotheticalIndex(ctx context.Context, stmt string) (string, error) {
+	return "", errors.WithStack(errEvalPlanner)
+}
+
+// DropHypotheticalIndex is part of the eval.Planner interface.
+func (*DummyEvalPlanner) DropHypotheticalIndex(ctx context.Context, name string) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// HypotheticalIndexes is part of the eval.Planner interface.
+func (*DummyEvalPlanner) HypotheticalIndexes(ctx context.Context) ([]eval.HypotheticalIndex, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// CalibrateCostModel is part of the eval.Planner interface.
+func (*DummyEvalPlanner) CalibrateCostModel(ctx context.Context, apply bool) (int64, error) {
+	return 0, errors.WithStack(errEvalPlanner)
+}
+
 // Mon is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) Mon() *mon.BytesMonitor {
 	return ep.Monitor
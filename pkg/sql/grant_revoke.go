@@ -60,6 +60,12 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 		return nil, err
 	}
 
+	if len(n.ColumnPrivileges) > 0 {
+		return p.newChangeColumnPrivilegesNode(
+			true /* isGrant */, n.WithGrantOption, n.Targets, grantOn, n.ColumnPrivileges, grantees,
+		)
+	}
+
 	if !grantOn.IsDescriptorBacked() {
 		return &changeNonDescriptorBackedPrivilegesNode{
 			changePrivilegesNode: changePrivilegesNode{
@@ -119,6 +125,12 @@ func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error)
 		return nil, err
 	}
 
+	if len(n.ColumnPrivileges) > 0 {
+		return p.newChangeColumnPrivilegesNode(
+			false /* isGrant */, n.GrantOptionFor, n.Targets, grantOn, n.ColumnPrivileges, grantees,
+		)
+	}
+
 	if !grantOn.IsDescriptorBacked() {
 		return &changeNonDescriptorBackedPrivilegesNode{
 			changePrivilegesNode: changePrivilegesNode{
@@ -0,0 +1,355 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// hypotheticalIndexDef is the definition of a hypothetical index created with
+// crdb_internal.create_hypothetical_index. Hypothetical indexes are never
+// built; they are only considered by EXPLAIN in the session that created them.
+type hypotheticalIndexDef struct {
+	name      tree.Name
+	tableID   descpb.ID
+	tableName tree.TableName
+
+	// keyCols contains the IDs of the key columns of the index, in order, and
+	// descending the direction of each of them.
+	keyCols    []descpb.ColumnID
+	descending []bool
+
+	// storedCols contains the IDs of the columns in the STORING clause.
+	storedCols []descpb.ColumnID
+
+	inverted bool
+
+	// definition is the CREATE INDEX statement of the index, with a fully
+	// qualified table name.
+	definition string
+}
+
+// indexrecDef returns the definition of the index in terms of the column
+// ordinals of the given table. It returns false if a column of the index no
+// longer exists in the table.
+func (d *hypotheticalIndexDef) indexrecDef(tab cat.Table) (indexrec.HypotheticalIndexDef, bool) {
+	ordByID := make(map[descpb.ColumnID]int, tab.ColumnCount())
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if col := tab.Column(i); col.Visibility() != cat.Inaccessible {
+			ordByID[descpb.ColumnID(col.ColID())] = i
+		}
+	}
+	def := indexrec.HypotheticalIndexDef{
+		Name:       d.name,
+		KeyCols:    make([]int, len(d.keyCols)),
+		Descending: d.descending,
+		Inverted:   d.inverted,
+	}
+	for i, id := range d.keyCols {
+		ord, ok := ordByID[id]
+		if !ok {
+			return indexrec.HypotheticalIndexDef{}, false
+		}
+		def.KeyCols[i] = ord
+	}
+	for _, id := range d.storedCols {
+		ord, ok := ordByID[id]
+		if !ok {
+			return indexrec.HypotheticalIndexDef{}, false
+		}
+		def.StoredCols.Add(ord)
+	}
+	return def, true
+}
+
+// hypotheticalIndexes provides access to the hypothetical indexes of a
+// session.
+type hypotheticalIndexes interface {
+	// add adds a hypothetical index to the session.
+	add(def hypotheticalIndexDef) error
+	// remove removes the hypothetical index with the given name from the
+	// session. It returns false if there was no such index.
+	remove(name tree.Name) bool
+	// list returns the hypothetical indexes of the session, in the order in
+	// which they were created.
+	list() []hypotheticalIndexDef
+}
+
+type connExHypotheticalIndexesAccessor struct {
+	ex *connExecutor
+}
+
+func (c connExHypotheticalIndexesAccessor) add(def hypotheticalIndexDef) error {
+	c.ex.hypotheticalIndexes = append(c.ex.hypotheticalIndexes, def)
+	return nil
+}
+
+func (c connExHypotheticalIndexesAccessor) remove(name tree.Name) bool {
+	for i := range c.ex.hypotheticalIndexes {
+		if c.ex.hypotheticalIndexes[i].name == name {
+			c.ex.hypotheticalIndexes = append(
+				c.ex.hypotheticalIndexes[:i], c.ex.hypotheticalIndexes[i+1:]...,
+			)
+			return true
+		}
+	}
+	return false
+}
+
+func (c connExHypotheticalIndexesAccessor) list() []hypotheticalIndexDef {
+	return c.ex.hypotheticalIndexes
+}
+
+// emptyHypotheticalIndexes is the default impl used by the planner when the
+// connExecutor is not available.
+type emptyHypotheticalIndexes struct{}
+
+func (emptyHypotheticalIndexes) add(def hypotheticalIndexDef) error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"hypothetical indexes are only supported in client sessions")
+}
+
+func (emptyHypotheticalIndexes) remove(name tree.Name) bool {
+	return false
+}
+
+func (emptyHypotheticalIndexes) list() []hypotheticalIndexDef {
+	return nil
+}
+
+// CreateHypotheticalIndex is part of the eval.Planner interface.
+func (p *planner) CreateHypotheticalIndex(ctx context.Context, stmt string) (string, error) {
+	parsed, err := parser.ParseOne(stmt)
+	if err != nil {
+		return "", err
+	}
+	n, ok := parsed.AST.(*tree.CreateIndex)
+	if !ok {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue,
+			"expected a CREATE INDEX statement, got %s", parsed.AST.StatementTag())
+	}
+	var unsupported string
+	switch {
+	case n.Unique:
+		unsupported = "unique"
+	case n.Sharded != nil:
+		unsupported = "hash-sharded"
+	case n.Predicate != nil:
+		unsupported = "partial"
+	case n.PartitionByIndex != nil:
+		unsupported = "partitioned"
+	case n.Invisibility != 0:
+		unsupported = "not visible"
+	}
+	if unsupported != "" {
+		return "", pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s hypothetical indexes are not supported", unsupported)
+	}
+	if n.Inverted && len(n.Storing) > 0 {
+		return "", pgerror.New(pgcode.InvalidSQLStatementName,
+			"inverted indexes don't support stored columns")
+	}
+
+	flags := tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveRequireTableDesc,
+	}
+	_, tableDesc, err := resolver.ResolveExistingTableObject(ctx, p, &n.Table, flags)
+	if err != nil {
+		return "", err
+	}
+	if err := p.CheckAnyPrivilege(ctx, tableDesc); err != nil {
+		return "", err
+	}
+
+	def := hypotheticalIndexDef{
+		tableID:   tableDesc.GetID(),
+		tableName: n.Table,
+		inverted:  n.Inverted,
+	}
+	nameSegments := []string{tableDesc.GetName()}
+	for i := range n.Columns {
+		elem := &n.Columns[i]
+		if elem.Expr != nil {
+			return "", pgerror.New(pgcode.FeatureNotSupported,
+				"hypothetical expression indexes are not supported")
+		}
+		if elem.OpClass != "" {
+			return "", pgerror.New(pgcode.FeatureNotSupported,
+				"hypothetical indexes with operator classes are not supported")
+		}
+		col, err := findHypotheticalIndexColumn(tableDesc, elem.Column)
+		if err != nil {
+			return "", err
+		}
+		isLastInverted := n.Inverted && i == len(n.Columns)-1
+		if isLastInverted {
+			if !colinfo.ColumnTypeIsInvertedIndexable(col.GetType()) ||
+				col.GetType().Family() == types.StringFamily {
+				return "", pgerror.Newf(pgcode.FeatureNotSupported,
+					"column %s of type %s is not allowed as the last column in an inverted index",
+					col.GetName(), col.GetType().Name())
+			}
+		} else if !colinfo.ColumnTypeIsIndexable(col.GetType()) {
+			return "", pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %s is of type %s and thus is not indexable",
+				col.GetName(), col.GetType().Name())
+		}
+		def.keyCols = append(def.keyCols, col.GetID())
+		def.descending = append(def.descending, elem.Direction == tree.Descending)
+		nameSegments = append(nameSegments, col.GetName())
+	}
+	for _, name := range n.Storing {
+		col, err := findHypotheticalIndexColumn(tableDesc, name)
+		if err != nil {
+			return "", err
+		}
+		def.storedCols = append(def.storedCols, col.GetID())
+	}
+
+	// Index names must be unique in the session, since they are used to drop
+	// the hypothetical indexes, and must not shadow the existing indexes of the
+	// table.
+	nameTaken := func(name tree.Name) bool {
+		if catalog.FindIndexByName(tableDesc, string(name)) != nil {
+			return true
+		}
+		for _, existing := range p.hypotheticalIndexes.list() {
+			if existing.name == name {
+				return true
+			}
+		}
+		return false
+	}
+	if n.Name == "" {
+		baseName := strings.Join(append(nameSegments, "idx"), "_")
+		n.Name = tree.Name(baseName)
+		for i := 1; nameTaken(n.Name); i++ {
+			n.Name = tree.Name(fmt.Sprintf("%s%d", baseName, i))
+		}
+	} else if nameTaken(n.Name) {
+		if n.IfNotExists {
+			return string(n.Name), nil
+		}
+		return "", pgerror.Newf(pgcode.DuplicateRelation, "index %q already exists", n.Name)
+	}
+	def.name = n.Name
+
+	n.Table.ExplicitCatalog = true
+	n.Table.ExplicitSchema = true
+	n.IfNotExists = false
+	n.Concurrently = false
+	n.StorageParams = nil
+	def.definition = tree.AsString(n)
+	if err := p.hypotheticalIndexes.add(def); err != nil {
+		return "", err
+	}
+	return string(def.name), nil
+}
+
+// findHypotheticalIndexColumn returns the public column with the given name.
+func findHypotheticalIndexColumn(
+	tableDesc catalog.TableDescriptor, name tree.Name,
+) (catalog.Column, error) {
+	col := catalog.FindColumnByTreeName(tableDesc, name)
+	if col == nil || !col.Public() || col.IsInaccessible() {
+		return nil, pgerror.Newf(pgcode.UndefinedColumn, "column %q does not exist", name)
+	}
+	return col, nil
+}
+
+// DropHypotheticalIndex is part of the eval.Planner interface.
+func (p *planner) DropHypotheticalIndex(ctx context.Context, name string) (bool, error) {
+	return p.hypotheticalIndexes.remove(tree.Name(name)), nil
+}
+
+// HypotheticalIndexes is part of the eval.Planner interface.
+func (p *planner) HypotheticalIndexes(ctx context.Context) ([]eval.HypotheticalIndex, error) {
+	defs := p.hypotheticalIndexes.list()
+	res := make([]eval.HypotheticalIndex, len(defs))
+	for i := range defs {
+		res[i] = eval.HypotheticalIndex{
+			Name:       string(defs[i].name),
+			TableName:  defs[i].tableName.FQString(),
+			Definition: defs[i].definition,
+		}
+	}
+	return res, nil
+}
+
+// applyHypotheticalIndexes makes the hypothetical indexes of the session
+// visible to the optimizer, by replacing the tables they are defined on with
+// hypothetical tables in the metadata of the memo. It must be called after the
+// memo is built and before it is optimized. Plans that use hypothetical indexes
+// cannot be executed, so it is only used for EXPLAIN modes that do not need an
+// executable plan.
+func (opc *optPlanningCtx) applyHypotheticalIndexes() {
+	explain, ok := opc.p.stmt.AST.(*tree.Explain)
+	if !ok {
+		return
+	}
+	switch explain.Mode {
+	case tree.ExplainPlan, tree.ExplainOpt, tree.ExplainGist:
+	default:
+		return
+	}
+	defs := opc.p.hypotheticalIndexes.list()
+	if len(defs) == 0 {
+		return
+	}
+
+	md := opc.optimizer.Memo().Metadata()
+	var hypTables map[cat.StableID]cat.Table
+	for _, tm := range md.AllTables() {
+		tab := tm.Table
+		if _, ok := hypTables[tab.ID()]; ok {
+			continue
+		}
+		var tabDefs []indexrec.HypotheticalIndexDef
+		for i := range defs {
+			if cat.StableID(defs[i].tableID) != tab.ID() {
+				continue
+			}
+			if def, ok := defs[i].indexrecDef(tab); ok {
+				tabDefs = append(tabDefs, def)
+				opc.hypotheticalIndexes = append(
+					opc.hypotheticalIndexes, fmt.Sprintf("%s@%s", tree.NameString(string(tab.Name())), tree.NameString(string(def.Name))),
+				)
+			}
+		}
+		if len(tabDefs) == 0 {
+			continue
+		}
+		if hypTables == nil {
+			hypTables = make(map[cat.StableID]cat.Table)
+		}
+		hypTables[tab.ID()] = indexrec.BuildHypotheticalTable(opc.catalog, tab, tabDefs)
+	}
+	if hypTables != nil {
+		md.UpdateTableMeta(opc.p.EvalContext(), hypTables)
+	}
+}
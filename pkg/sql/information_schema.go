@@ -372,7 +372,7 @@ https://www.postgresql.org/docs/9.5/infoschema-column-privileges.html`,
 		) error {
 			dbNameStr := tree.NewDString(db.GetName())
 			scNameStr := tree.NewDString(sc.GetName())
-			columndata := privilege.List{privilege.SELECT, privilege.INSERT, privilege.UPDATE} // privileges for column level granularity
+			columndata := privilege.ColumnPrivileges // privileges for column level granularity
 			privDesc, err := p.getPrivilegeDescriptor(ctx, table)
 			if err != nil {
 				return err
@@ -397,6 +397,33 @@ https://www.postgresql.org/docs/9.5/infoschema-column-privileges.html`,
 					}
 				}
 			}
+			// Add the privileges granted on individual columns, unless they are
+			// already granted on the whole table.
+			for _, cd := range table.PublicColumns() {
+				for _, u := range cd.ColumnDesc().Privileges {
+					var tablePrivs uint64
+					if tu, ok := privDesc.FindUser(u.User()); ok {
+						tablePrivs = tu.Privileges
+					}
+					for _, priv := range columndata {
+						if !priv.IsSetIn(u.Privileges) || priv.IsSetIn(tablePrivs) {
+							continue
+						}
+						if err := addRow(
+							tree.DNull,                                    // grantor
+							tree.NewDString(u.User().Normalized()),        // grantee
+							dbNameStr,                                     // table_catalog
+							scNameStr,                                     // table_schema
+							tree.NewDString(table.GetName()),              // table_name
+							tree.NewDString(cd.GetName()),                 // column_name
+							tree.NewDString(priv.String()),                // privilege_type
+							yesOrNoDatum(priv.IsSetIn(u.WithGrantOption)), // is_grantable
+						); err != nil {
+							return err
+						}
+					}
+				}
+			}
 			return nil
 		})
 	},
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -144,6 +145,18 @@ type instrumentationHelper struct {
 	vectorized       bool
 	containsMutation bool
 
+	// planType is the type of plan used to execute a prepared statement with
+	// placeholders, shown by EXPLAIN ANALYZE.
+	planType planType
+
+	// statementHints contains the descriptions of the hints of
+	// system.statement_hints applied to the statement.
+	statementHints []string
+
+	// costCalibration contains the calibrated cost factors that the optimizer
+	// used to plan the statement.
+	costCalibration memo.CostCalibration
+
 	traceMetadata execNodeTraceMetadata
 
 	// planGist is a compressed version of plan that can be converted (lossily)
@@ -563,6 +576,15 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	ob.AddExecutionTime(phaseTimes.GetRunLatency())
 	ob.AddDistribution(ih.distribution.String())
 	ob.AddVectorized(ih.vectorized)
+	if ih.planType != planTypeUnknown {
+		ob.AddPlanType(ih.planType.String())
+	}
+	if len(ih.statementHints) > 0 {
+		ob.AddStatementHints(ih.statementHints)
+	}
+	if !ih.costCalibration.IsDefault() {
+		ob.AddCostCalibration(ih.costCalibration.String())
+	}
 
 	if queryStats != nil {
 		if queryStats.KVRowsRead != 0 {
@@ -743,6 +765,7 @@ func (m execNodeTraceMetadata) annotateExplain(
 				nodeStats.VectorizedBatchCount.MaybeAdd(stats.Output.NumBatches)
 				nodeStats.MaxAllocatedMem.MaybeAdd(stats.Exec.MaxAllocatedMem)
 				nodeStats.MaxAllocatedDisk.MaybeAdd(stats.Exec.MaxAllocatedDisk)
+				nodeStats.AdaptiveJoinSwitches.MaybeAdd(stats.Exec.AdaptiveJoinSwitches)
 				if noMutations && !makeDeterministic {
 					// Currently we cannot separate SQL CPU time from local KV CPU time
 					// for mutations, since they do not collect statistics. Additionally,
@@ -822,7 +845,7 @@ func (ih *instrumentationHelper) SetIndexRecommendations(
 			if err != nil {
 				log.Warningf(ctx, "unable to build memo: %s", err)
 			} else {
-				recommendations, err = opc.makeQueryIndexRecommendation(ctx)
+				recommendations, _, err = opc.makeQueryIndexRecommendation(ctx)
 				if err != nil {
 					log.Warningf(ctx, "unable to generate index recommendations: %s", err)
 				}
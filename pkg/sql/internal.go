@@ -824,6 +824,7 @@ func applyOverrides(o sessiondata.InternalExecutorOverride, sd *sessiondata.Sess
 	}
 	// We always override the injection knob based on the override struct.
 	sd.InjectRetryErrorsEnabled = o.InjectRetryErrorsEnabled
+	sd.AllowMaterializedViewMutations = o.AllowMaterializedViewMutations
 }
 
 func (ie *InternalExecutor) maybeRootSessionDataOverride(
@@ -36,6 +36,11 @@ type joinNode struct {
 
 	// columns contains the metadata for the results of this node.
 	columns colinfo.ResultColumns
+
+	// adaptiveThreshold, if non-zero, is the number of left rows up to which
+	// the join is executed as a lookup join into the index scanned by the right
+	// side.
+	adaptiveThreshold int64
 }
 
 func (p *planner) makeJoinNode(
@@ -135,6 +135,13 @@ func TestLogic_distsql_numtables(
 	runLogicTest(t, "distsql_numtables")
 }
 
+func TestLogic_distsql_runtime_filter(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "distsql_runtime_filter")
+}
+
 func TestLogic_distsql_subquery(
 	t *testing.T,
 ) {
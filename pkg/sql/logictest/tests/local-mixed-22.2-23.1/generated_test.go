@@ -751,6 +751,13 @@ func TestLogic_export(
 	runLogicTest(t, "export")
 }
 
+func TestLogic_extended_stats_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "extended_stats_mixed")
+}
+
 func TestLogic_external_connection_privileges(
 	t *testing.T,
 ) {
@@ -233,6 +233,13 @@ func TestLogic_alter_view_owner(
 	runLogicTest(t, "alter_view_owner")
 }
 
+func TestLogic_analyze_workload(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "analyze_workload")
+}
+
 func TestLogic_and_or(
 	t *testing.T,
 ) {
@@ -268,6 +275,13 @@ func TestLogic_asyncpg(
 	runLogicTest(t, "asyncpg")
 }
 
+func TestLogic_audit_log_table(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "audit_log_table")
+}
+
 func TestLogic_auto_span_config_reconciliation_job(
 	t *testing.T,
 ) {
@@ -415,6 +429,13 @@ func TestLogic_column_families(
 	runLogicTest(t, "column_families")
 }
 
+func TestLogic_column_privileges(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "column_privileges")
+}
+
 func TestLogic_comment_on(
 	t *testing.T,
 ) {
@@ -450,6 +471,13 @@ func TestLogic_connect_privilege(
 	runLogicTest(t, "connect_privilege")
 }
 
+func TestLogic_cost_calibration(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "cost_calibration")
+}
+
 func TestLogic_crdb_internal(
 	t *testing.T,
 ) {
@@ -1675,6 +1703,13 @@ func TestLogic_role(
 	runLogicTest(t, "role")
 }
 
+func TestLogic_row_level_security(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "row_level_security")
+}
+
 func TestLogic_row_level_ttl(
 	t *testing.T,
 ) {
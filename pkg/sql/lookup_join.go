@@ -77,6 +77,10 @@ type lookupJoinNode struct {
 	// that read into remote regions, though the lookups are defined in
 	// lookupExpr, not remoteLookupExpr.
 	remoteOnlyLookups bool
+
+	// adaptiveThreshold, if non-zero, is the number of input rows above which
+	// the join is executed as a hash join against a full scan of the index.
+	adaptiveThreshold int64
 }
 
 func (lj *lookupJoinNode) startExec(params runParams) error {
@@ -128,6 +128,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterTableOwner(ctx, n)
 	case *tree.AlterTableSetSchema:
 		return p.AlterTableSetSchema(ctx, n)
+	case *tree.AlterPolicy:
+		return p.AlterPolicy(ctx, n)
 	case *tree.AlterTenantCapability:
 		return p.AlterTenantCapability(ctx, n)
 	case *tree.AlterTenantSetClusterSetting:
@@ -142,8 +144,12 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterRole(ctx, n)
 	case *tree.AlterRoleSet:
 		return p.AlterRoleSet(ctx, n)
+	case *tree.AlterRoleUnlock:
+		return p.AlterRoleUnlock(ctx, n)
 	case *tree.AlterSequence:
 		return p.AlterSequence(ctx, n)
+	case *tree.AnalyzeWorkload:
+		return p.AnalyzeWorkload(ctx, n)
 	case *tree.CloseCursor:
 		return p.CloseCursor(ctx, n)
 	case *tree.CommentOnColumn:
@@ -173,6 +179,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CreateType(ctx, n)
 	case *tree.CreateRole:
 		return p.CreateRole(ctx, n)
+	case *tree.CreatePolicy:
+		return p.CreatePolicy(ctx, n)
 	case *tree.CreateSequence:
 		return p.CreateSequence(ctx, n)
 	case *tree.CreateExtension:
@@ -197,6 +205,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DropIndex(ctx, n)
 	case *tree.DropOwnedBy:
 		return p.DropOwnedBy(ctx)
+	case *tree.DropPolicy:
+		return p.DropPolicy(ctx, n)
 	case *tree.DropRole:
 		return p.DropRole(ctx, n)
 	case *tree.DropSchema:
@@ -281,6 +291,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Truncate(ctx, n)
 	case *tree.Unlisten:
 		return p.Unlisten(ctx, n)
+	case *tree.VerifyAuditLog:
+		return p.VerifyAuditLog(ctx, n)
 	case tree.CCLOnlyStatement:
 		plan, err := p.maybePlanHook(ctx, stmt)
 		if plan == nil && err == nil {
@@ -321,6 +333,7 @@ func init() {
 		&tree.AlterTableLocality{},
 		&tree.AlterTableOwner{},
 		&tree.AlterTableSetSchema{},
+		&tree.AlterPolicy{},
 		&tree.AlterTenantCapability{},
 		&tree.AlterTenantRename{},
 		&tree.AlterTenantSetClusterSetting{},
@@ -329,6 +342,8 @@ func init() {
 		&tree.AlterSequence{},
 		&tree.AlterRole{},
 		&tree.AlterRoleSet{},
+		&tree.AlterRoleUnlock{},
+		&tree.AnalyzeWorkload{},
 		&tree.CloseCursor{},
 		&tree.CommentOnColumn{},
 		&tree.CommentOnDatabase{},
@@ -343,6 +358,7 @@ func init() {
 		&tree.CreateTenant{},
 		&tree.CreateIndex{},
 		&tree.CreateSchema{},
+		&tree.CreatePolicy{},
 		&tree.CreateSequence{},
 		&tree.CreateType{},
 		&tree.CreateRole{},
@@ -354,6 +370,7 @@ func init() {
 		&tree.DropFunction{},
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
+		&tree.DropPolicy{},
 		&tree.DropRole{},
 		&tree.DropSchema{},
 		&tree.DropSequence{},
@@ -396,6 +413,7 @@ func init() {
 		&tree.ShowTransactionStatus{},
 		&tree.Truncate{},
 		&tree.Unlisten{},
+		&tree.VerifyAuditLog{},
 
 		// CCL statements (without Export which has an optimizer operator).
 		&tree.AlterBackup{},
@@ -169,6 +169,12 @@ type Catalog interface {
 	// the given catalog object. If not, then CheckPrivilege returns an error.
 	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
 
+	// HasColumnPrivilege returns true if the given privilege has been granted on
+	// the column with the given ordinal in the given table to the current user,
+	// or to any role it is a member of. Privileges granted on the table itself
+	// are not taken into account.
+	HasColumnPrivilege(ctx context.Context, tab Table, colOrd int, priv privilege.Kind) (bool, error)
+
 	// CheckAnyPrivilege verifies that the current user has any privilege on
 	// the given catalog object. If not, then CheckAnyPrivilege returns an error.
 	CheckAnyPrivilege(ctx context.Context, o Object) error
@@ -200,4 +206,12 @@ type Catalog interface {
 
 	// RoleExists returns true if the role exists.
 	RoleExists(ctx context.Context, role username.SQLUsername) (bool, error)
+
+	// HasOwnership returns true if the current user, or any role it is a member
+	// of, owns the given catalog object.
+	HasOwnership(ctx context.Context, o Object) (bool, error)
+
+	// IsMemberOfRole returns true if the current user is the given role, or is a
+	// direct or indirect member of it.
+	IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error)
 }
@@ -176,6 +176,25 @@ type Table interface {
 	// GetDatabaseID returns the owning database id of the table, or zero, if the
 	// owning database could not be determined.
 	GetDatabaseID() descpb.ID
+
+	// IsRowLevelSecurityEnabled returns true if the row-level security policies
+	// of the table are enforced.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if the row-level security policies
+	// of the table are also enforced for the table owner.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// the table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy, where i < PolicyCount.
+	Policy(i int) *descpb.PolicyDescriptor
+
+	// IsColumnEncrypted returns true if the values of the ith column are
+	// encrypted, i.e. the column was declared with ENCRYPTED WITH KEY.
+	IsColumnEncrypted(i int) bool
 }
 
 // CheckConstraint contains the SQL text and the validity status for a check
@@ -243,6 +262,12 @@ type TableStatistic interface {
 
 	// IsAuto returns true if this statistic was collected automatically.
 	IsAuto() bool
+
+	// ExtendedStatistics returns the most common values and the functional
+	// dependencies collected on the columns of a multi-column statistic with
+	// CREATE STATISTICS ... (mcv, dependencies). It returns nil if there are no
+	// extended statistics.
+	ExtendedStatistics() *ExtendedStatistics
 }
 
 // HistogramBucket contains the data for a single histogram bucket. Note
@@ -266,6 +291,50 @@ type HistogramBucket struct {
 	UpperBound tree.Datum
 }
 
+// ExtendedStatistics contains the extended statistics of a multi-column table
+// statistic, which capture the correlations between its columns.
+type ExtendedStatistics struct {
+	// HasMostCommonValues is true if the most common values were collected. In
+	// that case, the combinations of values which are not in MostCommonValues
+	// are less frequent than the ones which are.
+	HasMostCommonValues bool
+
+	// MostCommonValues contains the most common combinations of non-NULL
+	// values of the columns, with the most frequent first.
+	MostCommonValues []MostCommonValue
+
+	// Dependencies contains the functional dependencies between the columns,
+	// which may only hold for a fraction of the rows.
+	Dependencies []ColumnDependency
+}
+
+// MostCommonValue is a combination of values of the columns of a statistic,
+// along with its frequency.
+type MostCommonValue struct {
+	// Values contains the value of each column of the statistic, i.e. the ith
+	// value is the value of the column with ordinal ColumnOrdinal(i).
+	Values tree.Datums
+
+	// Frequency is the estimated fraction of the rows of the table with these
+	// values.
+	Frequency float64
+}
+
+// ColumnDependency is a functional dependency between the columns of a
+// statistic. The columns are identified by their index i in the statistic,
+// i.e. the column with ordinal ColumnOrdinal(i).
+type ColumnDependency struct {
+	// Determinant contains the columns which determine the dependent column.
+	Determinant []int
+
+	// Dependent is the column determined by the Determinant columns.
+	Dependent int
+
+	// Degree is the estimated fraction of the rows of the table for which the
+	// dependency holds, between 0 and 1.
+	Degree float64
+}
+
 // ForeignKeyConstraint represents a foreign key constraint. A foreign key
 // constraint has an origin (or referencing) side and a referenced side. For
 // example:
@@ -271,6 +271,11 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.Max1RowExpr:
 		ep, err = b.buildMax1Row(t)
 
+	case *memo.BarrierExpr:
+		// The barrier only constrains the optimizer, and the filters of its
+		// input are evaluated before those above it.
+		ep, err = b.buildRelational(t.Input)
+
 	case *memo.ProjectSetExpr:
 		ep, err = b.buildProjectSet(t)
 
@@ -1313,6 +1318,7 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (execPlan, error) {
 	leftExpr := join.Child(0).(memo.RelExpr)
 	rightExpr := join.Child(1).(memo.RelExpr)
 	filters := join.Child(2).(*memo.FiltersExpr)
+	adaptiveThreshold := b.mem.AdaptiveJoinThreshold(join)
 	if joinType == descpb.LeftSemiJoin || joinType == descpb.LeftAntiJoin {
 		// We have a partial join, and we want to make sure that the relation
 		// with smaller cardinality is on the right side. Note that we assumed
@@ -1328,6 +1334,9 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (execPlan, error) {
 				joinType = descpb.RightAntiJoin
 			}
 			leftExpr, rightExpr = rightExpr, leftExpr
+			// The scanned index is now on the left side, so the join cannot be
+			// executed as a lookup join.
+			adaptiveThreshold = 0
 		}
 	}
 
@@ -1387,6 +1396,7 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (execPlan, error) {
 		leftEqOrdinals, rightEqOrdinals,
 		leftEqColsAreKey, rightEqColsAreKey,
 		onExpr,
+		adaptiveThreshold,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -2443,6 +2453,15 @@ func (b *Builder) buildLookupJoin(join *memo.LookupJoinExpr) (execPlan, error) {
 	if join.IsFirstJoinInPairedJoiner {
 		lookupCols.Remove(join.ContinuationCol)
 	}
+	adaptiveThreshold := b.mem.AdaptiveJoinThreshold(join)
+	if adaptiveThreshold != 0 {
+		// The hash join algorithm of an adaptive join needs the index columns
+		// matched by the key columns. They are projected away below.
+		idx := md.Table(join.Table).Index(join.Index)
+		for i := range join.KeyCols {
+			lookupCols.Add(join.Table.ColumnID(idx.Column(i).Ordinal()))
+		}
+	}
 
 	lookupOrdinals, lookupColMap := b.getColumns(lookupCols, join.Table)
 	// allExprCols are the columns used in expressions evaluated by this join.
@@ -2529,17 +2548,21 @@ func (b *Builder) buildLookupJoin(join *memo.LookupJoinExpr) (execPlan, error) {
 		locking,
 		join.RequiredPhysical().LimitHintInt64(),
 		join.RemoteOnlyLookups,
+		adaptiveThreshold,
 	)
 	if err != nil {
 		return execPlan{}, err
 	}
 
-	// Apply a post-projection if Cols doesn't contain all input columns.
+	// Apply a post-projection if Cols doesn't contain all input columns, or
+	// if the join outputs index columns only needed by an adaptive join.
 	//
 	// NB: For paired-joins, this is where the continuation column and the PK
 	// columns for the right side, which were part of the inputCols, are projected
 	// away.
-	if !inputCols.SubsetOf(join.Cols) {
+	extraLookupCols := !lookupCols.SubsetOf(join.Cols) &&
+		join.JoinType != opt.SemiJoinOp && join.JoinType != opt.AntiJoinOp
+	if !inputCols.SubsetOf(join.Cols) || extraLookupCols {
 		outCols := join.Cols
 		if join.JoinType == opt.SemiJoinOp || join.JoinType == opt.AntiJoinOp {
 			outCols = join.Cols.Intersection(inputCols)
@@ -3590,6 +3613,7 @@ var boundedStalenessAllowList = map[opt.Operator]struct{}{
 	opt.SortOp:             {},
 	opt.OrdinalityOp:       {},
 	opt.Max1RowOp:          {},
+	opt.BarrierOp:          {},
 	opt.ProjectSetOp:       {},
 	opt.WindowOp:           {},
 	opt.ExplainOp:          {},
@@ -84,6 +84,13 @@ func TestLogic_tmp(t *testing.T) {
 	logictest.RunLogicTests(t, serverArgs, configIdx, glob)
 }
 
+func TestExecBuild_adaptive_join(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "adaptive_join")
+}
+
 func TestExecBuild_aggregate(
 	t *testing.T,
 ) {
@@ -266,6 +273,13 @@ func TestExecBuild_hash_sharded_index(
 	runExecBuildLogicTest(t, "hash_sharded_index")
 }
 
+func TestExecBuild_hypothetical_indexes(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "hypothetical_indexes")
+}
+
 func TestExecBuild_information_schema(
 	t *testing.T,
 ) {
@@ -469,6 +483,13 @@ func TestExecBuild_scalar(
 	runExecBuildLogicTest(t, "scalar")
 }
 
+func TestExecBuild_scan_parallel_local(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "scan_parallel_local")
+}
+
 func TestExecBuild_schema_change_in_txn(
 	t *testing.T,
 ) {
@@ -567,6 +588,13 @@ func TestExecBuild_srfs(
 	runExecBuildLogicTest(t, "srfs")
 }
 
+func TestExecBuild_statement_hints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "statement_hints")
+}
+
 func TestExecBuild_subquery(
 	t *testing.T,
 ) {
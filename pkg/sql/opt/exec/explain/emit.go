@@ -453,6 +453,9 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if s.SQLCPUTime.HasValue() {
 			e.ob.AddField("sql cpu time", string(humanizeutil.Duration(s.SQLCPUTime.Value())))
 		}
+		if s.AdaptiveJoinSwitches.HasValue() {
+			e.ob.AddField("adaptive join", adaptiveJoinOutcome(n.op, s.AdaptiveJoinSwitches.Value() > 0))
+		}
 		if e.ob.flags.Verbose {
 			if s.StepCount.HasValue() {
 				e.ob.AddField("MVCC step count (ext/int)", fmt.Sprintf("%s/%s",
@@ -700,6 +703,7 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			a.LeftEqColsAreKey, a.RightEqColsAreKey,
 			a.ExtraOnCond,
 		)
+		e.emitAdaptiveJoinThreshold(a.AdaptiveThreshold)
 
 	case mergeJoinOp:
 		a := n.args.(*mergeJoinArgs)
@@ -756,6 +760,7 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		ob.Expr("remote lookup condition", a.RemoteLookupExpr, appendColumns(inputCols, tableColumns(a.Table, a.LookupCols)...))
 		ob.Expr("pred", a.OnCond, appendColumns(inputCols, tableColumns(a.Table, a.LookupCols)...))
 		e.emitLockingPolicy(a.Locking)
+		e.emitAdaptiveJoinThreshold(a.AdaptiveThreshold)
 
 	case zigzagJoinOp:
 		a := n.args.(*zigzagJoinArgs)
@@ -1146,6 +1151,31 @@ func (e *emitter) emitJoinAttributes(
 	e.ob.Expr("pred", extraOnCond, appendColumns(leftCols, rightCols...))
 }
 
+// emitAdaptiveJoinThreshold emits the threshold of a lookup or hash join which
+// is executed as an adaptive join.
+func (e *emitter) emitAdaptiveJoinThreshold(threshold int64) {
+	if threshold > 0 {
+		e.ob.Attrf(
+			"adaptive join threshold", "%s row%s",
+			humanizeutil.Count(uint64(threshold)), util.Pluralize(threshold),
+		)
+	}
+}
+
+// adaptiveJoinOutcome describes the algorithm used by an adaptive join during
+// execution, given whether it switched from the algorithm of the planned
+// operator.
+func adaptiveJoinOutcome(op execOperator, switched bool) string {
+	planned, other := "lookup join", "hash join"
+	if op == hashJoinOp {
+		planned, other = other, planned
+	}
+	if switched {
+		return "switched to " + other
+	}
+	return "kept " + planned
+}
+
 func printColumns(inputCols colinfo.ResultColumns) string {
 	var buf bytes.Buffer
 	for i, col := range inputCols {
@@ -301,6 +301,32 @@ func (ob *OutputBuilder) AddVectorized(value bool) {
 	ob.AddFlakyTopLevelField(DeflakeVectorized, "vectorized", fmt.Sprintf("%t", value))
 }
 
+// AddPlanType adds a top-level field for the type of plan (generic or custom)
+// used to execute a prepared statement. Cannot be called while inside a node.
+func (ob *OutputBuilder) AddPlanType(value string) {
+	ob.AddTopLevelField("plan type", value)
+}
+
+// AddStatementHints adds a top-level field for the statement hints applied to
+// the statement. Cannot be called while inside a node.
+func (ob *OutputBuilder) AddStatementHints(hints []string) {
+	ob.AddTopLevelField("statement hints", strings.Join(hints, ", "))
+}
+
+// AddHypotheticalIndexes adds a top-level field for the hypothetical indexes
+// that were considered when planning the statement. Cannot be called while
+// inside a node.
+func (ob *OutputBuilder) AddHypotheticalIndexes(indexes []string) {
+	ob.AddTopLevelField("hypothetical indexes", strings.Join(indexes, ", "))
+}
+
+// AddCostCalibration adds a top-level field for the calibrated cost factors
+// used by the optimizer to plan the statement. Cannot be called while inside a
+// node.
+func (ob *OutputBuilder) AddCostCalibration(calibration string) {
+	ob.AddTopLevelField("cost calibration", calibration)
+}
+
 // AddPlanningTime adds a top-level planning time field. Cannot be called
 // while inside a node.
 func (ob *OutputBuilder) AddPlanningTime(delta time.Duration) {
@@ -617,6 +617,31 @@ func (u *unknownTable) GetDatabaseID() descpb.ID {
 	return 0
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (u *unknownTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (u *unknownTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (u *unknownTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (u *unknownTable) Policy(i int) *descpb.PolicyDescriptor {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+// IsColumnEncrypted is part of the cat.Table interface.
+func (u *unknownTable) IsColumnEncrypted(i int) bool {
+	return false
+}
+
 var _ cat.Table = &unknownTable{}
 
 // unknownTable implements the cat.Index interface and is used to represent
@@ -420,6 +420,10 @@ type ExecutionStats struct {
 	MaxAllocatedDisk optional.Uint
 	SQLCPUTime       optional.Duration
 
+	// AdaptiveJoinSwitches is the number of processors of an adaptive join
+	// which switched from the planned join algorithm to the other one.
+	AdaptiveJoinSwitches optional.Uint
+
 	// Nodes on which this operator was executed.
 	Nodes []string
 
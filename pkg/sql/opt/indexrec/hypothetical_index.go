@@ -42,7 +42,8 @@ type hypotheticalIndex struct {
 	suffixKeyCols []cat.IndexColumn
 
 	// storedCols contains all the table's column ordinals that are not key
-	// columns (neither index columns nor suffix key columns).
+	// columns (neither index columns nor suffix key columns), unless it was
+	// restricted by restrictStoredCols.
 	storedCols []cat.IndexColumn
 
 	// inverted indicates if an index is inverted.
@@ -93,6 +94,18 @@ func (hi *hypotheticalIndex) init(
 	}
 }
 
+// restrictStoredCols removes all stored columns that are not in the given set
+// of column ordinals.
+func (hi *hypotheticalIndex) restrictStoredCols(storedColOrds intsets.Fast) {
+	storedCols := hi.storedCols[:0]
+	for _, col := range hi.storedCols {
+		if storedColOrds.Contains(col.Ordinal()) {
+			storedCols = append(storedCols, col)
+		}
+	}
+	hi.storedCols = storedCols
+}
+
 // ID is part of the cat.Index interface.
 func (hi *hypotheticalIndex) ID() cat.StableID {
 	return cat.StableID(hi.indexOrdinal)
@@ -269,3 +282,20 @@ func (hi *hypotheticalIndex) hasSameExplicitCols(existingIndex cat.Index, isInve
 	}
 	return true
 }
+
+// isContainedIn returns true if every explicit column of the hypothetical index
+// is a column of the given existing index, whose columns are existingCols. An
+// inverted hypothetical index is only contained in an inverted index with the
+// same explicit columns, since the inverted columns of different indexes are
+// different virtual columns.
+func (hi *hypotheticalIndex) isContainedIn(existingIndex cat.Index, existingCols intsets.Fast) bool {
+	if hi.IsInverted() {
+		return existingIndex.IsInverted() && hi.hasSameExplicitCols(existingIndex, true /* isInverted */)
+	}
+	for _, col := range hi.cols {
+		if !existingCols.Contains(col.Ordinal()) {
+			return false
+		}
+	}
+	return true
+}
@@ -77,6 +77,65 @@ func BuildOptAndHypTableMaps(
 	return optTables, hypTables
 }
 
+// HypotheticalIndexDef describes a hypothetical index that is explicitly
+// defined by a user rather than derived from index candidates.
+type HypotheticalIndexDef struct {
+	// Name is the name of the index.
+	Name tree.Name
+
+	// KeyCols contains the table ordinals of the index key columns, in order.
+	KeyCols []int
+
+	// Descending contains the direction of each key column in KeyCols.
+	Descending []bool
+
+	// StoredCols contains the table ordinals of the columns stored in the index.
+	// Primary key columns are always stored implicitly, and inverted indexes
+	// cannot store any columns.
+	StoredCols intsets.Fast
+
+	// Inverted is true if the last key column is inverted.
+	Inverted bool
+}
+
+// BuildHypotheticalTable builds a HypotheticalTable with a hypothetical index
+// for each of the given definitions, in addition to the existing indexes of
+// the table. Unlike the indexes built by BuildOptAndHypTableMaps, these
+// indexes only store the columns listed in their definitions.
+func BuildHypotheticalTable(
+	c cat.Catalog, table cat.Table, defs []HypotheticalIndexDef,
+) *HypotheticalTable {
+	var hypTable HypotheticalTable
+	hypTable.init(c, table)
+	hypIndexes := make([]hypotheticalIndex, len(defs))
+	for i := range defs {
+		def := &defs[i]
+		indexCols := make([]cat.IndexColumn, len(def.KeyCols))
+		for j, ord := range def.KeyCols {
+			indexCols[j] = cat.IndexColumn{
+				Column:     table.Column(ord),
+				Descending: def.Descending[j],
+			}
+		}
+		if def.Inverted {
+			lastKeyCol := indexCols[len(indexCols)-1]
+			invertedCol := hypTable.addInvertedCol(lastKeyCol.Column)
+			indexCols[len(indexCols)-1] = cat.IndexColumn{Column: invertedCol}
+		}
+		hypIndexes[i].init(
+			&hypTable,
+			def.Name,
+			indexCols,
+			table.IndexCount()+i,
+			def.Inverted,
+			table.Zone(),
+		)
+		hypIndexes[i].restrictStoredCols(def.StoredCols)
+	}
+	hypTable.hypotheticalIndexes = hypIndexes
+	return &hypTable
+}
+
 // HypotheticalTable is a wrapper around cat.Table, used for creating index
 // recommendations. The hypotheticalIndexes slice stores fake indexes that could
 // potentially speed up queries to this table.
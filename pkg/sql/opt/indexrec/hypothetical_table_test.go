@@ -10,7 +10,12 @@
 
 package indexrec
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
 
 func TestBuildOptAndHypTableMaps(t *testing.T) {
 	tables, indexCols := testTablesAndIndexCols()
@@ -48,3 +53,41 @@ func TestBuildOptAndHypTableMaps(t *testing.T) {
 		)
 	}
 }
+
+func TestBuildHypotheticalTable(t *testing.T) {
+	tables, indexCols := testTablesAndIndexCols()
+	table := tables[0].(*testcat.Table)
+	for _, col := range indexCols {
+		table.Columns = append(table.Columns, *col.Column)
+	}
+
+	var storedCols intsets.Fast
+	storedCols.Add(2)
+	hypTable := BuildHypotheticalTable(nil, table, []HypotheticalIndexDef{
+		{Name: "idx_i", KeyCols: []int{1}, Descending: []bool{true}},
+		{Name: "idx_i_j", KeyCols: []int{1}, Descending: []bool{false}, StoredCols: storedCols},
+	})
+
+	if hypTable.IndexCount() != table.IndexCount()+2 {
+		t.Fatalf("expected index count to be %d, got %d\n", table.IndexCount()+2, hypTable.IndexCount())
+	}
+
+	idx := hypTable.Index(table.IndexCount())
+	if idx.Name() != "idx_i" {
+		t.Errorf("expected index name to be idx_i, got %s\n", idx.Name())
+	}
+	if !idx.Column(0).Descending {
+		t.Errorf("expected the key column of idx_i to be descending\n")
+	}
+	if idx.ColumnCount() != 1 {
+		t.Errorf("expected idx_i to have 1 column, got %d\n", idx.ColumnCount())
+	}
+
+	idx = hypTable.Index(table.IndexCount() + 1)
+	if idx.ColumnCount() != 2 {
+		t.Fatalf("expected idx_i_j to have 2 columns, got %d\n", idx.ColumnCount())
+	}
+	if idx.Column(1).ColName() != "j" {
+		t.Errorf("expected idx_i_j to store column j, got %s\n", idx.Column(1).ColName())
+	}
+}
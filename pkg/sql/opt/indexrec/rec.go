@@ -52,6 +52,30 @@ type Rec struct {
 	// Replacement is true if SQL replaces an existing index, i.e., it contains
 	// both a CREATE INDEX and DROP INDEX statement.
 	RecType Type
+
+	// The fields below describe the recommendation in a structured way, so
+	// that the recommendations of several statements can be combined.
+
+	// Table is the table of the recommended index.
+	Table cat.Table
+	// TableName is the fully qualified name of Table.
+	TableName tree.TableName
+	// Columns are the explicit columns of the recommended index. They are nil
+	// for TypeAlterIndex.
+	Columns []tree.IndexElem
+	// KeyColOrds are the ordinals of the table columns in Columns. The source
+	// column is used for the inverted column of an inverted index.
+	KeyColOrds []int
+	// StoredColOrds are the ordinals of the table columns stored by the
+	// recommended index.
+	StoredColOrds intsets.Fast
+	// Inverted is true if the recommended index is inverted.
+	Inverted bool
+	// Unique is true if the recommended index is unique.
+	Unique bool
+	// ExistingIndex is the existing index replaced by TypeReplaceIndex or
+	// made visible by TypeAlterIndex. It is nil for TypeCreateIndex.
+	ExistingIndex cat.Index
 }
 
 // FindRecs finds index candidates that are scanned in an expression to
@@ -169,7 +193,8 @@ func findBestExistingIndexToReplace(
 		}
 		if existingIndex.GetInvisibility() != 0.0 {
 			existingIndexAllCols := getAllCols(existingIndex)
-			if actuallyScannedCols.Difference(existingIndexAllCols).Empty() {
+			if hypIndex.isContainedIn(existingIndex, existingIndexAllCols) &&
+				actuallyScannedCols.Difference(existingIndexAllCols).Empty() {
 				// There exists an invisible index containing every explicit column in
 				// hypIndex and column in actuallyScannedCol. Recommend alter index
 				// visible.
@@ -256,6 +281,18 @@ func (ir *indexRecommendation) constructIndexRec(ctx context.Context) (Rec, erro
 	if err != nil {
 		return Rec{}, err
 	}
+	rec := Rec{
+		RecType:       recType,
+		Table:         ir.index.tab.Table,
+		TableName:     tableName,
+		StoredColOrds: ir.newStoredColOrds.Copy(),
+		Inverted:      ir.index.IsInverted(),
+		ExistingIndex: existingIndex,
+	}
+	if recType != TypeAlterIndex {
+		rec.Columns = indexCols
+		rec.KeyColOrds = ir.indexColOrds()
+	}
 
 	// Formats index recommendation to its final output struct Rec.
 	switch recType {
@@ -269,7 +306,8 @@ func (ir *indexRecommendation) constructIndexRec(ctx context.Context) (Rec, erro
 		}
 		sb.WriteString(createCmd.String())
 		sb.WriteByte(';')
-		return Rec{sb.String(), TypeCreateIndex}, nil
+		rec.SQL = sb.String()
+		return rec, nil
 	case TypeReplaceIndex:
 		dropCmd := tree.DropIndex{
 			IndexList: []*tree.TableIndexName{{
@@ -290,7 +328,9 @@ func (ir *indexRecommendation) constructIndexRec(ctx context.Context) (Rec, erro
 		sb.WriteByte(' ')
 		sb.WriteString(dropCmd.String())
 		sb.WriteByte(';')
-		return Rec{sb.String(), TypeReplaceIndex}, nil
+		rec.SQL = sb.String()
+		rec.Unique = existingIndex.IsUnique()
+		return rec, nil
 	case TypeAlterIndex:
 		alterCmd := tree.AlterIndexVisible{
 			Index: tree.TableIndexName{
@@ -300,7 +340,8 @@ func (ir *indexRecommendation) constructIndexRec(ctx context.Context) (Rec, erro
 		}
 		sb.WriteString(alterCmd.String())
 		sb.WriteByte(';')
-		return Rec{sb.String(), TypeAlterIndex}, nil
+		rec.SQL = sb.String()
+		return rec, nil
 	}
 	return Rec{}, nil
 }
@@ -461,6 +502,21 @@ func (ir *indexRecommendation) indexCols() []tree.IndexElem {
 	return indexCols
 }
 
+// indexColOrds returns the ordinals of the explicit columns of the index. The
+// source column is used for the inverted column of an inverted index.
+func (ir *indexRecommendation) indexColOrds() []int {
+	ords := make([]int, len(ir.index.cols))
+	for i := range ir.index.cols {
+		indexCol := ir.index.Column(i)
+		if ir.index.IsInverted() && i == len(ir.index.cols)-1 {
+			ords[i] = indexCol.InvertedSourceColumnOrdinal()
+		} else {
+			ords[i] = indexCol.Column.Ordinal()
+		}
+	}
+	return ords
+}
+
 // storingColumns returns the stored columns of an index recommendation, used in
 // SQLString.
 func (ir *indexRecommendation) storingColumns() []tree.Name {
@@ -0,0 +1,63 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package memo
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+)
+
+// AdaptiveJoinsEnabled controls whether lookup and hash joins are planned as
+// adaptive joins, which choose between the two algorithms at execution time
+// based on the actual number of rows of their input.
+var AdaptiveJoinsEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.opt.adaptive_joins.enabled",
+	"if true, lookup and hash joins which can be executed with either algorithm "+
+		"switch to the other algorithm at execution time when the number of rows "+
+		"of their input crosses a threshold computed by the optimizer",
+	false,
+)
+
+// AdaptiveJoinsEnabled returns true if adaptive joins were enabled when the
+// memo was initialized.
+func (m *Memo) AdaptiveJoinsEnabled() bool {
+	return m.adaptiveJoinsEnabled
+}
+
+// SetAdaptiveJoinThreshold records that the given lookup or hash join should
+// be executed as an adaptive join. The threshold is the number of input rows
+// above which a hash join is cheaper than a lookup join.
+func (m *Memo) SetAdaptiveJoinThreshold(join RelExpr, threshold int64) {
+	if m.adaptiveJoinThresholds == nil {
+		m.adaptiveJoinThresholds = make(map[RelExpr]int64)
+	}
+	m.adaptiveJoinThresholds[join] = threshold
+}
+
+// AdaptiveJoinThreshold returns the threshold set by SetAdaptiveJoinThreshold
+// for the given join, or 0 if the join should not be executed as an adaptive
+// join.
+func (m *Memo) AdaptiveJoinThreshold(join RelExpr) int64 {
+	return m.adaptiveJoinThresholds[join]
+}
+
+// RequestTableRowCount returns the estimated number of rows in the given
+// table. It returns ok=false if the statistics builder has been cleared by
+// SetRoot.
+func (m *Memo) RequestTableRowCount(tabID opt.TableID) (_ float64, ok bool) {
+	// When SetRoot is called, the statistics builder may have been cleared.
+	// If this happens, we can't serve the request anymore.
+	if m.logPropsBuilder.sb.md != nil {
+		return m.logPropsBuilder.sb.makeTableStatistics(tabID).RowCount, true
+	}
+	return 0, false
+}
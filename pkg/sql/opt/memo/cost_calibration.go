@@ -0,0 +1,117 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package memo
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+)
+
+// CostCalibrationEnabled controls whether the optimizer uses the cost factors
+// fitted by a cost model calibration job instead of its built-in defaults.
+var CostCalibrationEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.enabled",
+	"if true, the optimizer uses the calibrated CPU, sequential I/O and random I/O "+
+		"cost factors instead of its built-in defaults",
+	false,
+)
+
+// CostCalibrationCPUCostFactor is the calibrated cost of processing a single
+// row or column value.
+var CostCalibrationCPUCostFactor = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.cpu_cost_factor",
+	"calibrated optimizer cost factor for CPU work; 0 uses the built-in default",
+	0,
+	settings.NonNegativeFloat,
+)
+
+// CostCalibrationSeqIOCostFactor is the calibrated cost of sequentially
+// reading a single row from storage.
+var CostCalibrationSeqIOCostFactor = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.seq_io_cost_factor",
+	"calibrated optimizer cost factor for sequential I/O; 0 uses the built-in default",
+	0,
+	settings.NonNegativeFloat,
+)
+
+// CostCalibrationRandIOCostFactor is the calibrated cost of seeking to a new
+// position in storage.
+var CostCalibrationRandIOCostFactor = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.rand_io_cost_factor",
+	"calibrated optimizer cost factor for random I/O; 0 uses the built-in default",
+	0,
+	settings.NonNegativeFloat,
+)
+
+// CostCalibrationJobID is the ID of the calibration job which produced the
+// calibrated cost factors. It is only informational, and is shown by EXPLAIN.
+var CostCalibrationJobID = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.opt.cost_calibration.job_id",
+	"identifier of the cost model calibration job that produced the calibrated cost factors",
+	0,
+	settings.NonNegativeInt,
+)
+
+// CostCalibration holds the per-cluster cost factors used by the coster. A
+// zero factor means that the coster uses its built-in default for that factor.
+type CostCalibration struct {
+	// JobID is the ID of the calibration job which produced the factors, or 0
+	// if they were set manually.
+	JobID int64
+
+	CPUCostFactor    Cost
+	SeqIOCostFactor  Cost
+	RandIOCostFactor Cost
+}
+
+// GetCostCalibration returns the cost calibration currently in effect. It
+// returns the zero CostCalibration if calibration is disabled.
+func GetCostCalibration(sv *settings.Values) CostCalibration {
+	if sv == nil || !CostCalibrationEnabled.Get(sv) {
+		return CostCalibration{}
+	}
+	return CostCalibration{
+		JobID:            CostCalibrationJobID.Get(sv),
+		CPUCostFactor:    Cost(CostCalibrationCPUCostFactor.Get(sv)),
+		SeqIOCostFactor:  Cost(CostCalibrationSeqIOCostFactor.Get(sv)),
+		RandIOCostFactor: Cost(CostCalibrationRandIOCostFactor.Get(sv)),
+	}
+}
+
+// IsDefault returns true if the calibration does not override any of the
+// coster's default cost factors.
+func (c CostCalibration) IsDefault() bool {
+	return c.CPUCostFactor == 0 && c.SeqIOCostFactor == 0 && c.RandIOCostFactor == 0
+}
+
+// String returns a description of the calibration suitable for EXPLAIN.
+func (c CostCalibration) String() string {
+	factor := func(f Cost) string {
+		if f == 0 {
+			return "default"
+		}
+		return fmt.Sprintf("%g", float64(f))
+	}
+	s := fmt.Sprintf(
+		"cpu: %s, seq io: %s, rand io: %s",
+		factor(c.CPUCostFactor), factor(c.SeqIOCostFactor), factor(c.RandIOCostFactor),
+	)
+	if c.JobID != 0 {
+		s = fmt.Sprintf("job %d (%s)", c.JobID, s)
+	}
+	return s
+}
@@ -1265,6 +1265,40 @@ func (b *logicalPropsBuilder) buildMax1RowProps(max1Row *Max1RowExpr, rel *props
 	}
 }
 
+func (b *logicalPropsBuilder) buildBarrierProps(barrier *BarrierExpr, rel *props.Relational) {
+	// Copy over the props from the input.
+	inputProps := barrier.Input.Relational()
+
+	BuildSharedProps(barrier, &rel.Shared, b.evalCtx)
+
+	// Output Columns
+	// --------------
+	// Inherited from the input expression.
+	rel.OutputCols = inputProps.OutputCols
+
+	// Not Null Columns
+	// ----------------
+	// Inherited from the input expression.
+	rel.NotNullCols = inputProps.NotNullCols
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by BuildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	rel.FuncDeps = inputProps.FuncDeps
+
+	// Cardinality
+	// -----------
+	rel.Cardinality = inputProps.Cardinality
+
+	// Statistics
+	// ----------
+	// Inherited from the input expression.
+	*rel.Statistics() = *inputProps.Statistics()
+}
+
 func (b *logicalPropsBuilder) buildOrdinalityProps(ord *OrdinalityExpr, rel *props.Relational) {
 	BuildSharedProps(ord, &rel.Shared, b.evalCtx)
 
@@ -171,12 +171,28 @@ type Memo struct {
 	useImprovedJoinElimination                 bool
 	implicitFKLockingForSerializable           bool
 	durableLockingForSerializable              bool
+	useExtendedStatistics                      bool
+	allowMaterializedViewMutations             bool
 
 	// txnIsoLevel is the isolation level under which the plan was created. This
 	// affects the planning of some locking operations, so it must be included in
 	// memo staleness calculation.
 	txnIsoLevel isolation.Level
 
+	// costCalibration holds the calibrated cost factors in effect when the memo
+	// was created. Plans costed with different factors may differ, so it is
+	// included in memo staleness calculation.
+	costCalibration CostCalibration
+
+	// adaptiveJoinsEnabled is the value of the sql.opt.adaptive_joins.enabled
+	// cluster setting when the memo was created.
+	adaptiveJoinsEnabled bool
+
+	// adaptiveJoinThresholds maps the lookup and hash joins of the lowest cost
+	// tree which are executed as adaptive joins to their threshold. See
+	// SetAdaptiveJoinThreshold.
+	adaptiveJoinThresholds map[RelExpr]int64
+
 	// curRank is the highest currently in-use scalar expression rank.
 	curRank opt.ScalarRank
 
@@ -240,8 +256,14 @@ func (m *Memo) Init(ctx context.Context, evalCtx *eval.Context) {
 		useImprovedJoinElimination:                 evalCtx.SessionData().OptimizerUseImprovedJoinElimination,
 		implicitFKLockingForSerializable:           evalCtx.SessionData().ImplicitFKLockingForSerializable,
 		durableLockingForSerializable:              evalCtx.SessionData().DurableLockingForSerializable,
+		useExtendedStatistics:                      evalCtx.SessionData().OptimizerUseExtendedStatistics,
+		allowMaterializedViewMutations:             evalCtx.SessionData().AllowMaterializedViewMutations,
 		txnIsoLevel:                                evalCtx.TxnIsoLevel,
 	}
+	if evalCtx.Settings != nil {
+		m.costCalibration = GetCostCalibration(&evalCtx.Settings.SV)
+		m.adaptiveJoinsEnabled = AdaptiveJoinsEnabled.Get(&evalCtx.Settings.SV)
+	}
 	m.metadata.Init()
 	m.logPropsBuilder.init(ctx, evalCtx, m)
 }
@@ -252,6 +274,12 @@ func (m *Memo) AllowUnconstrainedNonCoveringIndexScan() bool {
 	return m.allowUnconstrainedNonCoveringIndexScan
 }
 
+// CostCalibration returns the calibrated cost factors that were in effect when
+// the memo was initialized.
+func (m *Memo) CostCalibration() CostCalibration {
+	return m.costCalibration
+}
+
 // ResetLogProps resets the logPropsBuilder. It should be used in combination
 // with the perturb-cost OptTester flag in order to update the query plan tree
 // after optimization is complete with the real computed cost, not the perturbed
@@ -389,10 +417,22 @@ func (m *Memo) IsStale(
 		m.useImprovedJoinElimination != evalCtx.SessionData().OptimizerUseImprovedJoinElimination ||
 		m.implicitFKLockingForSerializable != evalCtx.SessionData().ImplicitFKLockingForSerializable ||
 		m.durableLockingForSerializable != evalCtx.SessionData().DurableLockingForSerializable ||
+		m.useExtendedStatistics != evalCtx.SessionData().OptimizerUseExtendedStatistics ||
+		m.allowMaterializedViewMutations != evalCtx.SessionData().AllowMaterializedViewMutations ||
 		m.txnIsoLevel != evalCtx.TxnIsoLevel {
 		return true, nil
 	}
 
+	// Memo is stale if the calibrated cost factors have changed.
+	if evalCtx.Settings != nil && m.costCalibration != GetCostCalibration(&evalCtx.Settings.SV) {
+		return true, nil
+	}
+
+	// Memo is stale if adaptive joins have been enabled or disabled.
+	if evalCtx.Settings != nil && m.adaptiveJoinsEnabled != AdaptiveJoinsEnabled.Get(&evalCtx.Settings.SV) {
+		return true, nil
+	}
+
 	// Memo is stale if the fingerprint of any object in the memo's metadata has
 	// changed, or if the current user no longer has sufficient privilege to
 	// access the object.
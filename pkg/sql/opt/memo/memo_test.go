@@ -392,12 +392,32 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().DurableLockingForSerializable = false
 	notStale()
 
+	// Stale optimizer_use_extended_statistics.
+	evalCtx.SessionData().OptimizerUseExtendedStatistics = true
+	stale()
+	evalCtx.SessionData().OptimizerUseExtendedStatistics = false
+	notStale()
+
+	// Stale allow materialized view mutations.
+	evalCtx.SessionData().AllowMaterializedViewMutations = true
+	stale()
+	evalCtx.SessionData().AllowMaterializedViewMutations = false
+	notStale()
+
 	// Stale txn isolation level.
 	evalCtx.TxnIsoLevel = isolation.ReadCommitted
 	stale()
 	evalCtx.TxnIsoLevel = isolation.Serializable
 	notStale()
 
+	// Stale cost calibration.
+	memo.CostCalibrationEnabled.Override(ctx, &evalCtx.Settings.SV, true)
+	memo.CostCalibrationCPUCostFactor.Override(ctx, &evalCtx.Settings.SV, 0.02)
+	stale()
+	memo.CostCalibrationEnabled.Override(ctx, &evalCtx.Settings.SV, false)
+	notStale()
+	memo.CostCalibrationCPUCostFactor.Override(ctx, &evalCtx.Settings.SV, 0)
+
 	// User no longer has access to view.
 	catalog.View(tree.NewTableNameWithSchema("t", catconstants.PublicSchemaName, "abcview")).Revoked = true
 	_, err = o.Memo().IsStale(ctx, &evalCtx, catalog)
@@ -14,6 +14,7 @@ import (
 	"context"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -487,6 +488,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.WithOp:
 		return sb.colStat(colSet, e.Child(1).(RelExpr))
 
+	case opt.BarrierOp:
+		return sb.colStat(colSet, e.Child(0).(RelExpr))
+
 	case opt.FakeRelOp:
 		rel := e.Relational()
 		return sb.colStatLeaf(colSet, rel.Statistics(), &rel.FuncDeps, rel.NotNullCols)
@@ -665,6 +669,13 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 				stats.AvgColSizes[colOrd] = stat.AvgSize()
 			}
 
+			// Add the extended statistics of the most recent statistic with
+			// extended statistics for each column set.
+			if ext := stat.ExtendedStatistics(); ext != nil && cols.Len() > 1 &&
+				sb.evalCtx.SessionData().OptimizerUseExtendedStatistics {
+				sb.addExtendedStatistic(stats, tabID, stat, cols, ext)
+			}
+
 			needHistogram := cols.Len() == 1 && stat.Histogram() != nil &&
 				sb.evalCtx.SessionData().OptimizerUseHistograms
 			seenInvertedStat := false
@@ -745,10 +756,42 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 			}
 		}
 	}
+	// Prefer the extended statistics on the most columns, since they capture
+	// the correlations between more columns.
+	sort.SliceStable(stats.ExtendedStats, func(i, j int) bool {
+		return stats.ExtendedStats[i].ColSet.Len() > stats.ExtendedStats[j].ColSet.Len()
+	})
 	sb.md.SetTableAnnotation(tabID, statsAnnID, stats)
 	return stats
 }
 
+// addExtendedStatistic adds the extended statistics of the given table
+// statistic to stats, unless stats already contains extended statistics on the
+// same columns.
+func (sb *statisticsBuilder) addExtendedStatistic(
+	stats *props.Statistics,
+	tabID opt.TableID,
+	stat cat.TableStatistic,
+	cols opt.ColSet,
+	ext *cat.ExtendedStatistics,
+) {
+	for i := range stats.ExtendedStats {
+		if stats.ExtendedStats[i].ColSet.Equals(cols) {
+			return
+		}
+	}
+	extCols := make([]opt.ColumnID, stat.ColumnCount())
+	for i := range extCols {
+		extCols[i] = tabID.ColumnID(stat.ColumnOrdinal(i))
+	}
+	stats.ExtendedStats = append(stats.ExtendedStats, props.ExtendedStatistic{
+		Cols:          extCols,
+		ColSet:        cols,
+		DistinctCount: max(float64(stat.DistinctCount()), 1),
+		Stats:         ext,
+	})
+}
+
 // invertedIndexColInfo is used to store information about an inverted column.
 type invertedIndexColInfo struct {
 	// invIdxColOrds is the list of inverted index column ordinals for a given
@@ -3071,8 +3114,13 @@ func (sb *statisticsBuilder) filterRelExpr(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
-	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, e, s, corr))
+	// The columns covered by extended statistics are estimated separately, since
+	// the extended statistics capture the correlations between them.
+	extSelectivity, extCols := sb.selectivityFromExtendedStats(filters, constrainedCols, histCols, e, s)
+	otherCols := constrainedCols.Difference(extCols)
+	corr := sb.correlationFromMultiColDistinctCounts(otherCols, e, s)
+	s.ApplySelectivity(extSelectivity)
+	s.ApplySelectivity(sb.selectivityFromConstrainedCols(otherCols, histCols.Difference(extCols), e, s, corr))
 	s.ApplySelectivity(sb.selectivityFromEquivalencies(equivReps, &relProps.FuncDeps, e, s))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(e, notNullCols, constrainedCols))
@@ -3997,6 +4045,177 @@ func (sb *statisticsBuilder) correlationFromMultiColDistinctCounts(
 	return (selectivity.AsFloat() - lowerBound.AsFloat()) / (upperBound.AsFloat() - lowerBound.AsFloat())
 }
 
+// selectivityFromExtendedStats calculates the selectivity of the filters on
+// the constrained columns which are covered by extended statistics, and returns
+// the set of these columns. The selectivity of the other constrained columns
+// must be calculated separately.
+//
+// If all the columns of an extended statistic with most common values are
+// constrained to constant values, the selectivity is estimated from the most
+// common values. See selectivityFromMostCommonValues.
+//
+// Otherwise, if two or more columns of an extended statistic with dependencies
+// are constrained, their selectivity is estimated from their individual
+// selectivities and the correlation indicated by the dependencies. See
+// correlationFromDependencies.
+func (sb *statisticsBuilder) selectivityFromExtendedStats(
+	filters FiltersExpr, constrainedCols, histCols opt.ColSet, e RelExpr, s *props.Statistics,
+) (selectivity props.Selectivity, extCols opt.ColSet) {
+	selectivity = props.OneSelectivity
+	if constrainedCols.Len() < 2 || !sb.evalCtx.SessionData().OptimizerUseExtendedStatistics {
+		return selectivity, opt.ColSet{}
+	}
+
+	var tables []opt.TableID
+	constrainedCols.ForEach(func(col opt.ColumnID) {
+		tabID := sb.md.ColumnMeta(col).Table
+		if tabID == 0 {
+			return
+		}
+		for _, t := range tables {
+			if t == tabID {
+				return
+			}
+		}
+		tables = append(tables, tabID)
+	})
+
+	var constCols opt.ColSet
+	extractedConstCols := false
+	for _, tabID := range tables {
+		tabStats := sb.makeTableStatistics(tabID)
+		for i := range tabStats.ExtendedStats {
+			ext := &tabStats.ExtendedStats[i]
+			if ext.Stats.HasMostCommonValues && ext.ColSet.SubsetOf(constrainedCols) &&
+				!ext.ColSet.Intersects(extCols) {
+				if !extractedConstCols {
+					constCols = ExtractConstColumns(filters, sb.evalCtx)
+					extractedConstCols = true
+				}
+				if ext.ColSet.SubsetOf(constCols) {
+					if mcvSelectivity, ok := sb.selectivityFromMostCommonValues(filters, ext); ok {
+						selectivity.Multiply(mcvSelectivity)
+						extCols.UnionWith(ext.ColSet)
+						continue
+					}
+				}
+			}
+
+			cols := ext.ColSet.Intersection(constrainedCols).Difference(extCols)
+			if cols.Len() < 2 {
+				continue
+			}
+			corr := sb.correlationFromDependencies(cols, ext)
+			if corr == 0 {
+				continue
+			}
+			corr = max(corr, sb.correlationFromMultiColDistinctCounts(cols, e, s))
+			selectivity.Multiply(sb.selectivityFromConstrainedCols(
+				cols, histCols.Intersection(cols), e, s, corr,
+			))
+			extCols.UnionWith(cols)
+		}
+	}
+	return selectivity, extCols
+}
+
+// selectivityFromMostCommonValues calculates the selectivity of the filters
+// which constrain all the columns of the given extended statistic to constant
+// values. If the combination of values is one of the most common values, the
+// selectivity is its frequency. Otherwise, the frequency of the values which
+// are not among the most common values is spread evenly across their distinct
+// combinations. Returns ok=false if the values of the columns could not be
+// determined.
+func (sb *statisticsBuilder) selectivityFromMostCommonValues(
+	filters FiltersExpr, ext *props.ExtendedStatistic,
+) (selectivity props.Selectivity, ok bool) {
+	values := make(tree.Datums, len(ext.Cols))
+	for i, col := range ext.Cols {
+		if values[i] = ExtractValueForConstColumn(filters, sb.evalCtx, col); values[i] == nil {
+			return props.OneSelectivity, false
+		}
+	}
+
+	mcv := ext.Stats.MostCommonValues
+	var sumFrequency float64
+	minFrequency := 1.0
+	for i := range mcv {
+		if sb.datumsEqual(values, mcv[i].Values) {
+			return props.MakeSelectivity(mcv[i].Frequency), true
+		}
+		sumFrequency += mcv[i].Frequency
+		minFrequency = min(minFrequency, mcv[i].Frequency)
+	}
+
+	// The values are not among the most common values, so they cannot be more
+	// common than the least common of them.
+	otherDistinctCount := max(ext.DistinctCount-float64(len(mcv)), 1)
+	return props.MakeSelectivity(min(max(1-sumFrequency, 0)/otherDistinctCount, minFrequency)), true
+}
+
+// datumsEqual returns true if the given datums are equal. Datums which cannot
+// be compared are not equal.
+func (sb *statisticsBuilder) datumsEqual(left, right tree.Datums) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if cmp, err := left[i].CompareError(sb.evalCtx, right[i]); err != nil || cmp != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// correlationFromDependencies returns the correlation between the given
+// columns of an extended statistic, as indicated by its functional
+// dependencies. It is a number between 0 and 1, where 0 means the columns are
+// completely independent, and 1 means one of the columns determines the values
+// of all the others.
+//
+// The degree of the dependency of column b on column a is the fraction of the
+// rows in which the value of a determines the value of b. As in PostgreSQL, the
+// selectivity of a filter on a and b is then estimated with:
+//
+//	P(a,b) = P(a) * (f + (1-f) * P(b))
+//
+// where f is the degree of the dependency. This is the selectivity calculated
+// by selectivityFromConstrainedCols with correlation f, when the filter on a is
+// the most selective. With more columns, the correlation is the lowest degree
+// of the dependencies of the other columns on a single column, which is chosen
+// to maximize the correlation.
+func (sb *statisticsBuilder) correlationFromDependencies(
+	cols opt.ColSet, ext *props.ExtendedStatistic,
+) float64 {
+	if len(ext.Stats.Dependencies) == 0 {
+		return 0
+	}
+	// degrees[i*n+j] is the degree of the dependency of the jth column of the
+	// statistic on its ith column.
+	n := len(ext.Cols)
+	degrees := make([]float64, n*n)
+	for _, dep := range ext.Stats.Dependencies {
+		if len(dep.Determinant) == 1 && dep.Determinant[0] < n && dep.Dependent < n {
+			degrees[dep.Determinant[0]*n+dep.Dependent] = dep.Degree
+		}
+	}
+
+	var corr float64
+	for i := 0; i < n; i++ {
+		if !cols.Contains(ext.Cols[i]) {
+			continue
+		}
+		determinantCorr := 1.0
+		for j := 0; j < n; j++ {
+			if j != i && cols.Contains(ext.Cols[j]) {
+				determinantCorr = min(determinantCorr, degrees[i*n+j])
+			}
+		}
+		corr = max(corr, determinantCorr)
+	}
+	return min(corr, 1)
+}
+
 // correlationFromMultiColDistinctCountsForJoin is similar to
 // correlationFromMultiColDistinctCounts, but used for join expressions.
 func (sb *statisticsBuilder) correlationFromMultiColDistinctCountsForJoin(
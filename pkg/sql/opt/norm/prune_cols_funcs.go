@@ -637,6 +637,14 @@ func (c *CustomFuncs) DerivePruneCols(e memo.RelExpr, disabledRules intsets.Fast
 		inputPruneCols := c.DerivePruneCols(ord.Input, disabledRules)
 		relProps.Rule.PruneCols = inputPruneCols.Difference(ord.Ordering.ColSet())
 
+	case opt.BarrierOp:
+		if disabledRules.Contains(int(opt.PruneBarrierCols)) {
+			// Avoid rule cycles.
+			break
+		}
+		// Any pruneable input columns can potentially be pruned.
+		relProps.Rule.PruneCols = c.DerivePruneCols(e.Child(0).(memo.RelExpr), disabledRules).Copy()
+
 	case opt.IndexJoinOp, opt.LookupJoinOp, opt.MergeJoinOp:
 		// There is no need to prune columns projected by Index, Lookup or Merge
 		// joins, since its parent will always be an "alternate" expression in the
@@ -105,6 +105,43 @@ func (c *CustomFuncs) GroupingAndConstCols(
 	return result
 }
 
+// CanPushIntoBarrier returns true if the given filter condition can be pushed
+// below a Barrier operator with the given input columns. This is the case if
+// the condition is leakproof, meaning that it cannot reveal anything about the
+// rows it is evaluated on other than through its result, and if it is bound by
+// the input columns.
+func (c *CustomFuncs) CanPushIntoBarrier(item *memo.FiltersItem, cols opt.ColSet) bool {
+	return item.ScalarProps().VolatilitySet.IsLeakproof() && c.IsBoundBy(item, cols)
+}
+
+// ExtractBarrierPushableConditions returns the filter conditions for which
+// CanPushIntoBarrier returns true.
+func (c *CustomFuncs) ExtractBarrierPushableConditions(
+	filters memo.FiltersExpr, cols opt.ColSet,
+) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if c.CanPushIntoBarrier(&filters[i], cols) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
+// ExtractBarrierUnpushableConditions is the opposite of
+// ExtractBarrierPushableConditions.
+func (c *CustomFuncs) ExtractBarrierUnpushableConditions(
+	filters memo.FiltersExpr, cols opt.ColSet,
+) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if !c.CanPushIntoBarrier(&filters[i], cols) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
 // CanConsolidateFilters returns true if there are at least two different
 // filter conditions that contain the same variable, where the conditions
 // have tight constraints and contain a single variable. For example,
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -71,6 +72,11 @@ type Builder struct {
 	// This is used when re-preparing invalidated queries.
 	KeepPlaceholders bool
 
+	// StatementHints is a control knob: if set, the hints are applied to the
+	// statement as if they were written inline, except where the statement
+	// already has an inline hint.
+	StatementHints *stmthints.Hints
+
 	// -- Results --
 	//
 	// These fields are set during the building process and can be used after
@@ -113,6 +119,22 @@ type Builder struct {
 	// be used with care.
 	skipSelectPrivilegeChecks bool
 
+	// If set, checkPrivilege does not raise an error if the current user lacks
+	// the SELECT, INSERT or UPDATE privilege on a table but has been granted it
+	// on some of its columns. The privilege must then be checked for each column
+	// accessed by the query. See checkColumnPrivilege.
+	allowColumnPrivileges bool
+
+	// columnPrivilegeErrs contains, for each table and privilege which the
+	// current user only holds on some columns, the error to raise when another
+	// column is accessed.
+	columnPrivilegeErrs map[columnPrivilegeKey]error
+
+	// deniedColumns contains the columns that the current user is not allowed
+	// to reference because they lack the SELECT privilege on them, along with
+	// the error to raise if they do.
+	deniedColumns map[opt.ColumnID]error
+
 	// views contains a cache of views that have already been parsed, in case they
 	// are referenced multiple times in the same query.
 	views map[cat.View]*tree.Select
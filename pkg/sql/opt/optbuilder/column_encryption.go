@@ -0,0 +1,145 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// hasEncryptedColumns returns true if any column of the given table is
+// declared with ENCRYPTED WITH KEY.
+func hasEncryptedColumns(tab cat.Table) bool {
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if tab.IsColumnEncrypted(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnEncryptionFuncExpr returns a call to the given builtin, which
+// encrypts or decrypts the given value of the column of the table with
+// the given ordinal.
+func columnEncryptionFuncExpr(fn string, tab cat.Table, ord int, value tree.TypedExpr) tree.Expr {
+	return &tree.FuncExpr{
+		Func: tree.WrapFunction(fn),
+		Exprs: tree.Exprs{
+			tree.NewDInt(tree.DInt(tab.ID())),
+			tree.NewDInt(tree.DInt(tab.Column(ord).ColID())),
+			value,
+		},
+	}
+}
+
+// addColumnDecryption replaces the encrypted columns of the given table in
+// the given scope with their decrypted values, if the current user has the
+// DECRYPT privilege on the table. Otherwise the columns are left as they
+// are, and their ciphertexts are returned.
+//
+// The encrypted columns remain in the scope, so that they can be fetched
+// by mutations, but they become inaccessible.
+func (b *Builder) addColumnDecryption(tab cat.Table, s *scope) {
+	if !hasEncryptedColumns(tab) {
+		return
+	}
+	// Whether the values are decrypted depends on the current user, so the
+	// memo cannot be reused by other users.
+	b.DisableMemoReuse = true
+	if err := b.catalog.CheckPrivilege(b.ctx, tab, privilege.DECRYPT); err != nil {
+		if pgerror.GetPGCode(err) == pgcode.InsufficientPrivilege {
+			return
+		}
+		panic(err)
+	}
+
+	md := b.factory.Metadata()
+	projectionScope := s.replace()
+	for i := range s.cols {
+		col := &s.cols[i]
+		projectionScope.appendColumn(col)
+		tabID := md.ColumnMeta(col.id).Table
+		if tabID == 0 || md.Table(tabID).ID() != tab.ID() || col.visibility == inaccessible {
+			continue
+		}
+		ord := tabID.ColumnOrdinal(col.id)
+		if !tab.IsColumnEncrypted(ord) {
+			continue
+		}
+		projectionScope.cols[len(projectionScope.cols)-1].visibility = inaccessible
+
+		expr := columnEncryptionFuncExpr("crdb_internal.decrypt_column_value", tab, ord, col)
+		texpr := s.resolveAndRequireType(expr, col.typ)
+		decrypted := projectionScope.addColumn(col.name, texpr)
+		decrypted.table = col.table
+		decrypted.visibility = col.visibility
+		b.buildScalar(texpr, s, projectionScope, decrypted, nil)
+		if err, ok := b.deniedColumns[col.id]; ok {
+			b.deniedColumns[decrypted.id] = err
+		}
+	}
+	b.constructProjectForScope(s, projectionScope)
+	s.cols = projectionScope.cols
+	s.expr = projectionScope.expr
+}
+
+// projectColumnEncryption projects the encryption of the values written to
+// the encrypted columns of the target table, and makes the mutation write
+// the ciphertexts. A value which is already a ciphertext of the column,
+// e.g. because it was read by a user without the DECRYPT privilege, is not
+// encrypted twice; see the crdb_internal.encrypt_column_value builtin.
+func (mb *mutationBuilder) projectColumnEncryption() {
+	if !hasEncryptedColumns(mb.tab) {
+		return
+	}
+	projectionScope := mb.outScope.replace()
+	projectionScope.appendColumnsFromScope(mb.outScope)
+
+	encrypted := make(map[opt.ColumnID]opt.ColumnID)
+	encrypt := func(ord int, colID opt.ColumnID) opt.ColumnID {
+		if colID == 0 {
+			return 0
+		}
+		if id, ok := encrypted[colID]; ok {
+			return id
+		}
+		tabCol := mb.tab.Column(ord)
+		expr := columnEncryptionFuncExpr(
+			"crdb_internal.encrypt_column_value", mb.tab, ord, mb.outScope.getColumn(colID),
+		)
+		texpr := mb.outScope.resolveAndRequireType(expr, tabCol.DatumType())
+
+		// Use an anonymous name because the column cannot be referenced in
+		// other expressions.
+		colName := scopeColName("").WithMetadataName(fmt.Sprintf("%s_encrypted", tabCol.ColName()))
+		scopeCol := projectionScope.addColumn(colName, texpr)
+		mb.b.buildScalar(texpr, mb.outScope, projectionScope, scopeCol, nil)
+		encrypted[colID] = scopeCol.id
+		return scopeCol.id
+	}
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		if !mb.tab.IsColumnEncrypted(i) {
+			continue
+		}
+		mb.insertColIDs[i] = encrypt(i, mb.insertColIDs[i])
+		mb.updateColIDs[i] = encrypt(i, mb.updateColIDs[i])
+		mb.upsertColIDs[i] = encrypt(i, mb.upsertColIDs[i])
+	}
+
+	mb.b.constructProjectForScope(mb.outScope, projectionScope)
+	mb.outScope = projectionScope
+}
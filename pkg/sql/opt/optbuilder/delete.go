@@ -68,8 +68,12 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 			"cannot specify a list of column IDs with DELETE"))
 	}
 
-	// Check Select permission as well, since existing values must be read.
+	// Check Select permission as well, since existing values must be read. It
+	// can be granted on some columns only, in which case the referenced columns
+	// are checked individually.
+	b.allowColumnPrivileges = true
 	b.checkPrivilege(depName, tab, privilege.SELECT)
+	b.allowColumnPrivileges = false
 
 	// Check if this table has already been mutated in another subquery.
 	b.checkMultipleMutations(tab, generalMutation)
@@ -86,6 +90,10 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	// All columns from the delete table will be projected.
 	mb.buildInputForDelete(inScope, del.Table, del.Where, del.Using, del.Limit, del.OrderBy)
 
+	// The remaining expressions are derived from the schema rather than
+	// provided by the user, so they may reference any column.
+	b.removeDeniedColumns(mb.fetchScope)
+
 	// Build the final delete statement, including any returned expressions.
 	if resultsNeeded(del.Returning) {
 		mb.buildDelete(del.Returning.(*tree.ReturningExprs))
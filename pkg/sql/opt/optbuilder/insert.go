@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
@@ -181,8 +182,12 @@ func init() {
 // ON CONFLICT clause is present, since it joins a new set of rows to the input
 // and thereby scrambles the input ordering.
 func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope) {
-	// Find which table we're working on, check the permissions.
+	// Find which table we're working on, check the permissions. The INSERT
+	// privilege can be granted on some columns only, in which case the target
+	// columns are checked individually.
+	b.allowColumnPrivileges = true
 	tab, depName, alias, refColumns := b.resolveTableForMutation(ins.Table, privilege.INSERT)
+	b.allowColumnPrivileges = false
 
 	// It is possible to insert into specific columns using table reference
 	// syntax:
@@ -275,6 +280,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	} else {
 		mb.buildInputForInsert(inScope, nil /* rows */)
 	}
+	mb.checkTargetColumnPrivileges(privilege.INSERT)
 
 	// Add default columns that were not explicitly specified by name or
 	// implicitly targeted by input columns. Also add any computed columns. In
@@ -366,6 +372,11 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 // of edge cases (that caused real correctness bugs #13437 #13962). As a result,
 // this support was removed and needs to re-enabled. See #14482.
 func (mb *mutationBuilder) needExistingRows() bool {
+	// Existing rows must be checked against the row-level security policies.
+	if mb.tab.IsRowLevelSecurityEnabled() {
+		return true
+	}
+
 	if mb.tab.DeletableIndexCount() > 1 {
 		return true
 	}
@@ -681,6 +692,9 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Ensure that the new rows satisfy the row-level security policies.
+	mb.addRowLevelSecurityChecks(descpb.PolicyDescriptor_INSERT)
+
 	// Project partial index PUT boolean columns.
 	mb.projectPartialIndexPutCols()
 
@@ -688,6 +702,9 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	// Write the ciphertexts of the values of the encrypted columns.
+	mb.projectColumnEncryption()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -889,6 +906,13 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Ensure that the conflicting existing rows may be updated, and that the
+	// new rows satisfy the row-level security policies. Since the new row may
+	// either be inserted or update an existing row, both the INSERT and UPDATE
+	// policies must be satisfied.
+	mb.addRowLevelSecurityUpsertCheck()
+	mb.addRowLevelSecurityChecks(descpb.PolicyDescriptor_INSERT, descpb.PolicyDescriptor_UPDATE)
+
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
 	// normalization.
@@ -911,6 +935,9 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	// Write the ciphertexts of the values of the encrypted columns.
+	mb.projectColumnEncryption()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
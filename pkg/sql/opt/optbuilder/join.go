@@ -54,6 +54,8 @@ func (b *Builder) buildJoin(
 	var flags memo.JoinFlags
 	switch join.Hint {
 	case "":
+		flags = b.statementHintJoinFlags(joinType)
+
 	case tree.AstHash:
 		telemetry.Inc(sqltelemetry.HashJoinHintUseCounter)
 		flags = memo.AllowOnlyHashJoinStoreRight
@@ -129,7 +131,7 @@ func (b *Builder) buildJoin(
 		left := leftScope.expr
 		right := rightScope.expr
 		outScope.expr = b.constructJoin(
-			joinType, left, right, filters, &memo.JoinPrivate{Flags: flags}, isLateral,
+			joinType, left, right, filters, b.makeJoinPrivate(flags), isLateral,
 		)
 		return outScope
 
@@ -229,6 +231,40 @@ func (b *Builder) constructJoin(
 	}
 }
 
+// statementHintJoinFlags returns the flags of a join which has no inline
+// hint, according to the join algorithm of the statement hints. Lookup and
+// inverted joins can only be forced for inner and left joins; the hint is
+// ignored for the other joins.
+func (b *Builder) statementHintJoinFlags(joinType descpb.JoinType) memo.JoinFlags {
+	if b.StatementHints == nil {
+		return 0
+	}
+	switch b.StatementHints.JoinAlgorithm {
+	case tree.AstHash:
+		return memo.AllowOnlyHashJoinStoreRight
+	case tree.AstMerge:
+		return memo.AllowOnlyMergeJoin
+	case tree.AstLookup:
+		if joinType == descpb.InnerJoin || joinType == descpb.LeftOuterJoin {
+			return memo.AllowOnlyLookupJoinIntoRight
+		}
+	case tree.AstInverted:
+		if joinType == descpb.InnerJoin || joinType == descpb.LeftOuterJoin {
+			return memo.AllowOnlyInvertedJoinIntoRight
+		}
+	}
+	return 0
+}
+
+// makeJoinPrivate returns the private of a join with the given flags. The
+// join is not reordered if the statement hints fix the join order.
+func (b *Builder) makeJoinPrivate(flags memo.JoinFlags) *memo.JoinPrivate {
+	return &memo.JoinPrivate{
+		Flags:            flags,
+		SkipReorderJoins: b.StatementHints != nil && b.StatementHints.FixedJoinOrder,
+	}
+}
+
 // usingJoinBuilder helps to build a USING join or natural join. It finds the
 // columns in the left and right relations that match the columns provided in
 // the names parameter (or names common to both sides in case of natural join),
@@ -354,6 +390,8 @@ func (jb *usingJoinBuilder) buildUsingJoin(using *tree.UsingJoinCond) {
 			jb.raiseUndefinedColError(name, "right")
 		}
 
+		jb.b.checkColumnAccess(leftCol)
+		jb.b.checkColumnAccess(rightCol)
 		jb.b.trackReferencedColumnForViews(leftCol)
 		jb.b.trackReferencedColumnForViews(rightCol)
 		jb.addEqualityCondition(leftCol, rightCol)
@@ -386,6 +424,8 @@ func (jb *usingJoinBuilder) buildNaturalJoin(natural tree.NaturalJoinCond) {
 
 		rightCol := jb.findUsingColumn(jb.rightScope.cols, leftCol.name.ReferenceName(), "right table")
 		if rightCol != nil {
+			jb.b.checkColumnAccess(leftCol)
+			jb.b.checkColumnAccess(rightCol)
 			jb.b.trackReferencedColumnForViews(leftCol)
 			jb.b.trackReferencedColumnForViews(rightCol)
 			jb.addEqualityCondition(leftCol, rightCol)
@@ -407,7 +447,7 @@ func (jb *usingJoinBuilder) finishBuild() {
 		jb.leftScope.expr,
 		jb.rightScope.expr,
 		jb.filters,
-		&memo.JoinPrivate{Flags: jb.joinFlags},
+		jb.b.makeJoinPrivate(jb.joinFlags),
 		jb.isLateral,
 	)
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -302,6 +304,16 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
+	// Only update the rows that the current user may update according to the
+	// row-level security policies of the table.
+	mb.b.addRowLevelSecurityFilter(mb.tab, descpb.PolicyDescriptor_UPDATE, mb.fetchScope)
+
+	// Prevent the user from referencing columns they cannot read.
+	mb.b.addDeniedColumns(mb.tab, mb.fetchScope)
+
+	// Let the user reference the decrypted values of the encrypted columns.
+	mb.b.addColumnDecryption(mb.tab, mb.fetchScope)
+
 	// If there is a FROM clause present, we must join all the tables
 	// together with the table being updated.
 	fromClausePresent := len(from) > 0
@@ -413,6 +425,16 @@ func (mb *mutationBuilder) buildInputForDelete(
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
+	// Only delete the rows that the current user may delete according to the
+	// row-level security policies of the table.
+	mb.b.addRowLevelSecurityFilter(mb.tab, descpb.PolicyDescriptor_DELETE, mb.fetchScope)
+
+	// Prevent the user from referencing columns they cannot read.
+	mb.b.addDeniedColumns(mb.tab, mb.fetchScope)
+
+	// Let the user reference the decrypted values of the encrypted columns.
+	mb.b.addColumnDecryption(mb.tab, mb.fetchScope)
+
 	// USING
 	usingClausePresent := len(using) > 0
 	if usingClausePresent {
@@ -1085,6 +1107,8 @@ func (mb *mutationBuilder) buildReturning(returning *tree.ReturningExprs) {
 	inScope := mb.outScope.replace()
 	inScope.expr = mb.outScope.expr
 	inScope.appendOrdinaryColumnsFromTable(mb.md.TableMeta(mb.tabID), &mb.alias)
+	mb.b.addDeniedColumns(mb.tab, inScope)
+	mb.b.addColumnDecryption(mb.tab, inScope)
 
 	// extraAccessibleCols contains all the columns that the RETURNING
 	// clause can refer to in addition to the table columns. This is useful for
@@ -1101,6 +1125,15 @@ func (mb *mutationBuilder) buildReturning(returning *tree.ReturningExprs) {
 	mb.outScope = outScope
 }
 
+// checkTargetColumnPrivileges raises an error if the current user only holds
+// the given privilege on some columns of the target table, and not on all the
+// target columns.
+func (mb *mutationBuilder) checkTargetColumnPrivileges(priv privilege.Kind) {
+	for _, id := range mb.targetColList {
+		mb.b.checkColumnPrivilege(mb.tab, mb.tabID.ColumnOrdinal(id), priv)
+	}
+}
+
 // checkNumCols raises an error if the expected number of columns does not match
 // the actual number of columns.
 func (mb *mutationBuilder) checkNumCols(expected, actual int) {
@@ -229,7 +229,9 @@ func (b *Builder) resolveColRef(e tree.Expr, inScope *scope) tree.TypedExpr {
 			}
 			panic(resolveErr)
 		}
-		return srcMeta.(tree.TypedExpr)
+		col := srcMeta.(*scopeColumn)
+		b.checkColumnAccess(col)
+		return col
 	}
 	return nil
 }
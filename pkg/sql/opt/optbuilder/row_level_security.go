@@ -0,0 +1,216 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// rowLevelSecurityExpr returns an expression combining the row-level security
+// policies of the given table that apply to the current user and the given
+// command. If withCheck is true, the WITH CHECK expressions of the policies are
+// used, falling back to the USING expressions as in Postgres. Otherwise the
+// USING expressions are used.
+//
+// Permissive policies are combined with OR, and the result is combined with
+// every restrictive policy using AND. If no permissive policy applies, the
+// expression is false, so that no rows are visible or modifiable.
+//
+// ok is false if row-level security is not enforced for the current user, in
+// which case no filter or check needs to be added.
+func (b *Builder) rowLevelSecurityExpr(
+	tab cat.Table, cmd descpb.PolicyDescriptor_Command, withCheck bool,
+) (_ tree.Expr, ok bool) {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return nil, false
+	}
+	// Whether the policies apply depends on the current user, so the memo
+	// cannot be reused by other users.
+	b.DisableMemoReuse = true
+
+	// Admins bypass row-level security, and so does the table owner unless
+	// row-level security is forced.
+	isAdmin, err := b.catalog.HasAdminRole(b.ctx)
+	if err != nil {
+		panic(err)
+	}
+	if isAdmin {
+		return nil, false
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		isOwner, err := b.catalog.HasOwnership(b.ctx, tab)
+		if err != nil {
+			panic(err)
+		}
+		if isOwner {
+			return nil, false
+		}
+	}
+
+	var permissive, restrictive tree.Expr
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		p := tab.Policy(i)
+		if p.Command != descpb.PolicyDescriptor_ALL && p.Command != cmd {
+			continue
+		}
+		if !b.policyAppliesToCurrentUser(p) {
+			continue
+		}
+		exprStr := p.UsingExpr
+		if withCheck && p.WithCheckExpr != "" {
+			exprStr = p.WithCheckExpr
+		}
+		if exprStr == "" {
+			// A policy without an expression for this command does not grant nor
+			// restrict access.
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		switch p.Type {
+		case descpb.PolicyDescriptor_PERMISSIVE:
+			if permissive == nil {
+				permissive = expr
+			} else {
+				permissive = &tree.OrExpr{Left: permissive, Right: expr}
+			}
+		case descpb.PolicyDescriptor_RESTRICTIVE:
+			if restrictive == nil {
+				restrictive = expr
+			} else {
+				restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+			}
+		}
+	}
+
+	if permissive == nil {
+		return tree.DBoolFalse, true
+	}
+	if restrictive == nil {
+		return permissive, true
+	}
+	return &tree.AndExpr{
+		Left:  &tree.ParenExpr{Expr: permissive},
+		Right: &tree.ParenExpr{Expr: restrictive},
+	}, true
+}
+
+// policyAppliesToCurrentUser returns true if the current user is, or is a
+// member of, one of the roles of the policy. A policy without roles applies to
+// all users.
+func (b *Builder) policyAppliesToCurrentUser(p *descpb.PolicyDescriptor) bool {
+	if len(p.RoleNames) == 0 {
+		return true
+	}
+	for _, name := range p.RoleNames {
+		isMember, err := b.catalog.IsMemberOfRole(b.ctx, username.MakeSQLUsernameFromPreNormalizedString(name))
+		if err != nil {
+			panic(err)
+		}
+		if isMember {
+			return true
+		}
+	}
+	return false
+}
+
+// addRowLevelSecurityFilter wraps the scan of the given table in a Select that
+// only passes the rows that are visible to the current user according to the
+// USING expressions of the policies for the given command. The Select is in
+// turn wrapped in a Barrier, so that filters of the query which are not
+// leakproof are only evaluated on the visible rows.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, cmd descpb.PolicyDescriptor_Command, scanScope *scope,
+) {
+	expr, ok := b.rowLevelSecurityExpr(tab, cmd, false /* withCheck */)
+	if !ok {
+		return
+	}
+	texpr := scanScope.resolveAndRequireType(expr, types.Bool)
+	filter := b.buildScalar(texpr, scanScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	scanScope.expr = b.factory.ConstructBarrier(b.factory.ConstructSelect(
+		scanScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	))
+}
+
+// addRowLevelSecurityChecks adds a Select to the mutation input which raises
+// an error if a new row does not satisfy the WITH CHECK expressions of the
+// policies for the given commands.
+func (mb *mutationBuilder) addRowLevelSecurityChecks(cmds ...descpb.PolicyDescriptor_Command) {
+	for _, cmd := range cmds {
+		expr, ok := mb.b.rowLevelSecurityExpr(mb.tab, cmd, true /* withCheck */)
+		if !ok {
+			continue
+		}
+		mb.addRowLevelSecurityViolationFilter(mb.outScope, expr, fmt.Sprintf(
+			"new row violates row-level security policy for table %q", mb.tab.Name(),
+		))
+	}
+}
+
+// addRowLevelSecurityUpsertCheck adds a Select to the input of an UPSERT or
+// INSERT ... ON CONFLICT DO UPDATE which raises an error if a conflicting
+// existing row is not visible to the current user according to the USING
+// expressions of the UPDATE policies.
+func (mb *mutationBuilder) addRowLevelSecurityUpsertCheck() {
+	if mb.canaryColID == 0 {
+		return
+	}
+	expr, ok := mb.b.rowLevelSecurityExpr(mb.tab, descpb.PolicyDescriptor_UPDATE, false /* withCheck */)
+	if !ok {
+		return
+	}
+	canary := mb.fetchScope.getColumn(mb.canaryColID)
+	mb.addRowLevelSecurityViolationFilter(mb.fetchScope, &tree.OrExpr{
+		Left:  &tree.IsNullExpr{Expr: canary},
+		Right: &tree.ParenExpr{Expr: expr},
+	}, fmt.Sprintf(
+		"new row violates row-level security policy (USING expression) for table %q", mb.tab.Name(),
+	))
+}
+
+// addRowLevelSecurityViolationFilter adds a Select to the mutation input which
+// raises an error with the given message for any row for which the given
+// expression, resolved in the given scope, is not true.
+func (mb *mutationBuilder) addRowLevelSecurityViolationFilter(
+	exprScope *scope, expr tree.Expr, msg string,
+) {
+	raise := &tree.FuncExpr{
+		Func: tree.WrapFunction("crdb_internal.force_error"),
+		Exprs: tree.Exprs{
+			tree.NewDString(pgcode.InsufficientPrivilege.String()),
+			tree.NewDString(msg),
+		},
+	}
+	check := &tree.CaseExpr{
+		Whens: []*tree.When{{Cond: expr, Val: tree.DBoolTrue}},
+		Else:  &tree.IsNullExpr{Expr: raise},
+	}
+	texpr := exprScope.resolveAndRequireType(check, types.Bool)
+	filter := mb.b.buildScalar(texpr, exprScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(filter)},
+	)
+}
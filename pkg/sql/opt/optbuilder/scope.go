@@ -1041,7 +1041,9 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			}
 			panic(resolveErr)
 		}
-		return false, colI.(*scopeColumn)
+		col := colI.(*scopeColumn)
+		s.builder.checkColumnAccess(col)
+		return false, col
 
 	case *tree.Placeholder:
 		// Replace placeholders that are references to function arguments with
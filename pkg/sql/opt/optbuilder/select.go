@@ -136,7 +136,11 @@ func (b *Builder) buildDataSource(
 			return outScope
 		}
 
+		// The SELECT privilege can be granted on some columns only, in which case
+		// the columns referenced by the query are checked individually.
+		b.allowColumnPrivileges = true
 		ds, depName, resName := b.resolveDataSource(tn, privilege.SELECT)
+		b.allowColumnPrivileges = false
 		locking = locking.filter(tn.ObjectName)
 		if locking.isSet() {
 			// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
@@ -145,8 +149,13 @@ func (b *Builder) buildDataSource(
 
 		switch t := ds.(type) {
 		case cat.Table:
+			if indexFlags == nil && b.StatementHints != nil {
+				if index, ok := b.StatementHints.Indexes[t.Name()]; ok {
+					indexFlags = &tree.IndexFlags{Index: index}
+				}
+			}
 			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -156,6 +165,10 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 			)
+			b.addRowLevelSecurityFilter(t, descpb.PolicyDescriptor_SELECT, outScope)
+			b.addDeniedColumns(t, outScope)
+			b.addColumnDecryption(t, outScope)
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -459,7 +472,10 @@ func (b *Builder) buildScanFromTableRef(
 	tn := tree.MakeUnqualifiedTableName(tab.Name())
 	tabMeta := b.addTable(tab, &tn)
 
-	return b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	outScope = b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	b.addRowLevelSecurityFilter(tab, descpb.PolicyDescriptor_SELECT, outScope)
+	b.addColumnDecryption(tab, outScope)
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -1347,7 +1363,11 @@ func (b *Builder) buildFromTablesRightDeep(
 
 	left := outScope.expr
 	right := tableScope.expr
-	outScope.expr = b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+	private := memo.EmptyJoinPrivate
+	if b.StatementHints != nil {
+		private = b.makeJoinPrivate(b.statementHintJoinFlags(descpb.InnerJoin))
+	}
+	outScope.expr = b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, private)
 	return outScope
 }
 
@@ -1399,7 +1419,13 @@ func (b *Builder) buildFromWithLateral(
 
 		left := outScope.expr
 		right := tableScope.expr
-		outScope.expr = b.factory.ConstructInnerJoinApply(left, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+		private := memo.EmptyJoinPrivate
+		if b.StatementHints != nil {
+			// The join algorithm of the statement hints is not forced for
+			// lateral joins, which may not be decorrelated.
+			private = b.makeJoinPrivate(0 /* flags */)
+		}
+		outScope.expr = b.factory.ConstructInnerJoinApply(left, right, memo.TrueFilter, private)
 	}
 
 	return outScope
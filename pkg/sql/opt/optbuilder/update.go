@@ -13,6 +13,7 @@ package optbuilder
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -74,7 +75,10 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 		panic(pgerror.DangerousStatementf("UPDATE without WHERE clause"))
 	}
 
-	// Find which table we're working on, check the permissions.
+	// Find which table we're working on, check the permissions. The UPDATE and
+	// SELECT privileges can be granted on some columns only, in which case the
+	// updated and referenced columns are checked individually.
+	b.allowColumnPrivileges = true
 	tab, depName, alias, refColumns := b.resolveTableForMutation(upd.Table, privilege.UPDATE)
 
 	if refColumns != nil {
@@ -84,6 +88,7 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 
 	// Check Select permission as well, since existing values must be read.
 	b.checkPrivilege(depName, tab, privilege.SELECT)
+	b.allowColumnPrivileges = false
 
 	// Check if this table has already been mutated in another subquery.
 	b.checkMultipleMutations(tab, generalMutation)
@@ -102,10 +107,15 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 
 	// Derive the columns that will be updated from the SET expressions.
 	mb.addTargetColsForUpdate(upd.Exprs)
+	mb.checkTargetColumnPrivileges(privilege.UPDATE)
 
 	// Build each of the SET expressions.
 	mb.addUpdateCols(upd.Exprs)
 
+	// The remaining expressions are derived from the schema rather than
+	// provided by the user, so they may reference any column.
+	b.removeDeniedColumns(mb.fetchScope)
+
 	// Build the final update statement, including any returned expressions.
 	if resultsNeeded(upd.Returning) {
 		mb.buildUpdate(upd.Returning.(*tree.ReturningExprs))
@@ -334,6 +344,9 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(true /* isUpdate */)
 
+	// Ensure that the updated rows satisfy the row-level security policies.
+	mb.addRowLevelSecurityChecks(descpb.PolicyDescriptor_UPDATE)
+
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
 	// normalization.
@@ -346,6 +359,9 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpdate()
 
+	// Write the ciphertexts of the values of the encrypted columns.
+	mb.projectColumnEncryption()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
@@ -128,6 +128,7 @@ func (b *Builder) expandStar(
 		for i := range refScope.cols {
 			col := &refScope.cols[i]
 			if col.table == *src && (col.visibility == visible || col.visibility == accessibleByQualifiedStar) {
+				b.checkColumnAccess(col)
 				exprs = append(exprs, col)
 				aliases = append(aliases, string(col.name.ReferenceName()))
 			}
@@ -143,6 +144,7 @@ func (b *Builder) expandStar(
 		for i := range inScope.cols {
 			col := &inScope.cols[i]
 			if col.visibility == visible {
+				b.checkColumnAccess(col)
 				exprs = append(exprs, col)
 				aliases = append(aliases, string(col.name.ReferenceName()))
 			}
@@ -576,8 +578,9 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	// We can't mutate materialized views, unless they are being refreshed
+	// incrementally.
+	if tab.IsMaterializedView() && !b.evalCtx.SessionData().AllowMaterializedViewMutations {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
@@ -671,7 +674,19 @@ func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv pri
 	if !(priv == privilege.SELECT && b.skipSelectPrivilegeChecks) {
 		err := b.catalog.CheckPrivilege(b.ctx, ds, priv)
 		if err != nil {
-			panic(err)
+			tab, ok := ds.(cat.Table)
+			if !ok || !b.allowColumnPrivileges || !b.hasAnyColumnPrivilege(tab, priv) {
+				panic(err)
+			}
+			// The privilege is checked for each column accessed by the query
+			// instead. The result depends on the current user, so the memo cannot
+			// be reused.
+			if b.columnPrivilegeErrs == nil {
+				b.columnPrivilegeErrs = make(map[columnPrivilegeKey]error)
+			}
+			b.columnPrivilegeErrs[columnPrivilegeKey{id: tab.ID(), priv: priv}] = err
+			b.DisableMemoReuse = true
+			priv = 0
 		}
 	} else {
 		// The check is skipped, so don't recheck when dependencies are checked.
@@ -683,6 +698,96 @@ func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv pri
 	b.factory.Metadata().AddDependency(name, ds, priv)
 }
 
+// columnPrivilegeKey identifies a privilege on a table.
+type columnPrivilegeKey struct {
+	id   cat.StableID
+	priv privilege.Kind
+}
+
+// hasAnyColumnPrivilege returns true if the current user has been granted the
+// given privilege on any ordinary column of the given table.
+func (b *Builder) hasAnyColumnPrivilege(tab cat.Table, priv privilege.Kind) bool {
+	if !privilege.ColumnPrivileges.Contains(priv) {
+		return false
+	}
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if tab.Column(i).Kind() != cat.Ordinary {
+			continue
+		}
+		has, err := b.catalog.HasColumnPrivilege(b.ctx, tab, i, priv)
+		if err != nil {
+			panic(err)
+		}
+		if has {
+			return true
+		}
+	}
+	return false
+}
+
+// checkColumnPrivilege raises an error if the current user only holds the
+// given privilege on some columns of the given table, as allowed by
+// checkPrivilege, and the column with the given ordinal is not one of them.
+func (b *Builder) checkColumnPrivilege(tab cat.Table, colOrd int, priv privilege.Kind) {
+	err, ok := b.columnPrivilegeErrs[columnPrivilegeKey{id: tab.ID(), priv: priv}]
+	if !ok {
+		return
+	}
+	has, hasErr := b.catalog.HasColumnPrivilege(b.ctx, tab, colOrd, priv)
+	if hasErr != nil {
+		panic(hasErr)
+	}
+	if !has {
+		panic(err)
+	}
+}
+
+// addDeniedColumns records the columns of the given table in the given scope
+// on which the current user lacks the SELECT privilege, if they only hold it
+// on some columns of the table. Referencing these columns raises an error.
+func (b *Builder) addDeniedColumns(tab cat.Table, s *scope) {
+	err, ok := b.columnPrivilegeErrs[columnPrivilegeKey{id: tab.ID(), priv: privilege.SELECT}]
+	if !ok {
+		return
+	}
+	md := b.factory.Metadata()
+	for i := range s.cols {
+		col := &s.cols[i]
+		tabID := md.ColumnMeta(col.id).Table
+		if tabID == 0 || md.Table(tabID).ID() != tab.ID() {
+			continue
+		}
+		has, hasErr := b.catalog.HasColumnPrivilege(b.ctx, tab, tabID.ColumnOrdinal(col.id), privilege.SELECT)
+		if hasErr != nil {
+			panic(hasErr)
+		}
+		if !has {
+			if b.deniedColumns == nil {
+				b.deniedColumns = make(map[opt.ColumnID]error)
+			}
+			b.deniedColumns[col.id] = err
+		}
+	}
+}
+
+// removeDeniedColumns allows the columns in the given scope to be referenced
+// again. It is used once the user-provided expressions of a mutation have been
+// built, so that the check constraints, computed columns and other expressions
+// derived from the schema can reference any column.
+func (b *Builder) removeDeniedColumns(s *scope) {
+	for i := range s.cols {
+		delete(b.deniedColumns, s.cols[i].id)
+	}
+}
+
+// checkColumnAccess raises an error if the current user is not allowed to
+// reference the given column. See addDeniedColumns.
+func (b *Builder) checkColumnAccess(col *scopeColumn) {
+	if err, ok := b.deniedColumns[col.id]; ok {
+		panic(err)
+	}
+}
+
 // resolveNumericColumnRefs converts a list of tree.ColumnIDs from a
 // tree.TableRef to a list of ordinal positions within the given table. Mutation
 // columns are not visible. See tree.Table for more information on column
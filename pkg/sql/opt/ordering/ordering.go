@@ -311,6 +311,11 @@ func init() {
 		buildChildReqOrdering: exportBuildChildReqOrdering,
 		buildProvidedOrdering: noProvidedOrdering,
 	}
+	funcMap[opt.BarrierOp] = funcs{
+		canProvideOrdering:    barrierCanProvideOrdering,
+		buildChildReqOrdering: barrierBuildChildReqOrdering,
+		buildProvidedOrdering: barrierBuildProvided,
+	}
 	funcMap[opt.WithOp] = funcs{
 		canProvideOrdering:    withCanProvideOrdering,
 		buildChildReqOrdering: withBuildChildReqOrdering,
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/olekukonko/tablewriter"
 )
@@ -71,6 +72,29 @@ type Statistics struct {
 	// size of the column with ordinal i in its table. AvgSize is only non-nil
 	// when the statistics are built from a table.
 	AvgColSizes []uint64
+
+	// ExtendedStats contains the extended statistics of the table, with the
+	// statistics on the most columns first. ExtendedStats is only non-nil when
+	// the statistics are built from a table.
+	ExtendedStats []ExtendedStatistic
+}
+
+// ExtendedStatistic contains the extended statistics collected on a set of
+// columns of a table. See cat.ExtendedStatistics for more details.
+type ExtendedStatistic struct {
+	// Cols contains the columns of the statistic. The column ordinals used by
+	// the most common values and dependencies of Stats refer to this slice.
+	Cols []opt.ColumnID
+
+	// ColSet contains the same columns as Cols.
+	ColSet opt.ColSet
+
+	// DistinctCount is the number of distinct combinations of values of the
+	// columns when the statistic was collected.
+	DistinctCount float64
+
+	// Stats contains the most common values and dependencies of the columns.
+	Stats *cat.ExtendedStatistics
 }
 
 // Init initializes the data members of Statistics.
@@ -264,6 +264,13 @@ func (tc *Catalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privil
 	return tc.CheckAnyPrivilege(ctx, o)
 }
 
+// HasColumnPrivilege is part of the cat.Catalog interface.
+func (tc *Catalog) HasColumnPrivilege(
+	ctx context.Context, tab cat.Table, colOrd int, priv privilege.Kind,
+) (bool, error) {
+	return false, nil
+}
+
 // CheckAnyPrivilege is part of the cat.Catalog interface.
 func (tc *Catalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error {
 	switch t := o.(type) {
@@ -316,6 +323,16 @@ func (tc *Catalog) RoleExists(ctx context.Context, role username.SQLUsername) (b
 	return true, nil
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (tc *Catalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	return true, nil
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (tc *Catalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	return true, nil
+}
+
 func (tc *Catalog) resolveSchema(toResolve *cat.SchemaName) (cat.Schema, cat.SchemaName, error) {
 	if string(toResolve.CatalogName) != testDB {
 		return nil, cat.SchemaName{}, pgerror.Newf(pgcode.InvalidSchemaName,
@@ -926,6 +943,31 @@ func (tt *Table) GetDatabaseID() descpb.ID {
 	return tt.DatabaseID
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (tt *Table) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (tt *Table) Policy(i int) *descpb.PolicyDescriptor {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// IsColumnEncrypted is part of the cat.Table interface.
+func (tt *Table) IsColumnEncrypted(i int) bool {
+	return false
+}
+
 // FindOrdinal returns the ordinal of the column with the given name.
 func (tt *Table) FindOrdinal(name string) int {
 	for i, col := range tt.Columns {
@@ -1216,6 +1258,7 @@ type TableStat struct {
 	evalCtx       *eval.Context
 	histogram     []cat.HistogramBucket
 	histogramType *types.T
+	extended      *cat.ExtendedStatistics
 	tc            *Catalog
 }
 
@@ -1350,6 +1393,58 @@ func (ts *TableStat) IsAuto() bool {
 	return ts.js.IsAuto()
 }
 
+// ExtendedStatistics is part of the cat.TableStatistic interface.
+func (ts *TableStat) ExtendedStatistics() *cat.ExtendedStatistics {
+	if ts.extended != nil || ts.js.Extended == nil {
+		return ts.extended
+	}
+	evalCtx := ts.evalCtx
+	if evalCtx == nil {
+		evalCtxVal := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		evalCtx = &evalCtxVal
+	}
+	colTypes := make([]*types.T, len(ts.js.Extended.ColumnTypes))
+	for i, typStr := range ts.js.Extended.ColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			panic(err)
+		}
+		colTypes[i], err = tree.ResolveType(context.Background(), colTypeRef, ts.tc)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	ext := &cat.ExtendedStatistics{HasMostCommonValues: ts.js.Extended.HasMCV}
+	for _, item := range ts.js.Extended.MCV {
+		values := make(tree.Datums, len(item.Values))
+		for i, s := range item.Values {
+			var err error
+			values[i], err = rowenc.ParseDatumStringAs(context.Background(), colTypes[i], s, evalCtx)
+			if err != nil {
+				panic(err)
+			}
+		}
+		ext.MostCommonValues = append(ext.MostCommonValues, cat.MostCommonValue{
+			Values:    values,
+			Frequency: item.Frequency,
+		})
+	}
+	for _, dep := range ts.js.Extended.Dependencies {
+		determinant := make([]int, len(dep.Determinant))
+		for i, c := range dep.Determinant {
+			determinant[i] = int(c)
+		}
+		ext.Dependencies = append(ext.Dependencies, cat.ColumnDependency{
+			Determinant: determinant,
+			Dependent:   int(dep.Dependent),
+			Degree:      dep.Degree,
+		})
+	}
+	ts.extended = ext
+	return ts.extended
+}
+
 // TableStats is a slice of TableStat pointers.
 type TableStats []*TableStat
 
@@ -0,0 +1,423 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package workloadindexrec combines the index recommendations of the
+// statements of a workload into a single set of recommendations, which
+// accounts for the overlap between the recommendations of different
+// statements, the cost of maintaining the recommended indexes when the
+// workload writes to their tables, and the existing indexes which are not
+// used by the workload.
+package workloadindexrec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// indexEntryWriteCost is the estimated cost of writing one entry of an index,
+// in the units of the optimizer's cost model. It is the cost of a random I/O,
+// since the entries of a secondary index are usually not written close to
+// each other.
+const indexEntryWriteCost = 4
+
+// Type is the type of a workload index recommendation.
+type Type uint8
+
+const (
+	// TypeCreateIndex recommends creating a new index.
+	TypeCreateIndex Type = iota
+	// TypeReplaceIndex recommends creating a new index and dropping an
+	// existing index with the same explicit columns.
+	TypeReplaceIndex
+	// TypeAlterIndex recommends making an invisible index visible.
+	TypeAlterIndex
+	// TypeDropIndex recommends dropping an index which is not used.
+	TypeDropIndex
+)
+
+// String implements the fmt.Stringer interface.
+func (t Type) String() string {
+	switch t {
+	case TypeCreateIndex:
+		return "creation"
+	case TypeReplaceIndex:
+		return "replacement"
+	case TypeAlterIndex:
+		return "alteration"
+	case TypeDropIndex:
+		return "drop"
+	}
+	return fmt.Sprintf("Type(%d)", t)
+}
+
+// Statement is a statement fingerprint of the workload, which was optimized
+// with the existing indexes and with hypothetical indexes.
+type Statement struct {
+	// Fingerprint is the fingerprint of the statement.
+	Fingerprint string
+	// Count is the number of executions of the statement.
+	Count int64
+	// Cost is the estimated cost of the statement with the existing indexes.
+	Cost memo.Cost
+	// HypotheticalCost is the estimated cost of the statement with the
+	// hypothetical indexes.
+	HypotheticalCost memo.Cost
+	// Recs are the index recommendations of the statement.
+	Recs []indexrec.Rec
+	// Writes are the rows written by one execution of the statement.
+	Writes []Write
+}
+
+// UnusedIndex is an existing index which has not been read for a while.
+type UnusedIndex struct {
+	Table     cat.Table
+	TableName tree.TableName
+	Index     cat.Index
+	// Reason describes why the index is considered unused.
+	Reason string
+}
+
+// Recommendation is an index recommendation for the workload.
+type Recommendation struct {
+	Type Type
+	// SQL is the statement(s) to run to apply the recommendation.
+	SQL string
+	// CostSavings is the estimated decrease of the total cost of the reads of
+	// the workload once the recommendation is applied.
+	CostSavings float64
+	// WriteOverhead is the estimated increase of the total cost of the writes
+	// of the workload once the recommendation is applied. It is negative if
+	// the recommendation makes the writes cheaper.
+	WriteOverhead float64
+	// Statements is the number of statement fingerprints which benefit from
+	// the recommendation.
+	Statements int
+	// Reason explains the recommendation.
+	Reason string
+}
+
+// NetSavings returns the estimated decrease of the total cost of the workload
+// once the recommendation is applied.
+func (r *Recommendation) NetSavings() float64 {
+	return r.CostSavings - r.WriteOverhead
+}
+
+// Report is the set of index recommendations for a workload, ranked by
+// decreasing net savings.
+type Report struct {
+	Recommendations []Recommendation
+	// TotalCostSavings is the sum of the cost savings of the recommendations.
+	TotalCostSavings float64
+	// TotalWriteOverhead is the sum of the write overheads of the
+	// recommendations.
+	TotalWriteOverhead float64
+}
+
+// FindWorkloadRecs combines the index recommendations of the given statements
+// and the unused indexes into a ranked and deduplicated report:
+//
+//   - The cost savings of a statement, which is the difference between its
+//     costs with and without the hypothetical indexes times its number of
+//     executions, are split between its recommendations.
+//   - Recommendations for the same index are merged, and their stored columns
+//     are combined.
+//   - An index whose explicit columns are a prefix of the explicit columns of
+//     another recommended index on the same table is merged into the latter.
+//   - The write overhead of a recommendation is the cost of maintaining the
+//     index for the rows written by the workload. Indexes whose write overhead
+//     exceeds their savings are not recommended.
+//   - Unused indexes are recommended to be dropped, unless they are replaced or
+//     made visible by another recommendation. Dropping them saves their write
+//     overhead.
+func FindWorkloadRecs(stmts []Statement, unused []UnusedIndex) Report {
+	w := makeWorkloadWrites(stmts)
+	candidates := make(map[string]*candidate)
+	var order []string
+	for i := range stmts {
+		stmt := &stmts[i]
+		if len(stmt.Recs) == 0 {
+			continue
+		}
+		savings := float64(stmt.Cost-stmt.HypotheticalCost) * float64(stmt.Count)
+		if savings < 0 {
+			savings = 0
+		}
+		savings /= float64(len(stmt.Recs))
+		for j := range stmt.Recs {
+			rec := &stmt.Recs[j]
+			key := candidateKey(rec)
+			c, ok := candidates[key]
+			if !ok {
+				c = newCandidate(rec)
+				candidates[key] = c
+				order = append(order, key)
+			}
+			c.storedColOrds.UnionWith(rec.StoredColOrds)
+			c.savings += savings
+			c.fingerprints[stmt.Fingerprint] = struct{}{}
+		}
+	}
+	list := make([]*candidate, len(order))
+	for i, key := range order {
+		list[i] = candidates[key]
+	}
+	list = mergeCoveredCandidates(list)
+
+	var report Report
+	replaced := make(map[indexKey]struct{})
+	for _, c := range list {
+		if c.existingIndex != nil {
+			replaced[makeIndexKey(c.table, c.existingIndex)] = struct{}{}
+		}
+		rec := c.recommendation(&w)
+		if rec.Type != TypeAlterIndex && rec.NetSavings() <= 0 {
+			continue
+		}
+		report.Recommendations = append(report.Recommendations, rec)
+	}
+	for i := range unused {
+		u := &unused[i]
+		if _, ok := replaced[makeIndexKey(u.Table, u.Index)]; ok {
+			continue
+		}
+		report.Recommendations = append(report.Recommendations, Recommendation{
+			Type:          TypeDropIndex,
+			SQL:           dropIndexSQL(&u.TableName, u.Index),
+			WriteOverhead: -w.overhead(u.Table, indexColOrds(u.Table, u.Index)),
+			Reason:        u.Reason,
+		})
+	}
+
+	sort.SliceStable(report.Recommendations, func(i, j int) bool {
+		ri, rj := &report.Recommendations[i], &report.Recommendations[j]
+		if ri.NetSavings() != rj.NetSavings() {
+			return ri.NetSavings() > rj.NetSavings()
+		}
+		return ri.SQL < rj.SQL
+	})
+	for i := range report.Recommendations {
+		report.TotalCostSavings += report.Recommendations[i].CostSavings
+		report.TotalWriteOverhead += report.Recommendations[i].WriteOverhead
+	}
+	return report
+}
+
+// indexKey identifies an existing index.
+type indexKey struct {
+	table cat.StableID
+	index cat.StableID
+}
+
+func makeIndexKey(table cat.Table, index cat.Index) indexKey {
+	return indexKey{table: table.ID(), index: index.ID()}
+}
+
+// candidate is a recommended index, combining the recommendations of one or
+// more statements.
+type candidate struct {
+	typ           indexrec.Type
+	table         cat.Table
+	tableName     tree.TableName
+	columns       []tree.IndexElem
+	keyColOrds    []int
+	storedColOrds intsets.Fast
+	inverted      bool
+	unique        bool
+	existingIndex cat.Index
+
+	savings      float64
+	fingerprints map[string]struct{}
+}
+
+func newCandidate(rec *indexrec.Rec) *candidate {
+	return &candidate{
+		typ:           rec.RecType,
+		table:         rec.Table,
+		tableName:     rec.TableName,
+		columns:       rec.Columns,
+		keyColOrds:    rec.KeyColOrds,
+		inverted:      rec.Inverted,
+		unique:        rec.Unique,
+		existingIndex: rec.ExistingIndex,
+		fingerprints:  make(map[string]struct{}),
+	}
+}
+
+// candidateKey returns a key which is the same for the recommendations of the
+// same index.
+func candidateKey(rec *indexrec.Rec) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d/%d", rec.Table.ID(), rec.RecType)
+	if rec.ExistingIndex != nil {
+		fmt.Fprintf(&sb, "@%d", rec.ExistingIndex.ID())
+	}
+	if rec.Inverted {
+		sb.WriteString("/inverted")
+	}
+	for i := range rec.Columns {
+		fmt.Fprintf(&sb, "/%d", rec.KeyColOrds[i])
+		if rec.Columns[i].Direction == tree.Descending {
+			sb.WriteString("-")
+		}
+	}
+	return sb.String()
+}
+
+// isPrefixOf returns true if the explicit columns of c are a prefix of the
+// explicit columns of other, which can then be used instead of c.
+func (c *candidate) isPrefixOf(other *candidate) bool {
+	if c.typ == indexrec.TypeAlterIndex || other.typ == indexrec.TypeAlterIndex ||
+		c.inverted || other.inverted || c.table.ID() != other.table.ID() ||
+		len(c.columns) > len(other.columns) {
+		return false
+	}
+	for i := range c.columns {
+		if c.keyColOrds[i] != other.keyColOrds[i] ||
+			c.columns[i].Direction != other.columns[i].Direction {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeCoveredCandidates merges each candidate whose explicit columns are a
+// prefix of the explicit columns of another candidate into the candidate with
+// the most explicit columns among those. A replacement is never merged into
+// another candidate, since it is needed to drop the existing index.
+func mergeCoveredCandidates(list []*candidate) []*candidate {
+	sort.SliceStable(list, func(i, j int) bool {
+		return len(list[i].columns) > len(list[j].columns)
+	})
+	merged := list[:0]
+	for _, c := range list {
+		var into *candidate
+		if c.typ == indexrec.TypeCreateIndex {
+			for _, other := range merged {
+				if c.isPrefixOf(other) {
+					into = other
+					break
+				}
+			}
+		}
+		if into == nil {
+			merged = append(merged, c)
+			continue
+		}
+		for _, ord := range into.keyColOrds {
+			c.storedColOrds.Remove(ord)
+		}
+		into.storedColOrds.UnionWith(c.storedColOrds)
+		into.savings += c.savings
+		for fingerprint := range c.fingerprints {
+			into.fingerprints[fingerprint] = struct{}{}
+		}
+	}
+	return merged
+}
+
+// colOrds returns the ordinals of the table columns written when an entry of
+// the recommended index is written, including the primary key columns.
+func (c *candidate) colOrds() intsets.Fast {
+	cols := primaryKeyColOrds(c.table)
+	for _, ord := range c.keyColOrds {
+		cols.Add(ord)
+	}
+	return cols.Union(c.storedColOrds)
+}
+
+// recommendation returns the Recommendation for the candidate.
+func (c *candidate) recommendation(w *workloadWrites) Recommendation {
+	rec := Recommendation{
+		CostSavings: c.savings,
+		Statements:  len(c.fingerprints),
+	}
+	switch c.typ {
+	case indexrec.TypeCreateIndex:
+		rec.Type = TypeCreateIndex
+		rec.SQL = c.createIndexSQL()
+		rec.WriteOverhead = w.overhead(c.table, c.colOrds())
+	case indexrec.TypeReplaceIndex:
+		rec.Type = TypeReplaceIndex
+		rec.SQL = c.createIndexSQL() + " " + dropIndexSQL(&c.tableName, c.existingIndex)
+		rec.WriteOverhead = w.overhead(c.table, c.colOrds()) -
+			w.overhead(c.table, indexColOrds(c.table, c.existingIndex))
+	case indexrec.TypeAlterIndex:
+		// The invisible index is already maintained by the writes.
+		rec.Type = TypeAlterIndex
+		alterCmd := tree.AlterIndexVisible{
+			Index: tree.TableIndexName{
+				Table: c.tableName,
+				Index: tree.UnrestrictedName(c.existingIndex.Name()),
+			},
+		}
+		rec.SQL = alterCmd.String() + ";"
+	}
+	return rec
+}
+
+// createIndexSQL returns the CREATE INDEX statement of the candidate.
+func (c *candidate) createIndexSQL() string {
+	var storing tree.NameList
+	c.storedColOrds.ForEach(func(ord int) {
+		storing = append(storing, c.table.Column(ord).ColName())
+	})
+	createCmd := tree.CreateIndex{
+		Table:    c.tableName,
+		Columns:  c.columns,
+		Storing:  storing,
+		Unique:   c.unique,
+		Inverted: c.inverted,
+	}
+	return createCmd.String() + ";"
+}
+
+// dropIndexSQL returns the DROP INDEX statement of an existing index.
+func dropIndexSQL(tableName *tree.TableName, index cat.Index) string {
+	dropCmd := tree.DropIndex{
+		IndexList: []*tree.TableIndexName{{
+			Table: *tableName,
+			Index: tree.UnrestrictedName(index.Name()),
+		}},
+	}
+	return dropCmd.String() + ";"
+}
+
+// primaryKeyColOrds returns the ordinals of the primary key columns of the
+// table, which are part of every index.
+func primaryKeyColOrds(table cat.Table) intsets.Fast {
+	var cols intsets.Fast
+	primaryIndex := table.Index(cat.PrimaryIndex)
+	for i, n := 0, primaryIndex.KeyColumnCount(); i < n; i++ {
+		cols.Add(primaryIndex.Column(i).Ordinal())
+	}
+	return cols
+}
+
+// indexColOrds returns the ordinals of the table columns written when an
+// entry of the existing index is written.
+func indexColOrds(table cat.Table, index cat.Index) intsets.Fast {
+	cols := primaryKeyColOrds(table)
+	for i, n := 0, index.ColumnCount(); i < n; i++ {
+		col := index.Column(i)
+		if col.Kind() == cat.Inverted {
+			cols.Add(col.InvertedSourceColumnOrdinal())
+		} else {
+			cols.Add(col.Ordinal())
+		}
+	}
+	return cols
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workloadindexrec
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWorkloadRecs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	catalog := testcat.New()
+	for _, ddl := range []string{
+		"CREATE TABLE t (k INT PRIMARY KEY, a INT, b INT, c INT, d INT)",
+		"CREATE INDEX t_b ON t (b)",
+		"CREATE INDEX t_c ON t (c) NOT VISIBLE",
+		"CREATE INDEX t_d ON t (d)",
+	} {
+		if _, err := catalog.ExecuteDDL(ddl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tn := tree.MakeUnqualifiedTableName("t")
+	tab := catalog.Table(&tn)
+	index := func(name tree.Name) cat.Index {
+		for i := 0; i < tab.IndexCount(); i++ {
+			if tab.Index(i).Name() == name {
+				return tab.Index(i)
+			}
+		}
+		t.Fatalf("index %s not found", name)
+		return nil
+	}
+	// The ordinals of the columns of t.
+	const a, b, c, d = 1, 2, 3, 4
+	create := func(keyCols []int, storedCols ...int) indexrec.Rec {
+		rec := indexrec.Rec{
+			RecType:       indexrec.TypeCreateIndex,
+			Table:         tab,
+			TableName:     tn,
+			KeyColOrds:    keyCols,
+			StoredColOrds: intsets.MakeFast(storedCols...),
+		}
+		for _, ord := range keyCols {
+			rec.Columns = append(rec.Columns, tree.IndexElem{Column: tab.Column(ord).ColName()})
+		}
+		return rec
+	}
+
+	replace := create([]int{b}, d)
+	replace.RecType = indexrec.TypeReplaceIndex
+	replace.ExistingIndex = index("t_b")
+	alter := indexrec.Rec{
+		RecType:       indexrec.TypeAlterIndex,
+		Table:         tab,
+		TableName:     tn,
+		ExistingIndex: index("t_c"),
+	}
+
+	stmts := []Statement{
+		{
+			// The recommended index is covered by the index recommended for the
+			// next statement.
+			Fingerprint:      "SELECT k, b FROM t WHERE a = _",
+			Count:            100,
+			Cost:             1000,
+			HypotheticalCost: 100,
+			Recs:             []indexrec.Rec{create([]int{a}, b)},
+		},
+		{
+			Fingerprint:      "SELECT k, c FROM t WHERE (a = _) AND (b = _) AND (c > _)",
+			Count:            10,
+			Cost:             500,
+			HypotheticalCost: 50,
+			Recs:             []indexrec.Rec{create([]int{a, b}, c), alter},
+		},
+		{
+			Fingerprint:      "SELECT d FROM t WHERE b = _",
+			Count:            300,
+			Cost:             200,
+			HypotheticalCost: 100,
+			Recs:             []indexrec.Rec{replace},
+		},
+		{
+			// The savings of the recommended index do not make up for its write
+			// overhead.
+			Fingerprint:      "SELECT k FROM t WHERE d > _",
+			Count:            1,
+			Cost:             10,
+			HypotheticalCost: 9,
+			Recs:             []indexrec.Rec{create([]int{d})},
+		},
+		{
+			Fingerprint: "INSERT INTO t VALUES (_, _, __more1_10__)",
+			Count:       1000,
+			Writes:      []Write{{Table: tab, Rows: 1}},
+		},
+		{
+			Fingerprint: "UPDATE t SET d = _ WHERE a > _",
+			Count:       100,
+			Writes:      []Write{{Table: tab, Rows: 10, UpdateColOrds: intsets.MakeFast(d)}},
+		},
+	}
+	unused := []UnusedIndex{
+		{Table: tab, TableName: tn, Index: index("t_b"), Reason: "never used"},
+		{Table: tab, TableName: tn, Index: index("t_d"), Reason: "never used"},
+	}
+
+	report := FindWorkloadRecs(stmts, unused)
+	// Every index is written by the 1000 inserted rows, and the indexes
+	// containing d are also written twice by the 1000 updated rows.
+	expected := []Recommendation{
+		{
+			Type:          TypeCreateIndex,
+			SQL:           "CREATE INDEX ON t (a, b) STORING (c);",
+			CostSavings:   90000 + 4500/2,
+			WriteOverhead: 1000 * indexEntryWriteCost,
+			Statements:    2,
+		},
+		{
+			Type:          TypeReplaceIndex,
+			SQL:           "CREATE INDEX ON t (b) STORING (d); DROP INDEX t@t_b;",
+			CostSavings:   30000,
+			WriteOverhead: 2000 * indexEntryWriteCost,
+			Statements:    1,
+		},
+		{
+			Type:          TypeDropIndex,
+			SQL:           "DROP INDEX t@t_d;",
+			WriteOverhead: -3000 * indexEntryWriteCost,
+			Reason:        "never used",
+		},
+		{
+			Type:        TypeAlterIndex,
+			SQL:         "ALTER INDEX t@t_c VISIBLE;",
+			CostSavings: 4500 / 2,
+			Statements:  1,
+		},
+	}
+	require.Equal(t, expected, report.Recommendations)
+	require.Equal(t, float64(90000+4500+30000), report.TotalCostSavings)
+	require.Equal(t, float64(0), report.TotalWriteOverhead)
+}
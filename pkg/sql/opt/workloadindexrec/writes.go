@@ -0,0 +1,119 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workloadindexrec
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// Write describes the rows written to a table by a mutation.
+type Write struct {
+	Table cat.Table
+	// Rows is the estimated number of rows written.
+	Rows float64
+	// UpdateColOrds are the ordinals of the columns written by an UPDATE. It
+	// is empty for the other mutations, which write every index of the table.
+	UpdateColOrds intsets.Fast
+}
+
+// FindWrites walks an optimized expression tree to find the rows written to
+// tables by its mutations.
+func FindWrites(expr opt.Expr, md *opt.Metadata) []Write {
+	var writes []Write
+	var walk func(e opt.Expr)
+	walk = func(e opt.Expr) {
+		switch t := e.(type) {
+		case *memo.InsertExpr:
+			writes = append(writes, makeWrite(md, t.Input, &t.MutationPrivate, false /* update */))
+		case *memo.UpsertExpr:
+			writes = append(writes, makeWrite(md, t.Input, &t.MutationPrivate, false /* update */))
+		case *memo.UpdateExpr:
+			writes = append(writes, makeWrite(md, t.Input, &t.MutationPrivate, true /* update */))
+		case *memo.DeleteExpr:
+			writes = append(writes, makeWrite(md, t.Input, &t.MutationPrivate, false /* update */))
+		}
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			walk(e.Child(i))
+		}
+	}
+	walk(expr)
+	return writes
+}
+
+func makeWrite(
+	md *opt.Metadata, input memo.RelExpr, private *memo.MutationPrivate, update bool,
+) Write {
+	w := Write{
+		Table: md.Table(private.Table),
+		Rows:  input.Relational().Statistics().RowCount,
+	}
+	if update {
+		for ord, col := range private.UpdateCols {
+			if col != 0 {
+				w.UpdateColOrds.Add(ord)
+			}
+		}
+	}
+	return w
+}
+
+// tableWrites accumulates the rows written to a table by the workload.
+type tableWrites struct {
+	// rows is the number of rows written by mutations which write every index
+	// of the table.
+	rows float64
+	// updates are the rows written by updates, which only write the indexes
+	// containing the updated columns.
+	updates []Write
+}
+
+// workloadWrites accumulates the rows written to each table by the workload.
+type workloadWrites map[cat.StableID]*tableWrites
+
+func makeWorkloadWrites(stmts []Statement) workloadWrites {
+	w := make(workloadWrites)
+	for i := range stmts {
+		for _, write := range stmts[i].Writes {
+			tw, ok := w[write.Table.ID()]
+			if !ok {
+				tw = &tableWrites{}
+				w[write.Table.ID()] = tw
+			}
+			write.Rows *= float64(stmts[i].Count)
+			if write.UpdateColOrds.Empty() {
+				tw.rows += write.Rows
+			} else {
+				tw.updates = append(tw.updates, write)
+			}
+		}
+	}
+	return w
+}
+
+// overhead returns the estimated cost of maintaining an index of the table
+// containing the given columns for the rows written by the workload.
+func (w workloadWrites) overhead(table cat.Table, colOrds intsets.Fast) float64 {
+	tw, ok := w[table.ID()]
+	if !ok {
+		return 0
+	}
+	rows := tw.rows
+	for i := range tw.updates {
+		if tw.updates[i].UpdateColOrds.Intersects(colOrds) {
+			// The old entry of the index is deleted and the new one is written.
+			rows += 2 * tw.updates[i].Rows
+		}
+	}
+	return rows * indexEntryWriteCost
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+)
+
+// setAdaptiveJoinThresholds walks the given lowest cost tree and records the
+// threshold of every lookup and hash join which can be executed as an adaptive
+// join. An adaptive join executes as a lookup join if its input produces at
+// most threshold rows, and as a hash join against a full scan of the lookup
+// index otherwise. This protects against plans chosen based on cardinality
+// estimates which are off by orders of magnitude, for example because the
+// statistics are stale.
+//
+// The thresholds must be computed before SetRoot, which releases the
+// statistics needed to compute them.
+func (o *Optimizer) setAdaptiveJoinThresholds(e opt.Expr) {
+	if rel, ok := e.(memo.RelExpr); ok {
+		if threshold, ok := o.adaptiveJoinThreshold(rel); ok {
+			o.mem.SetAdaptiveJoinThreshold(rel, threshold)
+		}
+	}
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		o.setAdaptiveJoinThresholds(e.Child(i))
+	}
+}
+
+// adaptiveJoinThreshold returns the threshold of the given expression if it is
+// a lookup or hash join which can be executed as an adaptive join.
+func (o *Optimizer) adaptiveJoinThreshold(e memo.RelExpr) (threshold int64, ok bool) {
+	if o.evalCtx.SessionData().DisallowFullTableScans {
+		// The hash join algorithm of an adaptive join scans the entire index.
+		return 0, false
+	}
+	md := o.mem.Metadata()
+	var input memo.RelExpr
+	var table opt.TableID
+	var index cat.IndexOrdinal
+	var cols opt.ColSet
+	var tableRows float64
+	var eqColsAreKey bool
+	switch t := e.(type) {
+	case *memo.LookupJoinExpr:
+		if !o.isAdaptiveLookupJoinCandidate(t) {
+			return 0, false
+		}
+		input, table, index = t.Input, t.Table, t.Index
+		cols = t.Cols.Difference(input.Relational().OutputCols)
+		eqColsAreKey = t.LookupColsAreTableKey
+		if tableRows, ok = o.mem.RequestTableRowCount(t.Table); !ok {
+			return 0, false
+		}
+
+	case *memo.InnerJoinExpr, *memo.LeftJoinExpr, *memo.SemiJoinExpr, *memo.AntiJoinExpr:
+		scan, ok := o.adaptiveHashJoinScan(e)
+		if !ok {
+			return 0, false
+		}
+		input, table, index, cols = e.Child(0).(memo.RelExpr), scan.Table, scan.Index, scan.Cols
+		leftEq, rightEq := memo.ExtractJoinEqualityColumns(
+			input.Relational().OutputCols, cols, *e.Child(2).(*memo.FiltersExpr),
+		)
+		if !adaptiveJoinEqColsMatch(md, leftEq, rightEq, scan.Table, scan.Index) {
+			return 0, false
+		}
+		eqColsAreKey = scan.Relational().FuncDeps.ColsAreStrictKey(rightEq.ToSet())
+		tableRows = scan.Relational().Statistics().RowCount
+
+	default:
+		return 0, false
+	}
+	if md.Table(table).IsVirtualTable() {
+		return 0, false
+	}
+
+	inputRows := input.Relational().Statistics().RowCount
+	rowsProcessed, ok := o.mem.RowsProcessed(e)
+	if !ok {
+		return 0, false
+	}
+	rowsPerLookup := rowsProcessed / math.Max(inputRows, 1)
+
+	// The cost of each input row is compared to the cost of hash joining it,
+	// as in computeIndexLookupJoinCost and computeHashJoinCost.
+	c := &o.defaultCoster
+	rowCost := c.rowScanCost(table, index, cols)
+	perLookupCost := c.randIOCostFactor
+	if !eqColsAreKey {
+		perLookupCost += 4 * c.randIOCostFactor
+	}
+	lookupCost := perLookupCost + memo.Cost(rowsPerLookup)*(c.lookupJoinRetrieveRowCost()+rowCost)
+	probeCost := 1.25 * c.cpuCostFactor
+	if lookupCost <= probeCost {
+		// Lookups are always cheaper.
+		return 0, false
+	}
+	buildCost := c.randIOCostFactor +
+		memo.Cost(tableRows)*(c.seqIOCostFactor+rowCost+1.75*c.cpuCostFactor) +
+		c.rowBufferCost(tableRows)
+	t := math.Ceil(float64(buildCost / (lookupCost - probeCost)))
+	if t < 1 {
+		t = 1
+	} else if t > math.MaxInt32 {
+		return 0, false
+	}
+	threshold = int64(t)
+
+	// There is no point in executing an adaptive join if its input cannot
+	// cross the threshold.
+	card := input.Relational().Cardinality
+	if !card.IsUnbounded() && int64(card.Max) <= threshold {
+		return 0, false
+	}
+	if int64(card.Min) > threshold {
+		return 0, false
+	}
+	return threshold, true
+}
+
+// isAdaptiveLookupJoinCandidate returns true if the given lookup join can be
+// replaced by a hash join against a full scan of its lookup index.
+func (o *Optimizer) isAdaptiveLookupJoinCandidate(join *memo.LookupJoinExpr) bool {
+	switch join.JoinType {
+	case opt.InnerJoinOp, opt.LeftJoinOp, opt.SemiJoinOp, opt.AntiJoinOp:
+	default:
+		return false
+	}
+	if len(join.KeyCols) == 0 || len(join.LookupExpr) > 0 || len(join.RemoteLookupExpr) > 0 ||
+		join.IsFirstJoinInPairedJoiner || join.IsSecondJoinInPairedJoiner ||
+		join.LocalityOptimized || join.ChildOfLocalityOptimizedSearch ||
+		join.RemoteOnlyLookups || join.Locking.IsLocking() || !join.Flags.Empty() {
+		return false
+	}
+	required := join.RequiredPhysical()
+	if !required.Ordering.Any() || required.LimitHint != 0 {
+		return false
+	}
+	md := o.mem.Metadata()
+	tab := md.Table(join.Table)
+	idx := tab.Index(join.Index)
+	for i, col := range join.KeyCols {
+		idxCol := join.Table.ColumnID(idx.Column(i).Ordinal())
+		if !md.ColumnMeta(col).Type.Identical(md.ColumnMeta(idxCol).Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// adaptiveHashJoinScan returns the right input of the given hash join if it is
+// a full scan of an index which can be replaced by lookups into the index.
+func (o *Optimizer) adaptiveHashJoinScan(join memo.RelExpr) (*memo.ScanExpr, bool) {
+	if !join.Private().(*memo.JoinPrivate).Flags.Empty() {
+		return nil, false
+	}
+	scan, ok := join.Child(1).(*memo.ScanExpr)
+	if !ok {
+		return nil, false
+	}
+	md := o.mem.Metadata()
+	if !scan.IsFullIndexScan(md) || scan.IsLocking() || !scan.Flags.Empty() {
+		return nil, false
+	}
+	if join.Op() == opt.SemiJoinOp || join.Op() == opt.AntiJoinOp {
+		// The execbuilder swaps the inputs of semi and anti joins with a larger
+		// right input, see buildHashJoin.
+		leftRows := join.Child(0).(memo.RelExpr).Relational().Statistics().RowCount
+		if leftRows < scan.Relational().Statistics().RowCount {
+			return nil, false
+		}
+	}
+	return scan, true
+}
+
+// adaptiveJoinEqColsMatch returns true if the right equality columns of a hash
+// join are exactly a prefix of the key columns of the given index, so that
+// the left equality columns can be used to look up into the index.
+func adaptiveJoinEqColsMatch(
+	md *opt.Metadata, leftEq, rightEq opt.ColList, table opt.TableID, index cat.IndexOrdinal,
+) bool {
+	if len(rightEq) == 0 || rightEq.ToSet().Len() != len(rightEq) {
+		return false
+	}
+	idx := md.Table(table).Index(index)
+	if len(rightEq) > idx.KeyColumnCount() {
+		return false
+	}
+	var prefix opt.ColSet
+	for i := range rightEq {
+		prefix.Add(table.ColumnID(idx.Column(i).Ordinal()))
+	}
+	if !rightEq.ToSet().Equals(prefix) {
+		return false
+	}
+	for i := range leftEq {
+		if !md.ColumnMeta(leftEq[i]).Type.Identical(md.ColumnMeta(rightEq[i]).Type) {
+			return false
+		}
+	}
+	return true
+}
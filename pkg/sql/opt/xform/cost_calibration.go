@@ -0,0 +1,234 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/errors"
+)
+
+// CostComponents splits the estimated cost of a plan into the amount of CPU,
+// sequential I/O and random I/O work it performs, each expressed in units of
+// the corresponding cost factor. The cost of the plan is then:
+//
+//	Fixed + CPU*cpuCostFactor + SeqIO*seqIOCostFactor + RandIO*randIOCostFactor
+//
+// where Fixed is the part of the cost which does not depend on any factor,
+// such as the cost of distributing rows across regions.
+type CostComponents struct {
+	Fixed  float64
+	CPU    float64
+	SeqIO  float64
+	RandIO float64
+}
+
+// CollectCostComponents instructs Optimize to compute the cost components of
+// the lowest cost plan, which are then returned by CostComponents. It must be
+// called before Optimize.
+func (o *Optimizer) CollectCostComponents() {
+	o.collectCostComponents = true
+}
+
+// CostComponents returns the cost components of the lowest cost plan found by
+// the optimizer. It must be called after Optimize, and CollectCostComponents
+// must have been called before it.
+func (o *Optimizer) CostComponents() CostComponents {
+	if !o.collectCostComponents || !o.mem.IsOptimized() {
+		panic(errors.AssertionFailedf("cost components were not collected"))
+	}
+	return o.costComponents
+}
+
+// computeCostComponents returns the cost components of the plan rooted at the
+// given expression.
+func (o *Optimizer) computeCostComponents(root memo.RelExpr) CostComponents {
+	planCost := func(cpu, seqIO, randIO memo.Cost) float64 {
+		var c coster
+		c.Init(o.ctx, o.evalCtx, o.mem, 0 /* perturbation */, nil /* rng */, o)
+		c.cpuCostFactor, c.seqIOCostFactor, c.randIOCostFactor = cpu, seqIO, randIO
+		return float64(c.computePlanCost(root))
+	}
+	// The cost of every operator is linear in the cost factors, so each
+	// component is isolated by costing the plan with a single unit factor and
+	// subtracting the fixed cost.
+	fixed := planCost(0, 0, 0)
+	return CostComponents{
+		Fixed:  fixed,
+		CPU:    planCost(1, 0, 0) - fixed,
+		SeqIO:  planCost(0, 1, 0) - fixed,
+		RandIO: planCost(0, 0, 1) - fixed,
+	}
+}
+
+// computePlanCost returns the sum of the costs of the relational operators in
+// the given expression tree, including those of subqueries.
+func (c *coster) computePlanCost(e opt.Expr) memo.Cost {
+	var cost memo.Cost
+	if rel, ok := e.(memo.RelExpr); ok {
+		cost += c.ComputeCost(rel, rel.RequiredPhysical())
+	}
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		cost += c.computePlanCost(e.Child(i))
+	}
+	return cost
+}
+
+// CostSample pairs the cost components of the plan of a statement with the
+// measured latency of its execution.
+type CostSample struct {
+	Components CostComponents
+
+	// Latency is the mean execution latency of the statement, in seconds.
+	Latency float64
+}
+
+// numCostFactors is the number of cost factors fitted by FitCostCalibration:
+// CPU, sequential I/O and random I/O, in that order.
+const numCostFactors = 3
+
+// FitCostCalibration fits the CPU, sequential I/O and random I/O cost factors
+// to the given samples.
+//
+// The latency of each sample is modeled as a linear combination of the cost
+// components of its plan. The coefficients are fitted by least squares on the
+// relative error, so that short statements weigh as much as long ones, and
+// must be positive. A factor which cannot be fitted, for example because no
+// sample performs random I/O, is left at the optimizer's default. The fitted
+// coefficients are then scaled so that the total cost of the samples is the
+// same as with the default factors, which keeps calibrated costs comparable
+// to the fixed costs of the cost model.
+//
+// FitCostCalibration returns false if no factor could be fitted.
+func FitCostCalibration(samples []CostSample) (_ memo.CostCalibration, ok bool) {
+	defaults := [numCostFactors]float64{
+		defaultCPUCostFactor, defaultSeqIOCostFactor, defaultRandIOCostFactor,
+	}
+	var components, rows [][numCostFactors]float64
+	for i := range samples {
+		s := &samples[i]
+		if !(s.Latency > 0) {
+			continue
+		}
+		x := [numCostFactors]float64{s.Components.CPU, s.Components.SeqIO, s.Components.RandIO}
+		components = append(components, x)
+		for j := range x {
+			x[j] /= s.Latency
+		}
+		rows = append(rows, x)
+	}
+
+	// Fit every subset of the factors, and keep the positive fit with the
+	// lowest residual.
+	var best [numCostFactors]float64
+	bestResidual := math.Inf(+1)
+	for subset := 1; subset < 1<<numCostFactors; subset++ {
+		coefs, ok := fitCostFactors(rows, subset)
+		if !ok {
+			continue
+		}
+		var residual float64
+		for _, x := range rows {
+			var predicted float64
+			for j := range x {
+				predicted += x[j] * coefs[j]
+			}
+			residual += (1 - predicted) * (1 - predicted)
+		}
+		if residual < bestResidual {
+			best, bestResidual = coefs, residual
+		}
+	}
+	if math.IsInf(bestResidual, +1) {
+		return memo.CostCalibration{}, false
+	}
+
+	var defaultTotal, fittedTotal float64
+	for _, x := range components {
+		for j := range x {
+			if best[j] > 0 {
+				defaultTotal += x[j] * defaults[j]
+				fittedTotal += x[j] * best[j]
+			}
+		}
+	}
+	if !(defaultTotal > 0 && fittedTotal > 0) {
+		return memo.CostCalibration{}, false
+	}
+	scale := defaultTotal / fittedTotal
+	return memo.CostCalibration{
+		CPUCostFactor:    memo.Cost(best[0] * scale),
+		SeqIOCostFactor:  memo.Cost(best[1] * scale),
+		RandIOCostFactor: memo.Cost(best[2] * scale),
+	}, true
+}
+
+// fitCostFactors solves the least squares problem x·coefs = 1 over the given
+// rows, restricted to the factors in the subset bitmap. It returns false if
+// the problem is singular or if any coefficient is not positive.
+func fitCostFactors(
+	rows [][numCostFactors]float64, subset int,
+) (coefs [numCostFactors]float64, ok bool) {
+	var idx []int
+	for j := 0; j < numCostFactors; j++ {
+		if subset&(1<<j) != 0 {
+			idx = append(idx, j)
+		}
+	}
+	n := len(idx)
+	if len(rows) < n {
+		return coefs, false
+	}
+
+	// Build the augmented matrix of the normal equations.
+	var a [numCostFactors][numCostFactors + 1]float64
+	for _, x := range rows {
+		for j := 0; j < n; j++ {
+			for k := 0; k < n; k++ {
+				a[j][k] += x[idx[j]] * x[idx[k]]
+			}
+			a[j][n] += x[idx[j]]
+		}
+	}
+
+	// Solve them with Gauss-Jordan elimination with partial pivoting.
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if a[pivot][col] == 0 {
+			return coefs, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := 0; row < n; row++ {
+			if row == col {
+				continue
+			}
+			f := a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+	for j := 0; j < n; j++ {
+		coef := a[j][n] / a[j][j]
+		if !(coef > 0) || math.IsInf(coef, +1) {
+			return coefs, false
+		}
+		coefs[idx[j]] = coef
+	}
+	return coefs, true
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestFitCostCalibration(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// makeSamples returns samples whose latency is exactly given by the cost
+	// components and the given seconds per unit of each component.
+	makeSamples := func(cpu, seqIO, randIO float64) []xform.CostSample {
+		var samples []xform.CostSample
+		for i := 1; i <= 10; i++ {
+			c := xform.CostComponents{
+				CPU:    float64(1000 * i * i),
+				SeqIO:  float64(100 * (11 - i)),
+				RandIO: float64(i % 4),
+			}
+			samples = append(samples, xform.CostSample{
+				Components: c,
+				Latency:    c.CPU*cpu + c.SeqIO*seqIO + c.RandIO*randIO,
+			})
+		}
+		return samples
+	}
+	approxEqual := func(a, b float64) bool {
+		return math.Abs(a-b) <= 1e-6*math.Max(math.Abs(a), math.Abs(b))
+	}
+
+	t.Run("all factors", func(t *testing.T) {
+		// CPU work is 5x more expensive and random I/O 2x less expensive
+		// relative to sequential I/O than with the default factors.
+		calibration, ok := xform.FitCostCalibration(makeSamples(5e-8, 1e-6, 2e-6))
+		if !ok {
+			t.Fatal("expected the factors to be fitted")
+		}
+		seqIO := float64(calibration.SeqIOCostFactor)
+		if cpu := float64(calibration.CPUCostFactor) / seqIO; !approxEqual(cpu, 0.05) {
+			t.Errorf("expected a CPU cost factor of 0.05 relative to sequential I/O, got %f", cpu)
+		}
+		if randIO := float64(calibration.RandIOCostFactor) / seqIO; !approxEqual(randIO, 2) {
+			t.Errorf("expected a random I/O cost factor of 2 relative to sequential I/O, got %f", randIO)
+		}
+	})
+
+	t.Run("no random I/O", func(t *testing.T) {
+		samples := makeSamples(5e-8, 1e-6, 0)
+		for i := range samples {
+			samples[i].Components.RandIO = 0
+		}
+		calibration, ok := xform.FitCostCalibration(samples)
+		if !ok {
+			t.Fatal("expected the factors to be fitted")
+		}
+		if calibration.RandIOCostFactor != 0 {
+			t.Errorf("expected the default random I/O cost factor, got %f", calibration.RandIOCostFactor)
+		}
+		if calibration.CPUCostFactor == 0 || calibration.SeqIOCostFactor == 0 {
+			t.Errorf("expected the CPU and sequential I/O cost factors to be fitted, got %s", calibration)
+		}
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		if _, ok := xform.FitCostCalibration(nil); ok {
+			t.Error("expected no factor to be fitted")
+		}
+	})
+}
@@ -94,6 +94,13 @@ type coster struct {
 	// rng is used for deterministic perturbation.
 	rng *rand.Rand
 
+	// cpuCostFactor, seqIOCostFactor and randIOCostFactor are the basic units
+	// of the cost model. They are set to the defaults unless the memo was
+	// created with a cost calibration which overrides them.
+	cpuCostFactor    memo.Cost
+	seqIOCostFactor  memo.Cost
+	randIOCostFactor memo.Cost
+
 	o *Optimizer
 }
 
@@ -103,13 +110,15 @@ var _ Coster = &coster{}
 func MakeDefaultCoster(
 	ctx context.Context, evalCtx *eval.Context, mem *memo.Memo, o *Optimizer,
 ) Coster {
-	return &coster{
+	c := &coster{
 		ctx:      ctx,
 		evalCtx:  evalCtx,
 		mem:      mem,
 		locality: evalCtx.Locality,
 		o:        o,
 	}
+	c.initCostFactors(mem.CostCalibration())
+	return c
 }
 
 const (
@@ -119,37 +128,17 @@ const (
 	// PostgreSQL ratio between CPU and I/O is probably unrealistic in modern
 	// systems since much of the data can be cached in memory. Consider
 	// increasing the cpuCostFactor to account for this.
-	cpuCostFactor    = 0.01
-	seqIOCostFactor  = 1
-	randIOCostFactor = 4
-
-	// TODO(justin): make this more sophisticated.
-	// lookupJoinRetrieveRowCost is the cost to retrieve a single row during a
-	// lookup join.
-	// See https://github.com/cockroachdb/cockroach/pull/35561 for the initial
-	// justification for this constant.
-	lookupJoinRetrieveRowCost = 2 * seqIOCostFactor
-
-	// virtualScanTableDescriptorFetchCost is the cost to retrieve the table
-	// descriptors when performing a virtual table scan.
-	virtualScanTableDescriptorFetchCost = 25 * randIOCostFactor
+	//
+	// These are the defaults; a cost model calibration can override them per
+	// cluster (see memo.CostCalibration).
+	defaultCPUCostFactor    = 0.01
+	defaultSeqIOCostFactor  = 1
+	defaultRandIOCostFactor = 4
 
 	// Input rows to a join are processed in batches of this size.
 	// See joinreader.go.
 	joinReaderBatchSize = 100.0
 
-	// latencyCostFactor represents the throughput impact of doing scans on an
-	// index that may be remotely located in a different locality. If latencies
-	// are higher, then overall cluster throughput will suffer somewhat, as there
-	// will be more queries in memory blocking on I/O. The impact on throughput
-	// is expected to be relatively low, so latencyCostFactor is set to a small
-	// value. However, even a low value will cause the optimizer to prefer
-	// indexes that are likely to be geographically closer, if they are otherwise
-	// the same cost to access.
-	// TODO(andyk): Need to do analysis to figure out right value and/or to come
-	// up with better way to incorporate latency into the coster.
-	latencyCostFactor = cpuCostFactor
-
 	// hugeCost is used with expressions we want to avoid; these are expressions
 	// that "violate" a hint like forcing a specific index or join algorithm.
 	// If the final expression has this cost or larger, it means that there was no
@@ -173,13 +162,6 @@ const (
 	// stale.
 	largeMaxCardinalityScanCostPenalty = unboundedMaxCardinalityScanCostPenalty / 2
 
-	// SmallDistributeCost is the per-operation cost overhead for scans which may
-	// access remote regions, but the scanned table is unpartitioned with no lease
-	// preferences, so locality information is not available. The distribution
-	// cost is unknown, so a small overhead cost is added to give optimizations
-	// like locality-optimized search a chance to get picked.
-	SmallDistributeCost = randIOCostFactor
-
 	// DistributeCost is the per-operation cost overhead for Distribute operations
 	// or scans which access remote regions.
 	// TODO(msirek): Measure actual latencies between regions and produce a table
@@ -214,12 +196,6 @@ const (
 	// limit, 6400000 rows with an average of at least 10 bytes per row will cause
 	// a disk spill.
 	spillRowCount = 6400000
-
-	// spillCostFactor is the cost of spilling to disk. We use seqIOCostFactor to
-	// model the cost of spilling to disk, because although there will be some
-	// random I/O required to insert rows into a sorted structure, the inherent
-	// batching in the LSM tree should amortize the cost.
-	spillCostFactor = seqIOCostFactor
 )
 
 // fnCost maps some functions to an execution cost. Currently this list
@@ -232,259 +208,262 @@ const (
 //
 // TODO(mjibson): Add costs directly to overloads. When that is done, we should
 // also add a test that ensures those costs match postgres.
+//
+// The costs are expressed in terms of defaultCPUCostFactor, and are rescaled
+// by the coster if the CPU cost factor has been calibrated.
 var fnCost = map[string]memo.Cost{
-	"st_3dclosestpoint":           1000 * cpuCostFactor,
-	"st_3ddfullywithin":           10000 * cpuCostFactor,
-	"st_3ddistance":               1000 * cpuCostFactor,
-	"st_3ddwithin":                10000 * cpuCostFactor,
-	"st_3dintersects":             10000 * cpuCostFactor,
-	"st_3dlength":                 100 * cpuCostFactor,
-	"st_3dlongestline":            1000 * cpuCostFactor,
-	"st_3dmakebox":                100 * cpuCostFactor,
-	"st_3dmaxdistance":            1000 * cpuCostFactor,
-	"st_3dperimeter":              100 * cpuCostFactor,
-	"st_3dshortestline":           1000 * cpuCostFactor,
-	"st_addmeasure":               1000 * cpuCostFactor,
-	"st_addpoint":                 100 * cpuCostFactor,
-	"st_affine":                   100 * cpuCostFactor,
-	"st_angle":                    100 * cpuCostFactor,
-	"st_area":                     100 * cpuCostFactor,
-	"st_area2d":                   100 * cpuCostFactor,
-	"st_asbinary":                 100 * cpuCostFactor,
-	"st_asencodedpolyline":        100 * cpuCostFactor,
-	"st_asewkb":                   100 * cpuCostFactor,
-	"st_asewkt":                   100 * cpuCostFactor,
-	"st_asgeojson":                100 * cpuCostFactor,
-	"st_asgml":                    100 * cpuCostFactor,
-	"st_ashexewkb":                100 * cpuCostFactor,
-	"st_askml":                    100 * cpuCostFactor,
-	"st_aslatlontext":             100 * cpuCostFactor,
-	"st_assvg":                    100 * cpuCostFactor,
-	"st_asmvtgeom":                100 * cpuCostFactor,
-	"st_astext":                   100 * cpuCostFactor,
-	"st_astwkb":                   1000 * cpuCostFactor,
-	"st_asx3d":                    100 * cpuCostFactor,
-	"st_azimuth":                  100 * cpuCostFactor,
-	"st_bdmpolyfromtext":          100 * cpuCostFactor,
-	"st_bdpolyfromtext":           100 * cpuCostFactor,
-	"st_boundary":                 1000 * cpuCostFactor,
-	"st_boundingdiagonal":         100 * cpuCostFactor,
-	"st_box2dfromgeohash":         1000 * cpuCostFactor,
-	"st_buffer":                   100 * cpuCostFactor,
-	"st_buildarea":                10000 * cpuCostFactor,
-	"st_centroid":                 100 * cpuCostFactor,
-	"st_chaikinsmoothing":         10000 * cpuCostFactor,
-	"st_cleangeometry":            10000 * cpuCostFactor,
-	"st_clipbybox2d":              10000 * cpuCostFactor,
-	"st_closestpoint":             1000 * cpuCostFactor,
-	"st_closestpointofapproach":   10000 * cpuCostFactor,
-	"st_clusterdbscan":            10000 * cpuCostFactor,
-	"st_clusterintersecting":      10000 * cpuCostFactor,
-	"st_clusterkmeans":            10000 * cpuCostFactor,
-	"st_clusterwithin":            10000 * cpuCostFactor,
-	"st_collectionextract":        100 * cpuCostFactor,
-	"st_collectionhomogenize":     100 * cpuCostFactor,
-	"st_concavehull":              10000 * cpuCostFactor,
-	"st_contains":                 10000 * cpuCostFactor,
-	"st_containsproperly":         10000 * cpuCostFactor,
-	"st_convexhull":               10000 * cpuCostFactor,
-	"st_coorddim":                 100 * cpuCostFactor,
-	"st_coveredby":                100 * cpuCostFactor,
-	"st_covers":                   100 * cpuCostFactor,
-	"st_cpawithin":                10000 * cpuCostFactor,
-	"st_createtopogeo":            100 * cpuCostFactor,
-	"st_crosses":                  10000 * cpuCostFactor,
-	"st_curvetoline":              10000 * cpuCostFactor,
-	"st_delaunaytriangles":        10000 * cpuCostFactor,
-	"st_dfullywithin":             10000 * cpuCostFactor,
-	"st_difference":               10000 * cpuCostFactor,
-	"st_dimension":                100 * cpuCostFactor,
-	"st_disjoint":                 10000 * cpuCostFactor,
-	"st_distance":                 100 * cpuCostFactor,
-	"st_distancecpa":              10000 * cpuCostFactor,
-	"st_distancesphere":           100 * cpuCostFactor,
-	"st_distancespheroid":         1000 * cpuCostFactor,
-	"st_dump":                     1000 * cpuCostFactor,
-	"st_dumppoints":               100 * cpuCostFactor,
-	"st_dumprings":                1000 * cpuCostFactor,
-	"st_dwithin":                  100 * cpuCostFactor,
-	"st_endpoint":                 100 * cpuCostFactor,
-	"st_envelope":                 100 * cpuCostFactor,
-	"st_equals":                   10000 * cpuCostFactor,
-	"st_expand":                   100 * cpuCostFactor,
-	"st_exteriorring":             100 * cpuCostFactor,
-	"st_filterbym":                1000 * cpuCostFactor,
-	"st_findextent":               100 * cpuCostFactor,
-	"st_flipcoordinates":          1000 * cpuCostFactor,
-	"st_force2d":                  100 * cpuCostFactor,
-	"st_force3d":                  100 * cpuCostFactor,
-	"st_force3dm":                 100 * cpuCostFactor,
-	"st_force3dz":                 100 * cpuCostFactor,
-	"st_force4d":                  100 * cpuCostFactor,
-	"st_forcecollection":          100 * cpuCostFactor,
-	"st_forcecurve":               1000 * cpuCostFactor,
-	"st_forcepolygonccw":          100 * cpuCostFactor,
-	"st_forcepolygoncw":           1000 * cpuCostFactor,
-	"st_forcerhr":                 1000 * cpuCostFactor,
-	"st_forcesfs":                 1000 * cpuCostFactor,
-	"st_frechetdistance":          10000 * cpuCostFactor,
-	"st_generatepoints":           10000 * cpuCostFactor,
-	"st_geogfromtext":             100 * cpuCostFactor,
-	"st_geogfromwkb":              100 * cpuCostFactor,
-	"st_geographyfromtext":        100 * cpuCostFactor,
-	"st_geohash":                  1000 * cpuCostFactor,
-	"st_geomcollfromtext":         100 * cpuCostFactor,
-	"st_geomcollfromwkb":          100 * cpuCostFactor,
-	"st_geometricmedian":          10000 * cpuCostFactor,
-	"st_geometryfromtext":         1000 * cpuCostFactor,
-	"st_geometryn":                100 * cpuCostFactor,
-	"st_geometrytype":             100 * cpuCostFactor,
-	"st_geomfromewkb":             100 * cpuCostFactor,
-	"st_geomfromewkt":             100 * cpuCostFactor,
-	"st_geomfromgeohash":          1000 * cpuCostFactor,
-	"st_geomfromgeojson":          1000 * cpuCostFactor,
-	"st_geomfromgml":              100 * cpuCostFactor,
-	"st_geomfromkml":              1000 * cpuCostFactor,
-	"st_geomfromtext":             1000 * cpuCostFactor,
-	"st_geomfromtwkb":             100 * cpuCostFactor,
-	"st_geomfromwkb":              100 * cpuCostFactor,
-	"st_gmltosql":                 100 * cpuCostFactor,
-	"st_hasarc":                   100 * cpuCostFactor,
-	"st_hausdorffdistance":        10000 * cpuCostFactor,
-	"st_inittopogeo":              100 * cpuCostFactor,
-	"st_interiorringn":            100 * cpuCostFactor,
-	"st_interpolatepoint":         1000 * cpuCostFactor,
-	"st_intersection":             100 * cpuCostFactor,
-	"st_intersects":               100 * cpuCostFactor,
-	"st_isclosed":                 100 * cpuCostFactor,
-	"st_iscollection":             1000 * cpuCostFactor,
-	"st_isempty":                  100 * cpuCostFactor,
-	"st_ispolygonccw":             100 * cpuCostFactor,
-	"st_ispolygoncw":              100 * cpuCostFactor,
-	"st_isring":                   1000 * cpuCostFactor,
-	"st_issimple":                 1000 * cpuCostFactor,
-	"st_isvalid":                  100 * cpuCostFactor,
-	"st_isvaliddetail":            10000 * cpuCostFactor,
-	"st_isvalidreason":            100 * cpuCostFactor,
-	"st_isvalidtrajectory":        10000 * cpuCostFactor,
-	"st_length":                   100 * cpuCostFactor,
-	"st_length2d":                 100 * cpuCostFactor,
-	"st_length2dspheroid":         1000 * cpuCostFactor,
-	"st_lengthspheroid":           1000 * cpuCostFactor,
-	"st_linecrossingdirection":    10000 * cpuCostFactor,
-	"st_linefromencodedpolyline":  1000 * cpuCostFactor,
-	"st_linefrommultipoint":       100 * cpuCostFactor,
-	"st_linefromtext":             100 * cpuCostFactor,
-	"st_linefromwkb":              100 * cpuCostFactor,
-	"st_lineinterpolatepoint":     1000 * cpuCostFactor,
-	"st_lineinterpolatepoints":    1000 * cpuCostFactor,
-	"st_linelocatepoint":          1000 * cpuCostFactor,
-	"st_linemerge":                10000 * cpuCostFactor,
-	"st_linestringfromwkb":        100 * cpuCostFactor,
-	"st_linesubstring":            1000 * cpuCostFactor,
-	"st_linetocurve":              10000 * cpuCostFactor,
-	"st_locatealong":              1000 * cpuCostFactor,
-	"st_locatebetween":            1000 * cpuCostFactor,
-	"st_locatebetweenelevations":  1000 * cpuCostFactor,
-	"st_longestline":              100 * cpuCostFactor,
-	"st_makeenvelope":             100 * cpuCostFactor,
-	"st_makeline":                 100 * cpuCostFactor,
-	"st_makepoint":                100 * cpuCostFactor,
-	"st_makepointm":               100 * cpuCostFactor,
-	"st_makepolygon":              100 * cpuCostFactor,
-	"st_makevalid":                10000 * cpuCostFactor,
-	"st_maxdistance":              100 * cpuCostFactor,
-	"st_memsize":                  100 * cpuCostFactor,
-	"st_minimumboundingcircle":    10000 * cpuCostFactor,
-	"st_minimumboundingradius":    10000 * cpuCostFactor,
-	"st_minimumclearance":         10000 * cpuCostFactor,
-	"st_minimumclearanceline":     10000 * cpuCostFactor,
-	"st_mlinefromtext":            100 * cpuCostFactor,
-	"st_mlinefromwkb":             100 * cpuCostFactor,
-	"st_mpointfromtext":           100 * cpuCostFactor,
-	"st_mpointfromwkb":            100 * cpuCostFactor,
-	"st_mpolyfromtext":            100 * cpuCostFactor,
-	"st_mpolyfromwkb":             100 * cpuCostFactor,
-	"st_multi":                    100 * cpuCostFactor,
-	"st_multilinefromwkb":         100 * cpuCostFactor,
-	"st_multilinestringfromtext":  100 * cpuCostFactor,
-	"st_multipointfromtext":       100 * cpuCostFactor,
-	"st_multipointfromwkb":        100 * cpuCostFactor,
-	"st_multipolyfromwkb":         100 * cpuCostFactor,
-	"st_multipolygonfromtext":     100 * cpuCostFactor,
-	"st_node":                     10000 * cpuCostFactor,
-	"st_normalize":                100 * cpuCostFactor,
-	"st_npoints":                  100 * cpuCostFactor,
-	"st_nrings":                   100 * cpuCostFactor,
-	"st_numgeometries":            100 * cpuCostFactor,
-	"st_numinteriorring":          100 * cpuCostFactor,
-	"st_numinteriorrings":         100 * cpuCostFactor,
-	"st_numpatches":               100 * cpuCostFactor,
-	"st_numpoints":                100 * cpuCostFactor,
-	"st_offsetcurve":              10000 * cpuCostFactor,
-	"st_orderingequals":           10000 * cpuCostFactor,
-	"st_orientedenvelope":         10000 * cpuCostFactor,
-	"st_overlaps":                 10000 * cpuCostFactor,
-	"st_patchn":                   100 * cpuCostFactor,
-	"st_perimeter":                100 * cpuCostFactor,
-	"st_perimeter2d":              100 * cpuCostFactor,
-	"st_point":                    100 * cpuCostFactor,
-	"st_pointfromgeohash":         1000 * cpuCostFactor,
-	"st_pointfromtext":            100 * cpuCostFactor,
-	"st_pointfromwkb":             100 * cpuCostFactor,
-	"st_pointinsidecircle":        1000 * cpuCostFactor,
-	"st_pointn":                   100 * cpuCostFactor,
-	"st_pointonsurface":           1000 * cpuCostFactor,
-	"st_points":                   1000 * cpuCostFactor,
-	"st_polyfromtext":             100 * cpuCostFactor,
-	"st_polyfromwkb":              100 * cpuCostFactor,
-	"st_polygon":                  100 * cpuCostFactor,
-	"st_polygonfromtext":          100 * cpuCostFactor,
-	"st_polygonfromwkb":           100 * cpuCostFactor,
-	"st_polygonize":               10000 * cpuCostFactor,
-	"st_project":                  1000 * cpuCostFactor,
-	"st_quantizecoordinates":      1000 * cpuCostFactor,
-	"st_relate":                   10000 * cpuCostFactor,
-	"st_relatematch":              1000 * cpuCostFactor,
-	"st_removepoint":              100 * cpuCostFactor,
-	"st_removerepeatedpoints":     1000 * cpuCostFactor,
-	"st_reverse":                  1000 * cpuCostFactor,
-	"st_rotate":                   100 * cpuCostFactor,
-	"st_rotatex":                  100 * cpuCostFactor,
-	"st_rotatey":                  100 * cpuCostFactor,
-	"st_rotatez":                  100 * cpuCostFactor,
-	"st_scale":                    100 * cpuCostFactor,
-	"st_segmentize":               1000 * cpuCostFactor,
-	"st_seteffectivearea":         1000 * cpuCostFactor,
-	"st_setpoint":                 100 * cpuCostFactor,
-	"st_setsrid":                  100 * cpuCostFactor,
-	"st_sharedpaths":              10000 * cpuCostFactor,
-	"st_shortestline":             1000 * cpuCostFactor,
-	"st_simplify":                 100 * cpuCostFactor,
-	"st_simplifypreservetopology": 10000 * cpuCostFactor,
-	"st_simplifyvw":               10000 * cpuCostFactor,
-	"st_snap":                     10000 * cpuCostFactor,
-	"st_snaptogrid":               100 * cpuCostFactor,
-	"st_split":                    10000 * cpuCostFactor,
-	"st_srid":                     100 * cpuCostFactor,
-	"st_startpoint":               100 * cpuCostFactor,
-	"st_subdivide":                10000 * cpuCostFactor,
-	"st_summary":                  100 * cpuCostFactor,
-	"st_swapordinates":            100 * cpuCostFactor,
-	"st_symdifference":            10000 * cpuCostFactor,
-	"st_symmetricdifference":      10000 * cpuCostFactor,
-	"st_tileenvelope":             100 * cpuCostFactor,
-	"st_touches":                  10000 * cpuCostFactor,
-	"st_transform":                100 * cpuCostFactor,
-	"st_translate":                100 * cpuCostFactor,
-	"st_transscale":               100 * cpuCostFactor,
-	"st_unaryunion":               10000 * cpuCostFactor,
-	"st_union":                    10000 * cpuCostFactor,
-	"st_voronoilines":             100 * cpuCostFactor,
-	"st_voronoipolygons":          100 * cpuCostFactor,
-	"st_within":                   10000 * cpuCostFactor,
-	"st_wkbtosql":                 100 * cpuCostFactor,
-	"st_wkttosql":                 1000 * cpuCostFactor,
+	"st_3dclosestpoint":           1000 * defaultCPUCostFactor,
+	"st_3ddfullywithin":           10000 * defaultCPUCostFactor,
+	"st_3ddistance":               1000 * defaultCPUCostFactor,
+	"st_3ddwithin":                10000 * defaultCPUCostFactor,
+	"st_3dintersects":             10000 * defaultCPUCostFactor,
+	"st_3dlength":                 100 * defaultCPUCostFactor,
+	"st_3dlongestline":            1000 * defaultCPUCostFactor,
+	"st_3dmakebox":                100 * defaultCPUCostFactor,
+	"st_3dmaxdistance":            1000 * defaultCPUCostFactor,
+	"st_3dperimeter":              100 * defaultCPUCostFactor,
+	"st_3dshortestline":           1000 * defaultCPUCostFactor,
+	"st_addmeasure":               1000 * defaultCPUCostFactor,
+	"st_addpoint":                 100 * defaultCPUCostFactor,
+	"st_affine":                   100 * defaultCPUCostFactor,
+	"st_angle":                    100 * defaultCPUCostFactor,
+	"st_area":                     100 * defaultCPUCostFactor,
+	"st_area2d":                   100 * defaultCPUCostFactor,
+	"st_asbinary":                 100 * defaultCPUCostFactor,
+	"st_asencodedpolyline":        100 * defaultCPUCostFactor,
+	"st_asewkb":                   100 * defaultCPUCostFactor,
+	"st_asewkt":                   100 * defaultCPUCostFactor,
+	"st_asgeojson":                100 * defaultCPUCostFactor,
+	"st_asgml":                    100 * defaultCPUCostFactor,
+	"st_ashexewkb":                100 * defaultCPUCostFactor,
+	"st_askml":                    100 * defaultCPUCostFactor,
+	"st_aslatlontext":             100 * defaultCPUCostFactor,
+	"st_assvg":                    100 * defaultCPUCostFactor,
+	"st_asmvtgeom":                100 * defaultCPUCostFactor,
+	"st_astext":                   100 * defaultCPUCostFactor,
+	"st_astwkb":                   1000 * defaultCPUCostFactor,
+	"st_asx3d":                    100 * defaultCPUCostFactor,
+	"st_azimuth":                  100 * defaultCPUCostFactor,
+	"st_bdmpolyfromtext":          100 * defaultCPUCostFactor,
+	"st_bdpolyfromtext":           100 * defaultCPUCostFactor,
+	"st_boundary":                 1000 * defaultCPUCostFactor,
+	"st_boundingdiagonal":         100 * defaultCPUCostFactor,
+	"st_box2dfromgeohash":         1000 * defaultCPUCostFactor,
+	"st_buffer":                   100 * defaultCPUCostFactor,
+	"st_buildarea":                10000 * defaultCPUCostFactor,
+	"st_centroid":                 100 * defaultCPUCostFactor,
+	"st_chaikinsmoothing":         10000 * defaultCPUCostFactor,
+	"st_cleangeometry":            10000 * defaultCPUCostFactor,
+	"st_clipbybox2d":              10000 * defaultCPUCostFactor,
+	"st_closestpoint":             1000 * defaultCPUCostFactor,
+	"st_closestpointofapproach":   10000 * defaultCPUCostFactor,
+	"st_clusterdbscan":            10000 * defaultCPUCostFactor,
+	"st_clusterintersecting":      10000 * defaultCPUCostFactor,
+	"st_clusterkmeans":            10000 * defaultCPUCostFactor,
+	"st_clusterwithin":            10000 * defaultCPUCostFactor,
+	"st_collectionextract":        100 * defaultCPUCostFactor,
+	"st_collectionhomogenize":     100 * defaultCPUCostFactor,
+	"st_concavehull":              10000 * defaultCPUCostFactor,
+	"st_contains":                 10000 * defaultCPUCostFactor,
+	"st_containsproperly":         10000 * defaultCPUCostFactor,
+	"st_convexhull":               10000 * defaultCPUCostFactor,
+	"st_coorddim":                 100 * defaultCPUCostFactor,
+	"st_coveredby":                100 * defaultCPUCostFactor,
+	"st_covers":                   100 * defaultCPUCostFactor,
+	"st_cpawithin":                10000 * defaultCPUCostFactor,
+	"st_createtopogeo":            100 * defaultCPUCostFactor,
+	"st_crosses":                  10000 * defaultCPUCostFactor,
+	"st_curvetoline":              10000 * defaultCPUCostFactor,
+	"st_delaunaytriangles":        10000 * defaultCPUCostFactor,
+	"st_dfullywithin":             10000 * defaultCPUCostFactor,
+	"st_difference":               10000 * defaultCPUCostFactor,
+	"st_dimension":                100 * defaultCPUCostFactor,
+	"st_disjoint":                 10000 * defaultCPUCostFactor,
+	"st_distance":                 100 * defaultCPUCostFactor,
+	"st_distancecpa":              10000 * defaultCPUCostFactor,
+	"st_distancesphere":           100 * defaultCPUCostFactor,
+	"st_distancespheroid":         1000 * defaultCPUCostFactor,
+	"st_dump":                     1000 * defaultCPUCostFactor,
+	"st_dumppoints":               100 * defaultCPUCostFactor,
+	"st_dumprings":                1000 * defaultCPUCostFactor,
+	"st_dwithin":                  100 * defaultCPUCostFactor,
+	"st_endpoint":                 100 * defaultCPUCostFactor,
+	"st_envelope":                 100 * defaultCPUCostFactor,
+	"st_equals":                   10000 * defaultCPUCostFactor,
+	"st_expand":                   100 * defaultCPUCostFactor,
+	"st_exteriorring":             100 * defaultCPUCostFactor,
+	"st_filterbym":                1000 * defaultCPUCostFactor,
+	"st_findextent":               100 * defaultCPUCostFactor,
+	"st_flipcoordinates":          1000 * defaultCPUCostFactor,
+	"st_force2d":                  100 * defaultCPUCostFactor,
+	"st_force3d":                  100 * defaultCPUCostFactor,
+	"st_force3dm":                 100 * defaultCPUCostFactor,
+	"st_force3dz":                 100 * defaultCPUCostFactor,
+	"st_force4d":                  100 * defaultCPUCostFactor,
+	"st_forcecollection":          100 * defaultCPUCostFactor,
+	"st_forcecurve":               1000 * defaultCPUCostFactor,
+	"st_forcepolygonccw":          100 * defaultCPUCostFactor,
+	"st_forcepolygoncw":           1000 * defaultCPUCostFactor,
+	"st_forcerhr":                 1000 * defaultCPUCostFactor,
+	"st_forcesfs":                 1000 * defaultCPUCostFactor,
+	"st_frechetdistance":          10000 * defaultCPUCostFactor,
+	"st_generatepoints":           10000 * defaultCPUCostFactor,
+	"st_geogfromtext":             100 * defaultCPUCostFactor,
+	"st_geogfromwkb":              100 * defaultCPUCostFactor,
+	"st_geographyfromtext":        100 * defaultCPUCostFactor,
+	"st_geohash":                  1000 * defaultCPUCostFactor,
+	"st_geomcollfromtext":         100 * defaultCPUCostFactor,
+	"st_geomcollfromwkb":          100 * defaultCPUCostFactor,
+	"st_geometricmedian":          10000 * defaultCPUCostFactor,
+	"st_geometryfromtext":         1000 * defaultCPUCostFactor,
+	"st_geometryn":                100 * defaultCPUCostFactor,
+	"st_geometrytype":             100 * defaultCPUCostFactor,
+	"st_geomfromewkb":             100 * defaultCPUCostFactor,
+	"st_geomfromewkt":             100 * defaultCPUCostFactor,
+	"st_geomfromgeohash":          1000 * defaultCPUCostFactor,
+	"st_geomfromgeojson":          1000 * defaultCPUCostFactor,
+	"st_geomfromgml":              100 * defaultCPUCostFactor,
+	"st_geomfromkml":              1000 * defaultCPUCostFactor,
+	"st_geomfromtext":             1000 * defaultCPUCostFactor,
+	"st_geomfromtwkb":             100 * defaultCPUCostFactor,
+	"st_geomfromwkb":              100 * defaultCPUCostFactor,
+	"st_gmltosql":                 100 * defaultCPUCostFactor,
+	"st_hasarc":                   100 * defaultCPUCostFactor,
+	"st_hausdorffdistance":        10000 * defaultCPUCostFactor,
+	"st_inittopogeo":              100 * defaultCPUCostFactor,
+	"st_interiorringn":            100 * defaultCPUCostFactor,
+	"st_interpolatepoint":         1000 * defaultCPUCostFactor,
+	"st_intersection":             100 * defaultCPUCostFactor,
+	"st_intersects":               100 * defaultCPUCostFactor,
+	"st_isclosed":                 100 * defaultCPUCostFactor,
+	"st_iscollection":             1000 * defaultCPUCostFactor,
+	"st_isempty":                  100 * defaultCPUCostFactor,
+	"st_ispolygonccw":             100 * defaultCPUCostFactor,
+	"st_ispolygoncw":              100 * defaultCPUCostFactor,
+	"st_isring":                   1000 * defaultCPUCostFactor,
+	"st_issimple":                 1000 * defaultCPUCostFactor,
+	"st_isvalid":                  100 * defaultCPUCostFactor,
+	"st_isvaliddetail":            10000 * defaultCPUCostFactor,
+	"st_isvalidreason":            100 * defaultCPUCostFactor,
+	"st_isvalidtrajectory":        10000 * defaultCPUCostFactor,
+	"st_length":                   100 * defaultCPUCostFactor,
+	"st_length2d":                 100 * defaultCPUCostFactor,
+	"st_length2dspheroid":         1000 * defaultCPUCostFactor,
+	"st_lengthspheroid":           1000 * defaultCPUCostFactor,
+	"st_linecrossingdirection":    10000 * defaultCPUCostFactor,
+	"st_linefromencodedpolyline":  1000 * defaultCPUCostFactor,
+	"st_linefrommultipoint":       100 * defaultCPUCostFactor,
+	"st_linefromtext":             100 * defaultCPUCostFactor,
+	"st_linefromwkb":              100 * defaultCPUCostFactor,
+	"st_lineinterpolatepoint":     1000 * defaultCPUCostFactor,
+	"st_lineinterpolatepoints":    1000 * defaultCPUCostFactor,
+	"st_linelocatepoint":          1000 * defaultCPUCostFactor,
+	"st_linemerge":                10000 * defaultCPUCostFactor,
+	"st_linestringfromwkb":        100 * defaultCPUCostFactor,
+	"st_linesubstring":            1000 * defaultCPUCostFactor,
+	"st_linetocurve":              10000 * defaultCPUCostFactor,
+	"st_locatealong":              1000 * defaultCPUCostFactor,
+	"st_locatebetween":            1000 * defaultCPUCostFactor,
+	"st_locatebetweenelevations":  1000 * defaultCPUCostFactor,
+	"st_longestline":              100 * defaultCPUCostFactor,
+	"st_makeenvelope":             100 * defaultCPUCostFactor,
+	"st_makeline":                 100 * defaultCPUCostFactor,
+	"st_makepoint":                100 * defaultCPUCostFactor,
+	"st_makepointm":               100 * defaultCPUCostFactor,
+	"st_makepolygon":              100 * defaultCPUCostFactor,
+	"st_makevalid":                10000 * defaultCPUCostFactor,
+	"st_maxdistance":              100 * defaultCPUCostFactor,
+	"st_memsize":                  100 * defaultCPUCostFactor,
+	"st_minimumboundingcircle":    10000 * defaultCPUCostFactor,
+	"st_minimumboundingradius":    10000 * defaultCPUCostFactor,
+	"st_minimumclearance":         10000 * defaultCPUCostFactor,
+	"st_minimumclearanceline":     10000 * defaultCPUCostFactor,
+	"st_mlinefromtext":            100 * defaultCPUCostFactor,
+	"st_mlinefromwkb":             100 * defaultCPUCostFactor,
+	"st_mpointfromtext":           100 * defaultCPUCostFactor,
+	"st_mpointfromwkb":            100 * defaultCPUCostFactor,
+	"st_mpolyfromtext":            100 * defaultCPUCostFactor,
+	"st_mpolyfromwkb":             100 * defaultCPUCostFactor,
+	"st_multi":                    100 * defaultCPUCostFactor,
+	"st_multilinefromwkb":         100 * defaultCPUCostFactor,
+	"st_multilinestringfromtext":  100 * defaultCPUCostFactor,
+	"st_multipointfromtext":       100 * defaultCPUCostFactor,
+	"st_multipointfromwkb":        100 * defaultCPUCostFactor,
+	"st_multipolyfromwkb":         100 * defaultCPUCostFactor,
+	"st_multipolygonfromtext":     100 * defaultCPUCostFactor,
+	"st_node":                     10000 * defaultCPUCostFactor,
+	"st_normalize":                100 * defaultCPUCostFactor,
+	"st_npoints":                  100 * defaultCPUCostFactor,
+	"st_nrings":                   100 * defaultCPUCostFactor,
+	"st_numgeometries":            100 * defaultCPUCostFactor,
+	"st_numinteriorring":          100 * defaultCPUCostFactor,
+	"st_numinteriorrings":         100 * defaultCPUCostFactor,
+	"st_numpatches":               100 * defaultCPUCostFactor,
+	"st_numpoints":                100 * defaultCPUCostFactor,
+	"st_offsetcurve":              10000 * defaultCPUCostFactor,
+	"st_orderingequals":           10000 * defaultCPUCostFactor,
+	"st_orientedenvelope":         10000 * defaultCPUCostFactor,
+	"st_overlaps":                 10000 * defaultCPUCostFactor,
+	"st_patchn":                   100 * defaultCPUCostFactor,
+	"st_perimeter":                100 * defaultCPUCostFactor,
+	"st_perimeter2d":              100 * defaultCPUCostFactor,
+	"st_point":                    100 * defaultCPUCostFactor,
+	"st_pointfromgeohash":         1000 * defaultCPUCostFactor,
+	"st_pointfromtext":            100 * defaultCPUCostFactor,
+	"st_pointfromwkb":             100 * defaultCPUCostFactor,
+	"st_pointinsidecircle":        1000 * defaultCPUCostFactor,
+	"st_pointn":                   100 * defaultCPUCostFactor,
+	"st_pointonsurface":           1000 * defaultCPUCostFactor,
+	"st_points":                   1000 * defaultCPUCostFactor,
+	"st_polyfromtext":             100 * defaultCPUCostFactor,
+	"st_polyfromwkb":              100 * defaultCPUCostFactor,
+	"st_polygon":                  100 * defaultCPUCostFactor,
+	"st_polygonfromtext":          100 * defaultCPUCostFactor,
+	"st_polygonfromwkb":           100 * defaultCPUCostFactor,
+	"st_polygonize":               10000 * defaultCPUCostFactor,
+	"st_project":                  1000 * defaultCPUCostFactor,
+	"st_quantizecoordinates":      1000 * defaultCPUCostFactor,
+	"st_relate":                   10000 * defaultCPUCostFactor,
+	"st_relatematch":              1000 * defaultCPUCostFactor,
+	"st_removepoint":              100 * defaultCPUCostFactor,
+	"st_removerepeatedpoints":     1000 * defaultCPUCostFactor,
+	"st_reverse":                  1000 * defaultCPUCostFactor,
+	"st_rotate":                   100 * defaultCPUCostFactor,
+	"st_rotatex":                  100 * defaultCPUCostFactor,
+	"st_rotatey":                  100 * defaultCPUCostFactor,
+	"st_rotatez":                  100 * defaultCPUCostFactor,
+	"st_scale":                    100 * defaultCPUCostFactor,
+	"st_segmentize":               1000 * defaultCPUCostFactor,
+	"st_seteffectivearea":         1000 * defaultCPUCostFactor,
+	"st_setpoint":                 100 * defaultCPUCostFactor,
+	"st_setsrid":                  100 * defaultCPUCostFactor,
+	"st_sharedpaths":              10000 * defaultCPUCostFactor,
+	"st_shortestline":             1000 * defaultCPUCostFactor,
+	"st_simplify":                 100 * defaultCPUCostFactor,
+	"st_simplifypreservetopology": 10000 * defaultCPUCostFactor,
+	"st_simplifyvw":               10000 * defaultCPUCostFactor,
+	"st_snap":                     10000 * defaultCPUCostFactor,
+	"st_snaptogrid":               100 * defaultCPUCostFactor,
+	"st_split":                    10000 * defaultCPUCostFactor,
+	"st_srid":                     100 * defaultCPUCostFactor,
+	"st_startpoint":               100 * defaultCPUCostFactor,
+	"st_subdivide":                10000 * defaultCPUCostFactor,
+	"st_summary":                  100 * defaultCPUCostFactor,
+	"st_swapordinates":            100 * defaultCPUCostFactor,
+	"st_symdifference":            10000 * defaultCPUCostFactor,
+	"st_symmetricdifference":      10000 * defaultCPUCostFactor,
+	"st_tileenvelope":             100 * defaultCPUCostFactor,
+	"st_touches":                  10000 * defaultCPUCostFactor,
+	"st_transform":                100 * defaultCPUCostFactor,
+	"st_translate":                100 * defaultCPUCostFactor,
+	"st_transscale":               100 * defaultCPUCostFactor,
+	"st_unaryunion":               10000 * defaultCPUCostFactor,
+	"st_union":                    10000 * defaultCPUCostFactor,
+	"st_voronoilines":             100 * defaultCPUCostFactor,
+	"st_voronoipolygons":          100 * defaultCPUCostFactor,
+	"st_within":                   10000 * defaultCPUCostFactor,
+	"st_wkbtosql":                 100 * defaultCPUCostFactor,
+	"st_wkttosql":                 1000 * defaultCPUCostFactor,
 }
 
 // Init initializes a new coster structure with the given memo.
@@ -507,6 +486,70 @@ func (c *coster) Init(
 		rng:          rng,
 		o:            o,
 	}
+	c.initCostFactors(mem.CostCalibration())
+}
+
+// initCostFactors sets the coster's basic cost factors, using the calibrated
+// value for each factor that has been calibrated and the default otherwise.
+func (c *coster) initCostFactors(calibration memo.CostCalibration) {
+	c.cpuCostFactor = defaultCPUCostFactor
+	if calibration.CPUCostFactor != 0 {
+		c.cpuCostFactor = calibration.CPUCostFactor
+	}
+	c.seqIOCostFactor = defaultSeqIOCostFactor
+	if calibration.SeqIOCostFactor != 0 {
+		c.seqIOCostFactor = calibration.SeqIOCostFactor
+	}
+	c.randIOCostFactor = defaultRandIOCostFactor
+	if calibration.RandIOCostFactor != 0 {
+		c.randIOCostFactor = calibration.RandIOCostFactor
+	}
+}
+
+// lookupJoinRetrieveRowCost is the cost to retrieve a single row during a
+// lookup join.
+// See https://github.com/cockroachdb/cockroach/pull/35561 for the initial
+// justification for this cost.
+// TODO(justin): make this more sophisticated.
+func (c *coster) lookupJoinRetrieveRowCost() memo.Cost {
+	return 2 * c.seqIOCostFactor
+}
+
+// virtualScanTableDescriptorFetchCost is the cost to retrieve the table
+// descriptors when performing a virtual table scan.
+func (c *coster) virtualScanTableDescriptorFetchCost() memo.Cost {
+	return 25 * c.randIOCostFactor
+}
+
+// latencyCostFactor represents the throughput impact of doing scans on an
+// index that may be remotely located in a different locality. If latencies
+// are higher, then overall cluster throughput will suffer somewhat, as there
+// will be more queries in memory blocking on I/O. The impact on throughput
+// is expected to be relatively low, so latencyCostFactor is set to a small
+// value. However, even a low value will cause the optimizer to prefer
+// indexes that are likely to be geographically closer, if they are otherwise
+// the same cost to access.
+// TODO(andyk): Need to do analysis to figure out right value and/or to come
+// up with better way to incorporate latency into the coster.
+func (c *coster) latencyCostFactor() memo.Cost {
+	return c.cpuCostFactor
+}
+
+// smallDistributeCost is the per-operation cost overhead for scans which may
+// access remote regions, but the scanned table is unpartitioned with no lease
+// preferences, so locality information is not available. The distribution
+// cost is unknown, so a small overhead cost is added to give optimizations
+// like locality-optimized search a chance to get picked.
+func (c *coster) smallDistributeCost() memo.Cost {
+	return c.randIOCostFactor
+}
+
+// spillCostFactor is the cost of spilling to disk. We use seqIOCostFactor to
+// model the cost of spilling to disk, because although there will be some
+// random I/O required to insert rows into a sorted structure, the inherent
+// batching in the LSM tree should amortize the cost.
+func (c *coster) spillCostFactor() memo.Cost {
+	return c.seqIOCostFactor
 }
 
 // MaybeGetBestCostRelation is part of the xform.Coster interface.
@@ -603,13 +646,13 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 	// Add a one-time cost for any operator, meant to reflect the cost of setting
 	// up execution for the operator. This makes plans with fewer operators
 	// preferable, all else being equal.
-	cost += cpuCostFactor
+	cost += c.cpuCostFactor
 
 	// Add a one-time cost for any operator with unbounded cardinality. This
 	// ensures we prefer plans that push limits as far down the tree as possible,
 	// all else being equal.
 	if candidate.Relational().Cardinality.IsUnbounded() {
-		cost += cpuCostFactor
+		cost += c.cpuCostFactor
 	}
 
 	if !cost.Less(memo.MaxCost) {
@@ -657,7 +700,7 @@ func (c *coster) computeTopKCost(topk *memo.TopKExpr, required *physical.Require
 	// Add the cost of sorting.
 	// Start with a cost of storing each row; TopK sort only stores K rows in a
 	// max heap.
-	cost := memo.Cost(cpuCostFactor * float64(rel.OutputCols.Len()) * outputRowCount)
+	cost := c.cpuCostFactor * memo.Cost(rel.OutputCols.Len()) * memo.Cost(outputRowCount)
 
 	// Add buffering cost for the output rows.
 	cost += c.rowBufferCost(outputRowCount)
@@ -692,14 +735,14 @@ func (c *coster) computeSortCost(sort *memo.SortExpr, required *physical.Require
 	// Start with a cost of storing each row; this takes the total number of
 	// columns into account so that a sort on fewer columns is preferred (e.g.
 	// sort before projecting a new column).
-	cost := memo.Cost(cpuCostFactor * float64(rel.OutputCols.Len()) * stats.RowCount)
+	cost := c.cpuCostFactor * memo.Cost(rel.OutputCols.Len()) * memo.Cost(stats.RowCount)
 
 	if !sort.InputOrdering.Any() {
 		// Add the cost for finding the segments: each row is compared to the
 		// previous row on the preordered columns. Most of these comparisons will
 		// yield equality, so we don't use rowCmpCost(): we expect to have to
 		// compare all preordered columns.
-		cost += cpuCostFactor * memo.Cost(numPreorderedCols) * memo.Cost(stats.RowCount)
+		cost += c.cpuCostFactor * memo.Cost(numPreorderedCols) * memo.Cost(stats.RowCount)
 	}
 
 	// Add the cost to sort the segments. On average, each row is involved in
@@ -781,11 +824,11 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 	} else if scan.InvertedConstraint != nil {
 		numSpans = len(scan.InvertedConstraint)
 	}
-	baseCost := memo.Cost(numSpans * randIOCostFactor)
+	baseCost := memo.Cost(numSpans) * c.randIOCostFactor
 
 	// If this is a virtual scan, add the cost of fetching table descriptors.
 	if c.mem.Metadata().Table(scan.Table).IsVirtualTable() {
-		baseCost += virtualScanTableDescriptorFetchCost
+		baseCost += c.virtualScanTableDescriptorFetchCost()
 	}
 
 	// Performing a reverse scan is more expensive than a forward scan, but it's
@@ -796,7 +839,7 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 	if ordering.ScanIsReverse(scan, &required.Ordering) {
 		if rowCount > 1 {
 			// Need to do binary search to seek to the previous row.
-			perRowCost += memo.Cost(math.Log2(rowCount)) * cpuCostFactor
+			perRowCost += memo.Cost(math.Log2(rowCount)) * c.cpuCostFactor
 		}
 	}
 
@@ -813,7 +856,7 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 		if partitionCount := index.PartitionCount(); partitionCount > 1 {
 			// Subtract 1 since we already accounted for the first partition when
 			// counting spans.
-			baseCost += memo.Cost(partitionCount-1) * randIOCostFactor
+			baseCost += memo.Cost(partitionCount-1) * c.randIOCostFactor
 		}
 	}
 
@@ -830,7 +873,7 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 		rowCount = math.Min(rowCount, required.LimitHint)
 	}
 
-	cost := baseCost + memo.Cost(rowCount)*(seqIOCostFactor+perRowCost)
+	cost := baseCost + memo.Cost(rowCount)*(c.seqIOCostFactor+perRowCost)
 
 	var regionsAccessed physical.Distribution
 	if scan.Distribution.Regions != nil {
@@ -879,7 +922,7 @@ func (c *coster) distributionCost(regionsAccessed physical.Distribution) (cost m
 		// don't add the somewhat large `DistributeCost` when
 		// `regionsAccessed.Any()` is true because query planning can't be done in
 		// that case to try and avoid the distribution anyway.
-		extraCost = memo.Cost(SmallDistributeCost)
+		extraCost = c.smallDistributeCost()
 	} else if !regionsAccessed.Any() && c.evalCtx != nil &&
 		c.evalCtx.Planner.EnforceHomeRegion() {
 		if len(regionsAccessed.Regions) == 1 {
@@ -913,22 +956,22 @@ func (c *coster) computeProjectCost(prj *memo.ProjectExpr) memo.Cost {
 	// Each synthesized column causes an expression to be evaluated on each row.
 	rowCount := prj.Relational().Statistics().RowCount
 	synthesizedColCount := len(prj.Projections)
-	cost := memo.Cost(rowCount) * memo.Cost(synthesizedColCount) * cpuCostFactor
+	cost := memo.Cost(rowCount) * memo.Cost(synthesizedColCount) * c.cpuCostFactor
 
 	// Add the CPU cost of emitting the rows.
-	cost += memo.Cost(rowCount) * cpuCostFactor
+	cost += memo.Cost(rowCount) * c.cpuCostFactor
 	return cost
 }
 
 func (c *coster) computeInvertedFilterCost(invFilter *memo.InvertedFilterExpr) memo.Cost {
 	// The filter has to be evaluated on each input row.
 	inputRowCount := invFilter.Input.Relational().Statistics().RowCount
-	cost := memo.Cost(inputRowCount) * cpuCostFactor
+	cost := memo.Cost(inputRowCount) * c.cpuCostFactor
 	return cost
 }
 
 func (c *coster) computeValuesCost(values *memo.ValuesExpr) memo.Cost {
-	return memo.Cost(values.Relational().Statistics().RowCount) * cpuCostFactor
+	return memo.Cost(values.Relational().Statistics().RowCount) * c.cpuCostFactor
 }
 
 func (c *coster) computeHashJoinCost(join memo.RelExpr) memo.Cost {
@@ -952,7 +995,7 @@ func (c *coster) computeHashJoinCost(join memo.RelExpr) memo.Cost {
 	// right side is the one stored in the hashtable, so we use a larger factor
 	// for that side. This ensures that a join with the smaller right side is
 	// preferred to the symmetric join.
-	cost := memo.Cost(1.25*leftRowCount+1.75*rightRowCount) * cpuCostFactor
+	cost := memo.Cost(1.25*leftRowCount+1.75*rightRowCount) * c.cpuCostFactor
 
 	// Add a cost for buffering rows that takes into account increased memory
 	// pressure and the possibility of spilling to disk.
@@ -999,7 +1042,7 @@ func (c *coster) computeMergeJoinCost(join *memo.MergeJoinExpr) memo.Cost {
 	// whereas the left side is processed in a streaming fashion. To account for
 	// this difference, we multiply both row counts so that a join with the
 	// smaller right side is preferred to the symmetric join.
-	cost := memo.Cost(0.9*leftRowCount+1.1*rightRowCount) * cpuCostFactor
+	cost := memo.Cost(0.9*leftRowCount+1.1*rightRowCount) * c.cpuCostFactor
 
 	filterSetup, filterPerRow := c.computeFiltersCost(join.On, intsets.Fast{})
 	cost += filterSetup
@@ -1169,7 +1212,7 @@ func (c *coster) computeIndexLookupJoinCost(
 		rowsProcessed = (rowsProcessed / unlimitedLookupCount) * lookupCount
 	}
 
-	perLookupCost := indexLookupJoinPerLookupCost(join)
+	perLookupCost := c.indexLookupJoinPerLookupCost(join)
 	if !lookupColsAreTableKey {
 		// If the lookup columns don't form a key, execution will have to limit
 		// KV batches which prevents running requests to multiple nodes in parallel.
@@ -1177,12 +1220,12 @@ func (c *coster) computeIndexLookupJoinCost(
 		// 100 ranges showed that a "non-parallel" lookup join is about 5 times
 		// slower.
 		// TODO(drewk): this no longer applies now that the streamer work is used.
-		perLookupCost += 4 * randIOCostFactor
+		perLookupCost += 4 * c.randIOCostFactor
 	}
 	if c.mem.Metadata().Table(table).IsVirtualTable() {
 		// It's expensive to perform a lookup join into a virtual table because
 		// we need to fetch the table descriptors on each lookup.
-		perLookupCost += virtualScanTableDescriptorFetchCost
+		perLookupCost += c.virtualScanTableDescriptorFetchCost()
 	}
 	cost := memo.Cost(lookupCount) * perLookupCost
 
@@ -1195,7 +1238,7 @@ func (c *coster) computeIndexLookupJoinCost(
 	// TODO(harding): Add the cost of reading all columns in the lookup table when
 	// we cost rows by column size.
 	lookupCols := cols.Difference(input.Relational().OutputCols)
-	perRowCost := lookupJoinRetrieveRowCost + filterPerRow +
+	perRowCost := c.lookupJoinRetrieveRowCost() + filterPerRow +
 		c.rowScanCost(table, index, lookupCols)
 
 	cost += memo.Cost(rowsProcessed) * perRowCost
@@ -1256,7 +1299,7 @@ func (c *coster) computeInvertedJoinCost(
 	// The rows in the (left) input are used to probe into the (right) table.
 	// Since the matching rows in the table may not all be in the same range, this
 	// counts as random I/O.
-	perLookupCost := memo.Cost(randIOCostFactor)
+	perLookupCost := c.randIOCostFactor
 	// Since inverted indexes can't form a key, execution will have to
 	// limit KV batches which prevents running requests to multiple nodes
 	// in parallel.  An experiment on a 4 node cluster with a table with
@@ -1272,7 +1315,7 @@ func (c *coster) computeInvertedJoinCost(
 	// rows (relevant when we expect many resulting rows per lookup) and the CPU
 	// cost of emitting the rows.
 	lookupCols := join.Cols.Difference(join.Input.Relational().OutputCols)
-	perRowCost := lookupJoinRetrieveRowCost + filterPerRow +
+	perRowCost := c.lookupJoinRetrieveRowCost() + filterPerRow +
 		c.rowScanCost(join.Table, join.Index, lookupCols)
 
 	cost += memo.Cost(rowsProcessed) * perRowCost
@@ -1290,7 +1333,7 @@ func (c *coster) computeExprCost(expr opt.Expr) memo.Cost {
 	if expr.Op() == opt.FunctionOp {
 		// We are ok with the zero value here for functions not in the map.
 		function := expr.(*memo.FunctionExpr)
-		perRowCost += fnCost[function.Name]
+		perRowCost += fnCost[function.Name] * (c.cpuCostFactor / defaultCPUCostFactor)
 	}
 	// recurse into the children of the current expression
 	for i := 0; i < expr.ChildCount(); i++ {
@@ -1311,7 +1354,7 @@ func (c *coster) computeFiltersCost(
 ) (setupCost, perRowCost memo.Cost) {
 	// Add a base perRowCost so that callers do not need to have their own
 	// base per-row cost.
-	perRowCost += cpuCostFactor
+	perRowCost += c.cpuCostFactor
 	for i := range filters {
 		if filtersToSkip.Contains(i) {
 			continue
@@ -1321,7 +1364,7 @@ func (c *coster) computeFiltersCost(
 		// Add a constant "setup" cost per ON condition to account for the fact that
 		// the rowsProcessed estimate alone cannot effectively discriminate between
 		// plans when RowCount is too small.
-		setupCost += cpuCostFactor
+		setupCost += c.cpuCostFactor
 	}
 	return setupCost, perRowCost
 }
@@ -1361,11 +1404,11 @@ func (c *coster) computeZigzagJoinCost(join *memo.ZigzagJoinExpr) memo.Cost {
 	// See `indexLookupJoinPerLookupCost` and `computeIndexLookupJoinCost`.
 	// Increased zigzag join costs mean that accurate selectivity estimation is
 	// needed to ensure this index access path can be picked.
-	seekCost := memo.Cost(randIOCostFactor + lookupJoinRetrieveRowCost)
+	seekCost := c.randIOCostFactor + c.lookupJoinRetrieveRowCost()
 
 	// Double the cost of emitting rows as well as the cost of seeking rows,
 	// given two indexes will be accessed.
-	cost := memo.Cost(rowCount) * (2*(cpuCostFactor+seekCost) + scanCost + filterPerRow)
+	cost := memo.Cost(rowCount) * (2*(c.cpuCostFactor+seekCost) + scanCost + filterPerRow)
 	cost += filterSetup
 
 	// Add a penalty if the cardinality exceeds the row count estimate. Adding a
@@ -1395,7 +1438,7 @@ func (c *coster) computeSetCost(set memo.RelExpr, required *physical.Required) m
 	if outputRowCount != 0 && required.LimitHint != 0 && outputRowCount > required.LimitHint {
 		outputRowCount = required.LimitHint
 	}
-	cost := memo.Cost(outputRowCount) * cpuCostFactor
+	cost := memo.Cost(outputRowCount) * c.cpuCostFactor
 
 	// A set operation must process every row from both tables once. UnionAll and
 	// LocalityOptimizedSearch can avoid any extra computation, but all other set
@@ -1409,7 +1452,7 @@ func (c *coster) computeSetCost(set memo.RelExpr, required *physical.Required) m
 		!isStreamingSetOperator(set) {
 		leftRowCount := set.Child(0).(memo.RelExpr).Relational().Statistics().RowCount
 		rightRowCount := set.Child(1).(memo.RelExpr).Relational().Statistics().RowCount
-		cost += memo.Cost(leftRowCount+rightRowCount) * cpuCostFactor
+		cost += memo.Cost(leftRowCount+rightRowCount) * c.cpuCostFactor
 
 		// Add a cost for buffering rows that takes into account increased memory
 		// pressure and the possibility of spilling to disk.
@@ -1440,11 +1483,11 @@ func (c *coster) computeGroupingCost(grouping memo.RelExpr, required *physical.R
 	// Start with some extra fixed overhead, since the grouping operators have
 	// setup overhead that is greater than other operators like Project. This
 	// can matter for rules like ReplaceMaxWithLimit.
-	cost := memo.Cost(cpuCostFactor)
+	cost := c.cpuCostFactor
 
 	// Add the CPU cost of emitting the rows.
 	outputRowCount := grouping.Relational().Statistics().RowCount
-	cost += memo.Cost(outputRowCount) * cpuCostFactor
+	cost += memo.Cost(outputRowCount) * c.cpuCostFactor
 
 	private := grouping.Private().(*memo.GroupingPrivate)
 	groupingColCount := private.GroupingCols.Len()
@@ -1463,7 +1506,7 @@ func (c *coster) computeGroupingCost(grouping memo.RelExpr, required *physical.R
 
 	// Cost per row depends on the number of grouping columns and the number of
 	// aggregates.
-	cost += memo.Cost(inputRowCount) * memo.Cost(aggsCount+groupingColCount) * cpuCostFactor
+	cost += memo.Cost(inputRowCount) * memo.Cost(aggsCount+groupingColCount) * c.cpuCostFactor
 
 	// Add a cost that reflects the use of a hash table - unless we are doing a
 	// streaming aggregation.
@@ -1472,7 +1515,7 @@ func (c *coster) computeGroupingCost(grouping memo.RelExpr, required *physical.R
 	// input.
 	if groupingColCount > 0 && streamingType != memo.Streaming {
 		// Add the cost to build the hash table.
-		cost += memo.Cost(inputRowCount) * cpuCostFactor
+		cost += memo.Cost(inputRowCount) * c.cpuCostFactor
 
 		// Add a cost for buffering rows that takes into account increased memory
 		// pressure and the possibility of spilling to disk.
@@ -1484,25 +1527,25 @@ func (c *coster) computeGroupingCost(grouping memo.RelExpr, required *physical.R
 
 func (c *coster) computeLimitCost(limit *memo.LimitExpr) memo.Cost {
 	// Add the CPU cost of emitting the rows.
-	cost := memo.Cost(limit.Relational().Statistics().RowCount) * cpuCostFactor
+	cost := memo.Cost(limit.Relational().Statistics().RowCount) * c.cpuCostFactor
 	return cost
 }
 
 func (c *coster) computeOffsetCost(offset *memo.OffsetExpr) memo.Cost {
 	// Add the CPU cost of emitting the rows.
-	cost := memo.Cost(offset.Relational().Statistics().RowCount) * cpuCostFactor
+	cost := memo.Cost(offset.Relational().Statistics().RowCount) * c.cpuCostFactor
 	return cost
 }
 
 func (c *coster) computeOrdinalityCost(ord *memo.OrdinalityExpr) memo.Cost {
 	// Add the CPU cost of emitting the rows.
-	cost := memo.Cost(ord.Relational().Statistics().RowCount) * cpuCostFactor
+	cost := memo.Cost(ord.Relational().Statistics().RowCount) * c.cpuCostFactor
 	return cost
 }
 
 func (c *coster) computeProjectSetCost(projectSet *memo.ProjectSetExpr) memo.Cost {
 	// Add the CPU cost of emitting the rows.
-	cost := memo.Cost(projectSet.Relational().Statistics().RowCount) * cpuCostFactor
+	cost := memo.Cost(projectSet.Relational().Statistics().RowCount) * c.cpuCostFactor
 	return cost
 }
 
@@ -1553,16 +1596,16 @@ func (c *coster) rowCmpCost(numKeyCols int) memo.Cost {
 	//   cpuCostFactor * [ 1 + Sum eqProb^(i-1) with i=1 to numKeyCols ]
 	//
 	const eqProb = 0.1
-	cost := cpuCostFactor
-	for i, c := 0, cpuCostFactor; i < numKeyCols; i, c = i+1, c*eqProb {
-		// c is cpuCostFactor * eqProb^i.
-		cost += c
+	cost := c.cpuCostFactor
+	for i, f := 0, c.cpuCostFactor; i < numKeyCols; i, f = i+1, f*eqProb {
+		// f is cpuCostFactor * eqProb^i.
+		cost += f
 	}
 
 	// There is a fixed "non-comparison" cost and a comparison cost proportional
 	// to the key columns. Note that the cost has to be high enough so that a
 	// sort is almost always more expensive than a reverse scan or an index scan.
-	return memo.Cost(cost)
+	return cost
 }
 
 // rowScanCost is the CPU cost to scan one row, which depends on the average
@@ -1582,14 +1625,14 @@ func (c *coster) rowScanCost(tabID opt.TableID, idxOrd int, scannedCols opt.ColS
 
 	// Adjust cost based on how well the current locality matches the index's
 	// zone constraints.
-	var costFactor memo.Cost = cpuCostFactor
+	costFactor := c.cpuCostFactor
 	if !tab.IsVirtualTable() && len(c.locality.Tiers) != 0 {
 		// If 0% of locality tiers have matching constraints, then add additional
 		// cost. If 100% of locality tiers have matching constraints, then add no
 		// additional cost. Anything in between is proportional to the number of
 		// matches.
 		adjustment := 1.0 - localityMatchScore(idx.Zone(), c.locality)
-		costFactor += latencyCostFactor * memo.Cost(adjustment)
+		costFactor += c.latencyCostFactor() * memo.Cost(adjustment)
 	}
 
 	// The number of the columns in the index matter because more columns means
@@ -1655,7 +1698,7 @@ func (c *coster) rowBufferCost(rowCount float64) memo.Cost {
 		fraction = memo.Cost(rowCount-noSpillRowCount) / (spillRowCount - noSpillRowCount)
 	}
 
-	return memo.Cost(rowCount) * spillCostFactor * fraction
+	return memo.Cost(rowCount) * c.spillCostFactor() * fraction
 }
 
 // largeCardinalityCostPenalty returns a penalty that should be added to the
@@ -1894,11 +1937,11 @@ func topKInputLimitHint(
 // a single input row. It accounts for the random IOs incurred for each span
 // (multiple spans mean multiple IOs). It also accounts for the extra CPU cost
 // of the lookupExpr, if there is one.
-func indexLookupJoinPerLookupCost(join memo.RelExpr) memo.Cost {
+func (c *coster) indexLookupJoinPerLookupCost(join memo.RelExpr) memo.Cost {
 	// The rows in the (left) input are used to probe into the (right) table.
 	// Since the matching rows in the table may not all be in the same range,
 	// this counts as random I/O.
-	cost := memo.Cost(randIOCostFactor)
+	cost := c.randIOCostFactor
 	lookupJoin, ok := join.(*memo.LookupJoinExpr)
 	if ok && len(lookupJoin.LookupExpr) > 0 {
 		numSpans := 1
@@ -1928,13 +1971,13 @@ func indexLookupJoinPerLookupCost(join memo.RelExpr) memo.Cost {
 		}
 		if numSpans > 1 {
 			// Account for the random IO incurred by looking up the extra spans.
-			cost += memo.Cost(randIOCostFactor * (numSpans - 1))
+			cost += memo.Cost(numSpans-1) * c.randIOCostFactor
 		}
 		// 1.1 is a fudge factor that pushes some plans over the edge when choosing
 		// between a partial index vs full index plus lookup expr in the
 		// regional_by_row.
 		// TODO(treilly): do some empirical analysis and model this better
-		cost += cpuCostFactor * memo.Cost(len(lookupJoin.LookupExpr)) * 1.1
+		cost += c.cpuCostFactor * memo.Cost(len(lookupJoin.LookupExpr)) * 1.1
 	}
 	return cost
 }
@@ -363,7 +363,7 @@ func (c *CustomFuncs) splitScanIntoUnionScansOrSelects(
 	if limit > 0 {
 		nLogN := rowCount * math.Log2(rowCount)
 		if scan.Relational().Statistics().Available &&
-			float64(scanCount*randIOCostFactor+limit*seqIOCostFactor) >= nLogN {
+			float64(scanCount*defaultRandIOCostFactor+limit*defaultSeqIOCostFactor) >= nLogN {
 			// Splitting the Scan may not be worth the overhead. Creating a sequence of
 			// Scans and Unions is expensive, so we only want to create the plan if it
 			// is likely to be used. This rewrite is competing against a plan with a
@@ -0,0 +1,95 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// GenericRulesEnabled returns true if the rules which optimize generic query
+// plans are enabled. They are disabled if plan_cache_mode is
+// force_custom_plan, since placeholders are then always replaced by their
+// values before a query is fully optimized.
+func (c *CustomFuncs) GenericRulesEnabled() bool {
+	return c.e.evalCtx.SessionData().PlanCacheMode != sessiondatapb.PlanCacheModeForceCustom
+}
+
+// HasPlaceholders returns true if the given filters contain a placeholder.
+func (c *CustomFuncs) HasPlaceholders(filters memo.FiltersExpr) bool {
+	for i := range filters {
+		if filters[i].ScalarProps().HasPlaceholder {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateParameterizedJoinValuesAndFilters returns a single-row Values
+// expression which produces the values of the placeholders in the given
+// filters, along with a copy of the filters in which the placeholders are
+// replaced by the columns of the Values. ok is false if the filters do not
+// contain placeholders.
+func (c *CustomFuncs) GenerateParameterizedJoinValuesAndFilters(
+	filters memo.FiltersExpr,
+) (values memo.RelExpr, newFilters memo.FiltersExpr, ok bool) {
+	md := c.e.f.Metadata()
+	var exprs memo.ScalarListExpr
+	var cols opt.ColList
+	var typs []*types.T
+	placeholderCols := make(map[tree.PlaceholderIdx]opt.ColumnID)
+
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if p, ok := e.(*memo.PlaceholderExpr); ok {
+			idx := p.Value.(*tree.Placeholder).Idx
+			col, ok := placeholderCols[idx]
+			if !ok {
+				col = md.AddColumn(fmt.Sprintf("param%d", idx+1), p.DataType())
+				placeholderCols[idx] = col
+				exprs = append(exprs, p)
+				cols = append(cols, col)
+				typs = append(typs, p.DataType())
+			}
+			return c.e.f.ConstructVariable(col)
+		}
+		return c.e.f.Replace(e, replace)
+	}
+	newFilters = make(memo.FiltersExpr, len(filters))
+	for i := range filters {
+		newFilters[i] = c.e.f.ConstructFiltersItem(replace(filters[i].Condition).(opt.ScalarExpr))
+	}
+	if len(cols) == 0 {
+		return nil, nil, false
+	}
+
+	values = c.e.f.ConstructValues(
+		memo.ScalarListExpr{c.e.f.ConstructTuple(exprs, types.MakeTuple(typs))},
+		&memo.ValuesPrivate{Cols: cols, ID: md.NextUniqueID()},
+	)
+	return values, newFilters, true
+}
+
+// ParameterizedJoinPrivate returns the JoinPrivate of the joins generated by
+// GenerateParameterizedJoin. They should be planned as lookup joins into the
+// scanned table, and must not be reordered.
+func (c *CustomFuncs) ParameterizedJoinPrivate() *memo.JoinPrivate {
+	return &memo.JoinPrivate{
+		Flags:            memo.PreferLookupJoinIntoRight,
+		SkipReorderJoins: true,
+	}
+}
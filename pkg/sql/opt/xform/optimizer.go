@@ -109,6 +109,11 @@ type Optimizer struct {
 	// a lower-cost expression). scratchSort should be accessed using
 	// getScratchSort to ensure that it is properly initialized.
 	scratchSort *memo.SortExpr
+
+	// collectCostComponents is set by CollectCostComponents, in which case
+	// Optimize computes the costComponents of the lowest cost plan.
+	collectCostComponents bool
+	costComponents        CostComponents
 }
 
 // maxGroupPasses is the maximum allowed number of optimization passes for any
@@ -273,6 +278,15 @@ func (o *Optimizer) Optimize() (_ opt.Expr, err error) {
 	// root points to the lowest cost tree by default (rather than the normalized
 	// tree by default.
 	root = o.setLowestCostTree(root, rootProps).(memo.RelExpr)
+
+	// The cost components and adaptive join thresholds are computed before
+	// SetRoot, which releases the statistics needed to compute them.
+	if o.collectCostComponents {
+		o.costComponents = o.computeCostComponents(root)
+	}
+	if o.mem.AdaptiveJoinsEnabled() {
+		o.setAdaptiveJoinThresholds(root)
+	}
 	o.mem.SetRoot(root, rootProps)
 
 	// Validate there are no dangling references.
@@ -13,6 +13,7 @@ package xform_test
 import (
 	"context"
 	"flag"
+	"math"
 	"strings"
 	"sync"
 	"testing"
@@ -133,6 +134,54 @@ func TestDetachMemoRace(t *testing.T) {
 	wg.Wait()
 }
 
+func TestComputeCostComponents(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	catalog := testcat.New()
+	if _, err := catalog.ExecuteDDL("CREATE TABLE abc (a INT PRIMARY KEY, b INT, c STRING, INDEX (c))"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	optimize := func() (memo.Cost, xform.CostComponents) {
+		t.Helper()
+		var o xform.Optimizer
+		testutils.BuildQuery(t, &o, catalog, &evalCtx,
+			"SELECT x.a, y.b FROM abc AS x INNER LOOKUP JOIN abc AS y ON x.b = y.a WHERE x.c = 'foo' ORDER BY y.b",
+		)
+		o.CollectCostComponents()
+		root, err := o.Optimize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return root.(memo.RelExpr).Cost(), o.CostComponents()
+	}
+
+	cost, components := optimize()
+	if components.CPU <= 0 || components.SeqIO <= 0 || components.RandIO <= 0 {
+		t.Fatalf("expected all cost components to be positive, got %+v", components)
+	}
+	// The components must add up to the cost of the plan with the default cost
+	// factors.
+	sum := components.Fixed + components.CPU*0.01 + components.SeqIO*1 + components.RandIO*4
+	if math.Abs(sum-float64(cost)) > 1e-6*float64(cost) {
+		t.Errorf("expected cost components to add up to %f, got %f", cost, sum)
+	}
+
+	// Doubling the calibrated CPU cost factor must increase the cost of the
+	// plan without changing its components.
+	memo.CostCalibrationEnabled.Override(ctx, &evalCtx.Settings.SV, true)
+	memo.CostCalibrationCPUCostFactor.Override(ctx, &evalCtx.Settings.SV, 0.02)
+	calibratedCost, calibratedComponents := optimize()
+	if !cost.Less(calibratedCost) {
+		t.Errorf("expected calibrated cost %f to be greater than %f", calibratedCost, cost)
+	}
+	if calibratedComponents != components {
+		t.Errorf("expected cost components %+v, got %+v", components, calibratedComponents)
+	}
+}
+
 // TestCoster files can be run separately like this:
 //
 //	./dev test pkg/sql/opt/xform -f TestCoster/sort
@@ -412,6 +412,29 @@ func (oc *optCatalog) CheckPrivilege(ctx context.Context, o cat.Object, priv pri
 	return oc.planner.CheckPrivilege(ctx, desc, priv)
 }
 
+// HasColumnPrivilege is part of the cat.Catalog interface.
+func (oc *optCatalog) HasColumnPrivilege(
+	ctx context.Context, tab cat.Table, colOrd int, priv privilege.Kind,
+) (bool, error) {
+	t, ok := tab.(*optTable)
+	if !ok {
+		return false, nil
+	}
+	col, err := catalog.MustFindColumnByID(t.desc, descpb.ColumnID(tab.Column(colOrd).ColID()))
+	if err != nil {
+		return false, err
+	}
+	if len(col.ColumnDesc().Privileges) == 0 {
+		return false, nil
+	}
+	user := oc.planner.User()
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	return priv.IsSetIn(columnPrivilegesForUser(col, user, memberOf)), nil
+}
+
 // CheckAnyPrivilege is part of the cat.Catalog interface.
 func (oc *optCatalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error {
 	desc, err := getDescFromCatalogObjectForPermissions(o)
@@ -489,6 +512,29 @@ func (oc *optCatalog) RoleExists(ctx context.Context, role username.SQLUsername)
 	return RoleExists(ctx, oc.planner.InternalSQLTxn(), role)
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.HasOwnership(ctx, desc)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	user := oc.planner.User()
+	if user == role {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -1359,6 +1405,36 @@ func (ot *optTable) GetDatabaseID() descpb.ID {
 	return ot.desc.GetParentID()
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.IsRowLevelSecurityForced()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.desc.GetPolicies())
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) *descpb.PolicyDescriptor {
+	return &ot.desc.GetPolicies()[i]
+}
+
+// IsColumnEncrypted is part of the cat.Table interface.
+func (ot *optTable) IsColumnEncrypted(i int) bool {
+	if ot.Column(i).Kind() == cat.Inverted {
+		// Inverted columns have no descriptor column of their own.
+		return false
+	}
+	col := catalog.FindColumnByID(ot.desc, descpb.ColumnID(ot.Column(i).ColID()))
+	return col != nil && col.IsEncrypted()
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -1812,6 +1888,11 @@ func (os *optTableStat) IsAuto() bool {
 	return os.stat.IsAuto()
 }
 
+// ExtendedStatistics is part of the cat.TableStatistic interface.
+func (os *optTableStat) ExtendedStatistics() *cat.ExtendedStatistics {
+	return os.stat.Extended
+}
+
 // optFamily is a wrapper around descpb.ColumnFamilyDescriptor that keeps a
 // reference to the table wrapper.
 type optFamily struct {
@@ -2348,6 +2429,31 @@ func (ot *optVirtualTable) GetDatabaseID() descpb.ID {
 	return 0
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) *descpb.PolicyDescriptor {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// IsColumnEncrypted is part of the cat.Table interface.
+func (ot *optVirtualTable) IsColumnEncrypted(i int) bool {
+	return false
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
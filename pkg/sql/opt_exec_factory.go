@@ -394,6 +394,7 @@ func (ef *execFactory) ConstructHashJoin(
 	leftEqCols, rightEqCols []exec.NodeColumnOrdinal,
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	extraOnCond tree.TypedExpr,
+	adaptiveThreshold int64,
 ) (exec.Node, error) {
 	p := ef.planner
 	leftSrc := asDataSource(left)
@@ -416,7 +417,9 @@ func (ef *execFactory) ConstructHashJoin(
 
 	pred.onCond = pred.iVarHelper.Rebind(extraOnCond)
 
-	return p.makeJoinNode(leftSrc, rightSrc, pred), nil
+	n := p.makeJoinNode(leftSrc, rightSrc, pred)
+	n.adaptiveThreshold = adaptiveThreshold
+	return n, nil
 }
 
 // ConstructApplyJoin is part of the exec.Factory interface.
@@ -709,6 +712,7 @@ func (ef *execFactory) ConstructLookupJoin(
 	locking opt.Locking,
 	limitHint int64,
 	remoteOnlyLookups bool,
+	adaptiveThreshold int64,
 ) (exec.Node, error) {
 	if table.IsVirtualTable() {
 		return ef.constructVirtualTableLookupJoin(joinType, input, table, index, eqCols, lookupCols, onCond)
@@ -744,6 +748,7 @@ func (ef *execFactory) ConstructLookupJoin(
 		reqOrdering:                ReqOrdering(reqOrdering),
 		limitHint:                  limitHint,
 		remoteOnlyLookups:          remoteOnlyLookups,
+		adaptiveThreshold:          adaptiveThreshold,
 	}
 	n.eqCols = make([]int, len(eqCols))
 	for i, c := range eqCols {
@@ -2140,11 +2145,18 @@ func (ef *execFactory) ConstructExplain(
 		return nil, errors.New("ENV only supported with (OPT) option")
 	}
 
-	plan, err := buildFn(&execFactory{
+	hypotheticalIndexes := ef.planner.optPlanningCtx.hypotheticalIndexes
+	var wrappedFactory exec.Factory = &execFactory{
 		ctx:       ef.ctx,
 		planner:   ef.planner,
 		isExplain: true,
-	})
+	}
+	if len(hypotheticalIndexes) > 0 {
+		// Plans that use hypothetical indexes cannot be executed, so only the
+		// explain.Plan is built.
+		wrappedFactory = exec.StubFactory{}
+	}
+	plan, err := buildFn(wrappedFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -2167,9 +2179,10 @@ func (ef *execFactory) ConstructExplain(
 		flags.Deflake = explain.DeflakeVolatile
 	}
 	n := &explainPlanNode{
-		options: options,
-		flags:   flags,
-		plan:    plan.(*explain.Plan),
+		options:             options,
+		flags:               flags,
+		plan:                plan.(*explain.Plan),
+		hypotheticalIndexes: hypotheticalIndexes,
 	}
 	return n, nil
 }
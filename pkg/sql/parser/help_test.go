@@ -122,12 +122,16 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER SEQUENCE blah RENAME ??`, `ALTER SEQUENCE`},
 		{`ALTER SEQUENCE blah RENAME TO blih ??`, `ALTER SEQUENCE`},
 
+		{`ALTER POLICY ??`, `ALTER POLICY`},
+		{`ALTER POLICY p ON t RENAME ??`, `ALTER POLICY`},
+
 		{`ALTER SCHEMA ??`, `ALTER SCHEMA`},
 		{`ALTER SCHEMA x RENAME ??`, `ALTER SCHEMA`},
 		{`ALTER SCHEMA x OWNER ??`, `ALTER SCHEMA`},
 
 		{`ALTER USER IF ??`, `ALTER ROLE`},
 		{`ALTER USER foo WITH PASSWORD ??`, `ALTER ROLE`},
+		{`ALTER USER foo ACCOUNT ??`, `ALTER ROLE`},
 
 		{`ALTER ROLE bleh ?? WITH NOCREATEROLE`, `ALTER ROLE`},
 
@@ -193,6 +197,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE SEQUENCE ??`, `CREATE SEQUENCE`},
 
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+
 		{`CREATE STATISTICS ??`, `CREATE STATISTICS`},
 
 		{`CREATE TABLE blah (??`, `CREATE TABLE`},
@@ -236,6 +242,9 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP ROLE IF ??`, `DROP ROLE`},
 		{`DROP ROLE IF EXISTS bluh ??`, `DROP ROLE`},
 
+		{`DROP POLICY ??`, `DROP POLICY`},
+		{`DROP POLICY IF ??`, `DROP POLICY`},
+
 		{`DROP SEQUENCE blah ??`, `DROP SEQUENCE`},
 		{`DROP SEQUENCE IF ??`, `DROP SEQUENCE`},
 		{`DROP SEQUENCE IF EXISTS blih, bloh ??`, `DROP SEQUENCE`},
@@ -525,6 +534,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`USE ??`, `USE`},
 
+		{`VERIFY ??`, `VERIFY AUDIT LOG`},
+		{`VERIFY AUDIT LOG ??`, `VERIFY AUDIT LOG`},
+
 		{`RESET blah ??`, `RESET`},
 		{`RESET SESSION ??`, `RESET`},
 		{`RESET CLUSTER SETTING ??`, `RESET CLUSTER SETTING`},
@@ -205,7 +205,7 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			}
 		}
 
-	case NOT, WITH, AS, GENERATED, NULLS, RESET, ROLE, USER, ON, TENANT, CLUSTER, SET:
+	case NOT, WITH, AS, GENERATED, NULLS, RESET, ROLE, USER, ON, TENANT, CLUSTER, SET, ANALYZE, ANALYSE, ENCRYPTED:
 		nextToken := sqlSymType{}
 		if l.lastPos+1 < len(l.tokens) {
 			nextToken = l.tokens[l.lastPos+1]
@@ -241,6 +241,11 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			case BY:
 				lval.id = GENERATED_BY_DEFAULT
 			}
+		case ENCRYPTED:
+			switch nextToken.id {
+			case WITH:
+				lval.id = ENCRYPTED_WITH
+			}
 
 		case WITH:
 			switch nextToken.id {
@@ -287,6 +292,11 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			case ALL:
 				lval.id = CLUSTER_ALL
 			}
+		case ANALYZE, ANALYSE:
+			switch nextToken.id {
+			case WORKLOAD:
+				lval.id = ANALYZE_WORKLOAD
+			}
 		case SET:
 			switch nextToken.id {
 			case TRACING:
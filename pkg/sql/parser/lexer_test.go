@@ -28,6 +28,8 @@ func TestLexer(t *testing.T) {
 		{`NOT SIMILAR`, []int{NOT_LA, SIMILAR}},
 		{`AS OF SYSTEM TIME`, []int{AS_LA, OF, SYSTEM, TIME}},
 		{`AS OF`, []int{AS, OF}},
+		{`ENCRYPTED WITH KEY`, []int{ENCRYPTED_WITH, WITH, KEY}},
+		{`ENCRYPTED PASSWORD`, []int{ENCRYPTED, PASSWORD}},
 	}
 	for i, d := range testData {
 		s := makeSQLScanner(d.sql)
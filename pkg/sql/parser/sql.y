@@ -552,6 +552,12 @@ func (u *sqlSymUnion) privilegeType() privilege.Kind {
 func (u *sqlSymUnion) privilegeList() privilege.List {
     return u.val.(privilege.List)
 }
+func (u *sqlSymUnion) columnPrivilege() tree.ColumnPrivilege {
+    return u.val.(tree.ColumnPrivilege)
+}
+func (u *sqlSymUnion) columnPrivilegeList() tree.ColumnPrivilegeList {
+    return u.val.(tree.ColumnPrivilegeList)
+}
 func (u *sqlSymUnion) onConflict() *tree.OnConflict {
     return u.val.(*tree.OnConflict)
 }
@@ -642,6 +648,18 @@ func (u *sqlSymUnion) idxElems() tree.IndexElemList {
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
+func (u *sqlSymUnion) policyType() tree.PolicyType {
+    return u.val.(tree.PolicyType)
+}
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
+func (u *sqlSymUnion) policyExprs() tree.PolicyExpressions {
+    return u.val.(tree.PolicyExpressions)
+}
+func (u *sqlSymUnion) tableRLSMode() tree.TableRLSMode {
+    return u.val.(tree.TableRLSMode)
+}
 func (u *sqlSymUnion) validationBehavior() tree.ValidationBehavior {
     return u.val.(tree.ValidationBehavior)
 }
@@ -899,9 +917,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACCESS ACCOUNT ACTION ADD ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
-%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUDIT AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
@@ -919,9 +937,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISABLE DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -955,7 +973,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOG LOGIN LOOKUP LOW LSHIFT
 
 %token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
@@ -972,8 +990,8 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PERMISSIVE PHYSICAL PLACEMENT PLACING
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PUBLIC PUBLICATION
 
@@ -982,8 +1000,8 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
-%token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROTATE ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
@@ -1000,14 +1018,14 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> TRUNCATE TRUSTED TYPE TYPES
 %token <str> TRACING
 
-%token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLISTEN UNLOGGED UNSAFE_RESTORE_INCOMPATIBLE_VERSION UNSPLIT
+%token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLISTEN UNLOCK UNLOGGED UNSAFE_RESTORE_INCOMPATIBLE_VERSION UNSPLIT
 %token <str> UPDATE UPSERT UNSET UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VERIFY_BACKUP_TABLE_DATA VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VERIFY VERIFY_BACKUP_TABLE_DATA VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WORKLOAD WRITE
 
 %token <str> YEAR
 
@@ -1032,8 +1050,12 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 // references.
 // - TENANT_ALL is used to differentiate `ALTER TENANT <id>` from
 // `ALTER TENANT ALL`. Ditto `CLUSTER_ALL` and `CLUSTER ALL`.
+// - ANALYZE_WORKLOAD is used to differentiate `ANALYZE <tablename>` from
+// `ANALYZE WORKLOAD`.
+// - ENCRYPTED_WITH is needed to differentiate `CREATE FAMILY ENCRYPTED WITH
+// KEY ...` from `CREATE FAMILY encrypted`.
 %token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT RESET_ALL ROLE_ALL
-%token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING
+%token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING ANALYZE_WORKLOAD ENCRYPTED_WITH
 
 %union {
   id    int32
@@ -1054,6 +1076,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> alter_index_stmt
 %type <tree.Statement> alter_view_stmt
 %type <tree.Statement> alter_sequence_stmt
+%type <tree.Statement> alter_policy_stmt
 %type <tree.Statement> alter_database_stmt
 %type <tree.Statement> alter_range_stmt
 %type <tree.Statement> alter_partition_stmt
@@ -1195,6 +1218,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
 %type <tree.Statement> create_stats_stmt
+%type <tree.Statement> create_policy_stmt
 %type <*tree.CreateStatsOptions> opt_create_stats_options
 %type <*tree.CreateStatsOptions> create_stats_option_list
 %type <*tree.CreateStatsOptions> create_stats_option
@@ -1219,6 +1243,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <bool>           opt_immediate
 
 %type <tree.Statement> analyze_stmt
+%type <tree.Statement> analyze_workload_stmt
 %type <tree.Statement> explain_stmt
 %type <tree.Statement> prepare_stmt
 %type <tree.Statement> preparable_stmt
@@ -1235,6 +1260,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.Select>   for_schedules_clause
 %type <tree.Statement> reassign_owned_by_stmt
 %type <tree.Statement> drop_owned_by_stmt
+%type <tree.Statement> drop_policy_stmt
 %type <tree.Statement> release_stmt
 %type <tree.Statement> reset_stmt reset_session_stmt reset_csetting_stmt
 %type <tree.Statement> resume_stmt resume_jobs_stmt resume_schedules_stmt resume_all_jobs_stmt
@@ -1319,6 +1345,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
 %type <tree.Statement> use_stmt
+%type <tree.Statement> verify_audit_log_stmt
 
 %type <tree.Statement> close_cursor_stmt
 %type <tree.Statement> declare_cursor_stmt
@@ -1442,7 +1469,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <empty> opt_privileges_clause
 %type <bool> distinct_clause opt_with_data
 %type <tree.DistinctOn> distinct_on_clause
-%type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
+%type <tree.NameList> opt_column_list insert_column_list opt_stats_kinds opt_stats_columns query_stats_cols
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params create_as_params
@@ -1636,8 +1663,15 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.GrantTargetList> opt_on_targets_roles
 %type <tree.RoleSpecList> for_grantee_clause
 %type <privilege.List> privileges
+%type <tree.ColumnPrivilege> column_privilege
+%type <tree.ColumnPrivilegeList> column_privileges
 %type <[]tree.KVOption> opt_role_options role_options
 %type <tree.AuditMode> audit_mode
+%type <tree.TableRLSMode> table_rls_mode
+%type <tree.PolicyType> opt_policy_type
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.PolicyExpressions> opt_policy_exprs
 
 %type <str> relocate_kw
 %type <tree.RelocateSubject> relocate_subject relocate_subject_nonlease
@@ -1795,6 +1829,7 @@ stmt_without_legacy_transaction:
 | reindex_stmt
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
+| verify_audit_log_stmt      // EXTEND WITH HELP: VERIFY AUDIT LOG
 
 // %Help: ALTER
 // %Category: Group
@@ -1821,6 +1856,7 @@ alter_ddl_stmt:
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
+| alter_policy_stmt             // EXTEND WITH HELP: ALTER POLICY
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -1854,12 +1890,15 @@ alter_ddl_stmt:
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY
+//   ALTER TABLE ... ROTATE ENCRYPTION KEYS
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
 //   FAMILY <familyname>, CREATE [IF NOT EXISTS] FAMILY [<familyname>]
 //   REFERENCES <tablename> [( <colnames...> )]
 //   COLLATE <collationname>
+//   ENCRYPTED WITH KEY <kms_uri>
 //
 // Zone configurations:
 //   DISCARD
@@ -1923,6 +1962,35 @@ alter_view_stmt:
 // prefix is spread over multiple non-terminals.
 | ALTER VIEW error // SHOW HELP: ALTER VIEW
 
+// %Help: ALTER POLICY - change the definition of a row-level security policy
+// %Category: DDL
+// %Text:
+// ALTER POLICY <name> ON <tablename> RENAME TO <newname>
+// ALTER POLICY <name> ON <tablename>
+//   [TO {<role> | PUBLIC | CURRENT_USER | SESSION_USER} [, ...]]
+//   [USING (<expr>)]
+//   [WITH CHECK (<expr>)]
+// %SeeAlso: CREATE POLICY, DROP POLICY
+alter_policy_stmt:
+  ALTER POLICY name ON table_name RENAME TO name
+  {
+    $$.val = &tree.AlterPolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      NewPolicyName: tree.Name($8),
+    }
+  }
+| ALTER POLICY name ON table_name opt_policy_roles opt_policy_exprs
+  {
+    $$.val = &tree.AlterPolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      Roles: $6.roleSpecList(),
+      Exprs: $7.policyExprs(),
+    }
+  }
+| ALTER POLICY error // SHOW HELP: ALTER POLICY
+
 // %Help: ALTER SEQUENCE - change the definition of a sequence
 // %Category: DDL
 // %Text:
@@ -2855,11 +2923,27 @@ alter_table_cmd:
       Params: $3.storageParamKeys(),
     }
   }
+  // ALTER TABLE <name> {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY
+| table_rls_mode ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRLSMode{Mode: $1.tableRLSMode()}
+  }
+  // ALTER TABLE <name> ROTATE ENCRYPTION KEYS
+| ROTATE ENCRYPTION KEYS
+  {
+    $$.val = &tree.AlterTableRotateEncryptionKeys{}
+  }
 
 audit_mode:
   READ WRITE { $$.val = tree.AuditModeReadWrite }
 | OFF        { $$.val = tree.AuditModeDisable }
 
+table_rls_mode:
+  ENABLE   { $$.val = tree.TableRLSEnable }
+| DISABLE  { $$.val = tree.TableRLSDisable }
+| FORCE    { $$.val = tree.TableRLSForce }
+| NO FORCE { $$.val = tree.TableRLSNoForce }
+
 alter_index_cmds:
   alter_index_cmd
   {
@@ -3113,7 +3197,7 @@ alter_attribute_action:
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
-// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA]
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA | INCREMENTAL]
 refresh_stmt:
   REFRESH MATERIALIZED VIEW opt_concurrently view_name opt_clear_data
   {
@@ -3134,6 +3218,10 @@ opt_clear_data:
   {
     $$.val = tree.RefreshDataClear
   }
+| INCREMENTAL
+  {
+    $$.val = tree.RefreshDataIncremental
+  }
 | /* EMPTY */
   {
     $$.val = tree.RefreshDataDefault
@@ -5057,25 +5145,102 @@ create_ddl_stmt:
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
+
+// %Help: CREATE POLICY - create a new row-level security policy
+// %Category: DDL
+// %Text:
+// CREATE POLICY <name> ON <tablename>
+//   [AS {PERMISSIVE | RESTRICTIVE}]
+//   [FOR {ALL | SELECT | INSERT | UPDATE | DELETE}]
+//   [TO {<role> | PUBLIC | CURRENT_USER | SESSION_USER} [, ...]]
+//   [USING (<expr>)]
+//   [WITH CHECK (<expr>)]
+// %SeeAlso: ALTER POLICY, DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_type opt_policy_command opt_policy_roles opt_policy_exprs
+  {
+    $$.val = &tree.CreatePolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      Type: $6.policyType(),
+      Cmd: $7.policyCommand(),
+      Roles: $8.roleSpecList(),
+      Exprs: $9.policyExprs(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_type:
+  AS PERMISSIVE  { $$.val = tree.PolicyTypePermissive }
+| AS RESTRICTIVE { $$.val = tree.PolicyTypeRestrictive }
+| /* EMPTY */    { $$.val = tree.PolicyTypeDefault }
+
+opt_policy_command:
+  FOR ALL     { $$.val = tree.PolicyCommandAll }
+| FOR SELECT  { $$.val = tree.PolicyCommandSelect }
+| FOR INSERT  { $$.val = tree.PolicyCommandInsert }
+| FOR UPDATE  { $$.val = tree.PolicyCommandUpdate }
+| FOR DELETE  { $$.val = tree.PolicyCommandDelete }
+| /* EMPTY */ { $$.val = tree.PolicyCommandDefault }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_exprs:
+  USING '(' a_expr ')' WITH CHECK '(' a_expr ')'
+  {
+    $$.val = tree.PolicyExpressions{Using: $3.expr(), WithCheck: $8.expr()}
+  }
+| USING '(' a_expr ')'
+  {
+    $$.val = tree.PolicyExpressions{Using: $3.expr()}
+  }
+| WITH CHECK '(' a_expr ')'
+  {
+    $$.val = tree.PolicyExpressions{WithCheck: $4.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyExpressions{}
+  }
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
 // %Text:
-// CREATE STATISTICS <statisticname>
+// CREATE STATISTICS <statisticname> [(<kind> [, ...])]
 //   [ON <colname> [, ...]]
 //   FROM <tablename> [AS OF SYSTEM TIME <expr>]
 create_stats_stmt:
-  CREATE STATISTICS statistics_name opt_stats_columns FROM create_stats_target opt_create_stats_options
+  CREATE STATISTICS statistics_name opt_stats_kinds opt_stats_columns FROM create_stats_target opt_create_stats_options
   {
     $$.val = &tree.CreateStats{
       Name: tree.Name($3),
-      ColumnNames: $4.nameList(),
-      Table: $6.tblExpr(),
-      Options: *$7.createStatsOptions(),
+      Kinds: $4.nameList(),
+      ColumnNames: $5.nameList(),
+      Table: $7.tblExpr(),
+      Options: *$8.createStatsOptions(),
     }
   }
 | CREATE STATISTICS error // SHOW HELP: CREATE STATISTICS
 
+opt_stats_kinds:
+  '(' name_list ')'
+  {
+    $$.val = $2.nameList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.NameList(nil)
+  }
+
 opt_stats_columns:
   ON name_list
   {
@@ -5442,6 +5607,7 @@ drop_ddl_stmt:
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5476,6 +5642,30 @@ drop_view_stmt:
   }
 | DROP VIEW error // SHOW HELP: DROP VIEW
 
+// %Help: DROP POLICY - remove a row-level security policy
+// %Category: DDL
+// %Text: DROP POLICY [IF EXISTS] <name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE POLICY, ALTER POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      PolicyName: tree.Name($5),
+      TableName: $7.unresolvedObjectName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
 // %Help: DROP SEQUENCE - remove a sequence
 // %Category: DDL
 // %Text: DROP SEQUENCE [IF EXISTS] <sequenceName> [, ...] [CASCADE | RESTRICT]
@@ -5680,10 +5870,15 @@ sequence_name_list:
 view_name_list:
   db_object_name_list
 
-// %Help: ANALYZE - collect table statistics
+// %Help: ANALYZE - collect table statistics or recommend indexes for the workload
 // %Category: Misc
 // %Text:
 // ANALYZE <tablename>
+// ANALYZE WORKLOAD
+//
+// ANALYZE WORKLOAD replays the most frequently executed statements of the
+// workload and recommends the indexes to create, drop or make visible,
+// ranked by their estimated savings.
 //
 // %SeeAlso: CREATE STATISTICS
 analyze_stmt:
@@ -5702,6 +5897,12 @@ analyze_stmt:
   }
 | ANALYSE error // SHOW HELP: ANALYZE
 
+analyze_workload_stmt:
+  ANALYZE_WORKLOAD WORKLOAD
+  {
+    $$.val = &tree.AnalyzeWorkload{}
+  }
+
 analyze_target:
   table_name
   {
@@ -5788,6 +5989,7 @@ explainable_stmt:
 
 preparable_stmt:
   alter_stmt     // help texts in sub-rule
+| analyze_workload_stmt // EXTEND WITH HELP: ANALYZE
 | backup_stmt    // EXTEND WITH HELP: BACKUP
 | cancel_stmt    // help texts in sub-rule
 | create_stmt    // help texts in sub-rule
@@ -5815,7 +6017,8 @@ preparable_stmt:
 // These are statements that can be used as a data source using the special
 // syntax with brackets. These are a subset of preparable_stmt.
 row_source_extension_stmt:
-  delete_stmt       // EXTEND WITH HELP: DELETE
+  analyze_workload_stmt // EXTEND WITH HELP: ANALYZE
+| delete_stmt       // EXTEND WITH HELP: DELETE
 | explain_stmt      // EXTEND WITH HELP: EXPLAIN
 | insert_stmt       // EXTEND WITH HELP: INSERT
 | select_stmt       // help texts in sub-rule
@@ -6137,6 +6340,8 @@ deallocate_stmt:
 // %Text:
 // Grant privileges:
 //   GRANT {ALL [PRIVILEGES] | <privileges...> } ON <targets...> TO <grantees...>
+// Grant column privileges:
+//   GRANT <privilege> (<columns...>) [, ...] ON [TABLE] <tablename> [, ...] TO <grantees...>
 // Grant role membership:
 //   GRANT <roles...> TO <grantees...> [WITH ADMIN OPTION]
 //
@@ -6157,6 +6362,10 @@ grant_stmt:
   {
     $$.val = &tree.Grant{Privileges: $2.privilegeList(), Grantees: $6.roleSpecList(), Targets: $4.grantTargetList(), WithGrantOption: $7.bool(),}
   }
+| GRANT column_privileges ON grant_targets TO role_spec_list opt_with_grant_option
+  {
+    $$.val = &tree.Grant{ColumnPrivileges: $2.columnPrivilegeList(), Grantees: $6.roleSpecList(), Targets: $4.grantTargetList(), WithGrantOption: $7.bool(),}
+  }
 | GRANT privilege_list TO role_spec_list
   {
     $$.val = &tree.GrantRole{Roles: $2.nameList(), Members: $4.roleSpecList(), AdminOption: false}
@@ -6238,6 +6447,8 @@ grant_stmt:
 // %Text:
 // Revoke privileges:
 //   REVOKE {ALL | <privileges...> } ON <targets...> FROM <grantees...>
+// Revoke column privileges:
+//   REVOKE [GRANT OPTION FOR] <privilege> (<columns...>) [, ...] ON [TABLE] <tablename> [, ...] FROM <grantees...>
 // Revoke role membership:
 //   REVOKE [ADMIN OPTION FOR] <roles...> FROM <grantees...>
 //
@@ -6262,6 +6473,14 @@ revoke_stmt:
   {
     $$.val = &tree.Revoke{Privileges: $5.privilegeList(), Grantees: $9.roleSpecList(), Targets: $7.grantTargetList(), GrantOptionFor: true}
   }
+| REVOKE column_privileges ON grant_targets FROM role_spec_list
+  {
+    $$.val = &tree.Revoke{ColumnPrivileges: $2.columnPrivilegeList(), Grantees: $6.roleSpecList(), Targets: $4.grantTargetList(), GrantOptionFor: false}
+  }
+| REVOKE GRANT OPTION FOR column_privileges ON grant_targets FROM role_spec_list
+  {
+    $$.val = &tree.Revoke{ColumnPrivileges: $5.columnPrivilegeList(), Grantees: $9.roleSpecList(), Targets: $7.grantTargetList(), GrantOptionFor: true}
+  }
 | REVOKE privilege_list FROM role_spec_list
   {
     $$.val = &tree.RevokeRole{Roles: $2.nameList(), Members: $4.roleSpecList(), AdminOption: false }
@@ -6413,6 +6632,26 @@ privilege_list:
     $$.val = append($1.nameList(), tree.Name($3))
   }
 
+column_privileges:
+  column_privilege
+  {
+    $$.val = tree.ColumnPrivilegeList{$1.columnPrivilege()}
+  }
+| column_privileges ',' column_privilege
+  {
+    $$.val = append($1.columnPrivilegeList(), $3.columnPrivilege())
+  }
+
+column_privilege:
+  privilege '(' name_list ')'
+  {
+    privList, err := privilege.ListFromStrings([]string{$1})
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = tree.ColumnPrivilege{Privilege: privList[0], Columns: $3.nameList()}
+  }
+
 // Privileges are parsed at execution time to avoid having to make them reserved.
 // Any privileges above `col_name_keyword` should be listed here.
 // The full list is in sql/privilege/privilege.go.
@@ -6420,6 +6659,7 @@ privilege:
   name
 | CREATE
 | GRANT
+| REFERENCES
 | SELECT
 
 reset_stmt:
@@ -7956,6 +8196,20 @@ show_commit_timestamp_stmt:
     $$.val = &tree.ShowCommitTimestamp{}
   }
 
+// %Help: VERIFY AUDIT LOG - check the integrity of the audit log table
+// %Category: Misc
+// %Text: VERIFY AUDIT LOG
+//
+// Recomputes the hash chains of the system.audit_log table and reports,
+// for each SQL instance, the range of records present in the table and
+// the first record found to be missing or modified, if any.
+verify_audit_log_stmt:
+  VERIFY AUDIT LOG
+  {
+    $$.val = &tree.VerifyAuditLog{}
+  }
+| VERIFY error // SHOW HELP: VERIFY AUDIT LOG
+
 // %Help: SHOW CONSTRAINTS - list constraints
 // %Category: DDL
 // %Text: SHOW CONSTRAINTS FROM <tablename>
@@ -9327,6 +9581,7 @@ alter_schema_stmt:
 //   FAMILY <familyname>, CREATE [IF NOT EXISTS] FAMILY [<familyname>]
 //   REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //   COLLATE <collationname>
+//   ENCRYPTED WITH KEY <kms_uri>
 //   AS ( <expr> ) { STORED | VIRTUAL }
 //
 // On commit clause:
@@ -9773,6 +10028,10 @@ col_qualification:
   {
     $$.val = tree.NamedColumnQualification{Qualification: &tree.ColumnFamilyConstraint{Family: tree.Name($6), Create: true, IfNotExists: true}}
   }
+| ENCRYPTED_WITH WITH KEY SCONST
+  {
+    $$.val = tree.NamedColumnQualification{Qualification: &tree.ColumnEncryption{KMSURI: $4}}
+  }
 
 // DEFAULT NULL is already the default for Postgres. But define it here and
 // carry it forward into the system to make it explicit.
@@ -10443,6 +10702,7 @@ create_role_stmt:
 // %Category: Priv
 // %Text:
 // ALTER ROLE <name> [WITH] <options...>
+// ALTER ROLE <name> ACCOUNT UNLOCK
 // ALTER ROLE { name | ALL } [ IN DATABASE database_name ] SET var { TO | = } { value | DEFAULT }
 // ALTER ROLE { name | ALL } [ IN DATABASE database_name ] RESET { var | ALL }
 // %SeeAlso: CREATE ROLE, DROP ROLE, SHOW ROLES
@@ -10455,6 +10715,14 @@ alter_role_stmt:
 {
   $$.val = &tree.AlterRole{Name: $5.roleSpec(), IfExists: true, KVOptions: $6.kvOptions(), IsRole: $2.bool()}
 }
+| ALTER role_or_group_or_user role_spec ACCOUNT UNLOCK
+{
+  $$.val = &tree.AlterRoleUnlock{Name: $3.roleSpec(), IsRole: $2.bool()}
+}
+| ALTER role_or_group_or_user IF EXISTS role_spec ACCOUNT UNLOCK
+{
+  $$.val = &tree.AlterRoleUnlock{Name: $5.roleSpec(), IfExists: true, IsRole: $2.bool()}
+}
 | ALTER role_or_group_or_user role_spec opt_in_database set_or_reset_clause
   {
     $$.val = &tree.AlterRoleSet{RoleName: $3.roleSpec(), DatabaseName: tree.Name($4), IsRole: $2.bool(), SetOrReset: $5.setVar()}
@@ -16519,6 +16787,7 @@ unreserved_keyword:
 | ABSOLUTE
 | ACTION
 | ACCESS
+| ACCOUNT
 | ADD
 | ADMIN
 | AFTER
@@ -16530,6 +16799,7 @@ unreserved_keyword:
 | AT
 | ATOMIC
 | ATTRIBUTE
+| AUDIT
 | AUTOMATIC
 | AVAILABILITY
 | BACKUP
@@ -16603,12 +16873,15 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| ENABLE
 | ENCODING
 | ENCRYPTED
+| ENCRYPTION
 | ENCRYPTION_PASSPHRASE
 | ENCRYPTION_INFO_DIR
 | ENUM
@@ -16709,6 +16982,7 @@ unreserved_keyword:
 | LIST
 | LOCAL
 | LOCKED
+| LOG
 | LOGIN
 | LOCALITY
 | LOOKUP
@@ -16787,6 +17061,7 @@ unreserved_keyword:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLAN
@@ -16794,6 +17069,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -16835,6 +17111,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -16846,6 +17123,7 @@ unreserved_keyword:
 | ROLES
 | ROLLBACK
 | ROLLUP
+| ROTATE
 | ROUTINES
 | ROWS
 | RULE
@@ -16940,6 +17218,7 @@ unreserved_keyword:
 | UNCOMMITTED
 | UNKNOWN
 | UNLISTEN
+| UNLOCK
 | UNLOGGED
 | UNSAFE_RESTORE_INCOMPATIBLE_VERSION
 | UNSET
@@ -16953,6 +17232,7 @@ unreserved_keyword:
 | VALIDATE
 | VALUE
 | VARYING
+| VERIFY
 | VERIFY_BACKUP_TABLE_DATA
 | VIEW
 | VIEWACTIVITY
@@ -16968,6 +17248,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WORKLOAD
 | WRITE
 | YEAR
 | ZONE
@@ -16980,6 +17261,7 @@ bare_label_keywords:
   ABORT
 | ABSOLUTE
 | ACCESS
+| ACCOUNT
 | ACTION
 | ADD
 | ADMIN
@@ -17000,6 +17282,7 @@ bare_label_keywords:
 | AT
 | ATOMIC
 | ATTRIBUTE
+| AUDIT
 | AUTHORIZATION
 | AUTOMATIC
 | AVAILABILITY
@@ -17101,6 +17384,7 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DISTINCT
 | DO
@@ -17108,8 +17392,10 @@ bare_label_keywords:
 | DOUBLE
 | DROP
 | ELSE
+| ENABLE
 | ENCODING
 | ENCRYPTED
+| ENCRYPTION
 | ENCRYPTION_INFO_DIR
 | ENCRYPTION_PASSPHRASE
 | END
@@ -17248,6 +17534,7 @@ bare_label_keywords:
 | LOCALTIME
 | LOCALTIMESTAMP
 | LOCKED
+| LOG
 | LOGIN
 | LOOKUP
 | LOW
@@ -17332,6 +17619,7 @@ bare_label_keywords:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLACING
@@ -17341,6 +17629,7 @@ bare_label_keywords:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGON
 | POLYGONM
 | POLYGONZ
@@ -17387,6 +17676,7 @@ bare_label_keywords:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -17399,6 +17689,7 @@ bare_label_keywords:
 | ROLES
 | ROLLBACK
 | ROLLUP
+| ROTATE
 | ROUTINES
 | ROW
 | ROWS
@@ -17513,6 +17804,7 @@ bare_label_keywords:
 | UNIQUE
 | UNKNOWN
 | UNLISTEN
+| UNLOCK
 | UNLOGGED
 | UNSAFE_RESTORE_INCOMPATIBLE_VERSION
 | UNSET
@@ -17531,6 +17823,7 @@ bare_label_keywords:
 | VARBIT
 | VARCHAR
 | VARIADIC
+| VERIFY
 | VERIFY_BACKUP_TABLE_DATA
 | VIEW
 | VIEWACTIVITY
@@ -17547,6 +17840,7 @@ bare_label_keywords:
 | VOTERS
 | WHEN
 | WORK
+| WORKLOAD
 | WRITE
 | ZONE
 
@@ -0,0 +1,274 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/security/password"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// passwordPolicyTablesActive returns whether system.password_history and
+// system.login_lockouts can be used.
+func passwordPolicyTablesActive(ctx context.Context, execCfg *ExecutorConfig) bool {
+	return execCfg.Settings.Version.IsActive(ctx, clusterversion.V23_2_PasswordPolicyTables)
+}
+
+// checkPasswordPolicy checks that the given cleartext password satisfies the
+// complexity rules and that it is not one of the recent passwords of the
+// given user.
+func (p *planner) checkPasswordPolicy(
+	ctx context.Context, user username.SQLUsername, passwordStr string,
+) error {
+	sv := &p.ExecCfg().Settings.SV
+	if err := security.CheckPasswordComplexity(sv, user, passwordStr); err != nil {
+		return pgerror.WithCandidateCode(err, pgcode.InvalidPassword)
+	}
+
+	historyCount := security.PasswordHistoryCount.Get(sv)
+	if historyCount == 0 || user.Undefined() {
+		return nil
+	}
+	// The current password is checked in addition to the history, so that
+	// passwords set before the history was recorded are also covered.
+	query := `SELECT "hashedPassword" FROM system.users WHERE username = $1 AND "hashedPassword" IS NOT NULL`
+	qargs := []interface{}{user.Normalized()}
+	if passwordPolicyTablesActive(ctx, p.ExecCfg()) {
+		query += `
+UNION ALL
+(SELECT hashed_password FROM system.password_history WHERE username = $1
+ORDER BY changed_at DESC LIMIT $2)`
+		qargs = append(qargs, historyCount)
+	}
+	rows, err := p.InternalSQLTxn().QueryBufferedEx(
+		ctx, "check-password-history", p.txn,
+		sessiondata.NodeUserSessionDataOverride,
+		query, qargs...,
+	)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		hashedPassword := password.LoadPasswordHash(ctx, []byte(tree.MustBeDBytes(row[0])))
+		ok, err := password.CompareHashAndCleartextPassword(
+			ctx, hashedPassword, passwordStr, security.GetExpensiveHashComputeSem(ctx),
+		)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return pgerror.WithCandidateCode(
+				errors.WithHintf(security.ErrPasswordReused,
+					"Passwords cannot be the same as any of the last %d passwords of the user.", historyCount),
+				pgcode.InvalidPassword)
+		}
+	}
+	return nil
+}
+
+// recordPasswordChange adds the given password hash to the password history
+// of the given user, and removes the entries that are no longer needed to
+// enforce server.user_login.password_policy.history_count. The most recent
+// entry is always retained since it determines when the password expires.
+func (p *planner) recordPasswordChange(
+	ctx context.Context, opName string, user username.SQLUsername, hashedPassword []byte,
+) error {
+	if !passwordPolicyTablesActive(ctx, p.ExecCfg()) {
+		return nil
+	}
+	if _, err := p.InternalSQLTxn().ExecEx(
+		ctx, opName, p.txn,
+		sessiondata.NodeUserSessionDataOverride,
+		`UPSERT INTO system.password_history (username, changed_at, hashed_password, user_id)
+VALUES ($1, now(), $2, (SELECT user_id FROM system.users WHERE username = $1))`,
+		user.Normalized(), hashedPassword,
+	); err != nil {
+		return err
+	}
+
+	keep := security.PasswordHistoryCount.Get(&p.ExecCfg().Settings.SV)
+	if keep < 1 {
+		keep = 1
+	}
+	_, err := p.InternalSQLTxn().ExecEx(
+		ctx, opName, p.txn,
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.password_history WHERE username = $1 AND changed_at NOT IN
+(SELECT changed_at FROM system.password_history WHERE username = $1 ORDER BY changed_at DESC LIMIT $2)`,
+		user.Normalized(), keep,
+	)
+	return err
+}
+
+// deletePasswordPolicyState removes the password history and the failed
+// login state of the given user.
+func (p *planner) deletePasswordPolicyState(
+	ctx context.Context, opName string, user username.SQLUsername,
+) error {
+	if !passwordPolicyTablesActive(ctx, p.ExecCfg()) {
+		return nil
+	}
+	for _, stmt := range []string{
+		`DELETE FROM system.password_history WHERE username = $1`,
+		`DELETE FROM system.login_lockouts WHERE username = $1`,
+	} {
+		if _, err := p.InternalSQLTxn().ExecEx(
+			ctx, opName, p.txn,
+			sessiondata.NodeUserSessionDataOverride,
+			stmt, user.Normalized(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoginPolicyState describes whether the password policy currently allows
+// a user to log in with a password.
+type LoginPolicyState struct {
+	// Locked is set if the account is locked after too many failed logins.
+	Locked bool
+	// PasswordExpired is set if the password of the user was last changed
+	// longer than server.user_login.password_policy.max_lifetime ago.
+	PasswordExpired bool
+	// FailedAttempts is the number of failed logins recorded for the user.
+	FailedAttempts int
+}
+
+// GetLoginPolicyState retrieves the LoginPolicyState of the given user. The
+// root user is never locked and its password never expires, so that it
+// can always be used to recover access to the cluster.
+func GetLoginPolicyState(
+	ctx context.Context, execCfg *ExecutorConfig, user username.SQLUsername,
+) (state LoginPolicyState, err error) {
+	if user.IsRootUser() || !passwordPolicyTablesActive(ctx, execCfg) {
+		return state, nil
+	}
+	sv := &execCfg.Settings.SV
+	maxLifetime := security.PasswordMaxLifetime.Get(sv)
+	if security.LockoutMaxFailedAttempts.Get(sv) == 0 && maxLifetime == 0 {
+		return state, nil
+	}
+
+	row, err := execCfg.InternalDB.Executor().QueryRowEx(
+		ctx, "get-login-policy-state", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT
+  (SELECT failed_attempts FROM system.login_lockouts WHERE username = $1),
+  (SELECT locked_at FROM system.login_lockouts WHERE username = $1),
+  (SELECT max(changed_at) FROM system.password_history WHERE username = $1)`,
+		user.Normalized(),
+	)
+	if err != nil {
+		return state, err
+	}
+	now := timeutil.Now()
+	if row[0] != tree.DNull {
+		state.FailedAttempts = int(tree.MustBeDInt(row[0]))
+	}
+	if row[1] != tree.DNull {
+		lockedAt := tree.MustBeDTimestampTZ(row[1]).Time
+		state.Locked = lockoutInEffect(sv, lockedAt, now)
+	}
+	if row[2] != tree.DNull && maxLifetime > 0 {
+		changedAt := tree.MustBeDTimestampTZ(row[2]).Time
+		state.PasswordExpired = now.After(changedAt.Add(maxLifetime))
+	}
+	return state, nil
+}
+
+// lockoutInEffect returns whether an account locked at the given time is
+// still locked.
+func lockoutInEffect(sv *settings.Values, lockedAt, now time.Time) bool {
+	d := security.LockoutDuration.Get(sv)
+	return d == 0 || now.Before(lockedAt.Add(d))
+}
+
+// RecordFailedLogin records a failed password login for the given user. It
+// returns true if the account became locked as a result.
+func RecordFailedLogin(
+	ctx context.Context, execCfg *ExecutorConfig, user username.SQLUsername,
+) (locked bool, err error) {
+	if user.IsRootUser() || !passwordPolicyTablesActive(ctx, execCfg) {
+		return false, nil
+	}
+	sv := &execCfg.Settings.SV
+	maxAttempts := security.LockoutMaxFailedAttempts.Get(sv)
+	if maxAttempts == 0 {
+		return false, nil
+	}
+	err = execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		locked = false
+		row, err := txn.QueryRowEx(
+			ctx, "get-failed-logins", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT failed_attempts, first_failure_at, locked_at FROM system.login_lockouts WHERE username = $1`,
+			user.Normalized(),
+		)
+		if err != nil {
+			return err
+		}
+		now := timeutil.Now()
+		attempts, firstFailure := int64(1), now
+		if row != nil {
+			prevFirstFailure := tree.MustBeDTimestampTZ(row[1]).Time
+			expiredLock := row[2] != tree.DNull &&
+				!lockoutInEffect(sv, tree.MustBeDTimestampTZ(row[2]).Time, now)
+			if !expiredLock && now.Before(prevFirstFailure.Add(security.LockoutWindow.Get(sv))) {
+				attempts, firstFailure = int64(tree.MustBeDInt(row[0]))+1, prevFirstFailure
+			}
+		}
+		lockedAt := tree.DNull
+		if attempts >= maxAttempts {
+			locked = true
+			lockedAt = tree.MustMakeDTimestampTZ(now, time.Microsecond)
+		}
+		_, err = txn.ExecEx(
+			ctx, "record-failed-login", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPSERT INTO system.login_lockouts (username, failed_attempts, first_failure_at, locked_at, user_id)
+VALUES ($1, $2, $3, $4, (SELECT user_id FROM system.users WHERE username = $1))`,
+			user.Normalized(), attempts,
+			tree.MustMakeDTimestampTZ(firstFailure, time.Microsecond), lockedAt,
+		)
+		return err
+	})
+	return locked, err
+}
+
+// ResetFailedLogins clears the failed login state of the given user,
+// unlocking its account if it was locked.
+func ResetFailedLogins(
+	ctx context.Context, execCfg *ExecutorConfig, user username.SQLUsername,
+) error {
+	if !passwordPolicyTablesActive(ctx, execCfg) {
+		return nil
+	}
+	_, err := execCfg.InternalDB.Executor().ExecEx(
+		ctx, "reset-failed-logins", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.login_lockouts WHERE username = $1`,
+		user.Normalized(),
+	)
+	return err
+}
@@ -160,7 +160,36 @@ func (c *conn) handleAuthentication(
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
 		return connClose, c.sendError(ctx, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
 	}
-	c.sessionArgs.IsSuperuser = isSuperuser
+
+	// If the user does not exist but the AuthMethod is able to provision
+	// it, the connection is authenticated first, and the user is then
+	// created before proceeding with the checks below.
+	authenticated := false
+	if !exists && behaviors.CanProvision() {
+		if err := behaviors.Authenticate(ctx, systemIdentity, true /* public */, pwRetrievalFn); err != nil {
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_UNKNOWN, err)
+			return connClose, c.sendError(ctx, authenticationErrorWithCode(err))
+		}
+		authenticated = true
+		if err := behaviors.Provision(ctx, dbUser); err != nil {
+			log.Warningf(ctx, "user provisioning failed for user=%q: %+v", dbUser, err)
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+			return connClose, c.sendError(ctx, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
+		}
+		ac.LogAuthInfof(ctx, "provisioned user %s", dbUser)
+		exists, canLoginSQL, _, canUseReplicationMode, isSuperuser, defaultSettings, pwRetrievalFn, err =
+			sql.GetUserSessionInitInfo(
+				ctx,
+				execCfg,
+				dbUser,
+				c.sessionArgs.SessionDefaults["database"],
+			)
+		if err != nil {
+			log.Warningf(ctx, "user retrieval failed for user=%q: %+v", dbUser, err)
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+			return connClose, c.sendError(ctx, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
+		}
+	}
 
 	if !exists {
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_NOT_FOUND, nil)
@@ -177,17 +206,39 @@ func (c *conn) handleAuthentication(
 
 	// At this point, we know that the requested user exists and is
 	// allowed to log in. Now we can delegate to the selected AuthMethod
-	// implementation to complete the authentication.
-	if err := behaviors.Authenticate(ctx, systemIdentity, true /* public */, pwRetrievalFn); err != nil {
-		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_UNKNOWN, err)
-		if pErr := (*security.PasswordUserAuthError)(nil); errors.As(err, &pErr) {
-			err = pgerror.WithCandidateCode(err, pgcode.InvalidPassword)
-		} else {
-			err = pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification)
+	// implementation to complete the authentication, unless this was
+	// already done before provisioning the user.
+	if !authenticated {
+		if err := behaviors.Authenticate(ctx, systemIdentity, true /* public */, pwRetrievalFn); err != nil {
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_UNKNOWN, err)
+			return connClose, c.sendError(ctx, authenticationErrorWithCode(err))
 		}
-		return connClose, c.sendError(ctx, err)
 	}
 
+	// Once authenticated, give the AuthMethod a chance to authorize the
+	// session user, e.g. by synchronizing its roles from a directory.
+	if behaviors.CanAuthorize() {
+		if err := behaviors.MaybeAuthorize(ctx, systemIdentity, dbUser); err != nil {
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_UNKNOWN, err)
+			return connClose, c.sendError(ctx, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
+		}
+		// The authorization may have changed the role memberships of the
+		// user, and thus whether it is a superuser.
+		_, _, _, canUseReplicationMode, isSuperuser, _, _, err =
+			sql.GetUserSessionInitInfo(
+				ctx,
+				execCfg,
+				dbUser,
+				c.sessionArgs.SessionDefaults["database"],
+			)
+		if err != nil {
+			log.Warningf(ctx, "user retrieval failed for user=%q: %+v", dbUser, err)
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+			return connClose, c.sendError(ctx, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
+		}
+	}
+	c.sessionArgs.IsSuperuser = isSuperuser
+
 	// Add all the defaults to this session's defaults. If there is an
 	// error (e.g., a setting that no longer exists, or bad input),
 	// log a warning instead of preventing login.
@@ -229,6 +280,15 @@ func (c *conn) handleAuthentication(
 	return connClose, nil
 }
 
+// authenticationErrorWithCode annotates an error returned by an
+// Authenticator with the pgcode reported to the client.
+func authenticationErrorWithCode(err error) error {
+	if pErr := (*security.PasswordUserAuthError)(nil); errors.As(err, &pErr) {
+		return pgerror.WithCandidateCode(err, pgcode.InvalidPassword)
+	}
+	return pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification)
+}
+
 func (c *conn) authOKMessage() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgAuth)
 	c.msgBuilder.putInt32(authOK)
@@ -25,7 +25,9 @@ import (
 // resources to be released.
 type AuthBehaviors struct {
 	authenticator       Authenticator
+	authorizer          Authorizer
 	connClose           func()
+	provisioner         Provisioner
 	replacementIdentity username.SQLUsername
 	replacedIdentity    bool
 	roleMapper          RoleMapper
@@ -33,6 +35,8 @@ type AuthBehaviors struct {
 
 // Ensure that an AuthBehaviors is easily composable with itself.
 var _ Authenticator = (*AuthBehaviors)(nil).Authenticate
+var _ Authorizer = (*AuthBehaviors)(nil).MaybeAuthorize
+var _ Provisioner = (*AuthBehaviors)(nil).Provision
 var _ func() = (*AuthBehaviors)(nil).ConnClose
 var _ RoleMapper = (*AuthBehaviors)(nil).MapRole
 
@@ -63,6 +67,49 @@ func (b *AuthBehaviors) SetAuthenticator(a Authenticator) {
 	b.authenticator = a
 }
 
+// MaybeAuthorize delegates to the Authorizer passed to SetAuthorizer.
+// This method is a no-op if SetAuthorizer has not been called or was
+// called with nil.
+func (b *AuthBehaviors) MaybeAuthorize(
+	ctx context.Context, systemIdentity, sessionUser username.SQLUsername,
+) error {
+	if found := b.authorizer; found != nil {
+		return found(ctx, systemIdentity, sessionUser)
+	}
+	return nil
+}
+
+// CanAuthorize returns true if SetAuthorizer has been called with a
+// non-nil Authorizer.
+func (b *AuthBehaviors) CanAuthorize() bool {
+	return b.authorizer != nil
+}
+
+// SetAuthorizer updates the Authorizer to be used.
+func (b *AuthBehaviors) SetAuthorizer(a Authorizer) {
+	b.authorizer = a
+}
+
+// CanProvision returns true if SetProvisioner has been called with a
+// non-nil Provisioner.
+func (b *AuthBehaviors) CanProvision() bool {
+	return b.provisioner != nil
+}
+
+// Provision delegates to the Provisioner passed to SetProvisioner or
+// returns an error if SetProvisioner has not been called.
+func (b *AuthBehaviors) Provision(ctx context.Context, sessionUser username.SQLUsername) error {
+	if found := b.provisioner; found != nil {
+		return found(ctx, sessionUser)
+	}
+	return errors.New("no Provisioner provided to AuthBehaviors")
+}
+
+// SetProvisioner updates the Provisioner to be used.
+func (b *AuthBehaviors) SetProvisioner(p Provisioner) {
+	b.provisioner = p
+}
+
 // ConnClose delegates to the function passed to SetConnClose to release
 // any resources associated with the connection. This method is a no-op
 // if SetConnClose has not been called or was called with nil.
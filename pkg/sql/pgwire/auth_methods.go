@@ -126,11 +126,64 @@ func authPassword(
 	) error {
 		return passwordAuthenticator(ctx, systemIdentity, clientConnection, pwRetrieveFn, c, execCfg)
 	})
+	b.SetAuthenticator(withPasswordPolicy(c, execCfg, b.authenticator))
 	return b, nil
 }
 
 var errExpiredPassword = errors.New("password is expired")
 
+var errAccountLocked = errors.New("account is locked")
+
+// withPasswordPolicy wraps the given password-based authenticator to
+// enforce account lockout and password expiry, as configured by the
+// server.user_login.lockout and server.user_login.password_policy cluster
+// settings.
+func withPasswordPolicy(
+	c AuthConn, execCfg *sql.ExecutorConfig, authenticator Authenticator,
+) Authenticator {
+	return func(
+		ctx context.Context,
+		systemIdentity username.SQLUsername,
+		clientConnection bool,
+		pwRetrieveFn PasswordRetrievalFn,
+	) error {
+		state, err := sql.GetLoginPolicyState(ctx, execCfg, systemIdentity)
+		if err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+			return err
+		}
+		if state.Locked {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_ACCOUNT_LOCKED, nil)
+			return errAccountLocked
+		}
+
+		if err := authenticator(ctx, systemIdentity, clientConnection, pwRetrieveFn); err != nil {
+			if errors.HasType(err, &security.PasswordUserAuthError{}) {
+				locked, lockErr := sql.RecordFailedLogin(ctx, execCfg, systemIdentity)
+				if lockErr != nil {
+					log.Warningf(ctx, "recording failed login for %s: %v", systemIdentity, lockErr)
+				} else if locked {
+					c.LogAuthInfof(ctx, "account locked after too many failed logins")
+				}
+			}
+			return err
+		}
+
+		if state.PasswordExpired {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_EXPIRED,
+				errors.New("password exceeded server.user_login.password_policy.max_lifetime"))
+			return errors.WithHint(errExpiredPassword,
+				"The password must be changed by an administrator.")
+		}
+		if state.FailedAttempts > 0 {
+			if err := sql.ResetFailedLogins(ctx, execCfg, systemIdentity); err != nil {
+				log.Warningf(ctx, "resetting failed logins for %s: %v", systemIdentity, err)
+			}
+		}
+		return nil
+	}
+}
+
 // passwordAuthenticator is the authenticator function for the
 // behavior constructed by authPassword().
 func passwordAuthenticator(
@@ -249,6 +302,7 @@ func authScram(
 	) error {
 		return scramAuthenticator(ctx, systemIdentity, clientConnection, pwRetrieveFn, c, execCfg)
 	})
+	b.SetAuthenticator(withPasswordPolicy(c, execCfg, b.authenticator))
 	return b, nil
 }
 
@@ -578,6 +632,7 @@ func authAutoSelectPasswordProtocol(
 		c.LogAuthInfof(ctx, "no crdb-bcrypt credentials found; proceeding with SCRAM-SHA-256")
 		return scramAuthenticator(ctx, systemIdentity, clientConnection, newpwfn, c, execCfg)
 	})
+	b.SetAuthenticator(withPasswordPolicy(c, execCfg, b.authenticator))
 	return b, nil
 }
 
@@ -692,6 +747,14 @@ type JWTVerifier interface {
 		_ []byte,
 		_ *identmap.Conf,
 	) error
+	// ProvisioningEnabled returns whether users that do not exist yet are
+	// created on their first successful JWT login.
+	ProvisioningEnabled(_ *cluster.Settings) bool
+	// RoleGrantsForJWTLogin returns the roles that a token validated by
+	// ValidateJWTLogin grants to its user, and the roles whose memberships
+	// are managed through JWT logins. ok is false if role memberships are not
+	// synchronized on JWT logins.
+	RoleGrantsForJWTLogin(_ *cluster.Settings, _ []byte) (grants, managed []username.SQLUsername, ok bool, err error)
 }
 
 var jwtVerifier JWTVerifier
@@ -704,6 +767,16 @@ func (c *noJWTConfigured) ValidateJWTLogin(
 	return errors.New("JWT token authentication requires CCL features")
 }
 
+func (c *noJWTConfigured) ProvisioningEnabled(_ *cluster.Settings) bool {
+	return false
+}
+
+func (c *noJWTConfigured) RoleGrantsForJWTLogin(
+	_ *cluster.Settings, _ []byte,
+) (grants, managed []username.SQLUsername, ok bool, err error) {
+	return nil, nil, false, nil
+}
+
 // ConfigureJWTAuth is a hook for the `jwtauthccl` library to add JWT login support. It's called to
 // setup the JWTVerifier just as it is needed.
 var ConfigureJWTAuth = func(
@@ -735,6 +808,9 @@ func authJwtToken(
 	if jwtVerifier == nil {
 		jwtVerifier = ConfigureJWTAuth(sctx, execCfg.AmbientCtx, execCfg.Settings, execCfg.NodeInfo.LogicalClusterID())
 	}
+	// validatedToken is set by the authenticator once the token has been
+	// validated, for use by the authorizer.
+	var validatedToken []byte
 	b := &AuthBehaviors{}
 	b.SetRoleMapper(UseProvidedIdentity)
 	b.SetAuthenticator(func(ctx context.Context, user username.SQLUsername, clientConnection bool, pwRetrieveFn PasswordRetrievalFn) error {
@@ -770,8 +846,21 @@ func authJwtToken(
 			c.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_INVALID, err)
 			return err
 		}
+		validatedToken = []byte(token)
 		c.LogAuthOK(ctx)
 		return nil
 	})
+	if jwtVerifier.ProvisioningEnabled(execCfg.Settings) {
+		b.SetProvisioner(func(ctx context.Context, sessionUser username.SQLUsername) error {
+			return sql.ProvisionUser(ctx, execCfg, sessionUser)
+		})
+	}
+	b.SetAuthorizer(func(ctx context.Context, _, sessionUser username.SQLUsername) error {
+		grants, managed, ok, err := jwtVerifier.RoleGrantsForJWTLogin(execCfg.Settings, validatedToken)
+		if err != nil || !ok {
+			return err
+		}
+		return sql.SyncRoleMemberships(ctx, execCfg, sessionUser, grants, managed)
+	})
 	return b, nil
 }
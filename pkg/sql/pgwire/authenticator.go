@@ -27,6 +27,25 @@ type Authenticator = func(
 	pwRetrieveFn PasswordRetrievalFn,
 ) error
 
+// Authorizer is an optional component of an AuthMethod that is invoked
+// once the connection has been authenticated. It may reject the
+// connection, or update the database state of the session user, e.g.
+// to synchronize its role memberships with an external directory.
+type Authorizer = func(
+	ctx context.Context,
+	systemIdentity username.SQLUsername,
+	sessionUser username.SQLUsername,
+) error
+
+// Provisioner is an optional component of an AuthMethod that creates
+// the session user if it does not exist yet. When one is provided, a
+// connection for a missing user is authenticated before the user is
+// provisioned, so it must not rely on the stored credentials of the user.
+type Provisioner = func(
+	ctx context.Context,
+	sessionUser username.SQLUsername,
+) error
+
 // PasswordRetrievalFn defines a method to retrieve a hashed password
 // and expiration time for a user logging in with password-based
 // authentication.
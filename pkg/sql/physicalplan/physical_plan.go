@@ -102,6 +102,12 @@ type PhysicalInfrastructure struct {
 	// generate processor input and output specs (see PopulateEndpoints).
 	Streams []Stream
 
+	// RuntimeFilterStreams accumulates the streams over which hash joiners send
+	// their runtime filters to the processors producing their left inputs. Only
+	// SourceProcessor and DestProcessor are set. The stream IDs are assigned
+	// after the IDs of the regular streams (see PopulateEndpoints).
+	RuntimeFilterStreams []Stream
+
 	// Used internally for numbering stages.
 	stageCounter int32
 }
@@ -206,6 +212,8 @@ func (p *PhysicalInfrastructure) Release() {
 		Processors:      p.Processors[:0],
 		LocalProcessors: p.LocalProcessors[:0],
 		Streams:         p.Streams[:0],
+
+		RuntimeFilterStreams: p.RuntimeFilterStreams[:0],
 	}
 	infraPool.Put(p)
 }
@@ -939,6 +947,55 @@ func (p *PhysicalPlan) PopulateEndpoints() {
 		}
 		router.Streams = append(router.Streams, endpoint)
 	}
+
+	for i, s := range p.RuntimeFilterStreams {
+		p1 := &p.Processors[s.SourceProcessor]
+		p2 := &p.Processors[s.DestProcessor]
+		endpoint := execinfrapb.StreamEndpointSpec{StreamID: execinfrapb.StreamID(len(p.Streams) + i)}
+		if p1.SQLInstanceID == p2.SQLInstanceID {
+			endpoint.Type = execinfrapb.StreamEndpointSpec_LOCAL
+		} else {
+			endpoint.Type = execinfrapb.StreamEndpointSpec_REMOTE
+			endpoint.OriginNodeID = p1.SQLInstanceID
+			endpoint.TargetNodeID = p2.SQLInstanceID
+		}
+		producer, consumer := p1.Spec.RuntimeFilterProducer, p2.Spec.RuntimeFilterConsumer
+		if producer == nil || consumer == nil {
+			panic(errors.AssertionFailedf(
+				"runtime filter stream between processors without runtime filter specs",
+			))
+		}
+		producer.Streams = append(producer.Streams, endpoint)
+		consumer.Streams = append(consumer.Streams, endpoint)
+	}
+}
+
+// AddRuntimeFilter sets up a runtime filter built by each of the given hash
+// joiners and applied to the output of each of the given processors producing
+// the left input of the joiners. keyColumns are the output columns of the
+// consumers that correspond to the left equality columns of the joiners.
+func (p *PhysicalPlan) AddRuntimeFilter(
+	producers, consumers []ProcessorIdx,
+	producerSpec execinfrapb.RuntimeFilterProducerSpec,
+	keyColumns []uint32,
+) {
+	for _, pIdx := range producers {
+		spec := producerSpec
+		p.Processors[pIdx].Spec.RuntimeFilterProducer = &spec
+	}
+	for _, pIdx := range consumers {
+		p.Processors[pIdx].Spec.RuntimeFilterConsumer = &execinfrapb.RuntimeFilterConsumerSpec{
+			KeyColumns: keyColumns,
+		}
+	}
+	for _, producer := range producers {
+		for _, consumer := range consumers {
+			p.RuntimeFilterStreams = append(p.RuntimeFilterStreams, Stream{
+				SourceProcessor: producer,
+				DestProcessor:   consumer,
+			})
+		}
+	}
 }
 
 // GenerateFlowSpecs takes a plan (with populated endpoints) and generates the
@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -234,13 +235,39 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	defer sp.Finish()
 	p.curPlan.init(&p.stmt, &p.instrumentation)
 
+	// Apply the hints of system.statement_hints attached to the fingerprint
+	// of the statement. Their session settings are overridden while the
+	// statement is planned.
+	hints, err := p.getStatementHints(ctx, p.stmt.AST)
+	if err != nil {
+		return err
+	}
+	var hintSettings []stmthints.SessionSetting
+	if hints != nil {
+		hintSettings = hints.SessionSettings
+		p.instrumentation.statementHints = hints.Descriptions
+	}
+	restoreSessionData, err := p.pushStatementHintSettings(ctx, hintSettings)
+	if err != nil {
+		return err
+	}
+	defer restoreSessionData()
+
 	opc := &p.optPlanningCtx
 	opc.reset(ctx)
+	if hints != nil {
+		// The memos built without the hints cannot be used, and the memo
+		// built with them must not be reused by other statements.
+		opc.statementHints = hints
+		opc.allowMemoReuse = false
+		opc.useCache = false
+	}
 
 	execMemo, err := opc.buildExecMemo(ctx)
 	if err != nil {
 		return err
 	}
+	p.instrumentation.costCalibration = execMemo.CostCalibration()
 
 	// Build the plan tree.
 	if mode := p.SessionData().ExperimentalDistSQLPlanningMode; mode != sessiondatapb.ExperimentalDistSQLPlanningOff {
@@ -336,6 +363,15 @@ type optPlanningCtx struct {
 	// allowMemoReuse is false.
 	useCache bool
 
+	// statementHints are the hints of system.statement_hints applied to the
+	// statement, if any.
+	statementHints *stmthints.Hints
+
+	// hypotheticalIndexes are the names of the hypothetical indexes of the
+	// session that were made visible to the optimizer for an EXPLAIN, if any.
+	// See applyHypotheticalIndexes.
+	hypotheticalIndexes []string
+
 	flags planFlags
 }
 
@@ -353,6 +389,8 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 	opc.catalog.reset()
 	opc.optimizer.Init(ctx, p.EvalContext(), opc.catalog)
 	opc.flags = 0
+	opc.statementHints = nil
+	opc.hypotheticalIndexes = nil
 
 	// We only allow memo caching for SELECT/INSERT/UPDATE/DELETE. We could
 	// support it for all statements in principle, but it would increase the
@@ -510,6 +548,97 @@ func (opc *optPlanningCtx) reuseMemo(
 	return f.Memo(), nil
 }
 
+// genericPlanCostFactor is the factor by which the cost of the generic plan of
+// a prepared statement can exceed the average cost of its custom plans for the
+// generic plan to be chosen when plan_cache_mode is auto. Generic plans are
+// preferred, since they avoid the cost of optimizing the statement on every
+// execution.
+const genericPlanCostFactor = 1.1
+
+// chooseGenericOrCustomMemo returns a fully optimized memo for the execution
+// of a prepared statement which has placeholders, according to the
+// plan_cache_mode session setting:
+//
+//   - force_custom_plan: the prepared memo is optimized with the values of
+//     the placeholders (a custom plan).
+//   - force_generic_plan: a memo optimized without the values of the
+//     placeholders (a generic plan) is built once, and reused by later
+//     executions.
+//   - auto: custom plans are used for the first executions of the statement.
+//     Afterwards, the generic plan is used if its cost is not significantly
+//     higher than the average cost of the custom plans.
+func (opc *optPlanningCtx) chooseGenericOrCustomMemo(
+	ctx context.Context, prepared *PreparedStatement,
+) (*memo.Memo, error) {
+	switch opc.p.SessionData().PlanCacheMode {
+	case sessiondatapb.PlanCacheModeForceGeneric:
+		return opc.buildGenericMemo(ctx, prepared)
+
+	case sessiondatapb.PlanCacheModeAuto:
+		if prepared.Costs.NumCustom() >= customPlanCostsToTrack {
+			generic, err := opc.buildGenericMemo(ctx, prepared)
+			if err != nil {
+				return nil, err
+			}
+			genericCost := generic.RootExpr().(memo.RelExpr).Cost()
+			if genericCost <= prepared.Costs.AvgCustom()*genericPlanCostFactor {
+				return generic, nil
+			}
+			opc.log(ctx, "generic plan is too expensive")
+		}
+	}
+
+	opc.log(ctx, "reusing cached memo")
+	mem, err := opc.reuseMemo(ctx, prepared.Memo)
+	if err != nil {
+		return nil, err
+	}
+	prepared.Costs.AddCustom(mem.RootExpr().(memo.RelExpr).Cost())
+	if opc.p.SessionData().PlanCacheMode != sessiondatapb.PlanCacheModeForceCustom {
+		// The plan type is only shown by EXPLAIN ANALYZE when generic plans
+		// are enabled.
+		opc.p.instrumentation.planType = planTypeCustom
+	}
+	return mem, nil
+}
+
+// buildGenericMemo returns the generic memo of the prepared statement, which
+// is optimized without assigning the placeholders of the prepared memo. The
+// generic memo is built if it does not exist yet or if it is stale, and then
+// stored in the prepared statement so that it can be reused by later
+// executions.
+func (opc *optPlanningCtx) buildGenericMemo(
+	ctx context.Context, prepared *PreparedStatement,
+) (*memo.Memo, error) {
+	if prepared.GenericMemo != nil {
+		isStale, err := prepared.GenericMemo.IsStale(ctx, opc.p.EvalContext(), opc.catalog)
+		if err != nil {
+			return nil, err
+		}
+		if !isStale {
+			opc.log(ctx, "reusing generic memo")
+			opc.p.instrumentation.planType = planTypeGenericReused
+			return prepared.GenericMemo, nil
+		}
+	}
+
+	opc.log(ctx, "optimizing generic memo")
+	f := opc.optimizer.Factory()
+	// Stable operators cannot be constant-folded, since the generic memo is
+	// reused by later executions.
+	f.CopyAndReplace(
+		prepared.Memo.RootExpr().(memo.RelExpr),
+		prepared.Memo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return nil, err
+	}
+	prepared.GenericMemo = opc.optimizer.DetachMemo(ctx)
+	opc.p.instrumentation.planType = planTypeGenericReoptimized
+	return prepared.GenericMemo, nil
+}
+
 // buildExecMemo creates a fully optimized memo, possibly reusing a previously
 // cached memo as a starting point.
 //
@@ -532,10 +661,20 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 			if err != nil {
 				return nil, err
 			}
+			// The generic memo and the costs of the custom plans are no longer
+			// valid either.
+			prepared.GenericMemo = nil
+			prepared.Costs.Reset()
 		}
-		opc.log(ctx, "reusing cached memo")
-		memo, err := opc.reuseMemo(ctx, prepared.Memo)
-		return memo, err
+		// If the prepared memo has no placeholders, or if it was fully optimized
+		// by the placeholder fast path, its plan does not depend on the values
+		// of the placeholders.
+		if !prepared.Memo.HasPlaceholders() || prepared.Memo.IsOptimized() {
+			opc.log(ctx, "reusing cached memo")
+			memo, err := opc.reuseMemo(ctx, prepared.Memo)
+			return memo, err
+		}
+		return opc.chooseGenericOrCustomMemo(ctx, prepared)
 	}
 
 	if opc.useCache {
@@ -550,10 +689,17 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 				if err != nil {
 					return nil, err
 				}
-				// Update the plan in the cache. If the cache entry had PrepareMetadata
-				// populated, it may no longer be valid.
-				cachedData.PrepareMetadata = nil
-				p.execCfg.QueryCache.Add(&p.queryCacheSession, &cachedData)
+				if opc.useCache {
+					// Update the plan in the cache. If the cache entry had
+					// PrepareMetadata populated, it may no longer be valid.
+					cachedData.PrepareMetadata = nil
+					p.execCfg.QueryCache.Add(&p.queryCacheSession, &cachedData)
+				} else {
+					// The rebuilt memo cannot be reused, for example because it
+					// depends on the row-level security policies that apply to the
+					// current user, so it must not replace the stale entry.
+					p.execCfg.QueryCache.Purge(opc.p.stmt.SQL)
+				}
 				opc.flags.Set(planFlagOptCacheMiss)
 			} else {
 				opc.log(ctx, "query cache hit")
@@ -573,6 +719,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	f := opc.optimizer.Factory()
 	f.FoldingControl().AllowStableFolds()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
+	bld.StatementHints = opc.statementHints
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
@@ -581,12 +728,15 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	// find potential index candidates in the memo.
 	_, isExplain := opc.p.stmt.AST.(*tree.Explain)
 	if isExplain && p.SessionData().IndexRecommendationsEnabled {
-		indexRecs, err := opc.makeQueryIndexRecommendation(ctx)
+		indexRecs, _, err := opc.makeQueryIndexRecommendation(ctx)
 		if err != nil {
 			return nil, err
 		}
 		opc.p.instrumentation.explainIndexRecs = indexRecs
 	}
+	if isExplain {
+		opc.applyHypotheticalIndexes()
+	}
 
 	if _, isCanned := opc.p.stmt.AST.(*tree.CannedOptPlan); !isCanned {
 		if _, err := opc.optimizer.Optimize(); err != nil {
@@ -734,10 +884,11 @@ func (p *planner) DecodeGist(gist string, external bool) ([]string, error) {
 // potential index candidates. It then optimizes the statement with those
 // indexes hypothetically added to the table. An index recommendation for the
 // query is outputted based on which hypothetical indexes are helpful in the
-// optimal plan.
+// optimal plan. The estimated cost of the optimal plan with the hypothetical
+// indexes is also returned.
 func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 	ctx context.Context,
-) (_ []indexrec.Rec, err error) {
+) (_ []indexrec.Rec, hypotheticalCost memo.Cost, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			// This code allows us to propagate internal errors without having to add
@@ -773,7 +924,7 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 		return ruleName.IsNormalize()
 	})
 	if _, err = opc.optimizer.Optimize(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// Walk through the fully normalized memo to determine index candidates and
@@ -791,13 +942,14 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 	)
 	opc.optimizer.Memo().Metadata().UpdateTableMeta(f.EvalContext(), hypTables)
 	if _, err = opc.optimizer.Optimize(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
+	hypotheticalCost = f.Memo().RootExpr().(memo.RelExpr).Cost()
 	var indexRecs []indexrec.Rec
 	indexRecs, err = indexrec.FindRecs(ctx, f.Memo().RootExpr(), f.Metadata())
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// Re-initialize the optimizer (which also re-initializes the factory) and
@@ -811,5 +963,5 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 		f.CopyWithoutAssigningPlaceholders,
 	)
 
-	return indexRecs, nil
+	return indexRecs, hypotheticalCost, nil
 }
@@ -228,6 +228,8 @@ type planner struct {
 
 	createdSequences createdSequences
 
+	hypotheticalIndexes hypotheticalIndexes
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
@@ -455,6 +457,7 @@ func newInternalPlanner(
 	p.sqlCursors = emptySqlCursors{}
 	p.preparedStatements = emptyPreparedStatements{}
 	p.createdSequences = emptyCreatedSequences{}
+	p.hypotheticalIndexes = emptyHypotheticalIndexes{}
 
 	p.schemaResolver.descCollection = p.Descriptors()
 	p.schemaResolver.sessionDataStack = sds
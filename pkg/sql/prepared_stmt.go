@@ -60,6 +60,15 @@ type PreparedStatement struct {
 	// if it is used by the optimizer as a starting point.
 	Memo *memo.Memo
 
+	// GenericMemo is a fully optimized memo of the statement, planned without
+	// the values of its placeholders. It is built lazily when the statement is
+	// executed with a generic plan, see the plan_cache_mode session setting.
+	GenericMemo *memo.Memo
+
+	// Costs tracks the costs of the custom plans of the statement, which are
+	// compared with the cost of its generic plan when plan_cache_mode is auto.
+	Costs planCosts
+
 	// refCount keeps track of the number of references to this PreparedStatement.
 	// New references are registered through incRef().
 	// Once refCount hits 0 (through calls to decRef()), the following memAcc is
@@ -83,13 +92,97 @@ func (p *PreparedStatement) MemoryEstimate() int64 {
 	// Account for the memory used by this prepared statement:
 	//   1. Size of the prepare metadata.
 	//   2. Size of the prepared memo, if using the cost-based optimizer.
+	//   3. Size of the generic memo, if one was built.
 	size := p.PrepareMetadata.MemoryEstimate()
 	if p.Memo != nil {
 		size += p.Memo.MemoryEstimate()
 	}
+	if p.GenericMemo != nil {
+		size += p.GenericMemo.MemoryEstimate()
+	}
 	return size
 }
 
+// customPlanCostsToTrack is the number of custom plan costs tracked by
+// planCosts. It is also the number of custom plans which are built before a
+// generic plan is considered when plan_cache_mode is auto.
+const customPlanCostsToTrack = 5
+
+// planCosts tracks the costs of the most recent custom plans of a prepared
+// statement.
+type planCosts struct {
+	custom [customPlanCostsToTrack]memo.Cost
+	// n is the number of custom plans built so far.
+	n int
+}
+
+// AddCustom adds the cost of a custom plan, replacing the cost of the oldest
+// tracked plan if necessary.
+func (c *planCosts) AddCustom(cost memo.Cost) {
+	c.custom[c.n%customPlanCostsToTrack] = cost
+	c.n++
+}
+
+// NumCustom returns the number of custom plans built so far.
+func (c *planCosts) NumCustom() int {
+	return c.n
+}
+
+// AvgCustom returns the average cost of the tracked custom plans.
+func (c *planCosts) AvgCustom() memo.Cost {
+	n := c.n
+	if n > customPlanCostsToTrack {
+		n = customPlanCostsToTrack
+	}
+	if n == 0 {
+		return 0
+	}
+	var sum memo.Cost
+	for i := 0; i < n; i++ {
+		sum += c.custom[i]
+	}
+	return sum / memo.Cost(n)
+}
+
+// Reset forgets the tracked costs.
+func (c *planCosts) Reset() {
+	*c = planCosts{}
+}
+
+// planType is the type of plan used to execute a statement, as shown by
+// EXPLAIN ANALYZE.
+type planType uint8
+
+const (
+	// planTypeUnknown is used for statements which are not prepared, which do
+	// not have placeholders, or which are executed with plan_cache_mode set to
+	// force_custom_plan.
+	planTypeUnknown planType = iota
+	// planTypeCustom is a plan optimized with the values of the placeholders
+	// of the statement.
+	planTypeCustom
+	// planTypeGenericReoptimized is a generic plan which was optimized for the
+	// current execution.
+	planTypeGenericReoptimized
+	// planTypeGenericReused is a generic plan which was optimized for a
+	// previous execution.
+	planTypeGenericReused
+)
+
+// String returns the description of the plan type shown by EXPLAIN ANALYZE.
+func (t planType) String() string {
+	switch t {
+	case planTypeCustom:
+		return "custom"
+	case planTypeGenericReoptimized:
+		return "generic, re-optimized"
+	case planTypeGenericReused:
+		return "generic, reused"
+	default:
+		return ""
+	}
+}
+
 func (p *PreparedStatement) decRef(ctx context.Context) {
 	if p.refCount <= 0 {
 		log.Fatal(ctx, "corrupt PreparedStatement refcount")
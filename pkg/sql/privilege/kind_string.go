@@ -38,6 +38,8 @@ func _() {
 	_ = x[MODIFYSQLCLUSTERSETTING-28]
 	_ = x[REPLICATION-29]
 	_ = x[MANAGETENANT-30]
+	_ = x[DECRYPT-31]
+	_ = x[REFERENCES-32]
 }
 
 func (i Kind) String() string {
@@ -102,6 +104,10 @@ func (i Kind) String() string {
 		return "REPLICATION"
 	case MANAGETENANT:
 		return "MANAGETENANT"
+	case DECRYPT:
+		return "DECRYPT"
+	case REFERENCES:
+		return "REFERENCES"
 	default:
 		return "Kind(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -71,6 +71,8 @@ const (
 	MODIFYSQLCLUSTERSETTING  Kind = 28
 	REPLICATION              Kind = 29
 	MANAGETENANT             Kind = 30
+	DECRYPT                  Kind = 31
+	REFERENCES               Kind = 32
 )
 
 // Privilege represents a privilege parsed from an Access Privilege Inquiry
@@ -141,7 +143,7 @@ var (
 	ReadWriteData         = List{SELECT, INSERT, DELETE, UPDATE}
 	ReadWriteSequenceData = List{SELECT, UPDATE, USAGE}
 	DBPrivileges          = List{ALL, BACKUP, CONNECT, CREATE, DROP, RESTORE, ZONECONFIG}
-	TablePrivileges       = List{ALL, BACKUP, CHANGEFEED, CREATE, DROP, SELECT, INSERT, DELETE, UPDATE, ZONECONFIG}
+	TablePrivileges       = List{ALL, BACKUP, CHANGEFEED, CREATE, DROP, SELECT, INSERT, DELETE, UPDATE, ZONECONFIG, DECRYPT, REFERENCES}
 	SchemaPrivileges      = List{ALL, CREATE, USAGE}
 	TypePrivileges        = List{ALL, USAGE}
 	FunctionPrivileges    = List{ALL, EXECUTE}
@@ -153,6 +155,9 @@ var (
 	GlobalPrivileges             = List{ALL, BACKUP, RESTORE, MODIFYCLUSTERSETTING, EXTERNALCONNECTION, VIEWACTIVITY, VIEWACTIVITYREDACTED, VIEWCLUSTERSETTING, CANCELQUERY, NOSQLLOGIN, VIEWCLUSTERMETADATA, VIEWDEBUG, EXTERNALIOIMPLICITACCESS, VIEWJOB, MODIFYSQLCLUSTERSETTING, REPLICATION, MANAGETENANT}
 	VirtualTablePrivileges       = List{ALL, SELECT}
 	ExternalConnectionPrivileges = List{ALL, USAGE, DROP}
+	// ColumnPrivileges are the privileges that can be granted on individual
+	// columns of a table.
+	ColumnPrivileges = List{SELECT, INSERT, UPDATE, REFERENCES}
 )
 
 // Mask returns the bitmask for a given privilege.
@@ -196,6 +201,8 @@ var ByName = map[string]Kind{
 	"MODIFYSQLCLUSTERSETTING":  MODIFYSQLCLUSTERSETTING,
 	"REPLICATION":              REPLICATION,
 	"MANAGETENANT":             MANAGETENANT,
+	"DECRYPT":                  DECRYPT,
+	"REFERENCES":               REFERENCES,
 }
 
 // List is a list of privileges.
@@ -366,6 +373,18 @@ func ValidatePrivileges(privileges List, objectType ObjectType) error {
 	return nil
 }
 
+// ValidateColumnPrivileges returns an error if any privilege in privileges
+// cannot be granted on a column.
+func ValidateColumnPrivileges(privileges List) error {
+	for _, priv := range privileges {
+		if !ColumnPrivileges.Contains(priv) {
+			return pgerror.Newf(pgcode.InvalidGrantOperation,
+				"invalid privilege type %s for column", priv.String())
+		}
+	}
+	return nil
+}
+
 // GetValidPrivilegesForObject returns the list of valid privileges for the
 // specified object type.
 func GetValidPrivilegesForObject(objectType ObjectType) (List, error) {
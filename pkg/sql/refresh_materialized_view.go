@@ -76,6 +76,13 @@ func (n *refreshMaterializedViewNode) startExec(params runParams) error {
 	// will return consistent data. The schema change process will backfill the
 	// results of the view query into the new set of indexes, and then change the
 	// set of indexes over to the new set of indexes atomically.
+	//
+	// An incremental refresh instead rewrites the affected rows of the view in
+	// the current transaction.
+	if n.n.RefreshDataOption == tree.RefreshDataIncremental {
+		telemetry.Inc(sqltelemetry.SchemaRefreshMaterializedView)
+		return n.refreshIncrementally(params)
+	}
 
 	if !params.p.extendedEvalCtx.TxnIsSingleStmt {
 		return pgerror.Newf(pgcode.InvalidTransactionState, "cannot refresh view in a multi-statement transaction")
@@ -0,0 +1,657 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+var incrementalRefreshMaxChangedRows = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.materialized_view.incremental_refresh.max_changed_rows",
+	"the maximum number of rows of each base table of a materialized view which "+
+		"can have changed since its last refresh for it to be refreshed incrementally",
+	100000,
+	settings.PositiveInt,
+)
+
+// incrementalRefreshOverride is the session data override of the internal
+// statements which refresh materialized views incrementally. The statements
+// run as root, like the backfill of a full refresh.
+var incrementalRefreshOverride = sessiondata.InternalExecutorOverride{
+	User:                           username.RootUserName(),
+	AllowMaterializedViewMutations: true,
+}
+
+// incrementalViewQuery is the query of a materialized view which can be
+// refreshed incrementally. The query must group the rows of inner joins of
+// tables by columns which are also output by the query. The view is then
+// refreshed by recomputing only the groups which contain rows of the tables
+// that changed since the last refresh.
+type incrementalViewQuery struct {
+	clause *tree.SelectClause
+
+	// tables contains the occurrences of tables in the FROM clause of the
+	// query, in the order in which they appear.
+	tables []incrementalViewTable
+
+	// groupingExprs contains the grouping expressions of the query, and
+	// groupingCols contains the names of the view columns to which they are
+	// output.
+	groupingExprs tree.Exprs
+	groupingCols  tree.NameList
+
+	// viewCols contains the names of the visible columns of the view.
+	viewCols tree.NameList
+}
+
+// incrementalViewTable is an occurrence of a table in the FROM clause of the
+// query of a materialized view which can be refreshed incrementally.
+type incrementalViewTable struct {
+	// slot points to the table expression in the FROM clause, so that it can be
+	// temporarily replaced with the rows of the table which changed.
+	slot  *tree.TableExpr
+	name  tree.TableName
+	alias tree.Name
+	desc  catalog.TableDescriptor
+}
+
+// refreshIncrementally refreshes a materialized view by recomputing the groups
+// of its query which are affected by the changes to the rows of its tables
+// since the last refresh. The changed rows are found by an incremental export
+// of the primary indexes of the tables. The groups which contained these rows
+// as of the last refresh, or which contain them now, are deleted from the view
+// and computed again.
+func (n *refreshMaterializedViewNode) refreshIncrementally(params runParams) error {
+	ctx, p := params.ctx, params.p
+	if n.desc.IsRefreshViewRequired() || n.desc.MaterializedViewRefreshTime.IsEmpty() {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"materialized view %q must be refreshed without INCREMENTAL first", n.desc.GetName())
+	}
+	q, err := p.analyzeIncrementalViewQuery(ctx, n.desc)
+	if err != nil {
+		return err
+	}
+
+	lastRefresh := n.desc.MaterializedViewRefreshTime
+	now := p.Txn().ReadTimestamp()
+	var affected []tree.Datums
+	seen := make(map[string]struct{})
+	addAffected := func(rows []tree.Datums) {
+		for _, row := range rows {
+			key := tree.AsStringWithFlags(&tree.Tuple{Exprs: datumsToExprs(row)}, tree.FmtParsable)
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				affected = append(affected, row)
+			}
+		}
+	}
+	for i := range q.tables {
+		t := &q.tables[i]
+		changed, err := p.changedPrimaryKeys(ctx, t.desc, lastRefresh, now)
+		if err != nil {
+			return wrapIncrementalRefreshError(err)
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		// The groups which contained the changed rows as of the last refresh.
+		oldGroups, err := p.ExecCfg().InternalDB.Executor().QueryBufferedEx(
+			ctx, "incremental-refresh-old-groups", nil, /* txn */
+			incrementalRefreshOverride,
+			q.affectedGroupsQuery(t, changed, lastRefresh),
+		)
+		if err != nil {
+			return wrapIncrementalRefreshError(err)
+		}
+		addAffected(oldGroups)
+		// The groups which contain the changed rows now.
+		newGroups, err := p.InternalSQLTxn().QueryBufferedEx(
+			ctx, "incremental-refresh-new-groups", p.Txn(),
+			incrementalRefreshOverride,
+			q.affectedGroupsQuery(t, changed, hlc.Timestamp{}),
+		)
+		if err != nil {
+			return err
+		}
+		addAffected(newGroups)
+	}
+
+	if len(affected) > 0 {
+		viewName, err := p.getQualifiedTableName(ctx, n.desc)
+		if err != nil {
+			return err
+		}
+		if _, err := p.InternalSQLTxn().ExecEx(
+			ctx, "incremental-refresh-delete", p.Txn(),
+			incrementalRefreshOverride,
+			fmt.Sprintf("DELETE FROM %s WHERE %s",
+				viewName.FQString(), groupKeyPredicate(nameListToStrings(q.groupingCols), affected)),
+		); err != nil {
+			return err
+		}
+		if _, err := p.InternalSQLTxn().ExecEx(
+			ctx, "incremental-refresh-insert", p.Txn(),
+			incrementalRefreshOverride,
+			q.recomputeGroupsStatement(viewName, affected),
+		); err != nil {
+			return err
+		}
+	}
+
+	n.desc.MaterializedViewRefreshTime = now
+	return p.writeSchemaChange(
+		ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// wrapIncrementalRefreshError adds a hint to errors caused by reading the
+// tables as of a timestamp which was garbage collected.
+func wrapIncrementalRefreshError(err error) error {
+	if errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+		return errors.WithHint(err,
+			"the view was last refreshed before the garbage collection threshold of its tables; "+
+				"use REFRESH MATERIALIZED VIEW without INCREMENTAL instead")
+	}
+	return err
+}
+
+// analyzeIncrementalViewQuery returns an error if the query of the given
+// materialized view cannot be refreshed incrementally.
+func (p *planner) analyzeIncrementalViewQuery(
+	ctx context.Context, desc catalog.TableDescriptor,
+) (*incrementalViewQuery, error) {
+	notSupported := func(reason string) error {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"materialized view %q cannot be refreshed incrementally: %s", desc.GetName(), reason)
+	}
+	stmt, err := parser.ParseOne(desc.GetViewQuery())
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok || sel.With != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, notSupported("only queries without WITH, LIMIT or locking clauses are supported")
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || clause.TableSelect {
+		return nil, notSupported("only SELECT clauses are supported")
+	}
+	if clause.Distinct || clause.DistinctOn != nil || clause.Window != nil || clause.From.AsOf.Expr != nil {
+		return nil, notSupported("DISTINCT, WINDOW and AS OF SYSTEM TIME clauses are not supported")
+	}
+	if len(clause.GroupBy) == 0 {
+		return nil, notSupported("the query must have a GROUP BY clause")
+	}
+	var v incrementalViewExprChecker
+	for _, e := range clause.Exprs {
+		switch t := e.Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			return nil, notSupported("* is not supported in the SELECT list")
+		case *tree.UnresolvedName:
+			if t.Star {
+				return nil, notSupported("* is not supported in the SELECT list")
+			}
+		}
+		tree.WalkExprConst(&v, e.Expr)
+	}
+	if clause.Where != nil {
+		tree.WalkExprConst(&v, clause.Where.Expr)
+	}
+	if clause.Having != nil {
+		tree.WalkExprConst(&v, clause.Having.Expr)
+	}
+	if v.err != nil {
+		return nil, notSupported(v.err.Error())
+	}
+
+	q := &incrementalViewQuery{clause: clause}
+	for _, col := range desc.VisibleColumns() {
+		q.viewCols = append(q.viewCols, tree.Name(col.GetName()))
+	}
+	if len(q.viewCols) != len(clause.Exprs) {
+		return nil, errors.AssertionFailedf(
+			"view %q has %d columns, expected %d", desc.GetName(), len(q.viewCols), len(clause.Exprs))
+	}
+
+	// Each grouping expression must be output to a column of the view, so that
+	// the rows of the view can be matched with the groups.
+	for _, g := range clause.GroupBy {
+		ord := -1
+		if num, ok := g.(*tree.NumVal); ok {
+			if i, err := strconv.Atoi(num.String()); err == nil && i >= 1 && i <= len(clause.Exprs) {
+				ord = i - 1
+			}
+		} else {
+			groupStr := tree.AsStringWithFlags(g, tree.FmtParsable)
+			for i, e := range clause.Exprs {
+				if tree.AsStringWithFlags(e.Expr, tree.FmtParsable) == groupStr {
+					ord = i
+					break
+				}
+			}
+		}
+		if ord < 0 {
+			return nil, notSupported(fmt.Sprintf(
+				"GROUP BY expression %s is not in the SELECT list", tree.AsString(g)))
+		}
+		q.groupingExprs = append(q.groupingExprs, clause.Exprs[ord].Expr)
+		q.groupingCols = append(q.groupingCols, q.viewCols[ord])
+	}
+
+	for i := range clause.From.Tables {
+		if err := p.addIncrementalViewTables(ctx, q, &clause.From.Tables[i], notSupported); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// addIncrementalViewTables adds the occurrences of tables in the given table
+// expression of the FROM clause to q.
+func (p *planner) addIncrementalViewTables(
+	ctx context.Context,
+	q *incrementalViewQuery,
+	slot *tree.TableExpr,
+	notSupported func(reason string) error,
+) error {
+	var tn *tree.TableName
+	var alias tree.Name
+	switch t := (*slot).(type) {
+	case *tree.TableName:
+		tn, alias = t, t.ObjectName
+	case *tree.AliasedTableExpr:
+		name, ok := t.Expr.(*tree.TableName)
+		if !ok || t.Ordinality || t.Lateral || len(t.As.Cols) > 0 {
+			return notSupported("only tables and inner joins are supported in the FROM clause")
+		}
+		tn, alias = name, t.As.Alias
+		if alias == "" {
+			alias = name.ObjectName
+		}
+	case *tree.ParenTableExpr:
+		return p.addIncrementalViewTables(ctx, q, &t.Expr, notSupported)
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+			return notSupported("only inner joins are supported")
+		}
+		if err := p.addIncrementalViewTables(ctx, q, &t.Left, notSupported); err != nil {
+			return err
+		}
+		return p.addIncrementalViewTables(ctx, q, &t.Right, notSupported)
+	default:
+		return notSupported("only tables and inner joins are supported in the FROM clause")
+	}
+
+	_, desc, err := resolver.ResolveExistingTableObject(ctx, p, tn, tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveRequireTableDesc,
+	})
+	if err != nil {
+		return err
+	}
+	if desc.IsVirtualTable() {
+		return notSupported("virtual tables are not supported")
+	}
+	q.tables = append(q.tables, incrementalViewTable{slot: slot, name: *tn, alias: alias, desc: desc})
+	return nil
+}
+
+// incrementalViewExprChecker finds the expressions which prevent a query from
+// being refreshed incrementally.
+type incrementalViewExprChecker struct {
+	err error
+}
+
+var _ tree.Visitor = &incrementalViewExprChecker{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *incrementalViewExprChecker) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.err = errors.New("subqueries are not supported")
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.err = errors.New("window functions are not supported")
+		}
+	}
+	return v.err == nil, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (v *incrementalViewExprChecker) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// affectedGroupsQuery returns a query which computes the grouping keys of the
+// groups which contain the given changed rows of the table t, as of the given
+// timestamp if it is not empty.
+func (q *incrementalViewQuery) affectedGroupsQuery(
+	t *incrementalViewTable, changedKeys []tree.Datums, asOf hlc.Timestamp,
+) string {
+	orig := *t.slot
+	defer func() { *t.slot = orig }()
+	*t.slot = changedRowsTableExpr(t, changedKeys)
+
+	groups := &tree.SelectClause{
+		Distinct: true,
+		From:     tree.From{Tables: q.clause.From.Tables},
+		Where:    q.clause.Where,
+	}
+	for _, e := range q.groupingExprs {
+		groups.Exprs = append(groups.Exprs, tree.SelectExpr{Expr: e})
+	}
+	if !asOf.IsEmpty() {
+		groups.From.AsOf = tree.AsOfClause{Expr: tree.NewStrVal(asOf.AsOfSystemTime())}
+	}
+	return tree.AsStringWithFlags(groups, tree.FmtParsable)
+}
+
+// recomputeGroupsStatement returns a statement which inserts the given groups
+// of the query into the view.
+func (q *incrementalViewQuery) recomputeGroupsStatement(
+	viewName *tree.TableName, groups []tree.Datums,
+) string {
+	groupingExprs := make([]string, len(q.groupingExprs))
+	for i, e := range q.groupingExprs {
+		groupingExprs[i] = tree.AsStringWithFlags(e, tree.FmtParsable)
+	}
+	pred, err := parser.ParseExpr(groupKeyPredicate(groupingExprs, groups))
+	if err != nil {
+		// The predicate is built from parsable expressions.
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "invalid group predicate"))
+	}
+	clause := *q.clause
+	if clause.Where == nil {
+		clause.Where = tree.NewWhere(tree.AstWhere, pred)
+	} else {
+		clause.Where = tree.NewWhere(tree.AstWhere, &tree.AndExpr{
+			Left:  &tree.ParenExpr{Expr: clause.Where.Expr},
+			Right: &tree.ParenExpr{Expr: pred},
+		})
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) %s",
+		viewName.FQString(),
+		tree.AsStringWithFlags(&q.viewCols, tree.FmtParsable),
+		tree.AsStringWithFlags(&clause, tree.FmtParsable),
+	)
+}
+
+// changedRowsTableExpr returns a table expression which contains the rows of
+// the table t with the given primary keys, under the alias of t.
+func changedRowsTableExpr(t *incrementalViewTable, changedKeys []tree.Datums) tree.TableExpr {
+	var cols tree.NameList
+	for _, col := range t.desc.PublicColumns() {
+		cols = append(cols, tree.Name(col.GetName()))
+	}
+	idx := t.desc.GetPrimaryIndex()
+	pkCols := make([]string, idx.NumKeyColumns())
+	for i := range pkCols {
+		pkCols[i] = tree.NameString(idx.GetKeyColumnName(i))
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		tree.AsStringWithFlags(&cols, tree.FmtParsable),
+		t.name.FQString(),
+		groupKeyPredicate(pkCols, changedKeys),
+	)
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		// The query is built from parsable expressions.
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "invalid changed rows query"))
+	}
+	return &tree.AliasedTableExpr{
+		Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: stmt.AST.(*tree.Select)}},
+		As:   tree.AliasClause{Alias: t.alias},
+	}
+}
+
+// groupKeyPredicate returns a predicate which is true for the rows in which
+// the values of the given expressions are equal to one of the given keys. NULL
+// values are equal to each other.
+func groupKeyPredicate(exprs []string, keys []tree.Datums) string {
+	var buf strings.Builder
+	var tuples []string
+	var nullConds []string
+	for _, key := range keys {
+		hasNull := false
+		for _, d := range key {
+			if d == tree.DNull {
+				hasNull = true
+				break
+			}
+		}
+		if !hasNull {
+			// A single expression is compared with scalars, since (a) is not a
+			// tuple and cannot be compared with 1-tuples.
+			var k tree.Expr = &tree.Tuple{Exprs: datumsToExprs(key)}
+			if len(key) == 1 {
+				k = key[0]
+			}
+			tuples = append(tuples, tree.AsStringWithFlags(k, tree.FmtParsable))
+			continue
+		}
+		conds := make([]string, len(key))
+		for i, d := range key {
+			if d == tree.DNull {
+				conds[i] = fmt.Sprintf("(%s) IS NULL", exprs[i])
+			} else {
+				conds[i] = fmt.Sprintf("(%s) = %s", exprs[i], tree.AsStringWithFlags(d, tree.FmtParsable))
+			}
+		}
+		nullConds = append(nullConds, "("+strings.Join(conds, " AND ")+")")
+	}
+	if len(tuples) > 0 {
+		fmt.Fprintf(&buf, "(%s) IN (%s)", strings.Join(exprs, ", "), strings.Join(tuples, ", "))
+	} else {
+		buf.WriteString("false")
+	}
+	for _, c := range nullConds {
+		buf.WriteString(" OR ")
+		buf.WriteString(c)
+	}
+	return buf.String()
+}
+
+func datumsToExprs(datums tree.Datums) tree.Exprs {
+	exprs := make(tree.Exprs, len(datums))
+	for i, d := range datums {
+		exprs[i] = d
+	}
+	return exprs
+}
+
+func nameListToStrings(names tree.NameList) []string {
+	res := make([]string, len(names))
+	for i := range names {
+		res[i] = names[i].String()
+	}
+	return res
+}
+
+// changedPrimaryKeys returns the primary keys of the rows of the given table
+// which were inserted, updated or deleted in the time interval (since, asOf].
+// Returns an error if there are more than
+// sql.materialized_view.incremental_refresh.max_changed_rows changed rows.
+func (p *planner) changedPrimaryKeys(
+	ctx context.Context, desc catalog.TableDescriptor, since, asOf hlc.Timestamp,
+) ([]tree.Datums, error) {
+	codec := p.ExecCfg().Codec
+	idx := desc.GetPrimaryIndex()
+	colTypes := make([]*types.T, idx.NumKeyColumns())
+	colDirs := make([]catenumpb.IndexColumn_Direction, idx.NumKeyColumns())
+	for i := range colTypes {
+		col, err := catalog.MustFindColumnByID(desc, idx.GetKeyColumnID(i))
+		if err != nil {
+			return nil, err
+		}
+		colTypes[i] = col.GetType()
+		colDirs[i] = idx.GetKeyColumnDirection(i)
+	}
+	maxChangedRows := int(incrementalRefreshMaxChangedRows.Get(&p.ExecCfg().Settings.SV))
+
+	var changed []tree.Datums
+	seen := make(map[string]struct{})
+	var alloc tree.DatumAlloc
+	header := kvpb.Header{Timestamp: asOf, ReturnElasticCPUResumeSpans: true}
+	span := desc.PrimaryIndexSpan(codec)
+	for {
+		req := &kvpb.ExportRequest{
+			RequestHeader: kvpb.RequestHeaderFromSpan(span),
+			StartTime:     since,
+			MVCCFilter:    kvpb.MVCCFilter_Latest,
+		}
+		rawResp, pErr := kv.SendWrappedWith(ctx, p.ExecCfg().DB.NonTransactionalSender(), header, req)
+		if pErr != nil {
+			return nil, pErr.GoError()
+		}
+		resp := rawResp.(*kvpb.ExportResponse)
+		for _, file := range resp.Files {
+			if err := func() error {
+				it, err := storage.NewMemSSTIterator(file.SST, false /* verify */, storage.IterOptions{
+					KeyTypes:   storage.IterKeyTypePointsAndRanges,
+					LowerBound: keys.MinKey,
+					UpperBound: keys.MaxKey,
+				})
+				if err != nil {
+					return err
+				}
+				defer it.Close()
+				for it.SeekGE(storage.NilKey); ; it.Next() {
+					if ok, err := it.Valid(); err != nil || !ok {
+						return err
+					}
+					if _, hasRange := it.HasPointAndRange(); hasRange {
+						return pgerror.Newf(pgcode.FeatureNotSupported,
+							"table %q had a range of rows deleted since the last refresh", desc.GetName())
+					}
+					key := it.UnsafeKey().Key
+					prefixLen, err := keys.GetRowPrefixLength(key)
+					if err != nil {
+						return err
+					}
+					if _, ok := seen[string(key[:prefixLen])]; ok {
+						continue
+					}
+					seen[string(key[:prefixLen])] = struct{}{}
+					if len(changed) >= maxChangedRows {
+						return pgerror.Newf(pgcode.ProgramLimitExceeded,
+							"more than %d rows of table %q changed since the last refresh",
+							maxChangedRows, desc.GetName())
+					}
+					datums, err := rowenc.DecodeIndexKeyToDatums(codec, colTypes, colDirs, key, &alloc)
+					if err != nil {
+						return err
+					}
+					changed = append(changed, datums)
+				}
+			}(); err != nil {
+				return nil, err
+			}
+		}
+		if resp.ResumeSpan == nil {
+			return changed, nil
+		}
+		span = *resp.ResumeSpan
+	}
+}
+
+// ScheduleMaterializedViewRefresh is part of the eval.Planner interface.
+func (p *planner) ScheduleMaterializedViewRefresh(
+	ctx context.Context, viewName string, recurrence string,
+) (int64, error) {
+	un, err := parser.ParseTableName(viewName)
+	if err != nil {
+		return 0, err
+	}
+	name := un.ToTableName()
+	_, desc, err := resolver.ResolveExistingTableObject(ctx, p, &name, tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveRequireViewDesc,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !desc.MaterializedView() {
+		return 0, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.GetName())
+	}
+	// Only the owner or an admin can refresh the view, so only they can
+	// schedule its refreshes.
+	hasAdminRole, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return 0, err
+	}
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return 0, err
+	}
+	if !(hasOwnership || hasAdminRole) {
+		return 0, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of materialized view %s", desc.GetName())
+	}
+	if _, err := p.analyzeIncrementalViewQuery(ctx, desc); err != nil {
+		return 0, err
+	}
+
+	tn, err := p.getQualifiedTableName(ctx, desc)
+	if err != nil {
+		return 0, err
+	}
+	stmt := fmt.Sprintf("REFRESH MATERIALIZED VIEW %s INCREMENTAL", tn.FQString())
+	sj := jobs.NewScheduledJob(JobSchedulerEnv(p.ExecCfg().JobsKnobs()))
+	sj.SetScheduleLabel(stmt)
+	sj.SetOwner(p.User())
+	sj.SetScheduleDetails(jobspb.ScheduleDetails{
+		Wait: jobspb.ScheduleDetails_WAIT,
+		// If a refresh fails, try again at the next scheduled time.
+		OnError: jobspb.ScheduleDetails_RETRY_SCHED,
+	})
+	if err := sj.SetSchedule(recurrence); err != nil {
+		return 0, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid recurrence %q", recurrence)
+	}
+	args, err := pbtypes.MarshalAny(&jobspb.SqlStatementExecutionArg{Statement: stmt})
+	if err != nil {
+		return 0, err
+	}
+	sj.SetExecutionDetails(jobs.InlineExecutorName, jobspb.ExecutionArguments{Args: args})
+	if err := jobs.ScheduledJobTxn(p.InternalSQLTxn()).Create(ctx, sj); err != nil {
+		return 0, err
+	}
+	return sj.ScheduleID(), nil
+}
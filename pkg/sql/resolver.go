@@ -787,6 +787,16 @@ type fkSelfResolver struct {
 
 var _ resolver.SchemaResolver = &fkSelfResolver{}
 
+// checkReferencesPrivilege implements the referencesPrivilegeChecker interface.
+func (r *fkSelfResolver) checkReferencesPrivilege(
+	ctx context.Context, target catalog.TableDescriptor, referencedCols []catalog.Column,
+) error {
+	if c, ok := r.SchemaResolver.(referencesPrivilegeChecker); ok {
+		return c.checkReferencesPrivilege(ctx, target, referencedCols)
+	}
+	return nil
+}
+
 // LookupObject implements the tree.ObjectNameExistingResolver interface.
 func (r *fkSelfResolver) LookupObject(
 	ctx context.Context, flags tree.ObjectLookupFlags, dbName, scName, obName string,
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 )
 
@@ -159,7 +160,17 @@ func (n *RevokeRoleNode) startExec(params runParams) error {
 		}
 	}
 
-	return nil
+	sqlUsernameToStrings := func(sqlUsernames []username.SQLUsername) []string {
+		strings := make([]string, len(sqlUsernames))
+		for i, sqlUsername := range sqlUsernames {
+			strings[i] = sqlUsername.Normalized()
+		}
+		return strings
+	}
+
+	return params.p.logEvent(params.ctx,
+		0, /* no target */
+		&eventpb.RevokeRole{RevokeeRoles: sqlUsernameToStrings(n.roles), Members: sqlUsernameToStrings(n.members)})
 }
 
 // Next implements the planNode interface.
@@ -0,0 +1,379 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/optional"
+	"github.com/cockroachdb/errors"
+)
+
+// adaptiveJoinerState represents the state of the adaptive join processor.
+type adaptiveJoinerState int
+
+const (
+	ajStateUnknown adaptiveJoinerState = iota
+	// ajBuffering represents the state the adaptiveJoiner is in when it
+	// buffers the rows of its input until either the input is exhausted or
+	// the number of rows exceeds the threshold.
+	ajBuffering
+	// ajJoining represents the state the adaptiveJoiner is in when it emits
+	// the rows produced by the lookup or the hash joiner.
+	ajJoining
+)
+
+// adaptiveJoiner joins its input with an index using either a lookup join or
+// a hash join against a full scan of the index. The algorithm is chosen once
+// the number of input rows is known to be at most, or greater than, the
+// threshold computed by the optimizer. The buffered rows are then replayed to
+// the chosen joiner, followed by the remaining rows of the input.
+type adaptiveJoiner struct {
+	execinfra.ProcessorBase
+
+	spec  *execinfrapb.AdaptiveJoinerSpec
+	input execinfra.RowSource
+
+	runningState adaptiveJoinerState
+
+	// buffered contains the rows read from the input while buffering.
+	buffered []rowenc.EncDatumRow
+	rowAlloc rowenc.EncDatumRowAlloc
+	memAcc   mon.BoundAccount
+	// inputDone is true if the input was exhausted while buffering.
+	inputDone bool
+
+	// joiner is the lookup or hash joiner chosen once buffering is done.
+	joiner execinfra.RowSource
+	// hashJoin is true if joiner is a hash joiner.
+	hashJoin bool
+
+	// drainSource is the input to drain: the input of the adaptiveJoiner
+	// until the joiner is created, and the joiner afterwards.
+	drainSource struct {
+		execinfra.RowSource
+	}
+
+	collectStats         bool
+	joinerStats, trStats func() *execinfrapb.ComponentStats
+}
+
+var _ execinfra.Processor = &adaptiveJoiner{}
+var _ execinfra.RowSource = &adaptiveJoiner{}
+var _ execopnode.OpNode = &adaptiveJoiner{}
+
+const adaptiveJoinerProcName = "adaptive joiner"
+
+// newAdaptiveJoiner creates a new adaptive join processor.
+func newAdaptiveJoiner(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.AdaptiveJoinerSpec,
+	input execinfra.RowSource,
+	post *execinfrapb.PostProcessSpec,
+) (*adaptiveJoiner, error) {
+	a := &adaptiveJoiner{spec: spec, input: input}
+	a.drainSource.RowSource = input
+
+	// The output columns of both joiners are the input columns followed by
+	// the fetched columns of the index, if the join type outputs them.
+	outputTypes := input.OutputTypes()
+	if spec.LookupJoiner.Type.ShouldIncludeRightColsInOutput() {
+		fetchedCols := spec.LookupJoiner.FetchSpec.FetchedColumns
+		outputTypes = make([]*types.T, 0, len(input.OutputTypes())+len(fetchedCols))
+		outputTypes = append(outputTypes, input.OutputTypes()...)
+		for i := range fetchedCols {
+			outputTypes = append(outputTypes, fetchedCols[i].Type)
+		}
+	}
+
+	if err := a.Init(
+		ctx,
+		a,
+		post,
+		outputTypes,
+		flowCtx,
+		processorID,
+		nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			InputsToDrain: []execinfra.RowSource{&a.drainSource},
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				a.close()
+				return nil
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	a.MemMonitor = execinfra.NewLimitedMonitor(ctx, flowCtx.Mon, flowCtx, "adaptivejoiner-limited")
+	a.memAcc = a.MemMonitor.MakeBoundAccount()
+
+	// If the trace is recording, the joiner chosen at execution time will be
+	// instrumented to collect stats, which are reported as the stats of the
+	// adaptiveJoiner.
+	if execstats.ShouldCollectStats(ctx, flowCtx.CollectStats) {
+		a.collectStats = true
+		a.ExecStatsForTrace = a.execStatsForTrace
+	}
+	return a, nil
+}
+
+// Start is part of the RowSource interface.
+func (a *adaptiveJoiner) Start(ctx context.Context) {
+	ctx = a.StartInternal(ctx, adaptiveJoinerProcName)
+	a.input.Start(ctx)
+	a.runningState = ajBuffering
+}
+
+// Next is part of the RowSource interface.
+func (a *adaptiveJoiner) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for a.State == execinfra.StateRunning {
+		var row rowenc.EncDatumRow
+		var meta *execinfrapb.ProducerMetadata
+		switch a.runningState {
+		case ajBuffering:
+			a.runningState, meta = a.buffer()
+		case ajJoining:
+			row, meta = a.joiner.Next()
+			if meta != nil {
+				if meta.Err != nil {
+					a.MoveToDraining(nil /* err */)
+				}
+				return nil, meta
+			}
+			if row == nil {
+				a.MoveToDraining(nil /* err */)
+				continue
+			}
+		default:
+			log.Fatalf(a.Ctx(), "unsupported state: %d", a.runningState)
+		}
+
+		if meta != nil {
+			return nil, meta
+		}
+		if row == nil {
+			continue
+		}
+		if outRow := a.ProcessRowHelper(row); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, a.DrainHelper()
+}
+
+// buffer reads rows from the input until either the input is exhausted or
+// more than threshold rows have been read, and then creates the joiner.
+func (a *adaptiveJoiner) buffer() (adaptiveJoinerState, *execinfrapb.ProducerMetadata) {
+	for uint64(len(a.buffered)) <= a.spec.Threshold {
+		row, meta := a.input.Next()
+		if meta != nil {
+			if meta.Err != nil {
+				a.MoveToDraining(nil /* err */)
+				return ajStateUnknown, meta
+			}
+			return ajBuffering, meta
+		}
+		if row == nil {
+			a.inputDone = true
+			break
+		}
+		if err := a.memAcc.Grow(a.Ctx(), int64(row.Size())); err != nil {
+			if !sqlerrors.IsOutOfMemoryError(err) {
+				a.MoveToDraining(err)
+				return ajStateUnknown, nil
+			}
+			// The input is too large to be buffered, which means that it
+			// has many rows, so the hash join is used. The row is still
+			// buffered since it has already been read.
+			a.buffered = append(a.buffered, a.rowAlloc.CopyRow(row))
+			break
+		}
+		a.buffered = append(a.buffered, a.rowAlloc.CopyRow(row))
+	}
+
+	a.hashJoin = !a.inputDone
+	if err := a.createJoiner(); err != nil {
+		a.MoveToDraining(err)
+		return ajStateUnknown, nil
+	}
+	return ajJoining, nil
+}
+
+// createJoiner creates and starts the lookup or hash joiner, depending on
+// a.hashJoin.
+func (a *adaptiveJoiner) createJoiner() error {
+	log.VEventf(a.Ctx(), 2,
+		"adaptive join read %d input rows with threshold %d, using %s",
+		len(a.buffered), a.spec.Threshold, a.algorithm(),
+	)
+	ctx := a.Ctx()
+	input := &adaptiveJoinerInput{a: a}
+	emptyPost := execinfrapb.PostProcessSpec{}
+	var joiner execinfra.RowSourcedProcessor
+	if a.hashJoin {
+		tr, err := newTableReader(ctx, a.FlowCtx, a.ProcessorID, &a.spec.TableReader, &emptyPost)
+		if err != nil {
+			return err
+		}
+		hj, err := newHashJoiner(ctx, a.FlowCtx, a.ProcessorID, &a.spec.HashJoiner, input, tr, &emptyPost)
+		if err != nil {
+			return err
+		}
+		if a.collectStats {
+			a.trStats = tr.HijackExecStatsForTrace()
+		}
+		joiner = hj
+	} else {
+		jr, err := newJoinReader(
+			ctx, a.FlowCtx, a.ProcessorID, &a.spec.LookupJoiner, input, &emptyPost, lookupJoinReaderType,
+		)
+		if err != nil {
+			return err
+		}
+		joiner = jr
+	}
+	if a.collectStats {
+		if h, ok := joiner.(execinfra.ExecStatsForTraceHijacker); ok {
+			a.joinerStats = h.HijackExecStatsForTrace()
+		}
+	}
+	joiner.Start(ctx)
+	a.joiner = joiner
+	a.drainSource.RowSource = joiner
+	return nil
+}
+
+// algorithm returns the name of the join algorithm used by the adaptiveJoiner.
+func (a *adaptiveJoiner) algorithm() string {
+	if a.hashJoin {
+		return "hash join"
+	}
+	return "lookup join"
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (a *adaptiveJoiner) ConsumerClosed() {
+	a.close()
+}
+
+func (a *adaptiveJoiner) close() {
+	if a.InternalClose() {
+		a.buffered = nil
+		a.memAcc.Close(a.Ctx())
+		a.MemMonitor.Stop(a.Ctx())
+	}
+}
+
+// execStatsForTrace implements ProcessorBase.ExecStatsForTrace.
+func (a *adaptiveJoiner) execStatsForTrace() *execinfrapb.ComponentStats {
+	if a.joinerStats == nil {
+		return nil
+	}
+	res := a.joinerStats()
+	if res == nil {
+		return nil
+	}
+	// Keep only the stats of the input of the adaptiveJoiner, and report the
+	// output of the adaptiveJoiner, which applies the post-processing.
+	if len(res.Inputs) > 1 {
+		res.Inputs = res.Inputs[:1]
+	}
+	res.Output = a.OutputHelper.Stats()
+	if a.trStats != nil {
+		// The hash joiner does not read from KV itself, so the KV stats are
+		// those of the table reader.
+		if trStats := a.trStats(); trStats != nil {
+			res.KV = trStats.KV
+			res.Exec.ConsumedRU.MaybeAdd(trStats.Exec.ConsumedRU)
+		}
+	}
+	res.Exec.MaxAllocatedMem.Add(a.MemMonitor.MaximumBytes())
+	var switches uint64
+	if a.hashJoin != a.spec.PlannedHashJoin {
+		switches = 1
+	}
+	res.Exec.AdaptiveJoinSwitches = optional.MakeUint(switches)
+	return res
+}
+
+// ChildCount is part of the execopnode.OpNode interface.
+func (a *adaptiveJoiner) ChildCount(verbose bool) int {
+	if _, ok := a.input.(execopnode.OpNode); ok {
+		return 1
+	}
+	return 0
+}
+
+// Child is part of the execopnode.OpNode interface.
+func (a *adaptiveJoiner) Child(nth int, verbose bool) execopnode.OpNode {
+	if nth == 0 {
+		if n, ok := a.input.(execopnode.OpNode); ok {
+			return n
+		}
+		panic("input to adaptiveJoiner is not an execopnode.OpNode")
+	}
+	panic(errors.AssertionFailedf("invalid index %d", nth))
+}
+
+// adaptiveJoinerInput is the input of the joiner created by an
+// adaptiveJoiner. It replays the rows buffered by the adaptiveJoiner, followed
+// by the remaining rows of its input.
+type adaptiveJoinerInput struct {
+	a *adaptiveJoiner
+}
+
+var _ execinfra.RowSource = &adaptiveJoinerInput{}
+
+// OutputTypes is part of the RowSource interface.
+func (i *adaptiveJoinerInput) OutputTypes() []*types.T {
+	return i.a.input.OutputTypes()
+}
+
+// Start is part of the RowSource interface. The input of the adaptiveJoiner
+// has already been started.
+func (i *adaptiveJoinerInput) Start(context.Context) {}
+
+// Next is part of the RowSource interface.
+func (i *adaptiveJoinerInput) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	if len(i.a.buffered) > 0 {
+		row := i.a.buffered[0]
+		i.a.buffered = i.a.buffered[1:]
+		return row, nil
+	}
+	if i.a.inputDone {
+		return nil, nil
+	}
+	return i.a.input.Next()
+}
+
+// ConsumerDone is part of the RowSource interface.
+func (i *adaptiveJoinerInput) ConsumerDone() {
+	i.a.buffered = nil
+	i.a.input.ConsumerDone()
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (i *adaptiveJoinerInput) ConsumerClosed() {
+	i.a.buffered = nil
+	i.a.input.ConsumerClosed()
+}
@@ -395,6 +395,12 @@ func NewProcessor(
 		}
 		return newHashGroupJoiner(ctx, flowCtx, processorID, core.HashGroupJoiner, inputs[0], inputs[1], post)
 	}
+	if core.AdaptiveJoiner != nil {
+		if err := checkNumIn(inputs, 1); err != nil {
+			return nil, err
+		}
+		return newAdaptiveJoiner(ctx, flowCtx, processorID, core.AdaptiveJoiner, inputs[0], post)
+	}
 	if core.GenerativeSplitAndScatter != nil {
 		if err := checkNumIn(inputs, 0); err != nil {
 			return nil, err
@@ -155,6 +155,13 @@ func newSampleAggregator(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateMCV || spec.Sketches[i].GenerateDependencies {
+			// Extended statistics are computed from the values of all the
+			// columns of the sketch.
+			for _, c := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(c))
+			}
+		}
 	}
 
 	s.sr.Init(
@@ -514,6 +521,16 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 					return err
 				}
 				histogram = &h
+			} else if (si.spec.GenerateMCV || si.spec.GenerateDependencies) &&
+				(si.numRows == 0 || len(s.sr.Get()) != 0) {
+				// Multi-column statistics don't have histograms, so their extended
+				// statistics are stored in place of the histogram. They are skipped
+				// if the sample collection was disabled.
+				ext, err := s.generateExtendedStatistics(ctx, &si)
+				if err != nil {
+					return err
+				}
+				histogram = &stats.HistogramData{Extended: &ext}
 			}
 
 			columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
@@ -664,6 +681,33 @@ func (s *sampleAggregator) generateHistogram(
 	return h, err
 }
 
+// generateExtendedStatistics computes the extended statistics on the columns
+// of the given sketch from the sampled rows.
+func (s *sampleAggregator) generateExtendedStatistics(
+	ctx context.Context, si *sketchInfo,
+) (stats.ExtendedStatisticsData, error) {
+	colIdxs := make([]int, len(si.spec.Columns))
+	colTypes := make([]*types.T, len(si.spec.Columns))
+	for i, c := range si.spec.Columns {
+		colIdxs[i] = int(c)
+		colTypes[i] = s.inTypes[c]
+	}
+	prevCapacity := s.sr.Cap()
+	rows, err := s.sr.GetRowDatums(ctx, &s.tempMemAcc, colIdxs)
+	if err != nil {
+		return stats.ExtendedStatisticsData{}, err
+	}
+	if s.sr.Cap() != prevCapacity {
+		log.Infof(
+			ctx, "histogram samples reduced from %d to %d due to excessive memory utilization",
+			prevCapacity, s.sr.Cap(),
+		)
+	}
+	return stats.ComputeExtendedStatistics(
+		ctx, &s.tempMemAcc, rows, colTypes, si.spec.GenerateMCV, si.spec.GenerateDependencies,
+	)
+}
+
 var _ execinfra.DoesNotUseTxn = &sampleAggregator{}
 
 // DoesNotUseTxn implements the DoesNotUseTxn interface.
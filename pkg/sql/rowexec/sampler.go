@@ -146,6 +146,13 @@ func newSamplerProcessor(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateMCV || spec.Sketches[i].GenerateDependencies {
+			// Extended statistics are computed from the values of all the
+			// columns of the sketch.
+			for _, c := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(c))
+			}
+		}
 	}
 	for i := range spec.InvertedSketches {
 		var sr stats.SampleReservoir
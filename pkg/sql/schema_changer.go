@@ -477,6 +477,10 @@ func (sc *SchemaChanger) maybeMakeAddTablePublic(
 			return nil
 		}
 		mut.State = descpb.DescriptorState_PUBLIC
+		// The data of a materialized view was computed as of its creation.
+		if mut.MaterializedView() && !mut.IsRefreshViewRequired() {
+			mut.MaterializedViewRefreshTime = mut.GetCreateAsOfTime()
+		}
 		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, mut, txn.KV())
 	})
 }
@@ -1012,6 +1012,10 @@ func (b *builderState) resolveRelation(
 	if err == nil {
 		return c
 	}
+	if p.FallBackIfPrivilegeMissing {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"missing %s privilege on %s", p.RequiredPrivilege, tree.ErrNameString(rel.GetName())))
+	}
 	if p.RequiredPrivilege != privilege.CREATE {
 		panic(err)
 	}
@@ -79,6 +79,9 @@ func alterTableAddColumn(
 	if d.GeneratedIdentity.IsGeneratedAsIdentity {
 		panic(scerrors.NotImplementedErrorf(d, "contains generated identity type"))
 	}
+	if d.IsEncrypted() {
+		panic(scerrors.NotImplementedErrorf(d, "contains encrypted column"))
+	}
 	// Unique without an index is unsupported.
 	if d.Unique.WithoutIndex {
 		// TODO(rytaft): add support for this in the future if we want to expose
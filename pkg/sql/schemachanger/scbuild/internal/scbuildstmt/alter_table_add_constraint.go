@@ -265,7 +265,7 @@ func alterTableAddForeignKey(
 	// table name) and check whether it's in the same database as the originTable
 	// (i.e. tbl). Cross database FK references is a deprecated feature and is in
 	// practice no longer supported. We will return an error here directly.
-	referencedTableID := mustGetTableIDFromTableName(b, fkDef.Table)
+	referencedTableID := mustGetReferencedTableIDFromTableName(b, fkDef.Table)
 	originalTableNamespaceElem := mustRetrieveNamespaceElem(b, tbl.TableID)
 	referencedTableNamespaceElem := mustRetrieveNamespaceElem(b, referencedTableID)
 	if originalTableNamespaceElem.DatabaseID != referencedTableNamespaceElem.DatabaseID {
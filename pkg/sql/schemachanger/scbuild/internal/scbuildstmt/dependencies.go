@@ -323,6 +323,11 @@ type ResolveParams struct {
 	// WithOffline, if set, instructs the catalog reader to include offline
 	// descriptors.
 	WithOffline bool
+
+	// FallBackIfPrivilegeMissing, if set, causes the statement to be handled by
+	// the legacy schema changer instead of failing when the current user does
+	// not have RequiredPrivilege on the resolved relation.
+	FallBackIfPrivilegeMissing bool
 }
 
 // NameResolver looks up elements in the catalog by name, and vice-versa.
@@ -383,10 +383,15 @@ func mustGetColumnIDFromColumnName(
 // Currently unused.
 var _ = mustGetColumnIDFromColumnName
 
-func mustGetTableIDFromTableName(b BuildCtx, tableName tree.TableName) catid.DescID {
+// mustGetReferencedTableIDFromTableName resolves the table referenced by a
+// foreign key. Users without the CREATE privilege on that table may still hold
+// the REFERENCES privilege on the table or on its columns, which is checked by
+// the legacy schema changer.
+func mustGetReferencedTableIDFromTableName(b BuildCtx, tableName tree.TableName) catid.DescID {
 	tableElems := b.ResolveTable(tableName.ToUnresolvedObjectName(), ResolveParams{
-		IsExistenceOptional: false,
-		RequiredPrivilege:   privilege.CREATE,
+		IsExistenceOptional:        false,
+		RequiredPrivilege:          privilege.CREATE,
+		FallBackIfPrivilegeMissing: true,
 	})
 	_, _, tableElem := scpb.FindTable(tableElems)
 	if tableElem == nil {
@@ -336,6 +336,13 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 			}(tbl),
 		})
 	default:
+		// Row-level security policies are not modeled as elements, so the
+		// declarative schema changer cannot maintain their dependencies on the
+		// columns of the table.
+		if len(tbl.GetPolicies()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil, /* n */
+				"table %q has row-level security policies", tbl.GetName()))
+		}
 		w.ev(descriptorStatus(tbl), &scpb.Table{
 			TableID:     tbl.GetID(),
 			IsTemporary: tbl.IsTemporary(),
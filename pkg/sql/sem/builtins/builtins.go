@@ -4838,6 +4838,165 @@ value if you rely on the HLC for accuracy.`,
 		},
 	),
 
+	"crdb_internal.encrypt_column_value": makeColumnEncryptionBuiltin(
+		func(evalCtx *eval.Context) columnEncryptionFunc { return evalCtx.Planner.EncryptColumnValue },
+		"Encrypts the given value with the current key of the given encrypted column. "+
+			"Used to write the values of encrypted columns.",
+	),
+
+	"crdb_internal.decrypt_column_value": makeColumnEncryptionBuiltin(
+		func(evalCtx *eval.Context) columnEncryptionFunc { return evalCtx.Planner.DecryptColumnValue },
+		"Decrypts the given value of the given encrypted column. "+
+			"Requires the DECRYPT privilege on the table.",
+	),
+
+	"crdb_internal.create_statement_hint": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "fingerprint_id", Typ: types.Bytes},
+				{Name: "hint_type", Typ: types.String},
+				{Name: "hint_value", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				id, err := evalCtx.Planner.CreateStatementHint(
+					ctx,
+					[]byte(tree.MustBeDBytes(args[0])),
+					string(tree.MustBeDString(args[1])),
+					string(tree.MustBeDString(args[2])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDInt(tree.DInt(id)), nil
+			},
+			Info: "Attaches a hint to the statements with the given fingerprint ID, as " +
+				"reported by the statement statistics, and returns the ID of the hint. " +
+				"The hint type is one of index (with a value of the form table@index), " +
+				"join_algorithm (hash, merge, lookup or inverted), join_order (fixed) " +
+				"and session_setting (with a value of the form name=value, where name " +
+				"is a setting of the optimizer).",
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	"crdb_internal.drop_statement_hint": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "hint_id", Typ: types.Int}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dropped, err := evalCtx.Planner.DropStatementHint(ctx, int64(tree.MustBeDInt(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(dropped)), nil
+			},
+			Info:       "Removes the statement hint with the given ID. Returns false if there was no such hint.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	"crdb_internal.schedule_materialized_view_refresh": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "view_name", Typ: types.String},
+				{Name: "recurrence", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				id, err := evalCtx.Planner.ScheduleMaterializedViewRefresh(
+					ctx,
+					string(tree.MustBeDString(args[0])),
+					string(tree.MustBeDString(args[1])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDInt(tree.DInt(id)), nil
+			},
+			Info: "Creates a schedule which refreshes the given materialized view " +
+				"incrementally with the given cron recurrence, and returns the ID of the schedule.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	"crdb_internal.create_hypothetical_index": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "create_index_stmt", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				name, err := evalCtx.Planner.CreateHypotheticalIndex(ctx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(name), nil
+			},
+			Info: "Adds a hypothetical index, defined by the given CREATE INDEX statement, to " +
+				"the session and returns its name. Hypothetical indexes are not built, but " +
+				"are considered by EXPLAIN in the session that created them.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	"crdb_internal.drop_hypothetical_index": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "index_name", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dropped, err := evalCtx.Planner.DropHypotheticalIndex(ctx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(dropped)), nil
+			},
+			Info:       "Removes the hypothetical index with the given name from the session. Returns false if there was no such index.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	"crdb_internal.calibrate_cost_model": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "apply", Typ: types.Bool}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				jobID, err := evalCtx.Planner.CalibrateCostModel(ctx, bool(tree.MustBeDBool(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDInt(tree.DInt(jobID)), nil
+			},
+			Info: "Starts a job which fits the CPU, sequential I/O and random I/O cost factors " +
+				"of the optimizer to the sampled execution statistics of the workload, and returns " +
+				"the ID of the job. If apply is true, the job writes the fitted factors to the " +
+				"sql.opt.cost_calibration cluster settings and enables them.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	"crdb_internal.node_executable_version": makeBuiltin(
 		tree.FunctionProperties{Category: builtinconstants.CategorySystemInfo},
 		tree.Overload{
@@ -10934,3 +11093,39 @@ func spanToDatum(span roachpb.Span) (tree.Datum, error) {
 	}
 	return result, nil
 }
+
+type columnEncryptionFunc func(
+	ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+) (tree.Datum, error)
+
+// makeColumnEncryptionBuiltin returns the definition of a builtin which
+// encrypts or decrypts the STRING or BYTES values of an encrypted column.
+func makeColumnEncryptionBuiltin(
+	fn func(evalCtx *eval.Context) columnEncryptionFunc, info string,
+) builtinDefinition {
+	makeOverload := func(typ *types.T) tree.Overload {
+		return tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "table_id", Typ: types.Int},
+				{Name: "column_id", Typ: types.Int},
+				{Name: "value", Typ: typ},
+			},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				tableID := descpb.ID(tree.MustBeDInt(args[0]))
+				colID := descpb.ColumnID(tree.MustBeDInt(args[1]))
+				return fn(evalCtx)(ctx, tableID, colID, args[2])
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		}
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		makeOverload(types.String),
+		makeOverload(types.Bytes),
+	)
+}
@@ -2437,6 +2437,17 @@ var builtinOidsArray = []string{
 	2464: `workload_index_recs(budget: string) -> string`,
 	2465: `workload_index_recs(timestamptz: timestamptz, budget: string) -> string`,
 	2466: `crdb_internal.setup_span_configs_stream(tenant_name: string) -> bytes`,
+	2467: `crdb_internal.encrypt_column_value(table_id: int, column_id: int, value: string) -> string`,
+	2468: `crdb_internal.encrypt_column_value(table_id: int, column_id: int, value: bytes) -> bytes`,
+	2469: `crdb_internal.decrypt_column_value(table_id: int, column_id: int, value: string) -> string`,
+	2470: `crdb_internal.decrypt_column_value(table_id: int, column_id: int, value: bytes) -> bytes`,
+	2471: `crdb_internal.create_statement_hint(fingerprint_id: bytes, hint_type: string, hint_value: string) -> int`,
+	2472: `crdb_internal.drop_statement_hint(hint_id: int) -> bool`,
+	2473: `crdb_internal.schedule_materialized_view_refresh(view_name: string, recurrence: string) -> int`,
+	2474: `crdb_internal.create_hypothetical_index(create_index_stmt: string) -> string`,
+	2475: `crdb_internal.drop_hypothetical_index(index_name: string) -> bool`,
+	2476: `crdb_internal.hypothetical_indexes() -> tuple{string AS index_name, string AS table_name, string AS definition}`,
+	2477: `crdb_internal.calibrate_cost_model(apply: bool) -> int`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -560,6 +560,20 @@ The output can be used to recreate a database.'
 			volatility.Volatile,
 		),
 	),
+	"crdb_internal.hypothetical_indexes": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			hypotheticalIndexesGeneratorType,
+			makeHypotheticalIndexesGenerator,
+			"Returns the hypothetical indexes of the session, created with "+
+				"crdb_internal.create_hypothetical_index.",
+			volatility.Volatile,
+		),
+	),
 	"crdb_internal.decode_external_plan_gist": makeBuiltin(
 		tree.FunctionProperties{},
 		makeGeneratorOverload(
@@ -738,6 +752,60 @@ func makeDecodeExternalPlanGistGenerator(
 	return &gistPlanGenerator{gist: gist, evalCtx: evalCtx, external: true}, nil
 }
 
+var hypotheticalIndexesGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.String, types.String, types.String},
+	[]string{"index_name", "table_name", "definition"},
+)
+
+type hypotheticalIndexesGenerator struct {
+	evalCtx *eval.Context
+	indexes []eval.HypotheticalIndex
+	index   int
+}
+
+var _ eval.ValueGenerator = &hypotheticalIndexesGenerator{}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexesGenerator) ResolvedType() *types.T {
+	return hypotheticalIndexesGeneratorType
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexesGenerator) Start(ctx context.Context, _ *kv.Txn) error {
+	indexes, err := g.evalCtx.Planner.HypotheticalIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	g.indexes = indexes
+	g.index = -1
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexesGenerator) Next(context.Context) (bool, error) {
+	g.index++
+	return g.index < len(g.indexes), nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexesGenerator) Close(context.Context) {}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexesGenerator) Values() (tree.Datums, error) {
+	idx := &g.indexes[g.index]
+	return tree.Datums{
+		tree.NewDString(idx.Name),
+		tree.NewDString(idx.TableName),
+		tree.NewDString(idx.Definition),
+	}, nil
+}
+
+func makeHypotheticalIndexesGenerator(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &hypotheticalIndexesGenerator{evalCtx: evalCtx}, nil
+}
+
 func makeGeneratorOverload(
 	in tree.TypeList, ret *types.T, g eval.GeneratorOverload, info string, volatility volatility.V,
 ) tree.Overload {
@@ -97,6 +97,10 @@ const (
 	SpanStatsBuckets                       SystemTableName = "span_stats_buckets"
 	SpanStatsSamples                       SystemTableName = "span_stats_samples"
 	SpanStatsTenantBoundaries              SystemTableName = "span_stats_tenant_boundaries"
+	PasswordHistoryTableName               SystemTableName = "password_history"
+	LoginLockoutsTableName                 SystemTableName = "login_lockouts"
+	AuditLogTableName                      SystemTableName = "audit_log"
+	StatementHintsTableName                SystemTableName = "statement_hints"
 )
 
 // Oid for virtual database and table.
@@ -137,6 +137,13 @@ type CatalogBuiltins interface {
 	) ([]byte, error)
 }
 
+// HypotheticalIndex describes a hypothetical index of the session.
+type HypotheticalIndex struct {
+	Name       string
+	TableName  string
+	Definition string
+}
+
 // HasPrivilegeSpecifier specifies an object to lookup privilege for.
 // Only one of { DatabaseName, DatabaseOID, SchemaName, TableName, TableOID } is filled.
 type HasPrivilegeSpecifier struct {
@@ -348,6 +355,56 @@ type Planner interface {
 	// it is invalid.
 	RepairTTLScheduledJobForTable(ctx context.Context, tableID int64) error
 
+	// EncryptColumnValue encrypts a value to be written to the given
+	// encrypted column. A value that is already a ciphertext of the column
+	// is returned as is, or re-encrypted if it was encrypted with an older
+	// key.
+	EncryptColumnValue(
+		ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+	) (tree.Datum, error)
+
+	// DecryptColumnValue decrypts a value read from the given encrypted
+	// column. It requires the DECRYPT privilege on the table.
+	DecryptColumnValue(
+		ctx context.Context, tableID descpb.ID, colID descpb.ColumnID, value tree.Datum,
+	) (tree.Datum, error)
+
+	// CreateStatementHint stores a hint for the statements with the given
+	// fingerprint ID in system.statement_hints, and returns the ID of the
+	// new hint.
+	CreateStatementHint(
+		ctx context.Context, fingerprintID []byte, hintType, value string,
+	) (int64, error)
+
+	// DropStatementHint removes the statement hint with the given ID. It
+	// returns false if there was no such hint.
+	DropStatementHint(ctx context.Context, hintID int64) (bool, error)
+
+	// ScheduleMaterializedViewRefresh creates a schedule which refreshes the
+	// given materialized view incrementally with the given cron recurrence,
+	// and returns the ID of the schedule.
+	ScheduleMaterializedViewRefresh(
+		ctx context.Context, viewName string, recurrence string,
+	) (int64, error)
+
+	// CreateHypotheticalIndex adds the hypothetical index defined by the given
+	// CREATE INDEX statement to the session, and returns its name.
+	// Hypothetical indexes are never built, but are considered by EXPLAIN.
+	CreateHypotheticalIndex(ctx context.Context, stmt string) (string, error)
+
+	// DropHypotheticalIndex removes the hypothetical index with the given name
+	// from the session. It returns false if there was no such index.
+	DropHypotheticalIndex(ctx context.Context, name string) (bool, error)
+
+	// HypotheticalIndexes returns the hypothetical indexes of the session.
+	HypotheticalIndexes(ctx context.Context) ([]HypotheticalIndex, error)
+
+	// CalibrateCostModel starts a job which fits the cost factors of the
+	// optimizer to the sampled execution statistics of the workload, and
+	// returns the ID of the job. If apply is true, the job also writes the
+	// fitted factors to the cluster settings used by the optimizer.
+	CalibrateCostModel(ctx context.Context, apply bool) (int64, error)
+
 	// QueryRowEx executes the supplied SQL statement and returns a single row, or
 	// nil if no row is found, or an error if more that one row is returned.
 	//
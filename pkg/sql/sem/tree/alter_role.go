@@ -37,6 +37,28 @@ func (node *AlterRole) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterRoleUnlock represents an `ALTER ROLE ... ACCOUNT UNLOCK` statement.
+type AlterRoleUnlock struct {
+	Name     RoleSpec
+	IfExists bool
+	IsRole   bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterRoleUnlock) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER")
+	if node.IsRole {
+		ctx.WriteString(" ROLE ")
+	} else {
+		ctx.WriteString(" USER ")
+	}
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ACCOUNT UNLOCK")
+}
+
 // AlterRoleSet represents an `ALTER ROLE ... SET` statement.
 type AlterRoleSet struct {
 	RoleName     RoleSpec
@@ -56,26 +56,28 @@ type AlterTableCmd interface {
 	alterTableCmd()
 }
 
-func (*AlterTableAddColumn) alterTableCmd()          {}
-func (*AlterTableAddConstraint) alterTableCmd()      {}
-func (*AlterTableAlterColumnType) alterTableCmd()    {}
-func (*AlterTableAlterPrimaryKey) alterTableCmd()    {}
-func (*AlterTableDropColumn) alterTableCmd()         {}
-func (*AlterTableDropConstraint) alterTableCmd()     {}
-func (*AlterTableDropNotNull) alterTableCmd()        {}
-func (*AlterTableDropStored) alterTableCmd()         {}
-func (*AlterTableSetNotNull) alterTableCmd()         {}
-func (*AlterTableRenameColumn) alterTableCmd()       {}
-func (*AlterTableRenameConstraint) alterTableCmd()   {}
-func (*AlterTableSetAudit) alterTableCmd()           {}
-func (*AlterTableSetDefault) alterTableCmd()         {}
-func (*AlterTableSetOnUpdate) alterTableCmd()        {}
-func (*AlterTableSetVisible) alterTableCmd()         {}
-func (*AlterTableValidateConstraint) alterTableCmd() {}
-func (*AlterTablePartitionByTable) alterTableCmd()   {}
-func (*AlterTableInjectStats) alterTableCmd()        {}
-func (*AlterTableSetStorageParams) alterTableCmd()   {}
-func (*AlterTableResetStorageParams) alterTableCmd() {}
+func (*AlterTableAddColumn) alterTableCmd()            {}
+func (*AlterTableAddConstraint) alterTableCmd()        {}
+func (*AlterTableAlterColumnType) alterTableCmd()      {}
+func (*AlterTableAlterPrimaryKey) alterTableCmd()      {}
+func (*AlterTableDropColumn) alterTableCmd()           {}
+func (*AlterTableDropConstraint) alterTableCmd()       {}
+func (*AlterTableDropNotNull) alterTableCmd()          {}
+func (*AlterTableDropStored) alterTableCmd()           {}
+func (*AlterTableSetNotNull) alterTableCmd()           {}
+func (*AlterTableRenameColumn) alterTableCmd()         {}
+func (*AlterTableRenameConstraint) alterTableCmd()     {}
+func (*AlterTableSetAudit) alterTableCmd()             {}
+func (*AlterTableSetDefault) alterTableCmd()           {}
+func (*AlterTableSetOnUpdate) alterTableCmd()          {}
+func (*AlterTableSetVisible) alterTableCmd()           {}
+func (*AlterTableValidateConstraint) alterTableCmd()   {}
+func (*AlterTablePartitionByTable) alterTableCmd()     {}
+func (*AlterTableInjectStats) alterTableCmd()          {}
+func (*AlterTableSetStorageParams) alterTableCmd()     {}
+func (*AlterTableResetStorageParams) alterTableCmd()   {}
+func (*AlterTableSetRLSMode) alterTableCmd()           {}
+func (*AlterTableRotateEncryptionKeys) alterTableCmd() {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -96,6 +98,8 @@ var _ AlterTableCmd = &AlterTablePartitionByTable{}
 var _ AlterTableCmd = &AlterTableInjectStats{}
 var _ AlterTableCmd = &AlterTableSetStorageParams{}
 var _ AlterTableCmd = &AlterTableResetStorageParams{}
+var _ AlterTableCmd = &AlterTableSetRLSMode{}
+var _ AlterTableCmd = &AlterTableRotateEncryptionKeys{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -644,6 +648,63 @@ func (node *AlterTableResetStorageParams) Format(ctx *FmtCtx) {
 	ctx.WriteString(")")
 }
 
+// TableRLSMode represents a row-level security mode change of a table.
+type TableRLSMode int
+
+const (
+	// TableRLSEnable enables row-level security on a table.
+	TableRLSEnable TableRLSMode = iota
+	// TableRLSDisable disables row-level security on a table.
+	TableRLSDisable
+	// TableRLSForce applies row-level security to the table owner.
+	TableRLSForce
+	// TableRLSNoForce exempts the table owner from row-level security.
+	TableRLSNoForce
+)
+
+var tableRLSModeName = [...]string{
+	TableRLSEnable:  "ENABLE",
+	TableRLSDisable: "DISABLE",
+	TableRLSForce:   "FORCE",
+	TableRLSNoForce: "NO FORCE",
+}
+
+func (m TableRLSMode) String() string {
+	return tableRLSModeName[m]
+}
+
+// AlterTableSetRLSMode represents an ALTER TABLE ... ROW LEVEL SECURITY
+// command.
+type AlterTableSetRLSMode struct {
+	Mode TableRLSMode
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableSetRLSMode) TelemetryName() string {
+	return "set_rls_mode"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableSetRLSMode) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ")
+	ctx.WriteString(node.Mode.String())
+	ctx.WriteString(" ROW LEVEL SECURITY")
+}
+
+// AlterTableRotateEncryptionKeys represents an ALTER TABLE ... ROTATE
+// ENCRYPTION KEYS command.
+type AlterTableRotateEncryptionKeys struct{}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableRotateEncryptionKeys) TelemetryName() string {
+	return "rotate_encryption_keys"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableRotateEncryptionKeys) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ROTATE ENCRYPTION KEYS")
+}
+
 // AlterTableLocality represents an ALTER TABLE LOCALITY command.
 type AlterTableLocality struct {
 	Name     *UnresolvedObjectName
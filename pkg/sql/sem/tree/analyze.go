@@ -10,6 +10,8 @@
 
 package tree
 
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
 // Analyze represents an ANALYZE statement.
 type Analyze struct {
 	Table TableExpr
@@ -18,5 +20,20 @@ type Analyze struct {
 // Format implements the NodeFormatter interface.
 func (node *Analyze) Format(ctx *FmtCtx) {
 	ctx.WriteString("ANALYZE ")
+	// A table named workload is quoted so that the statement is not parsed as
+	// ANALYZE WORKLOAD.
+	if name, ok := node.Table.(*UnresolvedObjectName); ok && name.NumParts == 1 &&
+		name.Parts[0] == "workload" && !ctx.HasFlags(FmtAnonymize) {
+		lexbase.EncodeEscapedSQLIdent(&ctx.Buffer, name.Parts[0])
+		return
+	}
 	ctx.FormatNode(node.Table)
 }
+
+// AnalyzeWorkload represents an ANALYZE WORKLOAD statement.
+type AnalyzeWorkload struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *AnalyzeWorkload) Format(ctx *FmtCtx) {
+	ctx.WriteString("ANALYZE WORKLOAD")
+}
@@ -525,6 +525,9 @@ type ColumnTableDef struct {
 		Create      bool
 		IfNotExists bool
 	}
+	Encryption struct {
+		KMSURI string
+	}
 }
 
 // ColumnTableDefCheckExpr represents a check constraint on a column definition
@@ -713,6 +716,16 @@ func NewColumnTableDef(
 			d.Family.Name = t.Family
 			d.Family.Create = t.Create
 			d.Family.IfNotExists = t.IfNotExists
+		case *ColumnEncryption:
+			if d.IsEncrypted() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"multiple encryption keys specified for column %q", name)
+			}
+			if t.KMSURI == "" {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"empty encryption key URI specified for column %q", name)
+			}
+			d.Encryption.KMSURI = t.KMSURI
 		default:
 			return nil, errors.AssertionFailedf("unexpected column qualification: %T", c)
 		}
@@ -751,6 +764,11 @@ func (node *ColumnTableDef) HasColumnFamily() bool {
 	return node.Family.Name != "" || node.Family.Create
 }
 
+// IsEncrypted returns if the ColumnTableDef is an encrypted column.
+func (node *ColumnTableDef) IsEncrypted() bool {
+	return node.Encryption.KMSURI != ""
+}
+
 // Format implements the NodeFormatter interface.
 func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Name)
@@ -890,6 +908,10 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.FormatNode(&node.Family.Name)
 		}
 	}
+	if node.IsEncrypted() {
+		ctx.WriteString(" ENCRYPTED WITH KEY ")
+		ctx.FormatNode(NewStrVal(node.Encryption.KMSURI))
+	}
 }
 
 func (node *ColumnTableDef) formatColumnType(ctx *FmtCtx) {
@@ -947,6 +969,7 @@ func (*ColumnFKConstraint) columnQualification()         {}
 func (*ColumnFamilyConstraint) columnQualification()     {}
 func (*GeneratedAlwaysAsIdentity) columnQualification()  {}
 func (*GeneratedByDefAsIdentity) columnQualification()   {}
+func (*ColumnEncryption) columnQualification()           {}
 
 // ColumnCollation represents a COLLATE clause for a column.
 type ColumnCollation string
@@ -1024,6 +1047,11 @@ type ColumnFamilyConstraint struct {
 	IfNotExists bool
 }
 
+// ColumnEncryption represents ENCRYPTED WITH KEY on a column.
+type ColumnEncryption struct {
+	KMSURI string
+}
+
 // IndexTableDef represents an index definition within a CREATE TABLE
 // statement.
 type IndexTableDef struct {
@@ -2013,6 +2041,9 @@ const (
 	// RefreshDataClear refers to the WITH NO DATA option provided to the REFRESH
 	// MATERIALIZED VIEW statement.
 	RefreshDataClear
+	// RefreshDataIncremental refers to the INCREMENTAL option provided to the
+	// REFRESH MATERIALIZED VIEW statement.
+	RefreshDataIncremental
 )
 
 // Format implements the NodeFormatter interface.
@@ -2027,12 +2058,17 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH DATA")
 	case RefreshDataClear:
 		ctx.WriteString(" WITH NO DATA")
+	case RefreshDataIncremental:
+		ctx.WriteString(" INCREMENTAL")
 	}
 }
 
 // CreateStats represents a CREATE STATISTICS statement.
 type CreateStats struct {
-	Name        Name
+	Name Name
+	// Kinds are the kinds of extended statistics to collect on the columns,
+	// e.g. mcv or dependencies. It is empty for regular statistics.
+	Kinds       NameList
 	ColumnNames NameList
 	Table       TableExpr
 	Options     CreateStatsOptions
@@ -2043,6 +2079,16 @@ func (node *CreateStats) Format(ctx *FmtCtx) {
 	ctx.WriteString("CREATE STATISTICS ")
 	ctx.FormatNode(&node.Name)
 
+	if len(node.Kinds) > 0 {
+		// NB: the kinds of statistics are not anonymized, since they are
+		// keywords rather than user-provided identifiers.
+		ctx.WriteString(" (")
+		ctx.WithFlags(ctx.flags&^FmtAnonymize, func() {
+			ctx.FormatNode(&node.Kinds)
+		})
+		ctx.WriteByte(')')
+	}
+
 	if len(node.ColumnNames) > 0 {
 		ctx.WriteString(" ON ")
 		ctx.FormatNode(&node.ColumnNames)
@@ -574,6 +574,7 @@ const (
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	PolicyExpr                      SchemaExprContext = "POLICY"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
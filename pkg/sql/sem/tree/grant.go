@@ -23,10 +23,48 @@ import "github.com/cockroachdb/cockroach/pkg/sql/privilege"
 
 // Grant represents a GRANT statement.
 type Grant struct {
-	Privileges      privilege.List
-	Targets         GrantTargetList
-	Grantees        RoleSpecList
-	WithGrantOption bool
+	Privileges privilege.List
+	// ColumnPrivileges is set instead of Privileges when the privileges are
+	// granted on columns of the target tables.
+	ColumnPrivileges ColumnPrivilegeList
+	Targets          GrantTargetList
+	Grantees         RoleSpecList
+	WithGrantOption  bool
+}
+
+// ColumnPrivilege represents a privilege on a list of columns, e.g.
+// SELECT (a, b).
+type ColumnPrivilege struct {
+	Privilege privilege.Kind
+	Columns   NameList
+}
+
+// ColumnPrivilegeList represents a list of column privileges.
+type ColumnPrivilegeList []ColumnPrivilege
+
+// Format implements the NodeFormatter interface.
+func (l *ColumnPrivilegeList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		p := &(*l)[i]
+		ctx.WriteString(p.Privilege.String())
+		ctx.WriteString(" (")
+		ctx.FormatNode(&p.Columns)
+		ctx.WriteString(")")
+	}
+}
+
+// Privileges returns the privileges in the list, without duplicates.
+func (l ColumnPrivilegeList) Privileges() privilege.List {
+	var ret privilege.List
+	for _, p := range l {
+		if !ret.Contains(p.Privilege) {
+			ret = append(ret, p.Privilege)
+		}
+	}
+	return ret
 }
 
 // GrantTargetList represents a list of targets.
@@ -102,7 +140,11 @@ func (node *Grant) Format(ctx *FmtCtx) {
 	if node.Targets.System {
 		ctx.WriteString(" SYSTEM ")
 	}
-	node.Privileges.Format(&ctx.Buffer)
+	if len(node.ColumnPrivileges) > 0 {
+		ctx.FormatNode(&node.ColumnPrivileges)
+	} else {
+		node.Privileges.Format(&ctx.Buffer)
+	}
 	if !node.Targets.System {
 		ctx.WriteString(" ON ")
 		ctx.FormatNode(&node.Targets)
@@ -0,0 +1,175 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// PolicyType represents whether a row-level security policy is permissive or
+// restrictive.
+type PolicyType int
+
+const (
+	// PolicyTypeDefault is used when the type is not specified, in which case
+	// the policy is permissive.
+	PolicyTypeDefault PolicyType = iota
+	// PolicyTypePermissive policies are combined using OR.
+	PolicyTypePermissive
+	// PolicyTypeRestrictive policies are combined using AND.
+	PolicyTypeRestrictive
+)
+
+var policyTypeName = [...]string{
+	PolicyTypeDefault:     "",
+	PolicyTypePermissive:  "PERMISSIVE",
+	PolicyTypeRestrictive: "RESTRICTIVE",
+}
+
+func (t PolicyType) String() string {
+	return policyTypeName[t]
+}
+
+// PolicyCommand represents the statement type a row-level security policy
+// applies to.
+type PolicyCommand int
+
+const (
+	// PolicyCommandDefault is used when the command is not specified, in which
+	// case the policy applies to all commands.
+	PolicyCommandDefault PolicyCommand = iota
+	// PolicyCommandAll applies the policy to all commands.
+	PolicyCommandAll
+	// PolicyCommandSelect applies the policy to SELECT.
+	PolicyCommandSelect
+	// PolicyCommandInsert applies the policy to INSERT.
+	PolicyCommandInsert
+	// PolicyCommandUpdate applies the policy to UPDATE.
+	PolicyCommandUpdate
+	// PolicyCommandDelete applies the policy to DELETE.
+	PolicyCommandDelete
+)
+
+var policyCommandName = [...]string{
+	PolicyCommandDefault: "",
+	PolicyCommandAll:     "ALL",
+	PolicyCommandSelect:  "SELECT",
+	PolicyCommandInsert:  "INSERT",
+	PolicyCommandUpdate:  "UPDATE",
+	PolicyCommandDelete:  "DELETE",
+}
+
+func (c PolicyCommand) String() string {
+	return policyCommandName[c]
+}
+
+// PolicyExpressions contains the expressions of a row-level security policy.
+type PolicyExpressions struct {
+	Using     Expr
+	WithCheck Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *PolicyExpressions) Format(ctx *FmtCtx) {
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteString(")")
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteString(")")
+	}
+}
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	PolicyName Name
+	TableName  *UnresolvedObjectName
+	Type       PolicyType
+	Cmd        PolicyCommand
+	Roles      RoleSpecList
+	Exprs      PolicyExpressions
+}
+
+var _ Statement = &CreatePolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.Type != PolicyTypeDefault {
+		ctx.WriteString(" AS ")
+		ctx.WriteString(node.Type.String())
+	}
+	if node.Cmd != PolicyCommandDefault {
+		ctx.WriteString(" FOR ")
+		ctx.WriteString(node.Cmd.String())
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	ctx.FormatNode(&node.Exprs)
+}
+
+// AlterPolicy represents an ALTER POLICY statement.
+type AlterPolicy struct {
+	PolicyName    Name
+	TableName     *UnresolvedObjectName
+	NewPolicyName Name
+	Roles         RoleSpecList
+	Exprs         PolicyExpressions
+}
+
+var _ Statement = &AlterPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER POLICY ")
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.NewPolicyName != "" {
+		ctx.WriteString(" RENAME TO ")
+		ctx.FormatNode(&node.NewPolicyName)
+		return
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	ctx.FormatNode(&node.Exprs)
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	PolicyName   Name
+	TableName    *UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
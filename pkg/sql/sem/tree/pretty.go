@@ -2080,6 +2080,12 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		clauses = append(clauses, p.maybePrependConstraintName(&node.References.ConstraintName, fk))
 	}
 
+	// ENCRYPTED WITH KEY.
+	if node.IsEncrypted() {
+		clauses = append(clauses, pretty.ConcatSpace(
+			pretty.Keyword("ENCRYPTED WITH KEY"), p.Doc(NewStrVal(node.Encryption.KMSURI))))
+	}
+
 	// Prevents an additional space from being appended at the end of every column
 	// name in the case of CREATE TABLE ... AS query. The additional space is
 	// being caused due to the absence of column type qualifiers in CTAS queries.
@@ -544,6 +544,15 @@ func (*AlterType) StatementTag() string { return "ALTER TYPE" }
 
 func (*AlterType) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterPolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterPolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterPolicy) StatementTag() string { return "ALTER POLICY" }
+
 // StatementReturnType implements the Statement interface.
 func (*AlterSequence) StatementReturnType() StatementReturnType { return DDL }
 
@@ -564,6 +573,17 @@ func (*AlterRole) StatementTag() string { return "ALTER ROLE" }
 
 func (*AlterRole) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterRoleUnlock) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterRoleUnlock) StatementType() StatementType { return TypeDCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterRoleUnlock) StatementTag() string { return "ALTER ROLE" }
+
+func (*AlterRoleUnlock) hiddenFromShowQueries() {}
+
 // StatementReturnType implements the Statement interface.
 func (*AlterRoleSet) StatementReturnType() StatementReturnType { return DDL }
 
@@ -584,6 +604,15 @@ func (*Analyze) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*Analyze) StatementTag() string { return "ANALYZE" }
 
+// StatementReturnType implements the Statement interface.
+func (*AnalyzeWorkload) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*AnalyzeWorkload) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AnalyzeWorkload) StatementTag() string { return "ANALYZE WORKLOAD" }
+
 // StatementReturnType implements the Statement interface.
 func (*Backup) StatementReturnType() StatementReturnType { return Rows }
 
@@ -951,6 +980,15 @@ func (*CreateView) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateView) StatementTag() string { return "CREATE VIEW" }
 
+// StatementReturnType implements the Statement interface.
+func (*CreatePolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreatePolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreatePolicy) StatementTag() string { return "CREATE POLICY" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateSequence) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1053,6 +1091,15 @@ func (*DropView) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropView) StatementTag() string { return "DROP VIEW" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropPolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropPolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropPolicy) StatementTag() string { return "DROP POLICY" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropSequence) StatementReturnType() StatementReturnType { return DDL }
 
@@ -2076,6 +2123,15 @@ func (*ValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*VerifyAuditLog) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*VerifyAuditLog) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*VerifyAuditLog) StatementTag() string { return "VERIFY AUDIT LOG" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRoutine) StatementReturnType() StatementReturnType { return DDL }
 
@@ -2197,8 +2253,11 @@ func (n *AlterTenantService) String() string                  { return AsString(
 func (n *AlterType) String() string                           { return AsString(n) }
 func (n *AlterRole) String() string                           { return AsString(n) }
 func (n *AlterRoleSet) String() string                        { return AsString(n) }
+func (n *AlterRoleUnlock) String() string                     { return AsString(n) }
+func (n *AlterPolicy) String() string                         { return AsString(n) }
 func (n *AlterSequence) String() string                       { return AsString(n) }
 func (n *Analyze) String() string                             { return AsString(n) }
+func (n *AnalyzeWorkload) String() string                     { return AsString(n) }
 func (n *Backup) String() string                              { return AsString(n) }
 func (n *BeginTransaction) String() string                    { return AsString(n) }
 func (n *ControlJobs) String() string                         { return AsString(n) }
@@ -2228,6 +2287,7 @@ func (n *CreateTable) String() string                         { return AsString(
 func (n *CreateTenant) String() string                        { return AsString(n) }
 func (n *CreateTenantFromReplication) String() string         { return AsString(n) }
 func (n *CreateSchema) String() string                        { return AsString(n) }
+func (n *CreatePolicy) String() string                        { return AsString(n) }
 func (n *CreateSequence) String() string                      { return AsString(n) }
 func (n *CreateStats) String() string                         { return AsString(n) }
 func (n *CreateView) String() string                          { return AsString(n) }
@@ -2239,6 +2299,7 @@ func (n *DropFunction) String() string                        { return AsString(
 func (n *DropIndex) String() string                           { return AsString(n) }
 func (n *DropOwnedBy) String() string                         { return AsString(n) }
 func (n *DropSchema) String() string                          { return AsString(n) }
+func (n *DropPolicy) String() string                          { return AsString(n) }
 func (n *DropSequence) String() string                        { return AsString(n) }
 func (n *DropTable) String() string                           { return AsString(n) }
 func (n *DropType) String() string                            { return AsString(n) }
@@ -2350,3 +2411,4 @@ func (n *UnionClause) String() string                         { return AsString(
 func (n *Unsplit) String() string                             { return AsString(n) }
 func (n *Update) String() string                              { return AsString(n) }
 func (n *ValuesClause) String() string                        { return AsString(n) }
+func (n *VerifyAuditLog) String() string                      { return AsString(n) }
@@ -0,0 +1,21 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// VerifyAuditLog represents a VERIFY AUDIT LOG statement.
+type VerifyAuditLog struct{}
+
+var _ Statement = &VerifyAuditLog{}
+
+// Format implements the NodeFormatter interface.
+func (node *VerifyAuditLog) Format(ctx *FmtCtx) {
+	ctx.WriteString("VERIFY AUDIT LOG")
+}
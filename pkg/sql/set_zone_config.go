@@ -104,6 +104,16 @@ func init() {
 				)
 			},
 		},
+		{
+			field:        config.ColdStorage,
+			requiredType: types.Bool,
+			setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.ColdStorage = proto.Bool(bool(tree.MustBeDBool(d))) },
+		},
+		{
+			field:        config.ColdMergeSeconds,
+			requiredType: types.Int,
+			setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.ColdMergeSeconds = proto.Int32(int32(tree.MustBeDInt(d))) },
+		},
 		{
 			field:        config.NumReplicas,
 			requiredType: types.Int,
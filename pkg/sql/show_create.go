@@ -107,6 +107,12 @@ func ShowCreateTable(
 			return "", err
 		}
 		f.WriteString(colstr)
+		if col.IsEncrypted() {
+			if key := desc.FindColumnEncryptionKey(col.GetEncryptionKeyID()); key != nil {
+				f.WriteString(" ENCRYPTED WITH KEY ")
+				f.FormatNode(tree.NewStrVal(redactColumnEncryptionKMSURI(key.KMSURI)))
+			}
+		}
 	}
 
 	if desc.IsPhysicalTable() {
@@ -203,6 +209,10 @@ func ShowCreateTable(
 		}
 	}
 
+	if err := showRowLevelSecurity(tn, desc, &f.Buffer); err != nil {
+		return "", err
+	}
+
 	return f.CloseAndGetString(), nil
 }
 
@@ -432,6 +432,76 @@ func showComments(
 	return nil
 }
 
+// showRowLevelSecurity appends the statements enabling row-level security on
+// the table and creating its policies.
+func showRowLevelSecurity(
+	tn *tree.TableName, table catalog.TableDescriptor, buf *bytes.Buffer,
+) error {
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	un := tn.ToUnresolvedObjectName()
+	for _, m := range []struct {
+		set  bool
+		mode tree.TableRLSMode
+	}{
+		{table.IsRowLevelSecurityEnabled(), tree.TableRLSEnable},
+		{table.IsRowLevelSecurityForced(), tree.TableRLSForce},
+	} {
+		if !m.set {
+			continue
+		}
+		f.WriteString(";\n")
+		f.FormatNode(&tree.AlterTable{
+			Table: un,
+			Cmds:  tree.AlterTableCmds{&tree.AlterTableSetRLSMode{Mode: m.mode}},
+		})
+	}
+
+	for i := range table.GetPolicies() {
+		policy := &table.GetPolicies()[i]
+		stmt := tree.CreatePolicy{
+			PolicyName: tree.Name(policy.Name),
+			TableName:  un,
+		}
+		if policy.Type == descpb.PolicyDescriptor_RESTRICTIVE {
+			stmt.Type = tree.PolicyTypeRestrictive
+		}
+		switch policy.Command {
+		case descpb.PolicyDescriptor_SELECT:
+			stmt.Cmd = tree.PolicyCommandSelect
+		case descpb.PolicyDescriptor_INSERT:
+			stmt.Cmd = tree.PolicyCommandInsert
+		case descpb.PolicyDescriptor_UPDATE:
+			stmt.Cmd = tree.PolicyCommandUpdate
+		case descpb.PolicyDescriptor_DELETE:
+			stmt.Cmd = tree.PolicyCommandDelete
+		}
+		for _, role := range policy.RoleNames {
+			stmt.Roles = append(stmt.Roles, tree.MakeRoleSpecWithRoleName(role))
+		}
+		for _, e := range []struct {
+			expr string
+			dst  *tree.Expr
+		}{
+			{policy.UsingExpr, &stmt.Exprs.Using},
+			{policy.WithCheckExpr, &stmt.Exprs.WithCheck},
+		} {
+			if e.expr == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(e.expr)
+			if err != nil {
+				return err
+			}
+			*e.dst = expr
+		}
+		f.WriteString(";\n")
+		f.FormatNode(&stmt)
+	}
+
+	buf.WriteString(f.CloseAndGetString())
+	return nil
+}
+
 // showForeignKeyConstraint returns a valid SQL representation of a FOREIGN KEY
 // clause for a given index. If the table's schema name is in the searchPath, then the
 // schema name will not be included in the result.
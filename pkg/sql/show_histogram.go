@@ -74,6 +74,10 @@ func (p *planner) ShowHistogram(ctx context.Context, n *tree.ShowHistogram) (pla
 			if err := protoutil.Unmarshal([]byte(histData), histogram); err != nil {
 				return nil, err
 			}
+			if histogram.ColumnType == nil {
+				// This is a multi-column statistic with extended statistics.
+				return nil, fmt.Errorf("histogram %d not found", n.HistogramID)
+			}
 
 			v := p.newContainerValuesNode(showHistogramColumns, 0)
 			resolver := descs.NewDistSQLTypeResolver(p.descCollection, p.InternalSQLTxn().KV())
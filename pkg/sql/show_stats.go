@@ -208,7 +208,8 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 						return nil, err
 					}
 					obs := &stats.TableStatistic{TableStatisticProto: *stat}
-					if obs.HistogramData != nil && !obs.HistogramData.ColumnType.UserDefined() {
+					if obs.HistogramData != nil && obs.HistogramData.ColumnType != nil &&
+						!obs.HistogramData.ColumnType.UserDefined() {
 						if err := stats.DecodeHistogramBuckets(obs); err != nil {
 							return nil, err
 						}
@@ -335,8 +336,10 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 					continue
 				}
 
+				// Multi-column statistics don't have histograms, but they may store
+				// extended statistics in the histogram column.
 				histogramID := tree.DNull
-				if r[histIdx] != tree.DNull {
+				if r[histIdx] != tree.DNull && len(colIDs) == 1 {
 					histogramID = r[statIDIdx]
 				}
 
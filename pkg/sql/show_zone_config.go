@@ -218,6 +218,14 @@ func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (string, e
 		maybeWriteComma(f)
 		f.Printf("\tglobal_reads = %t", *zone.GlobalReads)
 	}
+	if zone.ColdStorage != nil {
+		maybeWriteComma(f)
+		f.Printf("\tcold_storage = %t", *zone.ColdStorage)
+	}
+	if zone.ColdMergeSeconds != nil {
+		maybeWriteComma(f)
+		f.Printf("\tcold_merge_seconds = %d", *zone.ColdMergeSeconds)
+	}
 	if zone.NumReplicas != nil {
 		maybeWriteComma(f)
 		f.Printf("\tnum_replicas = %d", *zone.NumReplicas)
@@ -89,6 +89,12 @@ func BuildStmtMetadataJSON(statistics *appstatspb.CollectedStatementStatistics)
 //		        "type": "string",
 //		      },
 //		    },
+//		    "statement_hints": {
+//		      "type": "array",
+//		      "items": {
+//		        "type": "string",
+//		      },
+//		    },
 //		    "node_ids": {
 //		      "type": "array",
 //		      "items": {
@@ -179,6 +185,7 @@ func BuildStmtMetadataJSON(statistics *appstatspb.CollectedStatementStatistics)
 //		        "nodes":             { "type": "node_ids" },
 //		        "regions":           { "type": "regions" },
 //		        "indexes":           { "type": "indexes" },
+//		        "statementHints":    { "type": "statement_hints" },
 //		        "lastErrorCode":     { "type": "string" },
 //		      },
 //		      "required": [
@@ -297,6 +297,7 @@ func (s *innerStmtStats) jsonFields() jsonFields {
 		{"regions", (*stringArray)(&s.Regions)},
 		{"planGists", (*stringArray)(&s.PlanGists)},
 		{"indexes", (*stringArray)(&s.Indexes)},
+		{"statementHints", (*stringArray)(&s.StatementHints)},
 		{"latencyInfo", (*latencyInfo)(&s.LatencyInfo)},
 		{"lastErrorCode", (*jsonString)(&s.LastErrorCode)},
 	}
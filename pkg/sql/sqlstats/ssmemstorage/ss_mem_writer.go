@@ -179,6 +179,7 @@ func (s *Container) RecordStatement(
 	}
 	stats.mu.data.PlanGists = util.CombineUnique(stats.mu.data.PlanGists, planGist)
 	stats.mu.data.Indexes = util.CombineUnique(stats.mu.data.Indexes, value.Indexes)
+	stats.mu.data.StatementHints = util.CombineUnique(stats.mu.data.StatementHints, value.StatementHints)
 	stats.mu.data.IndexRecommendations = value.IndexRecommendations
 	stats.mu.data.LatencyInfo.Add(latencyInfo)
 
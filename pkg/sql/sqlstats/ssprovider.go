@@ -220,6 +220,7 @@ type RecordedStmtStats struct {
 	FullScan             bool
 	ExecStats            *execstats.QueryLevelStats
 	Indexes              []string
+	StatementHints       []string
 	Database             string
 }
 
@@ -0,0 +1,269 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/persistedsqlstats/sqlstatsutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/stmthints"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+var statementHintsEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.statement_hints.enabled",
+	"if set, the hints of system.statement_hints are applied to the matching statements",
+	true,
+)
+
+// statementHintsTableName represents system.statement_hints.
+var statementHintsTableName = tree.NewTableNameWithSchema(
+	"system", catconstants.PublicSchemaName, tree.Name(catconstants.StatementHintsTableName),
+)
+
+// checkStatementHintsSupported returns an error if the statement hints
+// cannot be modified.
+func (p *planner) checkStatementHintsSupported(ctx context.Context, action string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_StatementHintsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"statement hints require the cluster to be upgraded to version %s",
+			clusterversion.V23_2_StatementHintsTable)
+	}
+	return p.RequireAdminRole(ctx, action)
+}
+
+// CreateStatementHint is part of the eval.Planner interface.
+func (p *planner) CreateStatementHint(
+	ctx context.Context, fingerprintID []byte, hintType, value string,
+) (int64, error) {
+	if err := p.checkStatementHintsSupported(ctx, "create statement hints"); err != nil {
+		return 0, err
+	}
+	if len(fingerprintID) != 8 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"statement fingerprint ID must have 8 bytes, got %d", len(fingerprintID))
+	}
+	id, err := sqlstatsutil.DatumToUint64(tree.NewDBytes(tree.DBytes(fingerprintID)))
+	if err != nil {
+		return 0, err
+	}
+	hint, err := stmthints.MakeHint(appstatspb.StmtFingerprintID(id), hintType, value)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range stmthints.MakeHints([]stmthints.Hint{hint}).SessionSettings {
+		if err := CheckSessionVariableValueValid(ctx, p.ExecCfg().Settings, s.Name, s.Value); err != nil {
+			return 0, err
+		}
+	}
+
+	row, err := p.InternalSQLTxn().QueryRowEx(
+		ctx, "create-statement-hint", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.statement_hints (fingerprint_id, hint_type, hint_value)
+VALUES ($1, $2, $3) RETURNING hint_id`,
+		fingerprintID, string(hint.Type), hint.Value,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if row == nil {
+		return 0, errors.AssertionFailedf("no hint ID returned for the new statement hint")
+	}
+	if err := p.bumpStatementHintsTableVersion(ctx); err != nil {
+		return 0, err
+	}
+	return int64(tree.MustBeDInt(row[0])), nil
+}
+
+// DropStatementHint is part of the eval.Planner interface.
+func (p *planner) DropStatementHint(ctx context.Context, hintID int64) (bool, error) {
+	if err := p.checkStatementHintsSupported(ctx, "drop statement hints"); err != nil {
+		return false, err
+	}
+	rowsAffected, err := p.InternalSQLTxn().ExecEx(
+		ctx, "drop-statement-hint", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.statement_hints WHERE hint_id = $1`,
+		hintID,
+	)
+	if err != nil || rowsAffected == 0 {
+		return false, err
+	}
+	return true, p.bumpStatementHintsTableVersion(ctx)
+}
+
+// bumpStatementHintsTableVersion increases the table version for the
+// statement_hints table, which invalidates the statement hints cache.
+func (p *planner) bumpStatementHintsTableVersion(ctx context.Context) error {
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(ctx, statementHintsTableName, true, tree.ResolveAnyTableKind)
+	if err != nil {
+		return err
+	}
+
+	return p.writeSchemaChange(
+		ctx, tableDesc, descpb.InvalidMutationID, "updating version for statement hints table",
+	)
+}
+
+// getStatementHints returns the combined statement hints attached to the
+// fingerprint of the given statement, or nil if there are none.
+func (p *planner) getStatementHints(
+	ctx context.Context, stmt tree.Statement,
+) (*stmthints.Hints, error) {
+	if p.SessionData().Internal ||
+		!statementHintsEnabled.Get(&p.ExecCfg().Settings.SV) ||
+		!p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_StatementHintsTable) {
+		return nil, nil
+	}
+	// The hints of a statement also apply when it is explained.
+	if e, ok := stmt.(*tree.Explain); ok {
+		stmt = e.Statement
+	}
+	switch stmt.(type) {
+	case *tree.ParenSelect, *tree.Select, *tree.SelectClause, *tree.UnionClause, *tree.ValuesClause,
+		*tree.Insert, *tree.Update, *tree.Delete:
+	default:
+		return nil, nil
+	}
+	fingerprintID := appstatspb.ConstructStatementFingerprintID(
+		formatStatementHideConstants(stmt),
+		false, /* failed */
+		p.EvalContext().TxnImplicit,
+		p.CurrentDatabase(),
+	)
+
+	var hints []stmthints.Hint
+	if p.Descriptors().HasUncommittedTables() {
+		// The hints may have been modified by the current transaction, in
+		// which case the version of the table cannot be used to consult the
+		// cache. Read them in the transaction instead. The descriptor
+		// collection of the transaction is not shared with the internal
+		// executor, which would replace its temporary schemas with those of
+		// the session data of the executor.
+		all, err := loadStatementHints(ctx, p.ExecCfg().InternalDB.Executor(), p.Txn())
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range all {
+			if h.FingerprintID == fingerprintID {
+				hints = append(hints, h)
+			}
+		}
+	} else {
+		// The cached hints are read at the present time, so the version of the
+		// table is leased at the present time too, rather than at the timestamp
+		// of the transaction, which may be historical.
+		execCfg := p.ExecCfg()
+		tableDesc, err := execCfg.LeaseManager.AcquireByName(
+			ctx, execCfg.Clock.Now(), keys.SystemDatabaseID, keys.SystemPublicSchemaID,
+			string(catconstants.StatementHintsTableName),
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer tableDesc.Release(ctx)
+		hints, err = execCfg.StatementHintsCache.GetHints(
+			ctx, tableDesc.Underlying().GetVersion(), fingerprintID,
+			func(ctx context.Context) ([]stmthints.Hint, error) {
+				return loadStatementHints(ctx, execCfg.InternalDB.Executor(), nil /* txn */)
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stmthints.MakeHints(hints), nil
+}
+
+// loadStatementHints reads all the valid hints of system.statement_hints,
+// ordered by ID. Invalid hints, which can only have been written directly to
+// the table, are ignored.
+func loadStatementHints(
+	ctx context.Context, ex isql.Executor, txn *kv.Txn,
+) ([]stmthints.Hint, error) {
+	rows, err := ex.QueryBufferedEx(
+		ctx, "load-statement-hints", txn,
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT hint_id, fingerprint_id, hint_type, hint_value
+FROM system.statement_hints ORDER BY hint_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	hints := make([]stmthints.Hint, 0, len(rows))
+	for _, row := range rows {
+		id, err := sqlstatsutil.DatumToUint64(row[1])
+		if err != nil {
+			log.Warningf(ctx, "ignoring statement hint %s: %v", row[0], err)
+			continue
+		}
+		hint, err := stmthints.MakeHint(
+			appstatspb.StmtFingerprintID(id),
+			string(tree.MustBeDString(row[2])),
+			string(tree.MustBeDString(row[3])),
+		)
+		if err != nil {
+			log.Warningf(ctx, "ignoring statement hint %s: %v", row[0], err)
+			continue
+		}
+		hint.ID = int64(tree.MustBeDInt(row[0]))
+		hints = append(hints, hint)
+	}
+	return hints, nil
+}
+
+// pushStatementHintSettings overrides the session settings of the given hints
+// in a copy of the session data, which is used while the statement is
+// planned. The returned function restores the session data.
+func (p *planner) pushStatementHintSettings(
+	ctx context.Context, settings []stmthints.SessionSetting,
+) (func(), error) {
+	if len(settings) == 0 || p.sessionDataMutatorIterator == nil {
+		return func() {}, nil
+	}
+	stack := p.EvalContext().SessionDataStack
+	stack.PushTopClone()
+	pop := func() {
+		if err := stack.PopN(1); err != nil {
+			log.Warningf(ctx, "failed to restore the session data: %v", err)
+		}
+	}
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, stack.Top())
+	for _, s := range settings {
+		_, v, err := getSessionVar(s.Name, false /* missingOk */)
+		if err == nil && v.Set == nil {
+			err = pgerror.Newf(pgcode.CantChangeRuntimeParam, "parameter %q cannot be changed", s.Name)
+		}
+		if err == nil {
+			err = v.Set(ctx, m, s.Value)
+		}
+		if err != nil {
+			pop()
+			return nil, errors.Wrapf(err, "applying statement hint %s=%s", s.Name, s.Value)
+		}
+	}
+	return pop, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stats
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// ExtendedStatisticsMCV is the kind of extended statistics which contains
+	// the most common combinations of values of the columns.
+	ExtendedStatisticsMCV = "mcv"
+
+	// ExtendedStatisticsDependencies is the kind of extended statistics which
+	// contains the functional dependencies between the columns.
+	ExtendedStatisticsDependencies = "dependencies"
+
+	// MaxExtendedStatisticsColumns is the maximum number of columns of a
+	// statistic with extended statistics. The number of dependencies grows
+	// exponentially with the number of columns.
+	MaxExtendedStatisticsColumns = 8
+
+	// maxMCVItems is the maximum number of most common values stored for a
+	// statistic.
+	maxMCVItems = 100
+)
+
+// ComputeExtendedStatistics computes the extended statistics of a set of
+// columns from a sample of the rows of the table. Each row of the sample
+// contains the values of the columns, which have the given types.
+//
+// The most common values are the combinations of non-NULL values which appear
+// more than once in the sample, with their frequency in the sample.
+//
+// The degree of the dependency of a column on a set of determinant columns is
+// computed as in PostgreSQL: the rows of the sample are grouped by the values
+// of the determinant columns, and the degree is the fraction of the rows in
+// the groups where the dependent column has a single value.
+func ComputeExtendedStatistics(
+	ctx context.Context,
+	memAcc *mon.BoundAccount,
+	rows []tree.Datums,
+	colTypes []*types.T,
+	generateMCV, generateDependencies bool,
+) (ExtendedStatisticsData, error) {
+	res := ExtendedStatisticsData{
+		ColumnTypes:     colTypes,
+		HasMCV:          generateMCV,
+		HasDependencies: generateDependencies,
+	}
+	if len(rows) == 0 {
+		return res, nil
+	}
+
+	// Encode the values of the columns, so that they can be compared and
+	// combined into grouping keys.
+	encoded := make([][][]byte, len(rows))
+	hasNull := make([]bool, len(rows))
+	for i, row := range rows {
+		if len(row) != len(colTypes) {
+			return ExtendedStatisticsData{}, errors.AssertionFailedf(
+				"expected %d values in sampled row, found %d", len(colTypes), len(row),
+			)
+		}
+		var buf []byte
+		ends := make([]int, len(row))
+		for j, d := range row {
+			if d == tree.DNull {
+				hasNull[i] = true
+			}
+			var err error
+			if buf, err = keyside.Encode(buf, d, encoding.Ascending); err != nil {
+				return ExtendedStatisticsData{}, err
+			}
+			ends[j] = len(buf)
+		}
+		if memAcc != nil {
+			if err := memAcc.Grow(ctx, int64(len(buf))); err != nil {
+				return ExtendedStatisticsData{}, err
+			}
+		}
+		encoded[i] = make([][]byte, len(row))
+		start := 0
+		for j, end := range ends {
+			encoded[i][j] = buf[start:end:end]
+			start = end
+		}
+	}
+
+	if generateMCV {
+		res.MCV = computeMCV(encoded, hasNull)
+	}
+	if generateDependencies {
+		res.Dependencies = computeDependencies(encoded, len(colTypes))
+	}
+	return res, nil
+}
+
+// computeMCV returns the most common combinations of non-NULL values in the
+// given encoded rows, with the most frequent first.
+func computeMCV(encoded [][][]byte, hasNull []bool) []ExtendedStatisticsData_MCVItem {
+	type group struct {
+		key   []byte
+		row   int
+		count int
+	}
+	groups := make(map[string]*group)
+	var key []byte
+	for i, row := range encoded {
+		if hasNull[i] {
+			continue
+		}
+		key = key[:0]
+		for _, v := range row {
+			key = append(key, v...)
+		}
+		g, ok := groups[string(key)]
+		if !ok {
+			g = &group{key: append([]byte(nil), key...), row: i}
+			groups[string(key)] = g
+		}
+		g.count++
+	}
+
+	// Values which appear only once in the sample are not more common than the
+	// others.
+	common := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		if g.count > 1 {
+			common = append(common, g)
+		}
+	}
+	sort.Slice(common, func(i, j int) bool {
+		if common[i].count != common[j].count {
+			return common[i].count > common[j].count
+		}
+		return bytes.Compare(common[i].key, common[j].key) < 0
+	})
+	if len(common) > maxMCVItems {
+		common = common[:maxMCVItems]
+	}
+
+	items := make([]ExtendedStatisticsData_MCVItem, len(common))
+	for i, g := range common {
+		items[i] = ExtendedStatisticsData_MCVItem{
+			Values:    encoded[g.row],
+			Frequency: float64(g.count) / float64(len(encoded)),
+		}
+	}
+	return items
+}
+
+// computeDependencies returns the degree of the dependency of each column on
+// each set of the other columns, if it is not zero.
+func computeDependencies(encoded [][][]byte, numCols int) []ExtendedStatisticsData_Dependency {
+	type group struct {
+		dependentValue []byte
+		count          int
+		consistent     bool
+	}
+	var deps []ExtendedStatisticsData_Dependency
+	var key []byte
+	for dependent := 0; dependent < numCols; dependent++ {
+		for determinant := 1; determinant < 1<<numCols; determinant++ {
+			if determinant&(1<<dependent) != 0 {
+				continue
+			}
+			groups := make(map[string]*group)
+			for _, row := range encoded {
+				key = key[:0]
+				for j, v := range row {
+					if determinant&(1<<j) != 0 {
+						key = append(key, v...)
+					}
+				}
+				g, ok := groups[string(key)]
+				if !ok {
+					g = &group{dependentValue: row[dependent], consistent: true}
+					groups[string(key)] = g
+				}
+				g.count++
+				if g.consistent && !bytes.Equal(g.dependentValue, row[dependent]) {
+					g.consistent = false
+				}
+			}
+			var supporting int
+			for _, g := range groups {
+				if g.consistent {
+					supporting += g.count
+				}
+			}
+			if supporting == 0 {
+				continue
+			}
+			dep := ExtendedStatisticsData_Dependency{
+				Dependent: uint32(dependent),
+				Degree:    float64(supporting) / float64(len(encoded)),
+			}
+			for j := 0; j < numCols; j++ {
+				if determinant&(1<<j) != 0 {
+					dep.Determinant = append(dep.Determinant, uint32(j))
+				}
+			}
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// DecodeExtendedStatistics decodes the encoded extended statistics in tabStat
+// and writes the result into tabStat.Extended. The column types of the
+// extended statistics must have been hydrated.
+func DecodeExtendedStatistics(tabStat *TableStatistic) error {
+	ext := tabStat.HistogramData.Extended
+	res := &cat.ExtendedStatistics{HasMostCommonValues: ext.HasMCV}
+	var a tree.DatumAlloc
+	if len(ext.MCV) > 0 {
+		res.MostCommonValues = make([]cat.MostCommonValue, len(ext.MCV))
+	}
+	for i := range ext.MCV {
+		item := &ext.MCV[i]
+		if len(item.Values) != len(ext.ColumnTypes) {
+			return errors.AssertionFailedf(
+				"expected %d values in most common value, found %d", len(ext.ColumnTypes), len(item.Values),
+			)
+		}
+		values := make(tree.Datums, len(item.Values))
+		for j := range item.Values {
+			var err error
+			values[j], _, err = keyside.Decode(&a, ext.ColumnTypes[j], item.Values[j], encoding.Ascending)
+			if err != nil {
+				return err
+			}
+		}
+		res.MostCommonValues[i] = cat.MostCommonValue{Values: values, Frequency: item.Frequency}
+	}
+	if len(ext.Dependencies) > 0 {
+		res.Dependencies = make([]cat.ColumnDependency, len(ext.Dependencies))
+	}
+	for i := range ext.Dependencies {
+		dep := &ext.Dependencies[i]
+		determinant := make([]int, len(dep.Determinant))
+		for j, c := range dep.Determinant {
+			determinant[j] = int(c)
+		}
+		res.Dependencies[i] = cat.ColumnDependency{
+			Determinant: determinant,
+			Dependent:   int(dep.Dependent),
+			Degree:      dep.Degree,
+		}
+	}
+	tabStat.Extended = res
+	return nil
+}
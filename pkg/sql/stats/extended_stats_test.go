@@ -0,0 +1,80 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeExtendedStatistics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	var rows []tree.Datums
+	add := func(country, city string, count int) {
+		for i := 0; i < count; i++ {
+			rows = append(rows, tree.Datums{tree.NewDString(country), tree.NewDString(city)})
+		}
+	}
+	add("us", "nyc", 4)
+	add("fr", "paris", 3)
+	add("us", "sf", 2)
+	add("fr", "lyon", 1)
+	colTypes := []*types.T{types.String, types.String}
+
+	ext, err := ComputeExtendedStatistics(
+		ctx, nil /* memAcc */, rows, colTypes, true /* generateMCV */, true, /* generateDependencies */
+	)
+	require.NoError(t, err)
+
+	tabStat := &TableStatistic{}
+	tabStat.HistogramData = &HistogramData{Extended: &ext}
+	require.NoError(t, DecodeExtendedStatistics(tabStat))
+
+	// Combinations of values which appear only once are not among the most
+	// common values. Each city determines its country, but no country
+	// determines its city.
+	require.Equal(t, &cat.ExtendedStatistics{
+		HasMostCommonValues: true,
+		MostCommonValues: []cat.MostCommonValue{
+			{Values: tree.Datums{tree.NewDString("us"), tree.NewDString("nyc")}, Frequency: 0.4},
+			{Values: tree.Datums{tree.NewDString("fr"), tree.NewDString("paris")}, Frequency: 0.3},
+			{Values: tree.Datums{tree.NewDString("us"), tree.NewDString("sf")}, Frequency: 0.2},
+		},
+		Dependencies: []cat.ColumnDependency{
+			{Determinant: []int{1}, Dependent: 0, Degree: 1},
+		},
+	}, tabStat.Extended)
+
+	// Rows with NULL values are not counted in the most common values.
+	rows = nil
+	add("us", "nyc", 1)
+	for i := 0; i < 3; i++ {
+		rows = append(rows, tree.Datums{tree.NewDString("us"), tree.DNull})
+	}
+	ext, err = ComputeExtendedStatistics(
+		ctx, nil /* memAcc */, rows, colTypes, true /* generateMCV */, false, /* generateDependencies */
+	)
+	require.NoError(t, err)
+	require.True(t, ext.HasMCV)
+	require.Empty(t, ext.MCV)
+	require.False(t, ext.HasDependencies)
+	require.Empty(t, ext.Dependencies)
+}
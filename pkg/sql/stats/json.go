@@ -45,6 +45,9 @@ type JSONStatistic struct {
 	HistogramVersion    HistogramVersion  `json:"histo_version,omitempty"`
 	PartialPredicate    string            `json:"partial_predicate,omitempty"`
 	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
+	// Extended contains the extended statistics of a multi-column statistic,
+	// if any.
+	Extended *JSONExtendedStatistics `json:"extended,omitempty"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -60,6 +63,41 @@ type JSONHistoBucket struct {
 	UpperBound string `json:"upper_bound"`
 }
 
+// JSONExtendedStatistics is a struct used for JSON marshaling and
+// unmarshaling of extended statistics.
+//
+// See ExtendedStatisticsData for a description of the fields.
+type JSONExtendedStatistics struct {
+	// ColumnTypes contains the string representation of the type of each
+	// column of the statistic. Parsable with tree.GetTypeFromValidSQLSyntax.
+	ColumnTypes     []string         `json:"col_types"`
+	HasMCV          bool             `json:"has_mcv,omitempty"`
+	MCV             []JSONMCVItem    `json:"mcv,omitempty"`
+	HasDependencies bool             `json:"has_dependencies,omitempty"`
+	Dependencies    []JSONDependency `json:"dependencies,omitempty"`
+}
+
+// JSONMCVItem is a struct used for JSON marshaling and unmarshaling of a most
+// common value of extended statistics.
+//
+// See ExtendedStatisticsData_MCVItem for a description of the fields.
+type JSONMCVItem struct {
+	// Values contains the string representation of the datum of each column;
+	// parsable with sqlbase.ParseDatumStringAs.
+	Values    []string `json:"values"`
+	Frequency float64  `json:"frequency"`
+}
+
+// JSONDependency is a struct used for JSON marshaling and unmarshaling of a
+// functional dependency of extended statistics.
+//
+// See ExtendedStatisticsData_Dependency for a description of the fields.
+type JSONDependency struct {
+	Determinant []uint32 `json:"determinant"`
+	Dependent   uint32   `json:"dependent"`
+	Degree      float64  `json:"degree"`
+}
+
 // SetHistogram fills in the HistogramColumnType and HistogramBuckets fields.
 func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
 	typ := h.ColumnType
@@ -94,6 +132,44 @@ func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
 	return nil
 }
 
+// SetExtended fills in the Extended field.
+func (js *JSONStatistic) SetExtended(ext *ExtendedStatisticsData) error {
+	res := &JSONExtendedStatistics{
+		ColumnTypes:     make([]string, len(ext.ColumnTypes)),
+		HasMCV:          ext.HasMCV,
+		HasDependencies: ext.HasDependencies,
+	}
+	for i, typ := range ext.ColumnTypes {
+		res.ColumnTypes[i] = typ.SQLString()
+	}
+	var a tree.DatumAlloc
+	for i := range ext.MCV {
+		item := &ext.MCV[i]
+		if len(item.Values) != len(ext.ColumnTypes) {
+			return fmt.Errorf("most common value has %d values, expected %d", len(item.Values), len(ext.ColumnTypes))
+		}
+		values := make([]string, len(item.Values))
+		for j := range item.Values {
+			datum, _, err := keyside.Decode(&a, ext.ColumnTypes[j], item.Values[j], encoding.Ascending)
+			if err != nil {
+				return err
+			}
+			values[j] = tree.AsStringWithFlags(datum, tree.FmtExport)
+		}
+		res.MCV = append(res.MCV, JSONMCVItem{Values: values, Frequency: item.Frequency})
+	}
+	for i := range ext.Dependencies {
+		dep := &ext.Dependencies[i]
+		res.Dependencies = append(res.Dependencies, JSONDependency{
+			Determinant: dep.Determinant,
+			Dependent:   dep.Dependent,
+			Degree:      dep.Degree,
+		})
+	}
+	js.Extended = res
+	return nil
+}
+
 // DecodeAndSetHistogram decodes a histogram marshaled as a Bytes datum and
 // fills in the JSONStatistic histogram fields.
 func (js *JSONStatistic) DecodeAndSetHistogram(
@@ -113,6 +189,23 @@ func (js *JSONStatistic) DecodeAndSetHistogram(
 	if err := protoutil.Unmarshal([]byte(*datum.(*tree.DBytes)), h); err != nil {
 		return err
 	}
+	if h.Extended != nil {
+		// Multi-column statistics store their extended statistics instead of a
+		// histogram.
+		for i, typ := range h.Extended.ColumnTypes {
+			if typ.UserDefined() {
+				resolver := semaCtx.GetTypeResolver()
+				if resolver == nil {
+					return errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
+				}
+				var err error
+				if h.Extended.ColumnTypes[i], err = resolver.ResolveTypeByOID(ctx, typ.Oid()); err != nil {
+					return err
+				}
+			}
+		}
+		return js.SetExtended(h.Extended)
+	}
 	// If the serialized column type is user defined, then it needs to be
 	// hydrated before use.
 	if h.ColumnType.UserDefined() {
@@ -129,10 +222,19 @@ func (js *JSONStatistic) DecodeAndSetHistogram(
 	return js.SetHistogram(h)
 }
 
-// GetHistogram converts the json histogram into HistogramData.
+// GetHistogram converts the json histogram into HistogramData. For
+// multi-column statistics with extended statistics, the returned
+// HistogramData contains the extended statistics.
 func (js *JSONStatistic) GetHistogram(
 	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
 ) (*HistogramData, error) {
+	if js.Extended != nil {
+		ext, err := js.getExtended(ctx, semaCtx, evalCtx)
+		if err != nil {
+			return nil, err
+		}
+		return &HistogramData{Extended: ext}, nil
+	}
 	if js.HistogramColumnType == "" {
 		return nil, nil
 	}
@@ -165,6 +267,59 @@ func (js *JSONStatistic) GetHistogram(
 	return h, nil
 }
 
+// getExtended converts the json extended statistics into
+// ExtendedStatisticsData.
+func (js *JSONStatistic) getExtended(
+	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
+) (*ExtendedStatisticsData, error) {
+	ext := &ExtendedStatisticsData{
+		ColumnTypes:     make([]*types.T, len(js.Extended.ColumnTypes)),
+		HasMCV:          js.Extended.HasMCV,
+		HasDependencies: js.Extended.HasDependencies,
+	}
+	for i, typStr := range js.Extended.ColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			return nil, err
+		}
+		if ext.ColumnTypes[i], err = tree.ResolveType(ctx, colTypeRef, semaCtx.GetTypeResolver()); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range js.Extended.MCV {
+		if len(item.Values) != len(ext.ColumnTypes) {
+			return nil, fmt.Errorf("most common value has %d values, expected %d", len(item.Values), len(ext.ColumnTypes))
+		}
+		values := make([][]byte, len(item.Values))
+		for j, s := range item.Values {
+			d, err := rowenc.ParseDatumStringAs(ctx, ext.ColumnTypes[j], s, evalCtx)
+			if err != nil {
+				return nil, err
+			}
+			if values[j], err = keyside.Encode(nil, d, encoding.Ascending); err != nil {
+				return nil, err
+			}
+		}
+		ext.MCV = append(ext.MCV, ExtendedStatisticsData_MCVItem{Values: values, Frequency: item.Frequency})
+	}
+	for _, dep := range js.Extended.Dependencies {
+		if int(dep.Dependent) >= len(ext.ColumnTypes) {
+			return nil, fmt.Errorf("dependency column %d is out of range", dep.Dependent)
+		}
+		for _, c := range dep.Determinant {
+			if int(c) >= len(ext.ColumnTypes) {
+				return nil, fmt.Errorf("dependency column %d is out of range", c)
+			}
+		}
+		ext.Dependencies = append(ext.Dependencies, ExtendedStatisticsData_Dependency{
+			Determinant: dep.Determinant,
+			Dependent:   dep.Dependent,
+			Degree:      dep.Degree,
+		})
+	}
+	return ext, nil
+}
+
 // IsPartial returns true if this statistic was collected with a where clause.
 func (js *JSONStatistic) IsPartial() bool {
 	return js.PartialPredicate != ""
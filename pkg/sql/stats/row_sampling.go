@@ -240,6 +240,41 @@ func (sr *SampleReservoir) GetNonNullDatums(
 	return
 }
 
+// GetRowDatums returns the values of the specified columns in each sampled
+// row, including NULL values. Like GetNonNullDatums, the capacity of the
+// reservoir (K) will shrink if we hit a memory limit while building the return
+// slice, and GetRowDatums will return an error if the capacity goes below
+// minNumSamples.
+func (sr *SampleReservoir) GetRowDatums(
+	ctx context.Context, memAcc *mon.BoundAccount, colIdxs []int,
+) (rows []tree.Datums, err error) {
+	err = sr.retryMaybeResize(ctx, func() error {
+		// Account for the memory we'll use copying the samples into rows.
+		if memAcc != nil {
+			if err := memAcc.Grow(
+				ctx, memsize.DatumOverhead*int64(len(sr.samples)*len(colIdxs)),
+			); err != nil {
+				return err
+			}
+		}
+		rows = make([]tree.Datums, len(sr.samples))
+		values := make(tree.Datums, len(sr.samples)*len(colIdxs))
+		for i, sample := range sr.samples {
+			rows[i], values = values[:len(colIdxs):len(colIdxs)], values[len(colIdxs):]
+			for j, colIdx := range colIdxs {
+				ed := &sample.Row[colIdx]
+				if ed.Datum == nil {
+					rows = nil
+					return errors.AssertionFailedf("value in column %d not decoded", colIdx)
+				}
+				rows[i][j] = ed.Datum
+			}
+		}
+		return nil
+	})
+	return
+}
+
 func (sr *SampleReservoir) copyRow(
 	ctx context.Context, evalCtx *eval.Context, dst, src rowenc.EncDatumRow,
 ) error {
@@ -51,6 +51,9 @@ type TableStatistic struct {
 
 	// Histogram is the decoded histogram data.
 	Histogram []cat.HistogramBucket
+
+	// Extended is the decoded extended statistics data, if any.
+	Extended *cat.ExtendedStatistics
 }
 
 // A TableStatisticsCache contains two underlying LRU caches:
@@ -650,7 +653,16 @@ func (sc *TableStatisticsCache) parseStats(
 				return nil, err
 			}
 		}
-		if err := DecodeHistogramBuckets(res); err != nil {
+		if ext := res.HistogramData.Extended; ext != nil {
+			// Multi-column statistics don't have histogram buckets, but they may
+			// have extended statistics.
+			if err := sc.hydrateExtendedStatisticsTypes(ctx, ext); err != nil {
+				return nil, err
+			}
+			if err := DecodeExtendedStatistics(res); err != nil {
+				return nil, err
+			}
+		} else if err := DecodeHistogramBuckets(res); err != nil {
 			return nil, err
 		}
 	}
@@ -658,6 +670,34 @@ func (sc *TableStatisticsCache) parseStats(
 	return res, nil
 }
 
+// hydrateExtendedStatisticsTypes hydrates the user defined column types of
+// the given extended statistics. See parseStats for why the current metadata
+// of the types can be used.
+func (sc *TableStatisticsCache) hydrateExtendedStatisticsTypes(
+	ctx context.Context, ext *ExtendedStatisticsData,
+) error {
+	var userDefined bool
+	for _, typ := range ext.ColumnTypes {
+		userDefined = userDefined || typ.UserDefined()
+	}
+	if !userDefined {
+		return nil
+	}
+	return sc.db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		resolver := descs.NewDistSQLTypeResolver(txn.Descriptors(), txn.KV())
+		for i, typ := range ext.ColumnTypes {
+			if !typ.UserDefined() {
+				continue
+			}
+			var err error
+			if ext.ColumnTypes[i], err = resolver.ResolveTypeByOID(ctx, typ.Oid()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // DecodeHistogramBuckets decodes encoded HistogramData in tabStat and writes
 // the resulting buckets into tabStat.Histogram.
 func DecodeHistogramBuckets(tabStat *TableStatistic) error {
@@ -0,0 +1,108 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stmthints
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil/singleflight"
+)
+
+// Cache is a node-wide cache of the contents of the system.statement_hints
+// table. Every change to the hints bumps the version of the table descriptor,
+// which invalidates the cache.
+type Cache struct {
+	mu struct {
+		syncutil.Mutex
+		// tableVersion is the version of the system.statement_hints descriptor
+		// from which hints was loaded.
+		tableVersion descpb.DescriptorVersion
+		// hints contains the valid hints of the table, ordered by ID, for each
+		// statement fingerprint. It is nil if the hints were not loaded.
+		hints map[appstatspb.StmtFingerprintID][]Hint
+	}
+	loadGroup *singleflight.Group
+	stopper   *stop.Stopper
+}
+
+// NewCache creates a new Cache.
+func NewCache(stopper *stop.Stopper) *Cache {
+	return &Cache{
+		loadGroup: singleflight.NewGroup("load-statement-hints", "version"),
+		stopper:   stopper,
+	}
+}
+
+// GetHints returns the hints attached to the given statement fingerprint.
+// tableVersion is the version of the system.statement_hints descriptor leased
+// by the caller. If the cache was loaded from another version of the table,
+// all the hints are read again with the load function.
+func (c *Cache) GetHints(
+	ctx context.Context,
+	tableVersion descpb.DescriptorVersion,
+	fingerprintID appstatspb.StmtFingerprintID,
+	load func(ctx context.Context) ([]Hint, error),
+) ([]Hint, error) {
+	if hints, ok := c.getFromCache(tableVersion, fingerprintID); ok {
+		return hints, nil
+	}
+
+	// Load the hints outside of the lock. There is at most one request in
+	// flight for each version of the table.
+	future, _ := c.loadGroup.DoChan(ctx,
+		fmt.Sprintf("statement-hints-%d", tableVersion),
+		singleflight.DoOpts{
+			Stop:               c.stopper,
+			InheritCancelation: false,
+		},
+		func(ctx context.Context) (interface{}, error) {
+			hints, err := load(ctx)
+			if err != nil {
+				return nil, err
+			}
+			byFingerprint := make(map[appstatspb.StmtFingerprintID][]Hint)
+			for _, h := range hints {
+				byFingerprint[h.FingerprintID] = append(byFingerprint[h.FingerprintID], h)
+			}
+			return byFingerprint, nil
+		},
+	)
+	res := future.WaitForResult(ctx)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	byFingerprint := res.Val.(map[appstatspb.StmtFingerprintID][]Hint)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Don't overwrite hints loaded from a newer version of the table.
+	if c.mu.hints == nil || c.mu.tableVersion < tableVersion {
+		c.mu.tableVersion = tableVersion
+		c.mu.hints = byFingerprint
+	}
+	return byFingerprint[fingerprintID], nil
+}
+
+func (c *Cache) getFromCache(
+	tableVersion descpb.DescriptorVersion, fingerprintID appstatspb.StmtFingerprintID,
+) ([]Hint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.hints == nil || c.mu.tableVersion != tableVersion {
+		return nil, false
+	}
+	return c.mu.hints[fingerprintID], true
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package stmthints implements the statement hints stored in the
+// system.statement_hints table. A statement hint is attached to a statement
+// fingerprint ID, as reported by the SQL statistics, and is applied by the
+// optimizer to every statement with that fingerprint, as if it was written
+// inline in the statement. This allows steering the plans of statements whose
+// text cannot be changed.
+package stmthints
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// HintType is the type of a statement hint, as stored in the hint_type column
+// of system.statement_hints.
+type HintType string
+
+const (
+	// HintTypeIndex forces the use of an index for the scans of a table. Its
+	// value has the form table@index.
+	HintTypeIndex HintType = "index"
+	// HintTypeJoinAlgorithm forces the algorithm of the joins of the statement
+	// which do not have an inline hint. Its value is one of hash, merge, lookup
+	// and inverted.
+	HintTypeJoinAlgorithm HintType = "join_algorithm"
+	// HintTypeJoinOrder prevents the optimizer from reordering the joins of
+	// the statement. Its only value is fixed.
+	HintTypeJoinOrder HintType = "join_order"
+	// HintTypeSessionSetting overrides a session setting while the statement
+	// is planned. Its value has the form name=value, where name is one of
+	// planningSessionSettings.
+	HintTypeSessionSetting HintType = "session_setting"
+)
+
+// planningSessionSettings are the session settings which can be overridden
+// by a session_setting hint. The overrides are only in effect while the
+// statement is planned, so they are limited to the settings which are only
+// read by the optimizer.
+var planningSessionSettings = map[string]struct{}{
+	"cost_scans_with_default_col_size":                          {},
+	"disallow_full_table_scans":                                 {},
+	"enable_zigzag_join":                                        {},
+	"large_full_scan_rows":                                      {},
+	"locality_optimized_partitioned_index_scan":                 {},
+	"optimizer_always_use_histograms":                           {},
+	"optimizer_hoist_uncorrelated_equality_subqueries":          {},
+	"optimizer_use_extended_statistics":                         {},
+	"optimizer_use_forecasts":                                   {},
+	"optimizer_use_histograms":                                  {},
+	"optimizer_use_improved_computed_column_filters_derivation": {},
+	"optimizer_use_improved_disjunction_stats":                  {},
+	"optimizer_use_improved_join_elimination":                   {},
+	"optimizer_use_improved_split_disjunction_for_joins":        {},
+	"optimizer_use_limit_ordering_for_streaming_group_by":       {},
+	"optimizer_use_multicol_stats":                              {},
+	"optimizer_use_not_visible_indexes":                         {},
+	"prefer_lookup_joins_for_fks":                               {},
+	"propagate_input_ordering":                                  {},
+	"reorder_joins_limit":                                       {},
+	"unconstrained_non_covering_index_scan_enabled":             {},
+	"variable_inequality_lookup_join_enabled":                   {},
+}
+
+// fixedJoinOrder is the value of a join_order hint.
+const fixedJoinOrder = "fixed"
+
+// Hint is a statement hint, i.e. a row of system.statement_hints.
+type Hint struct {
+	ID            int64
+	FingerprintID appstatspb.StmtFingerprintID
+	Type          HintType
+	Value         string
+}
+
+// String returns the description of the hint shown by EXPLAIN and recorded
+// in the statement statistics.
+func (h Hint) String() string {
+	return fmt.Sprintf("%s=%s", h.Type, h.Value)
+}
+
+// MakeHint validates the given hint type and value, and returns the
+// corresponding hint. The value is normalized, so that hints which have the
+// same effect are stored identically.
+func MakeHint(
+	fingerprintID appstatspb.StmtFingerprintID, hintType, value string,
+) (Hint, error) {
+	h := Hint{FingerprintID: fingerprintID, Type: HintType(strings.ToLower(hintType))}
+	value = strings.TrimSpace(value)
+	switch h.Type {
+	case HintTypeIndex:
+		table, index, ok := strings.Cut(value, "@")
+		if !ok || table == "" || index == "" {
+			return Hint{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"index hint %q must have the form table@index", value)
+		}
+		h.Value = value
+
+	case HintTypeJoinAlgorithm:
+		algo := strings.ToUpper(value)
+		switch algo {
+		case tree.AstHash, tree.AstMerge, tree.AstLookup, tree.AstInverted:
+		default:
+			return Hint{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"join algorithm %q must be one of hash, merge, lookup or inverted", value)
+		}
+		h.Value = strings.ToLower(algo)
+
+	case HintTypeJoinOrder:
+		if !strings.EqualFold(value, fixedJoinOrder) {
+			return Hint{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"join order hint %q must be %s", value, fixedJoinOrder)
+		}
+		h.Value = fixedJoinOrder
+
+	case HintTypeSessionSetting:
+		name, settingValue, ok := strings.Cut(value, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return Hint{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"session setting hint %q must have the form name=value", value)
+		}
+		if _, ok := planningSessionSettings[name]; !ok {
+			return Hint{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"session setting %q cannot be overridden by a statement hint: only the settings of the optimizer can be", name)
+		}
+		h.Value = name + "=" + strings.TrimSpace(settingValue)
+
+	default:
+		return Hint{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"unknown statement hint type %q", hintType)
+	}
+	return h, nil
+}
+
+// SessionSetting is a session setting overridden by a statement hint.
+type SessionSetting struct {
+	Name  string
+	Value string
+}
+
+// Hints are the combined hints of a statement, in the form used by the
+// optimizer.
+type Hints struct {
+	// Indexes maps the names of the tables referenced by the statement to the
+	// index forced for them.
+	Indexes map[tree.Name]tree.UnrestrictedName
+	// JoinAlgorithm is the hint applied to the joins which do not have an
+	// inline hint, e.g. tree.AstHash. It is empty if there is no such hint.
+	JoinAlgorithm string
+	// FixedJoinOrder is set if the joins must not be reordered.
+	FixedJoinOrder bool
+	// SessionSettings are the session settings overridden while the statement
+	// is planned.
+	SessionSettings []SessionSetting
+	// Descriptions contains the descriptions of the hints, in the order of
+	// their IDs.
+	Descriptions []string
+}
+
+// MakeHints combines the given hints, which must be valid. If several hints
+// of the same type conflict, the most recent one wins.
+func MakeHints(hints []Hint) *Hints {
+	if len(hints) == 0 {
+		return nil
+	}
+	res := &Hints{}
+	for _, h := range hints {
+		res.Descriptions = append(res.Descriptions, h.String())
+		switch h.Type {
+		case HintTypeIndex:
+			table, index, _ := strings.Cut(h.Value, "@")
+			if res.Indexes == nil {
+				res.Indexes = make(map[tree.Name]tree.UnrestrictedName)
+			}
+			res.Indexes[tree.Name(table)] = tree.UnrestrictedName(index)
+		case HintTypeJoinAlgorithm:
+			res.JoinAlgorithm = strings.ToUpper(h.Value)
+		case HintTypeJoinOrder:
+			res.FixedJoinOrder = true
+		case HintTypeSessionSetting:
+			name, value, _ := strings.Cut(h.Value, "=")
+			res.SessionSettings = append(res.SessionSettings, SessionSetting{Name: name, Value: value})
+		}
+	}
+	return res
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stmthints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeHint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		hintType, value string
+		expected        string
+		err             string
+	}{
+		{hintType: "index", value: "t@t_a_idx", expected: "index=t@t_a_idx"},
+		{hintType: "INDEX", value: " t@primary ", expected: "index=t@primary"},
+		{hintType: "index", value: "t", err: `index hint "t" must have the form table@index`},
+		{hintType: "index", value: "@idx", err: `must have the form table@index`},
+		{hintType: "join_algorithm", value: "HASH", expected: "join_algorithm=hash"},
+		{hintType: "join_algorithm", value: "lookup", expected: "join_algorithm=lookup"},
+		{hintType: "join_algorithm", value: "nested", err: `join algorithm "nested" must be one of`},
+		{hintType: "join_order", value: "Fixed", expected: "join_order=fixed"},
+		{hintType: "join_order", value: "any", err: `join order hint "any" must be fixed`},
+		{hintType: "session_setting", value: "Reorder_Joins_Limit = 0", expected: "session_setting=reorder_joins_limit=0"},
+		{hintType: "session_setting", value: "=0", err: `must have the form name=value`},
+		{hintType: "session_setting", value: "a", err: `must have the form name=value`},
+		{hintType: "session_setting", value: "statement_timeout=1s", err: `session setting "statement_timeout" cannot be overridden by a statement hint`},
+		{hintType: "other", value: "a", err: `unknown statement hint type "other"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.hintType+"/"+tc.value, func(t *testing.T) {
+			h, err := MakeHint(1, tc.hintType, tc.value)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, h.String())
+		})
+	}
+}
+
+func TestMakeHints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	require.Nil(t, MakeHints(nil))
+
+	var hints []Hint
+	for _, h := range [][2]string{
+		{"index", "t@t_a_idx"},
+		{"join_algorithm", "hash"},
+		{"index", "u@u_b_idx"},
+		{"join_algorithm", "merge"},
+		{"join_order", "fixed"},
+		{"session_setting", "reorder_joins_limit=0"},
+	} {
+		hint, err := MakeHint(1, h[0], h[1])
+		require.NoError(t, err)
+		hints = append(hints, hint)
+	}
+	res := MakeHints(hints)
+	require.Equal(t, map[tree.Name]tree.UnrestrictedName{"t": "t_a_idx", "u": "u_b_idx"}, res.Indexes)
+	require.Equal(t, tree.AstMerge, res.JoinAlgorithm)
+	require.True(t, res.FixedJoinOrder)
+	require.Equal(t, []SessionSetting{{Name: "reorder_joins_limit", Value: "0"}}, res.SessionSettings)
+	require.Len(t, res.Descriptions, len(hints))
+}
+
+func TestCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	c := NewCache(stopper)
+	var loads int
+	var stored []Hint
+	load := func(context.Context) ([]Hint, error) {
+		loads++
+		return stored, nil
+	}
+	hint := func(id int64, fingerprintID appstatspb.StmtFingerprintID) Hint {
+		h, err := MakeHint(fingerprintID, "join_order", "fixed")
+		require.NoError(t, err)
+		h.ID = id
+		return h
+	}
+
+	stored = []Hint{hint(1, 10), hint(2, 20), hint(3, 10)}
+	hints, err := c.GetHints(ctx, 1, 10, load)
+	require.NoError(t, err)
+	require.Equal(t, []Hint{stored[0], stored[2]}, hints)
+	hints, err = c.GetHints(ctx, 1, 30, load)
+	require.NoError(t, err)
+	require.Empty(t, hints)
+	require.Equal(t, 1, loads)
+
+	// A new version of the table invalidates the cache.
+	stored = []Hint{hint(2, 20)}
+	hints, err = c.GetHints(ctx, 2, 10, load)
+	require.NoError(t, err)
+	require.Empty(t, hints)
+	hints, err = c.GetHints(ctx, 2, 20, load)
+	require.NoError(t, err)
+	require.Equal(t, stored, hints)
+	require.Equal(t, 2, loads)
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// ProvisionUser creates the given user if it does not exist yet. It is used by
+// authentication methods backed by an external identity provider to create
+// users on their first successful login. The user is created without a
+// password, so that it can only log in through such a method.
+//
+// The user creation is recorded in the event log like a CREATE USER
+// statement.
+func ProvisionUser(ctx context.Context, execCfg *ExecutorConfig, user username.SQLUsername) error {
+	if err := user.ValidateForCreation(); err != nil {
+		return err
+	}
+	_, err := execCfg.InternalDB.Executor().ExecEx(
+		ctx, "provision-user", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf("CREATE USER IF NOT EXISTS %s", user.SQLIdentifier()),
+	)
+	return errors.Wrapf(err, "provisioning user %s", user)
+}
+
+// SyncRoleMemberships makes the given user a direct member of exactly the
+// given roles, among the managed roles: it is granted the ones that exist and
+// that it is not yet a member of, and its memberships of the other managed
+// roles are revoked. If managed is nil, all the roles are managed, i.e. every
+// direct membership of the user not in roles is revoked.
+//
+// The admin role is never granted nor revoked, so that an external identity
+// provider cannot make a user an administrator, nor lock out the
+// administrators that were set up in the cluster.
+//
+// The grants and revokes are recorded in the event log like GRANT and REVOKE
+// statements.
+func SyncRoleMemberships(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	user username.SQLUsername,
+	roles []username.SQLUsername,
+	managed []username.SQLUsername,
+) error {
+	isManaged := func(role username.SQLUsername) bool { return !role.IsAdminRole() }
+	if managed != nil {
+		managedSet := make(map[username.SQLUsername]struct{}, len(managed))
+		for _, role := range managed {
+			managedSet[role] = struct{}{}
+		}
+		isManaged = func(role username.SQLUsername) bool {
+			_, ok := managedSet[role]
+			return ok && !role.IsAdminRole()
+		}
+	}
+
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		rows, err := txn.QueryBufferedEx(
+			ctx, "read-role-members", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT "role" FROM system.role_members WHERE "member" = $1`,
+			user.Normalized(),
+		)
+		if err != nil {
+			return err
+		}
+		current := make(map[username.SQLUsername]struct{}, len(rows))
+		for _, row := range rows {
+			// system.role_members stores pre-normalized usernames.
+			role := username.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[0])))
+			current[role] = struct{}{}
+		}
+		desired := make(map[username.SQLUsername]struct{}, len(roles))
+		for _, role := range roles {
+			if isManaged(role) {
+				desired[role] = struct{}{}
+			}
+		}
+
+		var toGrant, toRevoke []username.SQLUsername
+		for role := range desired {
+			if _, ok := current[role]; ok || role == user {
+				continue
+			}
+			exists, err := RoleExists(ctx, txn, role)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				log.Infof(ctx, "role %s does not exist; not granting it to %s", role, user)
+				continue
+			}
+			toGrant = append(toGrant, role)
+		}
+		for role := range current {
+			if _, ok := desired[role]; !ok && isManaged(role) {
+				toRevoke = append(toRevoke, role)
+			}
+		}
+		sort.Slice(toGrant, func(i, j int) bool { return toGrant[i].LessThan(toGrant[j]) })
+		sort.Slice(toRevoke, func(i, j int) bool { return toRevoke[i].LessThan(toRevoke[j]) })
+
+		for _, role := range toGrant {
+			if _, err := txn.ExecEx(
+				ctx, "sync-grant-role", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride,
+				fmt.Sprintf("GRANT %s TO %s", role.SQLIdentifier(), user.SQLIdentifier()),
+			); err != nil {
+				return errors.Wrapf(err, "granting role %s to %s", role, user)
+			}
+		}
+		for _, role := range toRevoke {
+			if _, err := txn.ExecEx(
+				ctx, "sync-revoke-role", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride,
+				fmt.Sprintf("REVOKE %s FROM %s", role.SQLIdentifier(), user.SQLIdentifier()),
+			); err != nil {
+				return errors.Wrapf(err, "revoking role %s from %s", role, user)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionUserAndSyncRoleMemberships(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	runner := sqlutils.MakeSQLRunner(db)
+	execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
+
+	users := func(names ...string) []username.SQLUsername {
+		var res []username.SQLUsername
+		for _, n := range names {
+			res = append(res, username.MakeSQLUsernameFromPreNormalizedString(n))
+		}
+		return res
+	}
+	alice := username.MakeSQLUsernameFromPreNormalizedString("alice")
+
+	// Provisioning is idempotent.
+	require.NoError(t, sql.ProvisionUser(ctx, &execCfg, alice))
+	require.NoError(t, sql.ProvisionUser(ctx, &execCfg, alice))
+	runner.CheckQueryResults(t,
+		`SELECT username, "hashedPassword" IS NULL FROM system.users WHERE username = 'alice'`,
+		[][]string{{"alice", "true"}})
+	require.ErrorContains(t,
+		sql.ProvisionUser(ctx, &execCfg, username.MakeSQLUsernameFromPreNormalizedString("pg_alice")),
+		"reserved")
+
+	runner.Exec(t, `CREATE ROLE analysts`)
+	runner.Exec(t, `CREATE ROLE writers`)
+	runner.Exec(t, `CREATE ROLE stale`)
+	runner.Exec(t, `CREATE ROLE manual`)
+	runner.Exec(t, `GRANT stale, manual TO alice`)
+	const membersQuery = `SELECT role FROM system.role_members WHERE member = 'alice' ORDER BY role`
+
+	// Only the managed roles are granted and revoked, and roles that do not
+	// exist are ignored.
+	managed := users("analysts", "writers", "stale", "missing")
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, users("analysts", "missing"), managed))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"analysts"}, {"manual"}})
+
+	// Syncing is idempotent.
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, users("analysts", "missing"), managed))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"analysts"}, {"manual"}})
+
+	// Unmanaged roles are not granted.
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, users("writers", "stale2"), users("writers")))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"analysts"}, {"manual"}, {"writers"}})
+
+	// Without managed roles, all the other memberships are revoked.
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, users("writers"), nil /* managed */))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"writers"}})
+
+	// The admin role is never granted nor revoked.
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, users("writers", "admin"), users("writers", "admin")))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"writers"}})
+	runner.Exec(t, `GRANT admin TO alice`)
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, users("writers"), nil /* managed */))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"admin"}, {"writers"}})
+	require.NoError(t, sql.SyncRoleMemberships(ctx, &execCfg, alice, nil /* roles */, nil /* managed */))
+	runner.CheckQueryResults(t, membersQuery, [][]string{{"admin"}})
+
+	// REVOKE statements are recorded in the event log like GRANT statements.
+	runner.Exec(t, `GRANT manual TO alice`)
+	runner.Exec(t, `REVOKE manual FROM alice`)
+
+	// Every grant and revoke is recorded in the event log, which is written
+	// asynchronously.
+	runner.CheckQueryResultsRetry(t, `
+SELECT "eventType", count(*) FROM system.eventlog
+ WHERE "eventType" IN ('grant_role', 'revoke_role')
+ GROUP BY "eventType" ORDER BY "eventType"`,
+		[][]string{{"grant_role", "5"}, {"revoke_role", "5"}})
+}
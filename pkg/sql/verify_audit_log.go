@@ -0,0 +1,117 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/auditlogging"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var verifyAuditLogColumns = colinfo.ResultColumns{
+	{Name: "instance_id", Typ: types.Int},
+	{Name: "first_seq", Typ: types.Int},
+	{Name: "last_seq", Typ: types.Int},
+	{Name: "num_records", Typ: types.Int},
+	{Name: "problem", Typ: types.String},
+}
+
+// VerifyAuditLog returns a VERIFY AUDIT LOG statement. It checks the
+// hash chain of each SQL instance in the system.audit_log table against
+// its head, and returns one row per chain. The problem column is NULL if
+// the chain is intact.
+// Privileges: admin.
+func (p *planner) VerifyAuditLog(ctx context.Context, n *tree.VerifyAuditLog) (planNode, error) {
+	if err := p.RequireAdminRole(ctx, "VERIFY AUDIT LOG"); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_AuditLogTable) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"VERIFY AUDIT LOG requires the cluster to be upgraded to version %s",
+			clusterversion.V23_2_AuditLogTable)
+	}
+	return &delayedNode{
+		name:    n.String(),
+		columns: verifyAuditLogColumns,
+
+		constructor: func(ctx context.Context, p *planner) (_ planNode, retErr error) {
+			it, err := p.InternalSQLTxn().QueryIteratorEx(ctx,
+				"verify-audit-log",
+				p.txn,
+				sessiondata.NodeUserSessionDataOverride,
+				`SELECT instance_id, seq, "timestamp", event_type, info, prev_hash, hash, expires_at
+				 FROM system.audit_log
+				 ORDER BY instance_id, seq`,
+			)
+			if err != nil {
+				return nil, err
+			}
+			defer func() {
+				retErr = errors.CombineErrors(retErr, it.Close())
+			}()
+
+			verifier := auditlogging.MakeChainVerifier(auditLogHMACKey(p.ExecCfg()))
+			for {
+				ok, err := it.Next(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					break
+				}
+				row := it.Cur()
+				var expiresAt time.Time
+				if row[7] != tree.DNull {
+					expiresAt = tree.MustBeDTimestampTZ(row[7]).Time
+				}
+				verifier.Add(auditlogging.ChainRecord{
+					InstanceID: int64(tree.MustBeDInt(row[0])),
+					Seq:        int64(tree.MustBeDInt(row[1])),
+					Timestamp:  tree.MustBeDTimestampTZ(row[2]).Time,
+					ExpiresAt:  expiresAt,
+					EventType:  string(tree.MustBeDString(row[3])),
+					Info:       string(tree.MustBeDString(row[4])),
+					PrevHash:   []byte(tree.MustBeDBytes(row[5])),
+					Hash:       []byte(tree.MustBeDBytes(row[6])),
+				})
+			}
+
+			v := p.newContainerValuesNode(verifyAuditLogColumns, 0)
+			for _, res := range verifier.Results() {
+				problem := tree.DNull
+				if res.Problem != "" {
+					problem = tree.NewDString(res.Problem)
+				}
+				row := tree.Datums{
+					tree.NewDInt(tree.DInt(res.InstanceID)),
+					tree.NewDInt(tree.DInt(res.FirstSeq)),
+					tree.NewDInt(tree.DInt(res.LastSeq)),
+					tree.NewDInt(tree.DInt(res.NumRecords)),
+					problem,
+				}
+				if _, err := v.rows.AddRow(ctx, row); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
@@ -361,6 +361,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterTableNode{}):                          "alter table",
 	reflect.TypeOf(&alterTableOwnerNode{}):                     "alter table owner",
 	reflect.TypeOf(&alterTableSetLocalityNode{}):               "alter table set locality",
+	reflect.TypeOf(&alterPolicyNode{}):                         "alter policy",
 	reflect.TypeOf(&alterTableSetSchemaNode{}):                 "alter table set schema",
 	reflect.TypeOf(&alterTenantCapabilityNode{}):               "alter tenant capability",
 	reflect.TypeOf(&alterTenantSetClusterSettingNode{}):        "alter tenant set cluster setting",
@@ -368,11 +369,13 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterTypeNode{}):                           "alter type",
 	reflect.TypeOf(&alterRoleNode{}):                           "alter role",
 	reflect.TypeOf(&alterRoleSetNode{}):                        "alter role set var",
+	reflect.TypeOf(&alterRoleUnlockNode{}):                     "alter role unlock",
 	reflect.TypeOf(&applyJoinNode{}):                           "apply join",
 	reflect.TypeOf(&bufferNode{}):                              "buffer",
 	reflect.TypeOf(&cancelQueriesNode{}):                       "cancel queries",
 	reflect.TypeOf(&cancelSessionsNode{}):                      "cancel sessions",
 	reflect.TypeOf(&cdcValuesNode{}):                           "wrapped streaming node",
+	reflect.TypeOf(&changeColumnPrivilegesNode{}):              "change column privileges",
 	reflect.TypeOf(&changeDescriptorBackedPrivilegesNode{}):    "change privileges",
 	reflect.TypeOf(&changeNonDescriptorBackedPrivilegesNode{}): "change system privileges",
 	reflect.TypeOf(&commentOnColumnNode{}):                     "comment on column",
@@ -389,6 +392,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&createExternalConectionNode{}):             "create external connection",
 	reflect.TypeOf(&createFunctionNode{}):                      "create function",
 	reflect.TypeOf(&createIndexNode{}):                         "create index",
+	reflect.TypeOf(&createPolicyNode{}):                        "create policy",
 	reflect.TypeOf(&createSequenceNode{}):                      "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                        "create schema",
 	reflect.TypeOf(&createStatsNode{}):                         "create statistics",
@@ -406,6 +410,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&dropExternalConnectionNode{}):              "drop external connection",
 	reflect.TypeOf(&dropFunctionNode{}):                        "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                           "drop index",
+	reflect.TypeOf(&dropPolicyNode{}):                          "drop policy",
 	reflect.TypeOf(&dropSequenceNode{}):                        "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                          "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                           "drop table",
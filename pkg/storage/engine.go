@@ -1124,6 +1124,11 @@ type Metrics struct {
 	SharedStorageWriteBytes int64
 	// SharedStorageReadBytes counts the number of bytes read from shared storage.
 	SharedStorageReadBytes int64
+	// SharedStorageReadCount counts the number of reads from shared storage.
+	SharedStorageReadCount int64
+	// SharedStorageReadDuration is the cumulative time spent reading from shared
+	// storage.
+	SharedStorageReadDuration time.Duration
 	// WriteStallCount counts the number of times Pebble intentionally delayed
 	// incoming writes. Currently, the only two reasons for this to happen are:
 	// - "memtable count limit reached"
@@ -778,6 +778,8 @@ type Pebble struct {
 	diskStallCount       int64
 	sharedBytesRead      int64
 	sharedBytesWritten   int64
+	sharedReadCount      int64
+	sharedReadNanos      int64
 	iterStats            struct {
 		syncutil.Mutex
 		AggregatedIteratorStats
@@ -1862,13 +1864,15 @@ func (p *Pebble) Flush() error {
 // GetMetrics implements the Engine interface.
 func (p *Pebble) GetMetrics() Metrics {
 	m := Metrics{
-		Metrics:                 p.db.Metrics(),
-		WriteStallCount:         atomic.LoadInt64(&p.writeStallCount),
-		WriteStallDuration:      time.Duration(atomic.LoadInt64((*int64)(&p.writeStallDuration))),
-		DiskSlowCount:           atomic.LoadInt64(&p.diskSlowCount),
-		DiskStallCount:          atomic.LoadInt64(&p.diskStallCount),
-		SharedStorageReadBytes:  atomic.LoadInt64(&p.sharedBytesRead),
-		SharedStorageWriteBytes: atomic.LoadInt64(&p.sharedBytesWritten),
+		Metrics:                   p.db.Metrics(),
+		WriteStallCount:           atomic.LoadInt64(&p.writeStallCount),
+		WriteStallDuration:        time.Duration(atomic.LoadInt64((*int64)(&p.writeStallDuration))),
+		DiskSlowCount:             atomic.LoadInt64(&p.diskSlowCount),
+		DiskStallCount:            atomic.LoadInt64(&p.diskStallCount),
+		SharedStorageReadBytes:    atomic.LoadInt64(&p.sharedBytesRead),
+		SharedStorageWriteBytes:   atomic.LoadInt64(&p.sharedBytesWritten),
+		SharedStorageReadCount:    atomic.LoadInt64(&p.sharedReadCount),
+		SharedStorageReadDuration: time.Duration(atomic.LoadInt64(&p.sharedReadNanos)),
 	}
 	p.iterStats.Lock()
 	m.Iterator = p.iterStats.AggregatedIteratorStats
@@ -16,6 +16,7 @@ import (
 	"sync/atomic"
 
 	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/objstorage/remote"
 )
@@ -36,6 +37,7 @@ type externalStorageReader struct {
 var _ remote.ObjectReader = (*externalStorageReader)(nil)
 
 func (r *externalStorageReader) ReadAt(ctx context.Context, p []byte, offset int64) error {
+	start := timeutil.Now()
 	reader, _, err := r.es.ReadFile(ctx, r.objName, cloud.ReadOptions{
 		Offset:     offset,
 		LengthHint: int64(len(p)),
@@ -56,6 +58,8 @@ func (r *externalStorageReader) ReadAt(ctx context.Context, p []byte, offset int
 		n += nn
 	}
 	atomic.AddInt64(&r.p.sharedBytesRead, int64(len(p)))
+	atomic.AddInt64(&r.p.sharedReadCount, 1)
+	atomic.AddInt64(&r.p.sharedReadNanos, int64(timeutil.Since(start)))
 	return nil
 }
 
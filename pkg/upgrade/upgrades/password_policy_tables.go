@@ -0,0 +1,57 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgrades
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/upgrade"
+)
+
+// seedPasswordHistoryStmt records the current password of every user which
+// does not have a password history yet, as if it had been set during the
+// upgrade. Otherwise, the passwords set before the upgrade would never
+// expire, since the expiration is based on the most recent entry of the
+// history.
+const seedPasswordHistoryStmt = `
+INSERT INTO system.password_history (username, changed_at, hashed_password, user_id)
+SELECT username, now(), "hashedPassword", user_id FROM system.users
+WHERE length("hashedPassword") > 0
+AND username NOT IN (SELECT username FROM system.password_history)
+`
+
+// createPasswordPolicyTables creates the system.password_history and
+// system.login_lockouts tables, and seeds the password history with the
+// current passwords.
+func createPasswordPolicyTables(
+	ctx context.Context, _ clusterversion.ClusterVersion, d upgrade.TenantDeps,
+) error {
+	tables := []catalog.TableDescriptor{
+		systemschema.PasswordHistoryTable,
+		systemschema.LoginLockoutsTable,
+	}
+
+	for _, table := range tables {
+		if err := createSystemTable(ctx, d.DB.KV(), d.Settings, d.Codec, table); err != nil {
+			return err
+		}
+	}
+	_, err := d.DB.Executor().ExecEx(
+		ctx, "seed-password-history", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		seedPasswordHistoryStmt,
+	)
+	return err
+}
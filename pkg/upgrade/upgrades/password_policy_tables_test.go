@@ -0,0 +1,85 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgrades_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/upgrade/upgrades"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicyTablesMigration(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	settings := cluster.MakeTestingClusterSettingsWithVersions(
+		clusterversion.TestingBinaryVersion,
+		clusterversion.ByKey(clusterversion.V23_2_PasswordPolicyTables-1),
+		false,
+	)
+
+	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			Settings: settings,
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					BinaryVersionOverride:          clusterversion.ByKey(clusterversion.V23_2_PasswordPolicyTables - 1),
+				},
+			},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	db := tc.ServerConn(0)
+	defer db.Close()
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	// The password of a user created before the upgrade is not recorded in
+	// the password history.
+	sqlDB.Exec(t, "CREATE USER alice WITH PASSWORD 'hunter2hunter2'")
+	sqlDB.Exec(t, "CREATE USER bob")
+	sqlDB.CheckQueryResults(t, "SELECT count(*) FROM system.password_history", [][]string{{"0"}})
+
+	// The tables are part of the bootstrap schema, so this only shows that the
+	// upgrade is idempotent.
+	upgrades.Upgrade(
+		t,
+		db,
+		clusterversion.V23_2_PasswordPolicyTables,
+		nil,
+		false,
+	)
+
+	_, err := db.Exec("SELECT * FROM system.password_history")
+	assert.NoError(t, err, "system.password_history exists")
+
+	_, err = db.Exec("SELECT * FROM system.login_lockouts")
+	assert.NoError(t, err, "system.login_lockouts exists")
+
+	// The upgrade seeds the password history with the current passwords, so
+	// that they can expire.
+	sqlDB.CheckQueryResults(t, `
+SELECT h.username, h.hashed_password = u."hashedPassword", h.user_id = u.user_id
+FROM system.password_history AS h JOIN system.users AS u ON h.username = u.username`,
+		[][]string{{"alice", "true", "true"}})
+}
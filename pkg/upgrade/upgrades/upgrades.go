@@ -317,6 +317,24 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.NoPrecondition,
 		NoTenantUpgradeFunc,
 	),
+	upgrade.NewTenantUpgrade(
+		"create system.password_history and system.login_lockouts",
+		toCV(clusterversion.V23_2_PasswordPolicyTables),
+		upgrade.NoPrecondition,
+		createPasswordPolicyTables,
+	),
+	upgrade.NewTenantUpgrade(
+		"create system.audit_log",
+		toCV(clusterversion.V23_2_AuditLogTable),
+		upgrade.NoPrecondition,
+		createAuditLogTable,
+	),
+	upgrade.NewTenantUpgrade(
+		"create system.statement_hints",
+		toCV(clusterversion.V23_2_StatementHintsTable),
+		upgrade.NoPrecondition,
+		createStatementHintsTable,
+	),
 }
 
 var (
@@ -37,6 +37,7 @@ func TestEventJSON(t *testing.T) {
 		{&AlterRole{Options: []string{"NOLOGIN", "PASSWORD"}}, `"Options":["NOLOGIN","PASSWORD"]`},
 		{&AlterRole{SetInfo: []string{"DEFAULTSETTINGS"}}, `"SetInfo":["DEFAULTSETTINGS"]`},
 		{&GrantRole{GranteeRoles: []string{"role1", "role2"}, Members: []string{"role3", " role4"}}, `"GranteeRoles":["‹role1›","‹role2›"],"Members":["‹role3›","‹ role4›"]`},
+		{&RevokeRole{RevokeeRoles: []string{"role1"}, Members: []string{"role2"}}, `"RevokeeRoles":["‹role1›"],"Members":["‹role2›"]`},
 		{&ChangeDatabasePrivilege{CommonSQLPrivilegeEventDetails: CommonSQLPrivilegeEventDetails{
 			GrantedPrivileges: []string{"INSERT", "CREATE"},
 		}}, `"GrantedPrivileges":["INSERT","CREATE"]`},
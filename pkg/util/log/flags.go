@@ -108,6 +108,9 @@ func ApplyConfig(config logconfig.Config) (logShutdownFn func(), err error) {
 	var secLoggers []*loggerT
 	// sinkInfos collects the sinkInfos derived by the configuration.
 	var sinkInfos []*sinkInfo
+	// otlpSinks collects the OTLP sinks, whose connections are closed
+	// on shutdown.
+	var otlpSinks []*otlpSink
 	// fd2CaptureCleanupFn is the cleanup function for the fd2 capture,
 	// which is populated if fd2 capture is enabled, below.
 	fd2CaptureCleanupFn := func() {}
@@ -124,6 +127,13 @@ func ApplyConfig(config logconfig.Config) (logShutdownFn func(), err error) {
 		if err := closer.Close(defaultCloserTimeout); err != nil {
 			fmt.Printf("# WARNING: %s\n", err.Error())
 		}
+		// The buffered sinks have been flushed above; the OTLP
+		// connections can be released.
+		for _, s := range otlpSinks {
+			if err := s.close(); err != nil {
+				fmt.Printf("# WARNING: %s\n", err.Error())
+			}
+		}
 		for _, l := range secLoggers {
 			logging.allLoggers.del(l)
 		}
@@ -345,6 +355,20 @@ func ApplyConfig(config logconfig.Config) (logShutdownFn func(), err error) {
 		attachSinkInfo(httpSinkInfo, &fc.Channels)
 	}
 
+	// Create the OTLP sinks.
+	for _, fc := range config.Sinks.OTLPServers {
+		if fc.Filter == severity.NONE {
+			continue
+		}
+		otlpSinkInfo, otlpSink, err := newOTLPSinkInfo(*fc)
+		if err != nil {
+			return nil, err
+		}
+		otlpSinks = append(otlpSinks, otlpSink)
+		attachBufferWrapper(otlpSinkInfo, fc.CommonSinkConfig.Buffering, closer)
+		attachSinkInfo(otlpSinkInfo, &fc.Channels)
+	}
+
 	// Prepend the interceptor sink to all channels.
 	// We prepend it because we want the interceptors
 	// to see every event before they make their way to disk/network.
@@ -411,6 +435,22 @@ func newHTTPSinkInfo(c logconfig.HTTPSinkConfig) (*sinkInfo, error) {
 	return info, nil
 }
 
+// newOTLPSinkInfo creates a new otlpSink and its accompanying sinkInfo
+// from the provided configuration.
+func newOTLPSinkInfo(c logconfig.OTLPSinkConfig) (*sinkInfo, *otlpSink, error) {
+	info := &sinkInfo{}
+	if err := info.applyConfig(c.CommonSinkConfig); err != nil {
+		return nil, nil, err
+	}
+	info.applyFilters(c.Channels)
+	otlpSink, err := newOTLPSink(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	info.sink = otlpSink
+	return info, otlpSink, nil
+}
+
 // applyFilters applies the channel filters to a sinkInfo.
 func (l *sinkInfo) applyFilters(chs logconfig.ChannelFilters) {
 	for ch, threshold := range chs.ChannelFilters {
@@ -634,6 +674,28 @@ func DescribeAppliedConfig() string {
 		return nil
 	})
 
+	// Describe the OTLP sinks.
+	config.Sinks.OTLPServers = make(map[string]*logconfig.OTLPSinkConfig)
+	sIdx = 1
+	_ = logging.allSinkInfos.iter(func(l *sinkInfo) error {
+		oSink, ok := l.sink.(*otlpSink)
+		if !ok {
+			// Check to see if it's an otlpSink wrapped in a bufferedSink.
+			bufferedSink, ok := l.sink.(*bufferedSink)
+			if !ok {
+				return nil
+			}
+			oSink, ok = bufferedSink.child.(*otlpSink)
+			if !ok {
+				return nil
+			}
+		}
+		skey := fmt.Sprintf("s%d", sIdx)
+		sIdx++
+		config.Sinks.OTLPServers[skey] = oSink.config
+		return nil
+	})
+
 	// Note: we cannot return 'config' directly, because this captures
 	// certain variables from the loggers by reference and thus could be
 	// invalidated by concurrent uses of ApplyConfig().
@@ -41,6 +41,11 @@ const DefaultFluentFormat = `json-fluent-compact`
 // when not specified in a configuration.
 const DefaultHTTPFormat = `json-compact`
 
+// DefaultOTLPFormat is the entry format for OTLP sinks. This is
+// the only format supported by OTLP sinks, since log entries are
+// decoded from it to build OTLP log records.
+const DefaultOTLPFormat = `json`
+
 // DefaultConfig returns a suitable default configuration when logging
 // is meant to primarily go to files.
 func DefaultConfig() (c Config) {
@@ -73,6 +78,15 @@ http-defaults:
       max-staleness: 5s	
       flush-trigger-size: 1mib
       max-buffer-size: 50mib
+otlp-defaults:
+    filter: INFO
+    format: ` + DefaultOTLPFormat + `
+    redactable: true
+    exit-on-error: false
+    buffering:
+      max-staleness: 5s
+      flush-trigger-size: 1mib
+      max-buffer-size: 50mib
 sinks:
   stderr:
     filter: NONE
@@ -126,6 +140,11 @@ type Config struct {
 	// configuration value.
 	HTTPDefaults HTTPDefaults `yaml:"http-defaults,omitempty"`
 
+	// OTLPDefaults represents the default configuration for OTLP sinks,
+	// inherited when a specific OTLP sink config does not provide a
+	// configuration value.
+	OTLPDefaults OTLPDefaults `yaml:"otlp-defaults,omitempty"`
+
 	// Sinks represents the sink configurations.
 	Sinks SinkConfig `yaml:",omitempty"`
 
@@ -250,6 +269,8 @@ type SinkConfig struct {
 	FluentServers map[string]*FluentSinkConfig `yaml:"fluent-servers,omitempty"`
 	// HTTPServers represents the list of configured http sinks.
 	HTTPServers map[string]*HTTPSinkConfig `yaml:"http-servers,omitempty"`
+	// OTLPServers represents the list of configured OTLP sinks.
+	OTLPServers map[string]*OTLPSinkConfig `yaml:"otlp-servers,omitempty"`
 	// Stderr represents the configuration for the stderr sink.
 	Stderr StderrSinkConfig `yaml:",omitempty"`
 }
@@ -566,6 +587,87 @@ type HTTPSinkConfig struct {
 	sinkName string
 }
 
+// OTLPModeGRPC and OTLPModeHTTP are the transports supported by OTLP
+// sinks.
+var OTLPModeGRPC = "grpc"
+var OTLPModeHTTP = "http"
+
+// OTLPDefaults represents the configuration defaults for OTLP sinks.
+type OTLPDefaults struct {
+	// Mode is the transport used to export log records: "grpc" or
+	// "http". Defaults to "grpc".
+	Mode *string `yaml:",omitempty"`
+
+	// Insecure disables TLS on gRPC connections to the collector. With
+	// the "http" mode, TLS is instead determined by the scheme of the
+	// address. Defaults to false.
+	Insecure *bool `yaml:",omitempty"`
+
+	// Timeout is the timeout for each export request.
+	// Defaults to 5s.
+	Timeout *time.Duration `yaml:",omitempty"`
+
+	// Headers is a list of headers (or gRPC metadata) to attach to each
+	// export request.
+	Headers map[string]string `yaml:",omitempty,flow"`
+
+	// Compression can be "none" or "gzip" to enable gzip compression.
+	// Set to "gzip" by default.
+	Compression *string `yaml:",omitempty"`
+
+	CommonSinkConfig `yaml:",inline"`
+}
+
+// OTLPSinkConfig represents the configuration for one OTLP sink.
+//
+// User-facing documentation follows.
+// TITLE: Output to OpenTelemetry collectors
+//
+// This sink type causes logging data to be exported over the network
+// as [OpenTelemetry](https://opentelemetry.io) log records, using the
+// OTLP protocol over gRPC or HTTP.
+//
+// The configuration key under the `sinks` key in the YAML
+// configuration is `otlp-servers`. Example configuration:
+//
+//	sinks:
+//	   otlp-servers:
+//	      health:
+//	         channels: HEALTH
+//	         address: 127.0.0.1:4317
+//
+// With `mode: http`, the address is the URL of the collector's
+// logs endpoint, for example `https://127.0.0.1:4318/v1/logs`.
+//
+// Every log entry is exported as one log record. The logging channel,
+// severity, tags and whether the message contains redaction markers
+// are exported as log record attributes. Log records are batched
+// according to the `buffering` configuration, and a batch is retried
+// once if the export fails.
+//
+// Every new server sink configured automatically inherits the configuration set in the `otlp-defaults` section.
+//
+// The only supported format for OTLP sinks is `json`, which is
+// used internally to convert log entries into log records.
+//
+// {{site.data.alerts.callout_info}}
+// Run `cockroach debug check-log-config` to verify the effect of defaults inheritance.
+// {{site.data.alerts.end}}
+type OTLPSinkConfig struct {
+	// Channels is the list of logging channels that use this sink.
+	Channels ChannelFilters `yaml:",omitempty,flow"`
+
+	// Address is the network address of the OTLP collector. With the
+	// "grpc" mode, the host/address and port parts are separated with a
+	// colon. With the "http" mode, this is the URL of the logs endpoint.
+	Address string `yaml:""`
+
+	OTLPDefaults `yaml:",inline"`
+
+	// sinkName is populated during validation.
+	sinkName string
+}
+
 // IterateDirectories calls the provided fn on every directory linked to
 // by the configuration.
 func (c *Config) IterateDirectories(fn func(d string) error) error {
@@ -247,6 +247,44 @@ func (c *Config) Export(onlyChans ChannelList) (string, string) {
 		}
 	}
 
+	// Collect OTLP sinks
+	// Add the destinations into the same map, for display in the "network server"
+	// section of the diagram
+	sortedNames = nil
+	for sinkName := range c.Sinks.OTLPServers {
+		sortedNames = append(sortedNames, sinkName)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		cfg := c.Sinks.OTLPServers[name]
+		if cfg.Filter == logpb.Severity_NONE {
+			continue
+		}
+		key := fmt.Sprintf("o__%s", name)
+		target, thisprocs, thislinks := process(key, cfg.CommonSinkConfig)
+		origTarget := target
+		hasLink := false
+		for _, ch := range cfg.Channels.AllChannels.Channels {
+			if !chanSel.HasChannel(ch) {
+				continue
+			}
+			sev := cfg.Channels.ChannelFilters[ch]
+			if sev == logpb.Severity_NONE {
+				continue
+			}
+			hasLink = true
+			target, thisprocs, thislinks = addFilter(origTarget, thisprocs, thislinks, sev)
+			links = append(links, fmt.Sprintf("%s --> %s", ch, target))
+		}
+		if hasLink {
+			processing = append(processing, thisprocs...)
+			links = append(links, thislinks...)
+			servers[name] = fmt.Sprintf("queue %s as \"otlp: %s\"",
+				key, cfg.Address)
+		}
+	}
+
 	// Export the stderr redirects.
 	if c.Sinks.Stderr.Filter != logpb.Severity_NONE {
 		target, thisprocs, thislinks := process("stderr", c.Sinks.Stderr.CommonSinkConfig)
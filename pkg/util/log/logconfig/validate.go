@@ -115,13 +115,34 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		Compression:       &GzipCompression,
 	}
 
+	defaultOTLPTimeout := 5 * time.Second
+	baseOTLPDefaults := OTLPDefaults{
+		CommonSinkConfig: CommonSinkConfig{
+			Format: func() *string { s := DefaultOTLPFormat; return &s }(),
+			Buffering: CommonBufferSinkConfigWrapper{
+				CommonBufferSinkConfig: CommonBufferSinkConfig{
+					MaxStaleness:     &defaultBufferedStaleness,
+					FlushTriggerSize: &defaultFlushTriggerSize,
+					MaxBufferSize:    &defaultMaxBufferSize,
+					Format:           &bufferFmt,
+				},
+			},
+		},
+		Mode:        &OTLPModeGRPC,
+		Insecure:    &bf,
+		Timeout:     &defaultOTLPTimeout,
+		Compression: &GzipCompression,
+	}
+
 	propagateCommonDefaults(&baseFileDefaults.CommonSinkConfig, baseCommonSinkConfig)
 	propagateCommonDefaults(&baseFluentDefaults.CommonSinkConfig, baseCommonSinkConfig)
 	propagateCommonDefaults(&baseHTTPDefaults.CommonSinkConfig, baseCommonSinkConfig)
+	propagateCommonDefaults(&baseOTLPDefaults.CommonSinkConfig, baseCommonSinkConfig)
 
 	propagateFileDefaults(&c.FileDefaults, baseFileDefaults)
 	propagateFluentDefaults(&c.FluentDefaults, baseFluentDefaults)
 	propagateHTTPDefaults(&c.HTTPDefaults, baseHTTPDefaults)
+	propagateOTLPDefaults(&c.OTLPDefaults, baseOTLPDefaults)
 
 	// Normalize the directory.
 	if err := normalizeDir(&c.FileDefaults.Dir); err != nil {
@@ -163,6 +184,17 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	for sinkName, oc := range c.Sinks.OTLPServers {
+		if oc == nil {
+			oc = &OTLPSinkConfig{Channels: SelectChannels()}
+			c.Sinks.OTLPServers[sinkName] = oc
+		}
+		oc.sinkName = sinkName
+		if err := c.validateOTLPSinkConfig(oc); err != nil {
+			fmt.Fprintf(&errBuf, "otlp server %q: %v\n", sinkName, err)
+		}
+	}
+
 	// Defaults for stderr.
 	if c.Sinks.Stderr.Filter == logpb.Severity_UNKNOWN {
 		c.Sinks.Stderr.Filter = logpb.Severity_NONE
@@ -240,6 +272,19 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	for sinkName, oc := range c.Sinks.OTLPServers {
+		if len(oc.Channels.Filters) == 0 {
+			fmt.Fprintf(&errBuf, "otlp server %q: no channel selected\n", sinkName)
+			continue
+		}
+		// Propagate the sink-wide default filter to all channels that don't
+		// have a filter yet.
+		if err := oc.Channels.Validate(oc.Filter); err != nil {
+			fmt.Fprintf(&errBuf, "otlp server %q: %v\n", sinkName, err)
+			continue
+		}
+	}
+
 	// If capture-stray-errors was enabled, then perform some additional
 	// validation on it.
 	if c.CaptureFd2.Enable {
@@ -345,6 +390,14 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	// Elide all the OTLP sinks where all channels have
+	// severity set to NONE.
+	for serverName, oc := range c.Sinks.OTLPServers {
+		if oc.Channels.noChannelsSelected() {
+			delete(c.Sinks.OTLPServers, serverName)
+		}
+	}
+
 	return nil
 }
 
@@ -461,6 +514,35 @@ func (c *Config) validateHTTPSinkConfig(hsc *HTTPSinkConfig) error {
 	return c.ValidateCommonSinkConfig(hsc.CommonSinkConfig)
 }
 
+func (c *Config) validateOTLPSinkConfig(oc *OTLPSinkConfig) error {
+	propagateOTLPDefaults(&oc.OTLPDefaults, c.OTLPDefaults)
+	oc.Address = strings.TrimSpace(oc.Address)
+	if oc.Address == "" {
+		return errors.New("address cannot be empty")
+	}
+	if *oc.Mode != OTLPModeGRPC && *oc.Mode != OTLPModeHTTP {
+		return errors.New("mode must be 'grpc' or 'http'")
+	}
+	if *oc.Compression != GzipCompression && *oc.Compression != NoneCompression {
+		return errors.New("compression must be 'gzip' or 'none'")
+	}
+	if *oc.Format != DefaultOTLPFormat {
+		return errors.Newf("format must be %q", DefaultOTLPFormat)
+	}
+	if f := oc.Buffering.Format; f != nil && *f != BufferFmtNewline {
+		return errors.Newf("buffering format must be %q", BufferFmtNewline)
+	}
+
+	// Apply the auditable flag if set.
+	if *oc.Auditable {
+		bt := true
+		oc.Criticality = &bt
+	}
+	oc.Auditable = nil
+
+	return c.ValidateCommonSinkConfig(oc.CommonSinkConfig)
+}
+
 func normalizeDir(dir **string) error {
 	if *dir == nil {
 		return nil
@@ -495,6 +577,10 @@ func propagateHTTPDefaults(target *HTTPDefaults, source HTTPDefaults) {
 	propagateDefaults(target, source)
 }
 
+func propagateOTLPDefaults(target *OTLPDefaults, source OTLPDefaults) {
+	propagateDefaults(target, source)
+}
+
 // propagateDefaults takes (target *T, source T) where T is a struct
 // and sets zero-valued exported fields in target to the values
 // from source (recursively for struct-valued fields).
@@ -63,6 +63,7 @@ func clearExpectedValues(c *Config) {
 	c.FileDefaults = FileDefaults{}
 	c.FluentDefaults = FluentDefaults{}
 	c.HTTPDefaults = HTTPDefaults{}
+	c.OTLPDefaults = OTLPDefaults{}
 
 	for _, f := range c.Sinks.FileGroups {
 		if *f.Dir == "/default-dir" {
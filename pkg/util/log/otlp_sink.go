@@ -0,0 +1,308 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	otel_collector_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/collector/logs/v1"
+	otel_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/common/v1"
+	otel_logs_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/logs/v1"
+	otel_res_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/resource/v1"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+)
+
+// otlpSink exports log entries as OpenTelemetry log records to an
+// OTLP collector, over gRPC or HTTP.
+//
+// The entries are formatted with the "json" format upstream of the
+// sink, possibly batched by a bufferedSink, and decoded again in
+// output() to build the log records.
+type otlpSink struct {
+	config *logconfig.OTLPSinkConfig
+
+	// export sends an export request to the collector.
+	export func(ctx context.Context, req *otel_collector_pb.ExportLogsServiceRequest) error
+
+	// conn and client are used with the "grpc" mode.
+	conn   *grpc.ClientConn
+	client otel_collector_pb.LogsServiceClient
+	// httpClient is used with the "http" mode.
+	httpClient http.Client
+}
+
+// otlpServiceName is the service name reported in the resource of the
+// exported log records.
+const otlpServiceName = "cockroachdb"
+
+func newOTLPSink(c logconfig.OTLPSinkConfig) (*otlpSink, error) {
+	s := &otlpSink{config: &c}
+	switch *c.Mode {
+	case logconfig.OTLPModeGRPC:
+		creds := credentials.NewTLS(&tls.Config{})
+		if *c.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		// Dial does not block: the connection is established in the
+		// background, and re-established as needed.
+		conn, err := grpc.Dial(c.Address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+		s.client = otel_collector_pb.NewLogsServiceClient(conn)
+		s.export = s.exportGRPC
+	case logconfig.OTLPModeHTTP:
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil, errors.AssertionFailedf("http.DefaultTransport is not a http.Transport: %T", http.DefaultTransport)
+		}
+		s.httpClient = http.Client{
+			Transport: transport.Clone(),
+			Timeout:   *c.Timeout,
+		}
+		s.export = s.exportHTTP
+	default:
+		return nil, errors.AssertionFailedf("unknown OTLP mode: %q", *c.Mode)
+	}
+	return s, nil
+}
+
+// close releases the gRPC connection of the sink, if any. It is called
+// when the logging configuration that created the sink is torn down.
+func (s *otlpSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// output emits some formatted bytes to this sink.
+// the sink is invited to perform an extra flush if indicated
+// by the argument. This is set to true for e.g. Fatal
+// entries.
+//
+// The parent logger's outputMu is held during this operation: log
+// sinks must not recursively call into logging when implementing
+// this method.
+func (s *otlpSink) output(b []byte, opts sinkOutputOptions) error {
+	req, err := makeOTLPExportRequest(b, s.editMode())
+	if err != nil {
+		return err
+	}
+	// Try to export and retry immediately if the first attempt fails.
+	if err := s.export(context.Background(), req); err == nil {
+		return nil
+	}
+	return s.export(context.Background(), req)
+}
+
+func (s *otlpSink) exportGRPC(
+	ctx context.Context, req *otel_collector_pb.ExportLogsServiceRequest,
+) error {
+	if *s.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *s.config.Timeout)
+		defer cancel()
+	}
+	for k, v := range s.config.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+	var callOpts []grpc.CallOption
+	if *s.config.Compression == logconfig.GzipCompression {
+		callOpts = append(callOpts, grpc.UseCompressor(grpcgzip.Name))
+	}
+	_, err := s.client.Export(ctx, req, callOpts...)
+	return err
+}
+
+func (s *otlpSink) exportHTTP(
+	ctx context.Context, req *otel_collector_pb.ExportLogsServiceRequest,
+) error {
+	data, err := req.Marshal()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if *s.config.Compression == logconfig.GzipCompression {
+		g := gzip.NewWriter(&buf)
+		if _, err := g.Write(data); err != nil {
+			return err
+		}
+		if err := g.Close(); err != nil {
+			return err
+		}
+	} else {
+		buf.Write(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.Address, &buf)
+	if err != nil {
+		return err
+	}
+	if *s.config.Compression == logconfig.GzipCompression {
+		httpReq.Header.Add(httputil.ContentEncodingHeader, httputil.GzipEncoding)
+	}
+	for k, v := range s.config.Headers {
+		httpReq.Header.Add(k, v)
+	}
+	httpReq.Header.Add(httputil.ContentTypeHeader, httputil.ProtoContentType)
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close() // don't care about content
+	if resp.StatusCode >= 400 {
+		return HTTPLogError{
+			StatusCode: resp.StatusCode,
+			Address:    s.config.Address,
+		}
+	}
+	return nil
+}
+
+// editMode returns the mode used to decode the log entries. Redaction
+// markers are only preserved if the sink is configured to be
+// redactable.
+func (s *otlpSink) editMode() EditSensitiveData {
+	if *s.config.Redactable {
+		return WithMarkedSensitiveData
+	}
+	return WithFlattenedSensitiveData
+}
+
+// makeOTLPExportRequest decodes the newline-delimited entries in the
+// given "json"-formatted bytes, and converts them into an OTLP export
+// request with one log record per entry.
+func makeOTLPExportRequest(
+	b []byte, editMode EditSensitiveData,
+) (*otel_collector_pb.ExportLogsServiceRequest, error) {
+	decoder, err := NewEntryDecoderWithFormat(bytes.NewReader(b), editMode, logconfig.DefaultOTLPFormat)
+	if err != nil {
+		return nil, err
+	}
+	var records []otel_logs_pb.LogRecord
+	for {
+		var entry logpb.Entry
+		if err := decoder.Decode(&entry); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "decoding log entry")
+		}
+		records = append(records, makeOTLPLogRecord(&entry))
+	}
+	return &otel_collector_pb.ExportLogsServiceRequest{
+		ResourceLogs: []*otel_logs_pb.ResourceLogs{{
+			Resource: &otel_res_pb.Resource{
+				Attributes: []*otel_pb.KeyValue{
+					otlpStringAttr("service.name", otlpServiceName),
+				},
+			},
+			ScopeLogs: []otel_logs_pb.ScopeLogs{{LogRecords: records}},
+		}},
+	}, nil
+}
+
+// makeOTLPLogRecord converts a log entry into an OTLP log record. The
+// channel, severity, tags and redactability of the entry are exported
+// as attributes. When the entry is redactable, the message and the
+// tags contain redaction markers.
+func makeOTLPLogRecord(entry *logpb.Entry) otel_logs_pb.LogRecord {
+	attrs := []*otel_pb.KeyValue{
+		otlpStringAttr("channel", entry.Channel.String()),
+		otlpStringAttr("severity", entry.Severity.String()),
+		{
+			Key:   "redactable",
+			Value: &otel_pb.AnyValue{Value: &otel_pb.AnyValue_BoolValue{BoolValue: entry.Redactable}},
+		},
+	}
+	if entry.Tags != "" {
+		attrs = append(attrs, otlpStringAttr("tags", entry.Tags))
+	}
+	if entry.File != "" {
+		attrs = append(attrs,
+			otlpStringAttr("file", entry.File),
+			&otel_pb.KeyValue{
+				Key:   "line",
+				Value: &otel_pb.AnyValue{Value: &otel_pb.AnyValue_IntValue{IntValue: entry.Line}},
+			})
+	}
+	if entry.Goroutine != 0 {
+		attrs = append(attrs, &otel_pb.KeyValue{
+			Key:   "goroutine",
+			Value: &otel_pb.AnyValue{Value: &otel_pb.AnyValue_IntValue{IntValue: entry.Goroutine}},
+		})
+	}
+	return otel_logs_pb.LogRecord{
+		TimeUnixNano:   uint64(entry.Time),
+		SeverityNumber: otlpSeverityNumber(entry.Severity),
+		SeverityText:   entry.Severity.String(),
+		Body:           &otel_pb.AnyValue{Value: &otel_pb.AnyValue_StringValue{StringValue: entry.Message}},
+		Attributes:     attrs,
+	}
+}
+
+func otlpStringAttr(key, value string) *otel_pb.KeyValue {
+	return &otel_pb.KeyValue{
+		Key:   key,
+		Value: &otel_pb.AnyValue{Value: &otel_pb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// otlpSeverityNumber maps the severity of a log entry to the
+// corresponding OTLP severity number.
+func otlpSeverityNumber(sev logpb.Severity) otel_logs_pb.SeverityNumber {
+	switch sev {
+	case severity.INFO:
+		return otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case severity.WARNING:
+		return otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case severity.ERROR:
+		return otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case severity.FATAL:
+		return otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	default:
+		return otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+// active returns true if this sink is currently active.
+func (*otlpSink) active() bool {
+	return true
+}
+
+// attachHints attaches some hints about the location of the message
+// to the stack message.
+func (*otlpSink) attachHints(stacks []byte) []byte {
+	return stacks
+}
+
+// exitCode returns the exit code to use if the logger decides
+// to terminate because of an error in output().
+func (*otlpSink) exitCode() exit.Code {
+	return exit.LoggingNetCollectorUnavailable()
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	otel_collector_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/collector/logs/v1"
+	otel_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/common/v1"
+	otel_logs_pb "github.com/cockroachdb/cockroach/pkg/obsservice/obspb/opentelemetry-proto/logs/v1"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log/channel"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/logtags"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+)
+
+// formatOTLPTestEntries formats a few entries with the format used by
+// OTLP sinks, as a bufferedSink would batch them.
+func formatOTLPTestEntries(t *testing.T) []byte {
+	ctx := logtags.AddTag(context.Background(), "s", "1")
+	f := formatters[logconfig.DefaultOTLPFormat]()
+	var buf bytes.Buffer
+	for _, e := range []logEntry{
+		makeUnstructuredEntry(ctx, severity.WARNING, channel.OPS, 0, true, "hello %s", "world"),
+		makeUnstructuredEntry(ctx, severity.ERROR, channel.HEALTH, 0, false, "goodbye"),
+	} {
+		b := f.formatEntry(e)
+		buf.Write(b.Bytes())
+		putBuffer(b)
+	}
+	return buf.Bytes()
+}
+
+func otlpAttrs(r otel_logs_pb.LogRecord) map[string]*otel_pb.AnyValue {
+	attrs := make(map[string]*otel_pb.AnyValue, len(r.Attributes))
+	for _, kv := range r.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func checkOTLPTestRecords(t *testing.T, req *otel_collector_pb.ExportLogsServiceRequest) {
+	require.Len(t, req.ResourceLogs, 1)
+	require.Len(t, req.ResourceLogs[0].ScopeLogs, 1)
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+
+	r := records[0]
+	require.Equal(t, otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_WARN, r.SeverityNumber)
+	require.Equal(t, "WARNING", r.SeverityText)
+	require.NotZero(t, r.TimeUnixNano)
+	require.Equal(t, "hello ‹world›", r.Body.GetStringValue())
+	attrs := otlpAttrs(r)
+	require.Equal(t, "OPS", attrs["channel"].GetStringValue())
+	require.Equal(t, "WARNING", attrs["severity"].GetStringValue())
+	require.Contains(t, attrs["tags"].GetStringValue(), "s")
+	require.True(t, attrs["redactable"].GetBoolValue())
+	require.Contains(t, attrs["file"].GetStringValue(), "otlp_sink_test.go")
+
+	// The second entry is not redactable, so its message is considered
+	// unsafe as a whole.
+	r = records[1]
+	require.Equal(t, otel_logs_pb.SeverityNumber_SEVERITY_NUMBER_ERROR, r.SeverityNumber)
+	require.Equal(t, "‹goodbye›", r.Body.GetStringValue())
+	attrs = otlpAttrs(r)
+	require.Equal(t, "HEALTH", attrs["channel"].GetStringValue())
+}
+
+func TestMakeOTLPExportRequest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	req, err := makeOTLPExportRequest(formatOTLPTestEntries(t), WithMarkedSensitiveData)
+	require.NoError(t, err)
+	checkOTLPTestRecords(t, req)
+
+	// Redaction markers are removed when the sink is not redactable.
+	req, err = makeOTLPExportRequest(formatOTLPTestEntries(t), WithFlattenedSensitiveData)
+	require.NoError(t, err)
+	r := req.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	require.Equal(t, "hello world", r.Body.GetStringValue())
+	require.False(t, otlpAttrs(r)["redactable"].GetBoolValue())
+}
+
+func TestOTLPSinkHTTP(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	reqCh := make(chan *otel_collector_pb.ExportLogsServiceRequest, 1)
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		// Fail the first request to exercise the retry.
+		if failures > 0 {
+			failures--
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.Equal(t, httputil.ProtoContentType, r.Header.Get(httputil.ContentTypeHeader))
+		require.Equal(t, "header-value", r.Header.Get("X-CRDB-HEADER"))
+		require.Equal(t, httputil.GzipEncoding, r.Header.Get(httputil.ContentEncodingHeader))
+		g, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(g)
+		require.NoError(t, err)
+		var req otel_collector_pb.ExportLogsServiceRequest
+		require.NoError(t, req.Unmarshal(body))
+		reqCh <- &req
+	}))
+	defer srv.Close()
+
+	timeout := 5 * time.Second
+	redactable := true
+	cfg := logconfig.OTLPSinkConfig{
+		Address: srv.URL,
+		OTLPDefaults: logconfig.OTLPDefaults{
+			Mode:             &logconfig.OTLPModeHTTP,
+			Timeout:          &timeout,
+			Headers:          map[string]string{"X-CRDB-HEADER": "header-value"},
+			Compression:      &logconfig.GzipCompression,
+			CommonSinkConfig: logconfig.CommonSinkConfig{Redactable: &redactable},
+		},
+	}
+	s, err := newOTLPSink(cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.output(formatOTLPTestEntries(t), sinkOutputOptions{}))
+	checkOTLPTestRecords(t, <-reqCh)
+}
+
+func TestOTLPSinkGRPCClose(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	insecure := true
+	cfg := logconfig.OTLPSinkConfig{
+		Address: "localhost:0",
+		OTLPDefaults: logconfig.OTLPDefaults{
+			Mode:     &logconfig.OTLPModeGRPC,
+			Insecure: &insecure,
+		},
+	}
+	s, err := newOTLPSink(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, s.conn)
+	require.NoError(t, s.close())
+	require.Equal(t, connectivity.Shutdown, s.conn.GetState())
+}
@@ -72,4 +72,5 @@ var _ logSink = (*stderrSink)(nil)
 var _ logSink = (*fileSink)(nil)
 var _ logSink = (*fluentSink)(nil)
 var _ logSink = (*httpSink)(nil)
+var _ logSink = (*otlpSink)(nil)
 var _ logSink = (*bufferedSink)(nil)